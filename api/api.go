@@ -22,6 +22,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/apache/incubator-devlake/api/auth"
 	_ "github.com/apache/incubator-devlake/api/docs"
 	"github.com/apache/incubator-devlake/api/shared"
 	"github.com/apache/incubator-devlake/logger"
@@ -53,8 +54,18 @@ func CreateApiService() {
 	gin.SetMode(v.GetString("MODE"))
 	router := gin.Default()
 
+	// CORS CONFIG, it goes first so preflight requests are answered without authentication
+	router.Use(cors.New(cors.Config{
+		AllowOrigins:     []string{"*"},
+		AllowMethods:     []string{"PUT", "PATCH", "POST", "GET", "OPTIONS"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization"},
+		ExposeHeaders:    []string{"Content-Length"},
+		AllowCredentials: true,
+		MaxAge:           120 * time.Hour,
+	}))
+
 	// Wait for user confirmation if db migration is needed
-	router.GET("/proceed-db-migration", func(ctx *gin.Context) {
+	router.GET("/proceed-db-migration", auth.RequireMigrationRole(), func(ctx *gin.Context) {
 		if !services.MigrationRequireConfirmation() {
 			shared.ApiOutputSuccess(ctx, nil, http.StatusOK)
 			return
@@ -77,6 +88,8 @@ func CreateApiService() {
 		ctx.Abort()
 	})
 
+	router.Use(auth.Authenticate())
+
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
 	//endpoint debug log
@@ -84,16 +97,6 @@ func CreateApiService() {
 		logger.Global.Printf("endpoint %v %v %v %v", httpMethod, absolutePath, handlerName, nuHandlers)
 	}
 
-	// CORS CONFIG
-	router.Use(cors.New(cors.Config{
-		AllowOrigins:     []string{"*"},
-		AllowMethods:     []string{"PUT", "PATCH", "POST", "GET", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type"},
-		ExposeHeaders:    []string{"Content-Length"},
-		AllowCredentials: true,
-		MaxAge:           120 * time.Hour,
-	}))
-
 	RegisterRouter(router)
 	err := router.Run(v.GetString("PORT"))
 	if err != nil {
@@ -0,0 +1,61 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"net/http"
+
+	"github.com/apache/incubator-devlake/api/shared"
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/services"
+	"github.com/gin-gonic/gin"
+)
+
+type PaginatedAuditLogs struct {
+	AuditLogs []*services.AuditLogOutput `json:"auditLogs"`
+	Count     int64                      `json:"count"`
+}
+
+// @Summary get audit logs
+// @Description get audit logs of configuration changes with before/after snapshots and the diff, latest first
+// @Tags framework/audit
+// @Param actor query string false "actor"
+// @Param entityType query string false "entity type, e.g. blueprint, project or gitlab/connections/:connectionId"
+// @Param entityId query string false "entity id"
+// @Param since query string false "since, RFC3339"
+// @Param until query string false "until, RFC3339"
+// @Param page query int false "page"
+// @Param pageSize query int false "page size"
+// @Success 200  {object} PaginatedAuditLogs
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /audit [get]
+func Index(c *gin.Context) {
+	var query services.AuditLogQuery
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	auditLogs, count, err := services.GetAuditLogs(&query)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error getting audit logs"))
+		return
+	}
+	shared.ApiOutputSuccess(c, PaginatedAuditLogs{AuditLogs: auditLogs, Count: count}, http.StatusOK)
+}
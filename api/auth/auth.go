@@ -0,0 +1,89 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auth
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/apache/incubator-devlake/api/shared"
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/services"
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary create an api token
+// @Description create an api token with the role, the plain token is only returned in the response
+// @Tags framework/auth
+// @Accept application/json
+// @Param token body services.NewApiToken true "json"
+// @Success 200  {object} services.CreatedApiToken
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /auth/tokens [post]
+func PostToken(c *gin.Context) {
+	newToken := &services.NewApiToken{}
+	err := c.ShouldBindJSON(newToken)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	token, err := services.CreateApiToken(newToken, shared.GetActor(c))
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error creating api token"))
+		return
+	}
+	shared.ApiOutputSuccess(c, token, http.StatusCreated)
+}
+
+// @Summary get api tokens
+// @Description get all api tokens, the tokens themselves are not included
+// @Tags framework/auth
+// @Success 200  {object} []models.ApiToken
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /auth/tokens [get]
+func GetTokens(c *gin.Context) {
+	tokens, err := services.GetApiTokens()
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error getting api tokens"))
+		return
+	}
+	shared.ApiOutputSuccess(c, tokens, http.StatusOK)
+}
+
+// @Summary revoke an api token
+// @Description revoke an api token
+// @Tags framework/auth
+// @Param tokenId path int true "token id"
+// @Success 200
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /auth/tokens/{tokenId} [delete]
+func DeleteToken(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("tokenId"), 10, 64)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad tokenId format supplied"))
+		return
+	}
+	err = services.DeleteApiToken(id, shared.GetActor(c))
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error deleting api token"))
+		return
+	}
+	shared.ApiOutputSuccess(c, nil, http.StatusOK)
+}
@@ -0,0 +1,102 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auth
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/apache/incubator-devlake/api/shared"
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/models"
+	"github.com/apache/incubator-devlake/services"
+	"github.com/gin-gonic/gin"
+)
+
+// Authenticate resolves the ApiToken from the `Authorization: Bearer <token>` header, requests without the header
+// are passed through and rejected by RequireRole later if needed
+func Authenticate() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !services.IsAuthEnabled() {
+			return
+		}
+		header := c.GetHeader("Authorization")
+		if header == "" {
+			return
+		}
+		token := strings.TrimSpace(strings.TrimPrefix(header, "Bearer "))
+		apiToken, err := services.AuthenticateApiToken(token)
+		if err != nil {
+			shared.ApiOutputError(c, err)
+			c.Abort()
+			return
+		}
+		shared.SetApiToken(c, apiToken)
+	}
+}
+
+// RequireRole rejects requests whose ApiToken is not granted the role
+func RequireRole(role string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !services.IsAuthEnabled() {
+			return
+		}
+		apiToken := shared.GetApiToken(c)
+		if apiToken == nil {
+			shared.ApiOutputError(c, errors.Unauthorized.New("api token is required"))
+			c.Abort()
+			return
+		}
+		if !models.RoleGrants(apiToken.Role, role) {
+			shared.ApiOutputError(c, errors.Forbidden.New(fmt.Sprintf("role %s is required", role)))
+			c.Abort()
+			return
+		}
+	}
+}
+
+// RequireMigrationRole requires the admin role to confirm the db migration, unless no api token could be
+// authenticated yet, i.e. API_ADMIN_TOKEN is not set and api tokens are not migrated
+func RequireMigrationRole() gin.HandlerFunc {
+	authenticate := Authenticate()
+	requireAdmin := RequireRole(models.ROLE_ADMIN)
+	return func(c *gin.Context) {
+		if !services.IsAuthEnabled() || !services.IsApiTokenAvailable() {
+			return
+		}
+		authenticate(c)
+		if c.IsAborted() {
+			return
+		}
+		requireAdmin(c)
+	}
+}
+
+// PluginApiRole returns the role required by the plugin api resource, connections are restricted to admin since
+// they carry credentials, other resources require viewer for reading and operator for writing
+func PluginApiRole(resourcePath string, method string) string {
+	if resourcePath == "connections" ||
+		resourcePath == "connections/:connectionId" ||
+		strings.Contains(resourcePath, "/proxy/") {
+		return models.ROLE_ADMIN
+	}
+	if method == "GET" {
+		return models.ROLE_VIEWER
+	}
+	return models.ROLE_OPERATOR
+}
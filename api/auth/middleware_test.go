@@ -0,0 +1,123 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/apache/incubator-devlake/api/shared"
+	"github.com/apache/incubator-devlake/config"
+	"github.com/apache/incubator-devlake/models"
+	"github.com/apache/incubator-devlake/services"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+const testAdminToken = "test-admin-token"
+
+func enableAuth(t *testing.T) {
+	cfg := config.GetConfig()
+	cfg.Set(services.AuthEnabledEnvStr, true)
+	cfg.Set(services.AdminTokenEnvStr, testAdminToken)
+	t.Cleanup(func() {
+		cfg.Set(services.AuthEnabledEnvStr, false)
+		cfg.Set(services.AdminTokenEnvStr, "")
+	})
+}
+
+func newTestContext(authorization string) (*gin.Context, *httptest.ResponseRecorder) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/pipelines", nil)
+	if authorization != "" {
+		c.Request.Header.Set("Authorization", authorization)
+	}
+	return c, w
+}
+
+func TestAuthenticate(t *testing.T) {
+	// disabled
+	c, _ := newTestContext("Bearer " + testAdminToken)
+	Authenticate()(c)
+	assert.False(t, c.IsAborted())
+	assert.Nil(t, shared.GetApiToken(c))
+
+	enableAuth(t)
+	// requests without the header are left to RequireRole
+	c, _ = newTestContext("")
+	Authenticate()(c)
+	assert.False(t, c.IsAborted())
+	assert.Nil(t, shared.GetApiToken(c))
+
+	c, _ = newTestContext("Bearer " + testAdminToken)
+	Authenticate()(c)
+	assert.False(t, c.IsAborted())
+	apiToken := shared.GetApiToken(c)
+	if assert.NotNil(t, apiToken) {
+		assert.Equal(t, services.ADMIN_TOKEN_NAME, apiToken.Name)
+		assert.Equal(t, models.ROLE_ADMIN, apiToken.Role)
+	}
+}
+
+func TestRequireRole(t *testing.T) {
+	// disabled
+	c, _ := newTestContext("")
+	RequireRole(models.ROLE_ADMIN)(c)
+	assert.False(t, c.IsAborted())
+
+	enableAuth(t)
+	c, w := newTestContext("")
+	RequireRole(models.ROLE_VIEWER)(c)
+	assert.True(t, c.IsAborted())
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	c, w = newTestContext("")
+	shared.SetApiToken(c, &models.ApiToken{Name: "ci", Role: models.ROLE_VIEWER})
+	RequireRole(models.ROLE_OPERATOR)(c)
+	assert.True(t, c.IsAborted())
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	c, _ = newTestContext("")
+	shared.SetApiToken(c, &models.ApiToken{Name: "ci", Role: models.ROLE_OPERATOR})
+	RequireRole(models.ROLE_OPERATOR)(c)
+	assert.False(t, c.IsAborted())
+}
+
+func TestRequireMigrationRole(t *testing.T) {
+	enableAuth(t)
+	c, w := newTestContext("")
+	RequireMigrationRole()(c)
+	assert.True(t, c.IsAborted())
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	c, _ = newTestContext("Bearer " + testAdminToken)
+	RequireMigrationRole()(c)
+	assert.False(t, c.IsAborted())
+}
+
+func TestPluginApiRole(t *testing.T) {
+	assert.Equal(t, models.ROLE_ADMIN, PluginApiRole("connections", http.MethodGet))
+	assert.Equal(t, models.ROLE_ADMIN, PluginApiRole("connections/:connectionId", http.MethodPatch))
+	assert.Equal(t, models.ROLE_ADMIN, PluginApiRole("connections/:connectionId/proxy/rest/*path", http.MethodGet))
+	assert.Equal(t, models.ROLE_VIEWER, PluginApiRole("user_account_candidates", http.MethodGet))
+	assert.Equal(t, models.ROLE_OPERATOR, PluginApiRole("user_account_candidates", http.MethodPatch))
+	assert.Equal(t, models.ROLE_OPERATOR, PluginApiRole("teams.csv", http.MethodPut))
+}
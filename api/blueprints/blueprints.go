@@ -51,7 +51,7 @@ func Post(c *gin.Context) {
 		return
 	}
 
-	err = services.CreateBlueprint(blueprint)
+	err = services.CreateBlueprint(blueprint, shared.GetActor(c))
 	if err != nil {
 		shared.ApiOutputError(c, errors.Default.Wrap(err, "error creating blueprint"))
 		return
@@ -126,7 +126,7 @@ func Delete(c *gin.Context) {
 		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad blueprintID format supplied"))
 		return
 	}
-	err = services.DeleteBlueprint(id)
+	err = services.DeleteBlueprint(id, shared.GetActor(c))
 	if err != nil {
 		shared.ApiOutputError(c, errors.Default.Wrap(err, "error deleting blueprint"))
 	}
@@ -154,7 +154,7 @@ func Patch(c *gin.Context) {
 		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
 		return
 	}
-	blueprint, err := services.PatchBlueprint(id, body)
+	blueprint, err := services.PatchBlueprint(id, body, shared.GetActor(c))
 	if err != nil {
 		shared.ApiOutputError(c, errors.Default.Wrap(err, "error patching the blueprint"))
 		return
@@ -178,7 +178,7 @@ func Trigger(c *gin.Context) {
 		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad blueprintID format supplied"))
 		return
 	}
-	pipeline, err := services.TriggerBlueprint(id)
+	pipeline, err := services.TriggerBlueprint(id, shared.GetActor(c))
 	if errors.Is(err, services.ErrBlueprintRunning) {
 		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "the blueprint is running"))
 		return
@@ -219,3 +219,51 @@ func GetBlueprintPipelines(c *gin.Context) {
 	}
 	shared.ApiOutputSuccess(c, shared.ResponsePipelines{Pipelines: pipelines, Count: count}, http.StatusOK)
 }
+
+// @Summary preview the plan of a blueprint
+// @Description preview what would be executed if the blueprint were triggered now, including subtasks, domain types and estimated api calls
+// @Tags framework/blueprints
+// @Accept application/json
+// @Param blueprintId path int true "blueprint id"
+// @Success 200  {object} services.PlanPreview
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /blueprints/{blueprintId}/plan-preview [post]
+func PlanPreview(c *gin.Context) {
+	blueprintId := c.Param("blueprintId")
+	id, err := strconv.ParseUint(blueprintId, 10, 64)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad blueprintID format supplied"))
+		return
+	}
+	preview, err := services.PreviewBlueprintPlan(id)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error previewing blueprint plan"))
+		return
+	}
+	shared.ApiOutputSuccess(c, preview, http.StatusOK)
+}
+
+// @Summary preview the plan of an unsaved blueprint
+// @Description preview what would be executed by the blueprint in the body without saving it
+// @Tags framework/blueprints
+// @Accept application/json
+// @Param blueprint body models.Blueprint true "json"
+// @Success 200  {object} services.PlanPreview
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /blueprints/plan-preview [post]
+func PlanPreviewUnsaved(c *gin.Context) {
+	blueprint := &models.Blueprint{}
+	err := c.ShouldBind(blueprint)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	preview, err := services.PreviewUnsavedBlueprintPlan(blueprint)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error previewing blueprint plan"))
+		return
+	}
+	shared.ApiOutputSuccess(c, preview, http.StatusOK)
+}
@@ -59,6 +59,7 @@ func Post(c *gin.Context) {
 		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad JSON request body format"))
 		return
 	}
+	newPipeline.TriggeredBy = shared.GetActor(c)
 
 	pipeline, err := services.CreatePipeline(newPipeline)
 	// Return all created tasks to the User
@@ -165,7 +166,7 @@ func Delete(c *gin.Context) {
 		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad pipelineID format supplied"))
 		return
 	}
-	err = services.CancelPipeline(id)
+	err = services.CancelPipeline(id, shared.GetActor(c))
 	if err != nil {
 		shared.ApiOutputError(c, errors.Default.Wrap(err, "error cancelling pipeline"))
 		return
@@ -114,7 +114,7 @@ func PostProject(c *gin.Context) {
 		return
 	}
 
-	err = services.CreateProject(&models.Project{BaseProject: projectInput.BaseProject})
+	err = services.CreateProject(&models.Project{BaseProject: projectInput.BaseProject}, shared.GetActor(c))
 	if err != nil {
 		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "error creating project"))
 		return
@@ -122,7 +122,7 @@ func PostProject(c *gin.Context) {
 
 	// check if need to changed the blueprint setting
 	if projectInput.Enable != nil {
-		_, err = services.PatchBlueprintEnableByProjectName(projectInput.Name, *projectInput.Enable)
+		_, err = services.PatchBlueprintEnableByProjectName(projectInput.Name, *projectInput.Enable, shared.GetActor(c))
 		if err != nil {
 			shared.ApiOutputError(c, errors.BadInput.Wrap(err, "Failed to set if project enable"))
 			return
@@ -131,7 +131,7 @@ func PostProject(c *gin.Context) {
 
 	// check if need flush the Metrics
 	if projectInput.Metrics != nil {
-		err = services.FlushProjectMetrics(projectInput.Name, projectInput.Metrics)
+		err = services.FlushProjectMetrics(projectInput.Name, projectInput.Metrics, shared.GetActor(c))
 		if err != nil {
 			shared.ApiOutputError(c, errors.BadInput.Wrap(err, "Failed to flush project metrics"))
 			return
@@ -167,7 +167,7 @@ func PatchProject(c *gin.Context) {
 		return
 	}
 
-	projectOutput, err := services.PatchProject(projectName, body)
+	projectOutput, err := services.PatchProject(projectName, body, shared.GetActor(c))
 	if err != nil {
 		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "error patch project"))
 		return
@@ -235,7 +235,7 @@ func PostProjectMetrics(c *gin.Context) {
 	}
 
 	projectMetric.ProjectName = projectName
-	err = services.CreateProjectMetric(&models.ProjectMetric{BaseProjectMetric: *projectMetric})
+	err = services.CreateProjectMetric(&models.ProjectMetric{BaseProjectMetric: *projectMetric}, shared.GetActor(c))
 	if err != nil {
 		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "error creating project metric"))
 		return
@@ -264,7 +264,7 @@ func PatchProjectMetrics(c *gin.Context) {
 		return
 	}
 
-	projectMetric, err := services.PatchProjectMetric(projectName, pluginName, body)
+	projectMetric, err := services.PatchProjectMetric(projectName, pluginName, body, shared.GetActor(c))
 	if err != nil {
 		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "error patch project metric"))
 		return
@@ -0,0 +1,134 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rawdata
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/apache/incubator-devlake/api/shared"
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/models"
+	"github.com/apache/incubator-devlake/services"
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary get raw data retention policies
+// @Description get raw data retention policies
+// @Tags framework/rawdata
+// @Success 200  {object} []models.RawDataRetentionPolicy
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /raw-data/retention-policies [get]
+func GetRetentionPolicies(c *gin.Context) {
+	policies, err := services.GetRawDataRetentionPolicies()
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error getting raw data retention policies"))
+		return
+	}
+	shared.ApiOutputSuccess(c, policies, http.StatusOK)
+}
+
+// @Summary create or update a raw data retention policy
+// @Description create or update the raw data retention policy of a plugin, or a raw table if rawDataTable is specified
+// @Tags framework/rawdata
+// @Accept application/json
+// @Param policy body models.RawDataRetentionPolicy true "json"
+// @Success 200  {object} models.RawDataRetentionPolicy
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /raw-data/retention-policies [put]
+func PutRetentionPolicy(c *gin.Context) {
+	policy := &models.RawDataRetentionPolicy{}
+	err := c.ShouldBindJSON(policy)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	err = services.SaveRawDataRetentionPolicy(policy, shared.GetActor(c))
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error saving raw data retention policy"))
+		return
+	}
+	shared.ApiOutputSuccess(c, policy, http.StatusOK)
+}
+
+// @Summary delete a raw data retention policy
+// @Description delete a raw data retention policy
+// @Tags framework/rawdata
+// @Param policyId path int true "policyId"
+// @Success 200
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /raw-data/retention-policies/{policyId} [delete]
+func DeleteRetentionPolicy(c *gin.Context) {
+	policyId, err := strconv.ParseUint(c.Param("policyId"), 10, 64)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "invalid policy ID format"))
+		return
+	}
+	err = services.DeleteRawDataRetentionPolicy(policyId, shared.GetActor(c))
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error deleting raw data retention policy"))
+		return
+	}
+	shared.ApiOutputSuccess(c, nil, http.StatusOK)
+}
+
+// @Summary get raw data scopes of a raw table
+// @Description get number of rows and collections of every scope(params) in the raw table
+// @Tags framework/rawdata
+// @Param tableName path string true "raw table name, i.e. _raw_jira_api_issues"
+// @Success 200  {object} []services.RawDataScope
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /raw-data/tables/{tableName}/scopes [get]
+func GetScopes(c *gin.Context) {
+	scopes, err := services.GetRawDataScopes(c.Param("tableName"))
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error getting raw data scopes"))
+		return
+	}
+	shared.ApiOutputSuccess(c, scopes, http.StatusOK)
+}
+
+// @Summary purge raw data of a scope
+// @Description delete raw data of the scope out of the specified window, the latest collection and raw data still
+// @Description referred by extracted records are always kept so extractors could be rerun
+// @Tags framework/rawdata
+// @Param tableName path string true "raw table name, i.e. _raw_jira_api_issues"
+// @Param params query string true "params of the scope"
+// @Param keepCollections query int false "number of latest collections to keep"
+// @Param keepDays query int false "number of days to keep"
+// @Success 200
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /raw-data/tables/{tableName}/scopes [delete]
+func PurgeScope(c *gin.Context) {
+	var query services.RawDataPurgeQuery
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	err = services.PurgeRawDataOfScope(c.Param("tableName"), &query)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error purging raw data"))
+		return
+	}
+	shared.ApiOutputSuccess(c, nil, http.StatusOK)
+}
@@ -18,10 +18,14 @@ limitations under the License.
 package api
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"sort"
 	"strings"
 
+	"github.com/apache/incubator-devlake/api/audit"
+	"github.com/apache/incubator-devlake/api/auth"
 	"github.com/apache/incubator-devlake/api/blueprints"
 	"github.com/apache/incubator-devlake/api/domainlayer"
 	"github.com/apache/incubator-devlake/api/ping"
@@ -29,53 +33,82 @@ import (
 	"github.com/apache/incubator-devlake/api/plugininfo"
 	"github.com/apache/incubator-devlake/api/project"
 	"github.com/apache/incubator-devlake/api/push"
+	"github.com/apache/incubator-devlake/api/rawdata"
+	"github.com/apache/incubator-devlake/api/secret"
 	"github.com/apache/incubator-devlake/api/shared"
 	"github.com/apache/incubator-devlake/api/task"
 	"github.com/apache/incubator-devlake/api/version"
+	"github.com/apache/incubator-devlake/logger"
+	"github.com/apache/incubator-devlake/models"
 	"github.com/apache/incubator-devlake/plugins/core"
 	"github.com/apache/incubator-devlake/services"
 	"github.com/gin-gonic/gin"
 )
 
 func RegisterRouter(r *gin.Engine) {
-	r.GET("/pipelines", pipelines.Index)
-	r.POST("/pipelines", pipelines.Post)
-	r.GET("/pipelines/:pipelineId", pipelines.Get)
-	r.PATCH("/blueprints/:blueprintId", blueprints.Patch)
-	r.POST("/blueprints/:blueprintId/trigger", blueprints.Trigger)
+	// routes are grouped by the role required, see models.Roles
+	viewer := r.Group("", auth.RequireRole(models.ROLE_VIEWER))
+	operator := r.Group("", auth.RequireRole(models.ROLE_OPERATOR))
+	admin := r.Group("", auth.RequireRole(models.ROLE_ADMIN))
+
+	viewer.GET("/pipelines", pipelines.Index)
+	operator.POST("/pipelines", pipelines.Post)
+	viewer.GET("/pipelines/:pipelineId", pipelines.Get)
+	operator.PATCH("/blueprints/:blueprintId", blueprints.Patch)
+	operator.POST("/blueprints/:blueprintId/trigger", blueprints.Trigger)
+	viewer.POST("/blueprints/:blueprintId/plan-preview", blueprints.PlanPreview)
+	viewer.POST("/blueprints/plan-preview", blueprints.PlanPreviewUnsaved)
 	// r.DELETE("/blueprints/:blueprintId", blueprints.Delete)
 
-	r.GET("/blueprints", blueprints.Index)
-	r.POST("/blueprints", blueprints.Post)
-	r.GET("/blueprints/:blueprintId", blueprints.Get)
-	r.GET("/blueprints/:blueprintId/pipelines", blueprints.GetBlueprintPipelines)
-	r.DELETE("/pipelines/:pipelineId", pipelines.Delete)
-	r.GET("/pipelines/:pipelineId/tasks", task.GetTaskByPipeline)
-	r.POST("/pipelines/:pipelineId/tasks", task.RerunTask)
+	viewer.GET("/blueprints", blueprints.Index)
+	operator.POST("/blueprints", blueprints.Post)
+	viewer.GET("/blueprints/:blueprintId", blueprints.Get)
+	viewer.GET("/blueprints/:blueprintId/pipelines", blueprints.GetBlueprintPipelines)
+	operator.DELETE("/pipelines/:pipelineId", pipelines.Delete)
+	viewer.GET("/pipelines/:pipelineId/tasks", task.GetTaskByPipeline)
+	operator.POST("/pipelines/:pipelineId/tasks", task.RerunTask)
 
-	r.GET("/pipelines/:pipelineId/logging.tar.gz", pipelines.DownloadLogs)
+	viewer.GET("/pipelines/:pipelineId/logging.tar.gz", pipelines.DownloadLogs)
 
 	r.GET("/ping", ping.Get)
 	r.GET("/version", version.Get)
-	r.POST("/push/:tableName", push.Post)
-	r.GET("/domainlayer/repos", domainlayer.ReposIndex)
+	admin.POST("/push/:tableName", push.Post)
+	viewer.GET("/domainlayer/repos", domainlayer.ReposIndex)
 
 	// plugin api
-	r.GET("/plugininfo", plugininfo.Get)
-	r.GET("/plugins", plugininfo.GetPluginMetas)
+	viewer.GET("/plugininfo", plugininfo.Get)
+	viewer.GET("/plugins", plugininfo.GetPluginMetas)
 
 	// project api
-	r.GET("/projects/:projectName", project.GetProject)
-	r.PATCH("/projects/:projectName", project.PatchProject)
+	viewer.GET("/projects/:projectName", project.GetProject)
+	operator.PATCH("/projects/:projectName", project.PatchProject)
 	//r.DELETE("/projects/:projectName", project.DeleteProject)
-	r.POST("/projects", project.PostProject)
-	r.GET("/projects", project.GetProjects)
+	operator.POST("/projects", project.PostProject)
+	viewer.GET("/projects", project.GetProjects)
 
 	// project metric api
-	r.GET("/projects/:projectName/metrics/:pluginName", project.GetProjectMetrics)
-	r.PATCH("/projects/:projectName/metrics/:pluginName", project.PatchProjectMetrics)
+	viewer.GET("/projects/:projectName/metrics/:pluginName", project.GetProjectMetrics)
+	operator.PATCH("/projects/:projectName/metrics/:pluginName", project.PatchProjectMetrics)
 	//r.DELETE("/projects/:projectName/metrics/:pluginName", project.DeleteProjectMetrics)
-	r.POST("/projects/:projectName/metrics", project.PostProjectMetrics)
+	operator.POST("/projects/:projectName/metrics", project.PostProjectMetrics)
+
+	// raw data retention api
+	viewer.GET("/raw-data/retention-policies", rawdata.GetRetentionPolicies)
+	admin.PUT("/raw-data/retention-policies", rawdata.PutRetentionPolicy)
+	admin.DELETE("/raw-data/retention-policies/:policyId", rawdata.DeleteRetentionPolicy)
+	viewer.GET("/raw-data/tables/:tableName/scopes", rawdata.GetScopes)
+	admin.DELETE("/raw-data/tables/:tableName/scopes", rawdata.PurgeScope)
+
+	// secret api
+	admin.POST("/secrets/rotate", secret.Rotate)
+
+	// auth api
+	admin.GET("/auth/tokens", auth.GetTokens)
+	admin.POST("/auth/tokens", auth.PostToken)
+	admin.DELETE("/auth/tokens/:tokenId", auth.DeleteToken)
+
+	// audit api
+	admin.GET("/audit", audit.Index)
 
 	// mount all api resources for all plugins
 	pluginsApiResources, err := services.GetPluginsApiResources()
@@ -88,14 +121,24 @@ func RegisterRouter(r *gin.Engine) {
 				r.Handle(
 					method,
 					fmt.Sprintf("/plugins/%s/%s", pluginName, resourcePath),
-					handlePluginCall(pluginName, h),
+					auth.RequireRole(auth.PluginApiRole(resourcePath, method)),
+					handlePluginCall(pluginName, resourcePath, method, h, resourceHandlers[http.MethodGet]),
 				)
 			}
 		}
 	}
 }
 
-func handlePluginCall(pluginName string, handler core.ApiResourceHandler) func(c *gin.Context) {
+// handlePluginCall invokes the handler of plugin api resource, mutations are recorded to the audit log with the
+// state before the mutation loaded by getHandler of the same resource if any
+func handlePluginCall(
+	pluginName string,
+	resourcePath string,
+	method string,
+	handler core.ApiResourceHandler,
+	getHandler core.ApiResourceHandler,
+) func(c *gin.Context) {
+	audited := isPluginCallAudited(resourcePath, method)
 	return func(c *gin.Context) {
 		var err error
 		input := &core.ApiResourceInput{}
@@ -117,7 +160,17 @@ func handlePluginCall(pluginName string, handler core.ApiResourceHandler) func(c
 				}
 			}
 		}
+		var before interface{}
+		if audited && method != http.MethodPost && getHandler != nil {
+			beforeOutput, err := getHandler(&core.ApiResourceInput{Params: input.Params})
+			if err == nil && beforeOutput != nil {
+				before = beforeOutput.Body
+			}
+		}
 		output, err := handler(input)
+		if err == nil && audited {
+			recordPluginCallAudit(c, pluginName, resourcePath, method, input, before, output)
+		}
 		if err != nil {
 			shared.ApiOutputError(c, err)
 		} else if output != nil {
@@ -139,3 +192,58 @@ func handlePluginCall(pluginName string, handler core.ApiResourceHandler) func(c
 		}
 	}
 }
+
+func isPluginCallAudited(resourcePath string, method string) bool {
+	if method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions {
+		return false
+	}
+	// testing connections and proxying requests to data sources mutate nothing
+	return resourcePath != "test" && !strings.HasSuffix(resourcePath, "/test") && !strings.Contains(resourcePath, "/proxy/")
+}
+
+func recordPluginCallAudit(
+	c *gin.Context,
+	pluginName string,
+	resourcePath string,
+	method string,
+	input *core.ApiResourceInput,
+	before interface{},
+	output *core.ApiResourceOutput,
+) {
+	action := services.AUDIT_ACTION_UPDATE
+	switch method {
+	case http.MethodPost:
+		action = services.AUDIT_ACTION_CREATE
+	case http.MethodDelete:
+		action = services.AUDIT_ACTION_DELETE
+	}
+	var after interface{}
+	if output != nil && action != services.AUDIT_ACTION_DELETE {
+		if _, isBlob := output.Body.([]byte); !isBlob {
+			after = output.Body
+		}
+	}
+	// identify the entity by path params, or the id of the created one
+	keys := make([]string, 0, len(input.Params))
+	for key := range input.Params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	ids := make([]string, 0, len(keys))
+	for _, key := range keys {
+		ids = append(ids, fmt.Sprintf("%s=%s", key, input.Params[key]))
+	}
+	if len(ids) == 0 && after != nil {
+		var created struct {
+			Id interface{} `json:"id"`
+		}
+		if blob, err := json.Marshal(after); err == nil && json.Unmarshal(blob, &created) == nil && created.Id != nil {
+			ids = append(ids, fmt.Sprintf("id=%v", created.Id))
+		}
+	}
+	entityType := fmt.Sprintf("%s/%s", pluginName, resourcePath)
+	err := services.RecordAudit(shared.GetActor(c), action, entityType, strings.Join(ids, ","), before, after)
+	if err != nil {
+		logger.Global.Error(err, "failed to record audit log for %s %s", method, entityType)
+	}
+}
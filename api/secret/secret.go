@@ -0,0 +1,54 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secret
+
+import (
+	"net/http"
+
+	"github.com/apache/incubator-devlake/api/shared"
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/services"
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary rotate secrets
+// @Description re-encrypt all connections and pipeline/blueprint plans with the new key.
+// @Description For the `aes` secret store, the new key is given by `encodeKey` and saved to .env afterward, please update the ENCODE_KEY of other processes accordingly.
+// @Description It is refused if ENCODE_KEY is set by the environment variable, which would override the saved key.
+// @Description For the `keyring` secret store, add the new key to the keyring file and make it active before calling this api.
+// @Tags framework/secrets
+// @Accept application/json
+// @Param rotation body services.SecretRotation true "json"
+// @Success 200  {object} services.SecretRotationResult
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /secrets/rotate [post]
+func Rotate(c *gin.Context) {
+	rotation := &services.SecretRotation{}
+	err := c.ShouldBindJSON(rotation)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	result, err := services.RotateSecrets(rotation, shared.GetActor(c))
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error rotating secrets"))
+		return
+	}
+	shared.ApiOutputSuccess(c, result, http.StatusOK)
+}
@@ -0,0 +1,49 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shared
+
+import (
+	"github.com/apache/incubator-devlake/models"
+	"github.com/gin-gonic/gin"
+)
+
+const apiTokenContextKey = "apiToken"
+
+// ANONYMOUS_ACTOR is the actor of requests when authentication is disabled
+const ANONYMOUS_ACTOR = "anonymous"
+
+// SetApiToken attaches the authenticated ApiToken to the request
+func SetApiToken(c *gin.Context, apiToken *models.ApiToken) {
+	c.Set(apiTokenContextKey, apiToken)
+}
+
+// GetApiToken returns the authenticated ApiToken of the request, or nil if it was not authenticated
+func GetApiToken(c *gin.Context) *models.ApiToken {
+	if value, ok := c.Get(apiTokenContextKey); ok {
+		return value.(*models.ApiToken)
+	}
+	return nil
+}
+
+// GetActor returns the name of the ApiToken making the request, it is used for auditing
+func GetActor(c *gin.Context) string {
+	if apiToken := GetApiToken(c); apiToken != nil {
+		return apiToken.Name
+	}
+	return ANONYMOUS_ACTOR
+}
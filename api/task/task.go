@@ -75,7 +75,8 @@ type rerunRequest struct {
 	TaskId uint64 `json:"taskId"`
 }
 
-// RerunTask rerun the specified the task. If taskId is 0, all failed tasks of this pipeline will rerun
+// RerunTask rerun the specified the task. If taskId is 0, all failed tasks of this pipeline will rerun.
+// Subtasks finished by the failed tasks would be skipped, the rerun resumes from the first unfinished one
 // @Summary rerun tasks
 // @Tags framework/task
 // @Accept application/json
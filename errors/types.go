@@ -34,6 +34,9 @@ var (
 	Forbidden    = register(&Type{httpCode: http.StatusForbidden, meta: "forbidden"})
 	Internal     = register(&Type{httpCode: http.StatusInternalServerError, meta: "internal"})
 	Timeout      = register(&Type{httpCode: http.StatusGatewayTimeout, meta: "timeout"})
+	Deadlock     = register(&Type{meta: "deadlock"})
+	// TokenExpired isn't registered, so HttpStatus(401) stays Unauthorized
+	TokenExpired = &Type{httpCode: http.StatusUnauthorized, meta: "token-expired"}
 
 	//cached values
 	typesByHttpCode = newSyncMap[int, *Type]()
@@ -112,6 +115,11 @@ func (t *Type) Combine(errs []error) Error {
 	return newCombinedCrdbError(t, errs)
 }
 
+// GetName gets the name of this Type, e.g. "timeout" or "type_http_429"
+func (t *Type) GetName() string {
+	return t.meta
+}
+
 // GetHttpCode gets the associated Http code with this Type, if explicitly set, otherwise http.StatusInternalServerError
 func (t *Type) GetHttpCode() int {
 	if t.httpCode == 0 {
@@ -122,12 +122,12 @@ var _ dal.Dal = (*Dalgorm)(nil)
 
 // Exec executes raw sql query
 func (d *Dalgorm) Exec(query string, params ...interface{}) errors.Error {
-	return errors.Convert(d.db.Exec(query, transformParams(params)...).Error)
+	return ConvertError(d.db.Exec(query, transformParams(params)...).Error)
 }
 
 // AutoMigrate runs auto migration for given models
 func (d *Dalgorm) AutoMigrate(entity interface{}, clauses ...dal.Clause) errors.Error {
-	err := errors.Convert(buildTx(d.db, clauses).AutoMigrate(entity))
+	err := ConvertError(buildTx(d.db, clauses).AutoMigrate(entity))
 	if err == nil {
 		// fix pg cache plan error
 		_ = d.First(entity, clauses...)
@@ -137,7 +137,8 @@ func (d *Dalgorm) AutoMigrate(entity interface{}, clauses ...dal.Clause) errors.
 
 // Cursor returns a database cursor, cursor is especially useful when handling big amount of rows of data
 func (d *Dalgorm) Cursor(clauses ...dal.Clause) (dal.Rows, errors.Error) {
-	return errors.Convert01(buildTx(d.db, clauses).Rows())
+	rows, err := buildTx(d.db, clauses).Rows()
+	return rows, ConvertError(err)
 }
 
 // CursorTx FIXME ...
@@ -148,7 +149,7 @@ func (d *Dalgorm) CursorTx(clauses ...dal.Clause) *gorm.DB {
 // Fetch loads row data from `cursor` into `dst`
 func (d *Dalgorm) Fetch(cursor dal.Rows, dst interface{}) errors.Error {
 	if rows, ok := cursor.(*sql.Rows); ok {
-		return errors.Convert(d.db.ScanRows(rows, dst))
+		return ConvertError(d.db.ScanRows(rows, dst))
 	} else {
 		return errors.Default.New(fmt.Sprintf("can not support type %s to be a dal.Rows interface", reflect.TypeOf(cursor).String()))
 	}
@@ -156,49 +157,49 @@ func (d *Dalgorm) Fetch(cursor dal.Rows, dst interface{}) errors.Error {
 
 // All loads matched rows from database to `dst`, USE IT WITH COUTIOUS!!
 func (d *Dalgorm) All(dst interface{}, clauses ...dal.Clause) errors.Error {
-	return errors.Convert(buildTx(d.db, clauses).Find(dst).Error)
+	return ConvertError(buildTx(d.db, clauses).Find(dst).Error)
 }
 
 // First loads first matched row from database to `dst`, error will be returned if no records were found
 func (d *Dalgorm) First(dst interface{}, clauses ...dal.Clause) errors.Error {
-	return errors.Convert(buildTx(d.db, clauses).First(dst).Error)
+	return ConvertError(buildTx(d.db, clauses).First(dst).Error)
 }
 
 // Count total records
 func (d *Dalgorm) Count(clauses ...dal.Clause) (int64, errors.Error) {
 	var count int64
 	err := buildTx(d.db, clauses).Count(&count).Error
-	return errors.Convert01(count, err)
+	return count, ConvertError(err)
 }
 
 // Pluck used to query single column
 func (d *Dalgorm) Pluck(column string, dest interface{}, clauses ...dal.Clause) errors.Error {
-	return errors.Convert(buildTx(d.db, clauses).Pluck(column, dest).Error)
+	return ConvertError(buildTx(d.db, clauses).Pluck(column, dest).Error)
 }
 
 // Create insert record to database
 func (d *Dalgorm) Create(entity interface{}, clauses ...dal.Clause) errors.Error {
-	return errors.Convert(buildTx(d.db, clauses).Create(entity).Error)
+	return ConvertError(buildTx(d.db, clauses).Create(entity).Error)
 }
 
 // Update updates record
 func (d *Dalgorm) Update(entity interface{}, clauses ...dal.Clause) errors.Error {
-	return errors.Convert(buildTx(d.db, clauses).Save(entity).Error)
+	return ConvertError(buildTx(d.db, clauses).Save(entity).Error)
 }
 
 // CreateOrUpdate tries to create the record, or fallback to update all if failed
 func (d *Dalgorm) CreateOrUpdate(entity interface{}, clauses ...dal.Clause) errors.Error {
-	return errors.Convert(buildTx(d.db, clauses).Clauses(clause.OnConflict{UpdateAll: true}).Create(entity).Error)
+	return ConvertError(buildTx(d.db, clauses).Clauses(clause.OnConflict{UpdateAll: true}).Create(entity).Error)
 }
 
 // CreateIfNotExist tries to create the record if not exist
 func (d *Dalgorm) CreateIfNotExist(entity interface{}, clauses ...dal.Clause) errors.Error {
-	return errors.Convert(buildTx(d.db, clauses).Clauses(clause.OnConflict{DoNothing: true}).Create(entity).Error)
+	return ConvertError(buildTx(d.db, clauses).Clauses(clause.OnConflict{DoNothing: true}).Create(entity).Error)
 }
 
 // Delete records from database
 func (d *Dalgorm) Delete(entity interface{}, clauses ...dal.Clause) errors.Error {
-	return errors.Convert(buildTx(d.db, clauses).Delete(entity).Error)
+	return ConvertError(buildTx(d.db, clauses).Delete(entity).Error)
 }
 
 // UpdateColumn allows you to update mulitple records
@@ -206,7 +207,7 @@ func (d *Dalgorm) UpdateColumn(entity interface{}, columnName string, value inte
 	if expr, ok := value.(dal.DalClause); ok {
 		value = gorm.Expr(expr.Expr, transformParams(expr.Params)...)
 	}
-	return errors.Convert(buildTx(d.db, clauses).Model(entity).Update(columnName, value).Error)
+	return ConvertError(buildTx(d.db, clauses).Model(entity).Update(columnName, value).Error)
 }
 
 // UpdateColumns allows you to update multiple columns of mulitple records
@@ -220,19 +221,19 @@ func (d *Dalgorm) UpdateColumns(entity interface{}, set []dal.DalSet, clauses ..
 		updatesSet[s.ColumnName] = s.Value
 	}
 
-	return errors.Convert(buildTx(d.db, clauses).Model(entity).Updates(updatesSet).Error)
+	return ConvertError(buildTx(d.db, clauses).Model(entity).Updates(updatesSet).Error)
 }
 
 // UpdateAllColumn updated all Columns of entity
 func (d *Dalgorm) UpdateAllColumn(entity interface{}, clauses ...dal.Clause) errors.Error {
-	return errors.Convert(buildTx(d.db, clauses).UpdateColumns(entity).Error)
+	return ConvertError(buildTx(d.db, clauses).UpdateColumns(entity).Error)
 }
 
 // GetColumns FIXME ...
 func (d *Dalgorm) GetColumns(dst dal.Tabler, filter func(columnMeta dal.ColumnMeta) bool) (cms []dal.ColumnMeta, _ errors.Error) {
 	columnTypes, err := d.db.Migrator().ColumnTypes(dst.TableName())
 	if err != nil {
-		return nil, errors.Convert(err)
+		return nil, ConvertError(err)
 	}
 	for _, columnType := range columnTypes {
 		if filter == nil {
@@ -265,7 +266,7 @@ func (d *Dalgorm) DropColumns(table string, columnNames ...string) errors.Error
 		err := d.Exec("ALTER TABLE ? DROP COLUMN ?", clause.Table{Name: table}, clause.Column{Name: columnName})
 		// err := d.db.Migrator().DropColumn(table, columnName)
 		if err != nil {
-			return errors.Convert(err)
+			return ConvertError(err)
 		}
 	}
 	return nil
@@ -304,7 +305,7 @@ func (d *Dalgorm) AllTables() ([]string, errors.Error) {
 	var tables []string
 	err := d.db.Raw(tableSql).Scan(&tables).Error
 	if err != nil {
-		return nil, errors.Convert(err)
+		return nil, ConvertError(err)
 	}
 	var filteredTables []string
 	for _, table := range tables {
@@ -317,12 +318,12 @@ func (d *Dalgorm) AllTables() ([]string, errors.Error) {
 
 // DropTables drop multiple tables by Model Pointer or Table Name
 func (d *Dalgorm) DropTables(dst ...interface{}) errors.Error {
-	return errors.Convert(d.db.Migrator().DropTable(dst...))
+	return ConvertError(d.db.Migrator().DropTable(dst...))
 }
 
 // RenameTable renames table name
 func (d *Dalgorm) RenameTable(oldName, newName string) errors.Error {
-	return errors.Convert(d.db.Migrator().RenameTable(oldName, newName))
+	return ConvertError(d.db.Migrator().RenameTable(oldName, newName))
 }
 
 // DropIndexes drops indexes for specified table
@@ -330,7 +331,7 @@ func (d *Dalgorm) DropIndexes(table string, indexNames ...string) errors.Error {
 	for _, indexName := range indexNames {
 		err := d.db.Migrator().DropIndex(table, indexName)
 		if err != nil {
-			return errors.Convert(err)
+			return ConvertError(err)
 		}
 	}
 	return nil
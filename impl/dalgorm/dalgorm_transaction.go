@@ -34,7 +34,7 @@ var _ dal.Transaction = (*DalgormTransaction)(nil)
 func (t *DalgormTransaction) Rollback() errors.Error {
 	r := t.db.Rollback()
 	if r.Error != nil {
-		return errors.Default.Wrap(r.Error, "failed to rollback transaction")
+		return errors.Default.Wrap(ConvertError(r.Error), "failed to rollback transaction")
 	}
 	return nil
 }
@@ -43,7 +43,7 @@ func (t *DalgormTransaction) Rollback() errors.Error {
 func (t *DalgormTransaction) Commit() errors.Error {
 	r := t.db.Commit()
 	if r.Error != nil {
-		return errors.Default.Wrap(r.Error, "failed to commit transaction")
+		return errors.Default.Wrap(ConvertError(r.Error), "failed to commit transaction")
 	}
 	return nil
 }
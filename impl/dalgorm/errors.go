@@ -0,0 +1,59 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dalgorm
+
+import (
+	goerror "errors"
+
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgconn"
+)
+
+// error numbers of mysql: deadlock found when trying to get lock, and lock wait timeout exceeded
+const (
+	mysqlErrDeadlock        = 1213
+	mysqlErrLockWaitTimeout = 1205
+)
+
+// sqlstate of postgres: deadlock detected
+const pgErrDeadlock = "40P01"
+
+// ConvertError converts err returned by the database into errors.Error, deadlocks and lock wait timeouts are
+// converted into errors.Deadlock since they would likely succeed when run again
+func ConvertError(err error) errors.Error {
+	if err == nil {
+		return nil
+	}
+	if isDeadlock(err) {
+		return errors.Deadlock.WrapRaw(err)
+	}
+	return errors.Convert(err)
+}
+
+func isDeadlock(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	if goerror.As(err, &mysqlErr) {
+		return mysqlErr.Number == mysqlErrDeadlock || mysqlErr.Number == mysqlErrLockWaitTimeout
+	}
+	var pgErr *pgconn.PgError
+	if goerror.As(err, &pgErr) {
+		return pgErr.Code == pgErrDeadlock
+	}
+	return false
+}
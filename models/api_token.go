@@ -0,0 +1,62 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/models/common"
+)
+
+const (
+	ROLE_VIEWER   = "viewer"
+	ROLE_OPERATOR = "operator"
+	ROLE_ADMIN    = "admin"
+)
+
+// Roles in the order of privilege, a role is granted everything granted to roles before it
+var Roles = []string{ROLE_VIEWER, ROLE_OPERATOR, ROLE_ADMIN}
+
+// ApiToken grants its Role to requests carrying it in the `Authorization: Bearer <token>` header, only the hash of
+// the token is persisted
+type ApiToken struct {
+	common.Model
+	Name       string     `json:"name" gorm:"type:varchar(100);uniqueIndex" validate:"required"`
+	Role       string     `json:"role" gorm:"type:varchar(20)" validate:"required,oneof=viewer operator admin"`
+	TokenHash  string     `json:"-" gorm:"type:varchar(64);uniqueIndex"`
+	ExpiredAt  *time.Time `json:"expiredAt"`
+	LastUsedAt *time.Time `json:"lastUsedAt"`
+	CreatedBy  string     `json:"createdBy" gorm:"type:varchar(100)"`
+}
+
+func (ApiToken) TableName() string {
+	return "_devlake_api_tokens"
+}
+
+// RoleGrants checks if role is granted everything required by the other role
+func RoleGrants(role string, required string) bool {
+	rank := func(r string) int {
+		for i, known := range Roles {
+			if known == r {
+				return i
+			}
+		}
+		return -1
+	}
+	return rank(role) >= 0 && rank(role) >= rank(required)
+}
@@ -0,0 +1,39 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+)
+
+// AuditLog records a mutation of configurations like blueprints, projects and connections, Before and After are json
+// snapshots of the entity which are encrypted since they might carry sensitive options
+type AuditLog struct {
+	ID         uint64    `gorm:"primaryKey" json:"id"`
+	CreatedAt  time.Time `json:"createdAt" gorm:"index"`
+	Actor      string    `json:"actor" gorm:"type:varchar(100);index"`
+	Action     string    `json:"action" gorm:"type:varchar(20)"`
+	EntityType string    `json:"entityType" gorm:"type:varchar(255);index"`
+	EntityId   string    `json:"entityId" gorm:"type:varchar(255);index"`
+	Before     string    `json:"before" encrypt:"yes"`
+	After      string    `json:"after" encrypt:"yes"`
+}
+
+func (AuditLog) TableName() string {
+	return "_devlake_audit_logs"
+}
@@ -42,6 +42,7 @@ type Blueprint struct {
 	CronConfig   string          `json:"cronConfig" format:"* * * * *" example:"0 0 * * 1"`
 	IsManual     bool            `json:"isManual"`
 	SkipOnFail   bool            `json:"skipOnFail"`
+	Priority     int             `json:"priority"`
 	Labels       []string        `json:"labels"`
 	Settings     json.RawMessage `json:"settings" swaggertype:"array,string" example:"please check api: /blueprints/<PLUGIN_NAME>/blueprint-setting"`
 	common.Model `swaggerignore:"true"`
@@ -53,6 +54,8 @@ type BlueprintSettings struct {
 	Connections json.RawMessage `json:"connections" validate:"required"`
 	BeforePlan  json.RawMessage `json:"before_plan"`
 	AfterPlan   json.RawMessage `json:"after_plan"`
+	// RetryPolicy applies to all tasks in the plan which don't have their own
+	RetryPolicy *core.RetryPolicy `json:"retryPolicy"`
 }
 
 // UnmarshalPlan unmarshals Plan in JSON to strong-typed core.PipelinePlan
@@ -76,6 +79,7 @@ type DbBlueprint struct {
 	CronConfig   string `json:"cronConfig" format:"* * * * *" example:"0 0 * * 1"`
 	IsManual     bool   `json:"isManual"`
 	SkipOnFail   bool   `json:"skipOnFail"`
+	Priority     int    `json:"priority"`
 	Settings     string `json:"settings" encrypt:"yes" swaggertype:"array,string" example:"please check api: /blueprints/<PLUGIN_NAME>/blueprint-setting"`
 	common.Model `swaggerignore:"true"`
 
@@ -0,0 +1,42 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package code
+
+import "github.com/apache/incubator-devlake/models/common"
+
+// RefsReleaseNote summarizes what changed between two refs, Content is rendered as markdown
+type RefsReleaseNote struct {
+	NewRefId         string `gorm:"primaryKey;type:varchar(255)"`
+	OldRefId         string `gorm:"primaryKey;type:varchar(255)"`
+	RepoId           string `gorm:"index;type:varchar(255)"`
+	NewRefName       string `gorm:"type:varchar(255)"`
+	OldRefName       string `gorm:"type:varchar(255)"`
+	NewRefCommitSha  string `gorm:"type:varchar(40)"`
+	OldRefCommitSha  string `gorm:"type:varchar(40)"`
+	CommitCount      int
+	PrCount          int
+	IssueCount       int
+	ContributorCount int
+	CherrypickCount  int
+	Content          string `gorm:"type:text"`
+	common.NoPKModel
+}
+
+func (RefsReleaseNote) TableName() string {
+	return "refs_release_notes"
+}
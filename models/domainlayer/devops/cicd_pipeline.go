@@ -25,15 +25,16 @@ import (
 
 type CICDPipeline struct {
 	domainlayer.DomainEntity
-	Name         string `gorm:"type:varchar(255)"`
-	Result       string `gorm:"type:varchar(100)"`
-	Status       string `gorm:"type:varchar(100)"`
-	Type         string `gorm:"type:varchar(100);comment: to indicate this is CI or CD"`
-	DurationSec  uint64
-	Environment  string `gorm:"type:varchar(255)"`
-	CreatedDate  time.Time
-	FinishedDate *time.Time
-	CicdScopeId  string `gorm:"index;type:varchar(255)"`
+	Name              string `gorm:"type:varchar(255)"`
+	Result            string `gorm:"type:varchar(100)"`
+	Status            string `gorm:"type:varchar(100)"`
+	Type              string `gorm:"type:varchar(100);comment: to indicate this is CI or CD"`
+	DurationSec       uint64
+	QueuedDurationSec uint64 // time waiting in queue for an executor before running, excluded from DurationSec
+	Environment       string `gorm:"type:varchar(255)"`
+	CreatedDate       time.Time
+	FinishedDate      *time.Time
+	CicdScopeId       string `gorm:"index;type:varchar(255)"`
 }
 
 func (CICDPipeline) TableName() string {
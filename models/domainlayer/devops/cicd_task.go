@@ -38,16 +38,17 @@ const (
 
 type CICDTask struct {
 	domainlayer.DomainEntity
-	Name         string `gorm:"type:varchar(255)"`
-	PipelineId   string `gorm:"index;type:varchar(255)"`
-	Result       string `gorm:"type:varchar(100)"`
-	Status       string `gorm:"type:varchar(100)"`
-	Type         string `gorm:"type:varchar(100);comment: to indicate this is CI or CD"`
-	Environment  string `gorm:"type:varchar(255)"`
-	DurationSec  uint64
-	StartedDate  time.Time
-	FinishedDate *time.Time
-	CicdScopeId  string `gorm:"index;type:varchar(255)"`
+	Name            string `gorm:"type:varchar(255)"`
+	PipelineId      string `gorm:"index;type:varchar(255)"`
+	Result          string `gorm:"type:varchar(100)"`
+	Status          string `gorm:"type:varchar(100)"`
+	Type            string `gorm:"type:varchar(100);comment: to indicate this is CI or CD"`
+	Environment     string `gorm:"type:varchar(255)"`
+	DurationSec     uint64
+	WaitDurationSec uint64 // time waiting for manual input or approval, excluded from DurationSec
+	StartedDate     time.Time
+	FinishedDate    *time.Time
+	CicdScopeId     string `gorm:"index;type:varchar(255)"`
 }
 
 func (CICDTask) TableName() string {
@@ -0,0 +1,45 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package devops
+
+import (
+	"github.com/apache/incubator-devlake/models/domainlayer"
+)
+
+// this is for the field `result` in table.cicd_test_results besides SUCCESS and FAILURE
+const (
+	SKIPPED = "SKIPPED"
+)
+
+type CICDTestResult struct {
+	domainlayer.DomainEntity
+	PipelineId   string `gorm:"index;type:varchar(255)"`
+	CicdScopeId  string `gorm:"index;type:varchar(255)"`
+	SuiteName    string `gorm:"type:varchar(255)"`
+	ClassName    string `gorm:"type:varchar(255)"`
+	Name         string `gorm:"type:varchar(255)"`
+	Result       string `gorm:"type:varchar(100)"`
+	DurationSec  float64
+	ErrorMessage string
+	RerunCount   int  // times the test was rerun within the pipeline before getting the result
+	IsFlaky      bool // the test failed at first but passed on rerun
+}
+
+func (CICDTestResult) TableName() string {
+	return "cicd_test_results"
+}
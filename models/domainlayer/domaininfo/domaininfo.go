@@ -40,11 +40,13 @@ func GetDomainTablesInfo() []Tabler {
 		&code.PullRequestComment{},
 		&code.PullRequestCommit{},
 		&code.PullRequestLabel{},
+		&code.PullRequestReviewer{},
 		&code.Ref{},
 		&code.CommitsDiff{},
 		&code.RefCommit{},
 		&code.FinishedCommitsDiff{},
 		&code.RefsPrCherrypick{},
+		&code.RefsReleaseNote{},
 		&code.Repo{},
 		&code.RepoCommit{},
 		&code.RepoLanguage{},
@@ -58,11 +60,13 @@ func GetDomainTablesInfo() []Tabler {
 		&crossdomain.RefsIssuesDiffs{},
 		&crossdomain.Team{},
 		&crossdomain.TeamUser{},
+		&crossdomain.TeamUserHistory{},
 		&crossdomain.User{},
 		&crossdomain.UserAccount{},
 		// devops
 		&devops.CICDPipeline{},
 		&devops.CICDTask{},
+		&devops.CICDTestResult{},
 		// didgen no table
 		// ticket
 		&ticket.Board{},
@@ -0,0 +1,60 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+	"github.com/apache/incubator-devlake/plugins/core"
+)
+
+var _ core.MigrationScript = (*addResumeToTasks)(nil)
+
+type task20221208 struct {
+	ResumeFrom uint64
+}
+
+func (task20221208) TableName() string {
+	return "_devlake_tasks"
+}
+
+type subtask20221208 struct {
+	Status string
+}
+
+func (subtask20221208) TableName() string {
+	return "_devlake_subtasks"
+}
+
+type addResumeToTasks struct{}
+
+func (*addResumeToTasks) Up(basicRes core.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&task20221208{},
+		&subtask20221208{},
+	)
+}
+
+func (*addResumeToTasks) Version() uint64 {
+	return 20221208000001
+}
+
+func (*addResumeToTasks) Name() string {
+	return "add resume_from to _devlake_tasks and status to _devlake_subtasks"
+}
@@ -0,0 +1,54 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+	"github.com/apache/incubator-devlake/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/plugins/core"
+)
+
+var _ core.MigrationScript = (*addApiTokens)(nil)
+
+type pipeline20221211 struct {
+	TriggeredBy string `gorm:"type:varchar(100)"`
+	CancelledBy string `gorm:"type:varchar(100)"`
+}
+
+func (pipeline20221211) TableName() string {
+	return "_devlake_pipelines"
+}
+
+type addApiTokens struct{}
+
+func (*addApiTokens) Up(basicRes core.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&archived.ApiToken{},
+		&pipeline20221211{},
+	)
+}
+
+func (*addApiTokens) Version() uint64 {
+	return 20221211000001
+}
+
+func (*addApiTokens) Name() string {
+	return "add _devlake_api_tokens and triggered_by/cancelled_by to _devlake_pipelines"
+}
@@ -0,0 +1,51 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+	"github.com/apache/incubator-devlake/plugins/core"
+)
+
+var _ core.MigrationScript = (*addWaitDurationToCicdTasks)(nil)
+
+type cicdTask20221224 struct {
+	WaitDurationSec uint64
+}
+
+func (cicdTask20221224) TableName() string {
+	return "cicd_tasks"
+}
+
+type addWaitDurationToCicdTasks struct{}
+
+func (*addWaitDurationToCicdTasks) Up(basicRes core.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&cicdTask20221224{},
+	)
+}
+
+func (*addWaitDurationToCicdTasks) Version() uint64 {
+	return 20221224000001
+}
+
+func (*addWaitDurationToCicdTasks) Name() string {
+	return "add wait_duration_sec to cicd_tasks"
+}
@@ -0,0 +1,53 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+	"github.com/apache/incubator-devlake/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/plugins/core"
+)
+
+var _ core.MigrationScript = (*addCicdTestResults)(nil)
+
+type cicdPipeline20221225 struct {
+	QueuedDurationSec uint64
+}
+
+func (cicdPipeline20221225) TableName() string {
+	return "cicd_pipelines"
+}
+
+type addCicdTestResults struct{}
+
+func (*addCicdTestResults) Up(basicRes core.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&archived.CICDTestResult{},
+		&cicdPipeline20221225{},
+	)
+}
+
+func (*addCicdTestResults) Version() uint64 {
+	return 20221225000001
+}
+
+func (*addCicdTestResults) Name() string {
+	return "add cicd_test_results and queued_duration_sec to cicd_pipelines"
+}
@@ -0,0 +1,36 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archived
+
+import (
+	"time"
+)
+
+type ApiToken struct {
+	Model
+	Name       string `gorm:"type:varchar(100);uniqueIndex"`
+	Role       string `gorm:"type:varchar(20)"`
+	TokenHash  string `gorm:"type:varchar(64);uniqueIndex"`
+	ExpiredAt  *time.Time
+	LastUsedAt *time.Time
+	CreatedBy  string `gorm:"type:varchar(100)"`
+}
+
+func (ApiToken) TableName() string {
+	return "_devlake_api_tokens"
+}
@@ -64,5 +64,18 @@ func All() []core.MigrationScript {
 		new(renameFiledsInProjectPrMetric),
 		new(addEnableToProjectMetric),
 		new(addCollectorMeta20221125),
+		new(addResumeToTasks),
+		new(addRawDataRetention),
+		new(addApiCallsToSubtasks),
+		new(addApiTokens),
+		new(addAuditLogs),
+		new(addRetryPolicyToTasks),
+		new(addPriorityToBlueprints),
+		new(addRateLimitQuotas),
+		new(addPullRequestReviewers),
+		new(addWaitDurationToCicdTasks),
+		new(addCicdTestResults),
+		new(addTeamUserHistories),
+		new(addRefsReleaseNotes),
 	}
 }
@@ -40,6 +40,9 @@ type Pipeline struct {
 	SpentSeconds  int            `json:"spentSeconds"`
 	Stage         int            `json:"stage"`
 	Labels        []string       `json:"labels"`
+	TriggeredBy   string         `json:"triggeredBy"`
+	CancelledBy   string         `json:"cancelledBy"`
+	Priority      int            `json:"priority"`
 }
 
 // We use a 2D array because the request body must be an array of a set of tasks
@@ -49,6 +52,10 @@ type NewPipeline struct {
 	Plan        core.PipelinePlan `json:"plan" swaggertype:"array,string" example:"please check api /pipelines/<PLUGIN_NAME>/pipeline-plan"`
 	Labels      []string          `json:"labels"`
 	BlueprintId uint64
+	// Priority decides the order of pipelines waiting in the queue, the higher the sooner
+	Priority int `json:"priority"`
+	// TriggeredBy is the name of the api token or `cron`
+	TriggeredBy string `json:"-"`
 }
 
 type DbPipeline struct {
@@ -64,6 +71,9 @@ type DbPipeline struct {
 	Message       string     `json:"message"`
 	SpentSeconds  int        `json:"spentSeconds"`
 	Stage         int        `json:"stage"`
+	TriggeredBy   string     `json:"triggeredBy" gorm:"type:varchar(100)"`
+	CancelledBy   string     `json:"cancelledBy" gorm:"type:varchar(100)"`
+	Priority      int        `json:"priority" gorm:"index"`
 
 	Labels []DbPipelineLabel `json:"-" gorm:"-"`
 }
@@ -0,0 +1,34 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import "time"
+
+// RateLimitQuota is the remaining request quota of a connection, shared by all api clients working on the connection
+// across tasks and devlake instances
+type RateLimitQuota struct {
+	ConnectionKey string    `json:"connectionKey" gorm:"primaryKey;type:varchar(255)"`
+	Remaining     int       `json:"remaining"`
+	Leased        int       `json:"leased"` // requests taken by api clients but not sent yet
+	ResetAt       time.Time `json:"resetAt"`
+	UpdatedAt     time.Time `json:"updatedAt"`
+}
+
+func (RateLimitQuota) TableName() string {
+	return "_devlake_rate_limit_quotas"
+}
@@ -0,0 +1,52 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/models/common"
+)
+
+// RawDataRetentionPolicy limits how much history is kept in `_raw_*` tables. A policy with empty RawDataTable applies
+// to all raw tables of the plugin, and a table specific policy overrides it
+type RawDataRetentionPolicy struct {
+	common.Model
+	Plugin       string `json:"plugin" gorm:"type:varchar(100);uniqueIndex:idx_raw_data_retention_policy"`
+	RawDataTable string `json:"rawDataTable" gorm:"type:varchar(255);uniqueIndex:idx_raw_data_retention_policy"`
+	// KeepCollections is the number of latest collections to be kept for every RawDataParams
+	KeepCollections int `json:"keepCollections"`
+	// KeepDays is the number of days raw data to be kept
+	KeepDays int `json:"keepDays"`
+}
+
+func (RawDataRetentionPolicy) TableName() string {
+	return "_devlake_raw_data_retention_policies"
+}
+
+// RawDataCollection records the start of a collection into a raw table, collections are the unit of raw data retention
+type RawDataCollection struct {
+	ID            uint64    `gorm:"primaryKey" json:"id"`
+	RawDataTable  string    `gorm:"type:varchar(255);index" json:"rawDataTable"`
+	RawDataParams string    `gorm:"type:varchar(255);index" json:"rawDataParams"`
+	BeganAt       time.Time `json:"beganAt"`
+}
+
+func (RawDataCollection) TableName() string {
+	return "_devlake_raw_data_collections"
+}
@@ -63,6 +63,12 @@ type Task struct {
 	FinishedAt    *time.Time `json:"finishedAt" gorm:"index"`
 	SpentSeconds  int        `json:"spentSeconds"`
 	SkipOnFail    bool       `json:"-"`
+	// ResumeFrom is the id of the failed task this one was spawned from, subtasks finished by it would be skipped
+	ResumeFrom uint64 `json:"resumeFrom"`
+	// RetryPolicy is the json of core.RetryPolicy, failed runs would be retried in place according to it
+	RetryPolicy datatypes.JSON `json:"retryPolicy"`
+	// Attempts is the number of runs the task has taken so far
+	Attempts int `json:"attempts"`
 }
 
 type NewTask struct {
@@ -81,6 +87,8 @@ type Subtask struct {
 	BeganAt      *time.Time `json:"beganAt"`
 	FinishedAt   *time.Time `json:"finishedAt" gorm:"index"`
 	SpentSeconds int64      `json:"spentSeconds"`
+	Status       string     `json:"status"`
+	ApiCalls     int        `json:"apiCalls"`
 }
 
 func (Task) TableName() string {
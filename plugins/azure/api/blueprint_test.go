@@ -0,0 +1,62 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/apache/incubator-devlake/plugins/azure/models"
+	"github.com/apache/incubator-devlake/plugins/azure/tasks"
+	"github.com/apache/incubator-devlake/plugins/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMakePipelinePlan(t *testing.T) {
+	scope := []*core.BlueprintScopeV100{
+		{
+			Entities: []string{core.DOMAIN_TYPE_CICD, core.DOMAIN_TYPE_TICKET},
+			Options:  json.RawMessage(`{"project": "devlake"}`),
+			Transformation: json.RawMessage(`{
+				"deploymentPattern": "(?i)deploy",
+				"productionPattern": "(?i)prod",
+				"typeMappings": {
+					"User Story": {
+						"standardType": "REQUIREMENT",
+						"statusMappings": {"Resolved": {"standardStatus": "DONE"}}
+					}
+				}
+			}`),
+		},
+	}
+	plan, err := MakePipelinePlan(nil, 1, scope)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(plan))
+	assert.Equal(t, "azure", plan[0][0].Plugin)
+
+	op, err := tasks.DecodeAndValidateTaskOptions(plan[0][0].Options)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(1), op.ConnectionId)
+	assert.Equal(t, "devlake", op.Project)
+	assert.Equal(t, "(?i)deploy", op.DeploymentPattern)
+	assert.Equal(t, "(?i)prod", op.ProductionPattern)
+	assert.Equal(t, models.TypeMapping{
+		StandardType:   "REQUIREMENT",
+		StatusMappings: models.StatusMappings{"Resolved": {StandardStatus: "DONE"}},
+	}, op.TypeMappings["User Story"])
+}
@@ -0,0 +1,167 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/models/domainlayer"
+	"github.com/apache/incubator-devlake/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/plugins/azure/models"
+	"github.com/apache/incubator-devlake/plugins/azure/tasks"
+	"github.com/apache/incubator-devlake/plugins/core"
+	"github.com/apache/incubator-devlake/plugins/core/dal"
+	"github.com/apache/incubator-devlake/plugins/helper"
+	"github.com/apache/incubator-devlake/utils"
+	"github.com/mitchellh/mapstructure"
+)
+
+func MakeDataSourcePipelinePlanV200(subtaskMetas []core.SubTaskMeta, connectionId uint64, bpScopes []*core.BlueprintScopeV200) (core.PipelinePlan, []core.Scope, errors.Error) {
+	connection := &models.AzureConnection{}
+	err := connectionHelper.FirstById(connection, connectionId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	plan, err := makeDataSourcePipelinePlanV200(subtaskMetas, bpScopes, connection)
+	if err != nil {
+		return nil, nil, err
+	}
+	scopes, err := makeScopesV200(bpScopes, connection)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return plan, scopes, nil
+}
+
+// makeDataSourcePipelinePlanV200 makes a stage for every project the repos belong to, since all repos of a project
+// are collected by the same task
+func makeDataSourcePipelinePlanV200(
+	subtaskMetas []core.SubTaskMeta,
+	bpScopes []*core.BlueprintScopeV200,
+	connection *models.AzureConnection,
+) (core.PipelinePlan, errors.Error) {
+	var projectIds []string
+	ruleIds := make(map[string]uint64)
+	entities := make(map[string][]string)
+	for _, bpScope := range bpScopes {
+		azureRepo, err := getAzureRepo(connection.ID, bpScope.Id)
+		if err != nil {
+			return nil, err
+		}
+		projectId := azureRepo.ProjectId
+		if ruleId, ok := ruleIds[projectId]; !ok {
+			projectIds = append(projectIds, projectId)
+			ruleIds[projectId] = azureRepo.TransformationRuleId
+		} else if ruleId != azureRepo.TransformationRuleId {
+			return nil, errors.BadInput.New(fmt.Sprintf("repos of the Azure project %s must share the same transformation rule", projectId))
+		}
+		for _, entity := range bpScope.Entities {
+			if !utils.StringsContains(entities[projectId], entity) {
+				entities[projectId] = append(entities[projectId], entity)
+			}
+		}
+	}
+
+	plan := make(core.PipelinePlan, 0, len(projectIds))
+	for _, projectId := range projectIds {
+		transformationRule := &models.AzureTransformationRule{}
+		if ruleIds[projectId] != 0 {
+			err := basicRes.GetDal().First(transformationRule, dal.Where(`id = ?`, ruleIds[projectId]))
+			if err != nil {
+				return nil, errors.Default.Wrap(err, fmt.Sprintf("fail to find transformation rule %d", ruleIds[projectId]))
+			}
+		}
+		var transformationRuleMap map[string]interface{}
+		err := errors.Convert(mapstructure.Decode(transformationRule, &transformationRuleMap))
+		if err != nil {
+			return nil, err
+		}
+		options := map[string]interface{}{
+			"connectionId":         connection.ID,
+			"project":              projectId,
+			"transformationRuleId": ruleIds[projectId],
+			"transformationRules":  transformationRuleMap,
+		}
+		// make sure task options is valid
+		_, err = tasks.DecodeAndValidateTaskOptions(options)
+		if err != nil {
+			return nil, err
+		}
+		subtasks, err := helper.MakePipelinePlanSubtasks(subtaskMetas, entities[projectId])
+		if err != nil {
+			return nil, err
+		}
+		plan = append(plan, core.PipelineStage{
+			{
+				Plugin:   "azure",
+				Subtasks: subtasks,
+				Options:  options,
+			},
+		})
+	}
+	return plan, nil
+}
+
+func makeScopesV200(bpScopes []*core.BlueprintScopeV200, connection *models.AzureConnection) ([]core.Scope, errors.Error) {
+	scopes := make([]core.Scope, 0)
+	repoIdGen := didgen.NewDomainIdGenerator(&models.AzureRepo{})
+	for _, bpScope := range bpScopes {
+		azureRepo, err := getAzureRepo(connection.ID, bpScope.Id)
+		if err != nil {
+			return nil, err
+		}
+		if utils.StringsContains(bpScope.Entities, core.DOMAIN_TYPE_CODE_REVIEW) ||
+			utils.StringsContains(bpScope.Entities, core.DOMAIN_TYPE_CODE) ||
+			utils.StringsContains(bpScope.Entities, core.DOMAIN_TYPE_CROSS) {
+			scopeRepo := &code.Repo{
+				DomainEntity: domainlayer.DomainEntity{
+					Id: repoIdGen.Generate(connection.ID, azureRepo.AzureId),
+				},
+				Name: azureRepo.Name,
+				Url:  azureRepo.WebUrl,
+			}
+			scopes = append(scopes, scopeRepo)
+		}
+		// builds are attached to the repos they are running on, see ConvertBuilds
+		if utils.StringsContains(bpScope.Entities, core.DOMAIN_TYPE_CICD) {
+			scopeCICD := &devops.CicdScope{
+				DomainEntity: domainlayer.DomainEntity{
+					Id: repoIdGen.Generate(connection.ID, azureRepo.AzureId),
+				},
+				Name: azureRepo.Name,
+				Url:  azureRepo.WebUrl,
+			}
+			scopes = append(scopes, scopeCICD)
+		}
+	}
+	return scopes, nil
+}
+
+func getAzureRepo(connectionId uint64, repoId string) (*models.AzureRepo, errors.Error) {
+	azureRepo := &models.AzureRepo{}
+	err := basicRes.GetDal().First(azureRepo, dal.Where(`connection_id = ? AND azure_id = ?`, connectionId, repoId))
+	if err != nil {
+		return nil, errors.Default.Wrap(err, fmt.Sprintf("fail to find repo %s", repoId))
+	}
+	return azureRepo, nil
+}
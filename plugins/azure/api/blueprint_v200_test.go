@@ -0,0 +1,184 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/mocks"
+	"github.com/apache/incubator-devlake/models/common"
+	"github.com/apache/incubator-devlake/models/domainlayer"
+	"github.com/apache/incubator-devlake/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/plugins/azure/models"
+	"github.com/apache/incubator-devlake/plugins/core"
+	"github.com/apache/incubator-devlake/plugins/helper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestMakeDataSourcePipelinePlanV200(t *testing.T) {
+	mockMeta := mocks.NewPluginMeta(t)
+	mockMeta.On("RootPkgPath").Return("github.com/apache/incubator-devlake/plugins/azure")
+	err := core.RegisterPlugin("azure", mockMeta)
+	assert.Nil(t, err)
+	bpScopes := []*core.BlueprintScopeV200{
+		{
+			Entities: []string{core.DOMAIN_TYPE_CODE},
+			Id:       "repo-1",
+		},
+		{
+			Entities: []string{core.DOMAIN_TYPE_CICD},
+			Id:       "repo-2",
+		},
+	}
+
+	connection := &models.AzureConnection{
+		RestConnection: helper.RestConnection{
+			BaseConnection: helper.BaseConnection{
+				Name: "azure",
+				Model: common.Model{
+					ID: 1,
+				},
+			},
+		},
+	}
+	basicRes = NewMockBasicRes(true)
+	plan, err := makeDataSourcePipelinePlanV200(nil, bpScopes, connection)
+	assert.Nil(t, err)
+	basicRes = NewMockBasicRes(false)
+	scopes, err := makeScopesV200(bpScopes, connection)
+	assert.Nil(t, err)
+
+	// both repos belong to the same project, so they are collected by a single task
+	expectPlan := core.PipelinePlan{
+		core.PipelineStage{
+			{
+				Plugin:   "azure",
+				Subtasks: []string{},
+				Options: map[string]interface{}{
+					"connectionId":         uint64(1),
+					"project":              "project-1",
+					"transformationRuleId": uint64(1),
+					"transformationRules": map[string]interface{}{
+						"name":              "azure transformation rule",
+						"deploymentPattern": "deploy",
+						"productionPattern": "prod",
+						"typeMappings":      models.TypeMappings(nil),
+					},
+				},
+			},
+		},
+	}
+	assert.Equal(t, expectPlan, plan)
+
+	expectScopes := []core.Scope{
+		&code.Repo{
+			DomainEntity: domainlayer.DomainEntity{
+				Id: "azure:AzureRepo:1:repo-1",
+			},
+			Name: "repo one",
+			Url:  "https://dev.azure.com/org/project-1/_git/repo-1",
+		},
+		&devops.CicdScope{
+			DomainEntity: domainlayer.DomainEntity{
+				Id: "azure:AzureRepo:1:repo-2",
+			},
+			Name: "repo two",
+			Url:  "https://dev.azure.com/org/project-1/_git/repo-2",
+		},
+	}
+	assert.Equal(t, expectScopes, scopes)
+}
+
+func TestMakeDataSourcePipelinePlanV200WithDifferentRules(t *testing.T) {
+	bpScopes := []*core.BlueprintScopeV200{
+		{Entities: []string{core.DOMAIN_TYPE_CODE}, Id: "repo-1"},
+		{Entities: []string{core.DOMAIN_TYPE_CODE}, Id: "repo-2"},
+	}
+	connection := &models.AzureConnection{}
+	connection.ID = 1
+
+	mockRes := new(mocks.BasicRes)
+	mockDal := new(mocks.Dal)
+	for _, repo := range []*models.AzureRepo{
+		{ConnectionId: 1, AzureId: "repo-1", ProjectId: "project-1", TransformationRuleId: 1},
+		{ConnectionId: 1, AzureId: "repo-2", ProjectId: "project-1", TransformationRuleId: 2},
+	} {
+		repo := repo
+		mockDal.On("First", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			*args.Get(0).(*models.AzureRepo) = *repo
+		}).Return(nil).Once()
+	}
+	mockRes.On("GetDal").Return(mockDal)
+	basicRes = mockRes
+
+	_, err := makeDataSourcePipelinePlanV200(nil, bpScopes, connection)
+	assert.NotNil(t, err)
+}
+
+// NewMockBasicRes returns repo-1 and repo-2 of project-1 in order, followed by their transformation rule if withRule
+func NewMockBasicRes(withRule bool) *mocks.BasicRes {
+	repos := []*models.AzureRepo{
+		{
+			ConnectionId:         1,
+			AzureId:              "repo-1",
+			Name:                 "repo one",
+			ProjectId:            "project-1",
+			TransformationRuleId: 1,
+			WebUrl:               "https://dev.azure.com/org/project-1/_git/repo-1",
+		},
+		{
+			ConnectionId:         1,
+			AzureId:              "repo-2",
+			Name:                 "repo two",
+			ProjectId:            "project-1",
+			TransformationRuleId: 1,
+			WebUrl:               "https://dev.azure.com/org/project-1/_git/repo-2",
+		},
+	}
+	transformationRule := &models.AzureTransformationRule{
+		Model: common.Model{
+			ID: 1,
+		},
+		Name:              "azure transformation rule",
+		DeploymentPattern: "deploy",
+		ProductionPattern: "prod",
+	}
+	mockRes := new(mocks.BasicRes)
+	mockDal := new(mocks.Dal)
+
+	for _, repo := range repos {
+		repo := repo
+		mockDal.On("First", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			dst := args.Get(0).(*models.AzureRepo)
+			*dst = *repo
+		}).Return(nil).Once()
+	}
+	if withRule {
+		mockDal.On("First", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			dst := args.Get(0).(*models.AzureTransformationRule)
+			*dst = *transformationRule
+		}).Return(nil).Once()
+	}
+
+	mockRes.On("GetDal").Return(mockDal)
+	mockRes.On("GetConfig", mock.Anything).Return("")
+
+	return mockRes
+}
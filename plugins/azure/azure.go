@@ -31,11 +31,17 @@ func main() {
 
 	connectionId := cmd.Flags().Uint64P("connection", "c", 1, "azure connection id")
 	project := cmd.Flags().StringP("project", "p", "", "azure project name")
+	deploymentPattern := cmd.Flags().String("deploymentPattern", "(?i)deploy", "deployment pattern of builds and jobs")
+	productionPattern := cmd.Flags().String("productionPattern", "(?i)prod", "production pattern of builds and jobs")
 
 	cmd.Run = func(cmd *cobra.Command, args []string) {
 		runner.DirectRun(cmd, args, PluginEntry, map[string]interface{}{
 			"connectionId": *connectionId,
 			"project":      *project,
+			"transformationRules": map[string]interface{}{
+				"deploymentPattern": *deploymentPattern,
+				"productionPattern": *productionPattern,
+			},
 		})
 	}
 	runner.RunCmd(cmd)
@@ -0,0 +1,83 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/models/common"
+	"github.com/apache/incubator-devlake/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/plugins/azure/impl"
+	"github.com/apache/incubator-devlake/plugins/azure/models"
+	"github.com/apache/incubator-devlake/plugins/azure/tasks"
+)
+
+func TestAzurePullRequestDataFlow(t *testing.T) {
+	var azure impl.Azure
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "azure", azure)
+
+	taskData := &tasks.AzureTaskData{
+		Options: &tasks.AzureOptions{
+			ConnectionId:            1,
+			Project:                 "test",
+			AzureTransformationRule: new(models.AzureTransformationRule),
+		},
+		ProjectId: "30473eea-ca3f-4f40-a711-9cfa2e75e4b0",
+	}
+
+	// import raw data table
+	// SELECT * FROM _raw_azure_api_pull_requests INTO OUTFILE "/tmp/_raw_azure_api_pull_requests.csv" FIELDS TERMINATED BY ',' OPTIONALLY ENCLOSED BY '"' LINES TERMINATED BY '\r\n';
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_azure_api_pull_requests.csv", "_raw_azure_api_pull_requests")
+
+	// verify extraction
+	dataflowTester.FlushTabler(&models.AzurePullRequest{})
+	dataflowTester.Subtask(tasks.ExtractApiPullRequestsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&models.AzurePullRequest{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_azure_pull_requests.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+
+	// verify conversion
+	dataflowTester.FlushTabler(&code.PullRequest{})
+	dataflowTester.Subtask(tasks.ConvertPullRequestsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&code.PullRequest{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/pull_requests.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+
+	// import raw data table
+	// SELECT * FROM _raw_azure_api_pull_request_threads INTO OUTFILE "/tmp/_raw_azure_api_pull_request_threads.csv" FIELDS TERMINATED BY ',' OPTIONALLY ENCLOSED BY '"' LINES TERMINATED BY '\r\n';
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_azure_api_pull_request_threads.csv", "_raw_azure_api_pull_request_threads")
+
+	// verify extraction
+	dataflowTester.FlushTabler(&models.AzurePrComment{})
+	dataflowTester.Subtask(tasks.ExtractApiPullRequestThreadsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&models.AzurePrComment{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_azure_pr_comments.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+
+	// verify conversion
+	dataflowTester.FlushTabler(&code.PullRequestComment{})
+	dataflowTester.Subtask(tasks.ConvertPullRequestCommentsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&code.PullRequestComment{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/pull_request_comments.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+}
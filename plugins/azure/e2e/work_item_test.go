@@ -0,0 +1,157 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/models/common"
+	"github.com/apache/incubator-devlake/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/plugins/azure/impl"
+	"github.com/apache/incubator-devlake/plugins/azure/models"
+	"github.com/apache/incubator-devlake/plugins/azure/tasks"
+)
+
+func TestAzureWorkItemDataFlow(t *testing.T) {
+	var azure impl.Azure
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "azure", azure)
+
+	taskData := &tasks.AzureTaskData{
+		Options: &tasks.AzureOptions{
+			ConnectionId: 1,
+			Project:      "test",
+			AzureTransformationRule: &models.AzureTransformationRule{
+				TypeMappings: models.TypeMappings{
+					"Feature": models.TypeMapping{
+						StandardType: "Epic",
+						StatusMappings: models.StatusMappings{
+							"Doing": models.StatusMapping{StandardStatus: ticket.IN_PROGRESS},
+						},
+					},
+				},
+			},
+		},
+		ProjectId: "30473eea-ca3f-4f40-a711-9cfa2e75e4b0",
+	}
+
+	// import raw data table
+	// SELECT * FROM _raw_azure_api_classification_nodes INTO OUTFILE "/tmp/_raw_azure_api_classification_nodes.csv" FIELDS TERMINATED BY ',' OPTIONALLY ENCLOSED BY '"' LINES TERMINATED BY '\r\n';
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_azure_api_classification_nodes.csv", "_raw_azure_api_classification_nodes")
+
+	// verify extraction
+	dataflowTester.FlushTabler(&models.AzureArea{})
+	dataflowTester.FlushTabler(&models.AzureIteration{})
+	dataflowTester.Subtask(tasks.ExtractApiClassificationNodesMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&models.AzureArea{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_azure_areas.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(&models.AzureIteration{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_azure_iterations.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+
+	// verify conversion
+	dataflowTester.FlushTabler(&ticket.Board{})
+	dataflowTester.Subtask(tasks.ConvertAreasMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&ticket.Board{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/boards.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+
+	dataflowTester.FlushTabler(&ticket.Sprint{})
+	dataflowTester.FlushTabler(&ticket.BoardSprint{})
+	dataflowTester.Subtask(tasks.ConvertIterationsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&ticket.Sprint{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/sprints.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(&ticket.BoardSprint{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/board_sprints.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+
+	// import raw data table
+	// SELECT * FROM _raw_azure_api_work_items INTO OUTFILE "/tmp/_raw_azure_api_work_items.csv" FIELDS TERMINATED BY ',' OPTIONALLY ENCLOSED BY '"' LINES TERMINATED BY '\r\n';
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_azure_api_work_items.csv", "_raw_azure_api_work_items")
+
+	// verify extraction
+	dataflowTester.FlushTabler(&models.AzureWorkItem{})
+	dataflowTester.FlushTabler(&models.AzureWorkItemLink{})
+	dataflowTester.Subtask(tasks.ExtractApiWorkItemsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&models.AzureWorkItem{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_azure_work_items.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(&models.AzureWorkItemLink{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_azure_work_item_links.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+
+	// verify conversion
+	dataflowTester.FlushTabler(&ticket.Issue{})
+	dataflowTester.FlushTabler(&ticket.BoardIssue{})
+	dataflowTester.FlushTabler(&ticket.SprintIssue{})
+	dataflowTester.Subtask(tasks.ConvertWorkItemsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&ticket.Issue{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/issues.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(&ticket.BoardIssue{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/board_issues.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(&ticket.SprintIssue{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/sprint_issues.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+
+	dataflowTester.FlushTabler(&crossdomain.IssueCommit{})
+	dataflowTester.FlushTabler(&crossdomain.PullRequestIssue{})
+	dataflowTester.Subtask(tasks.ConvertWorkItemLinksMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&crossdomain.IssueCommit{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/issue_commits.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(&crossdomain.PullRequestIssue{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/pull_request_issues.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+
+	// import raw data table
+	// SELECT * FROM _raw_azure_api_work_item_revisions INTO OUTFILE "/tmp/_raw_azure_api_work_item_revisions.csv" FIELDS TERMINATED BY ',' OPTIONALLY ENCLOSED BY '"' LINES TERMINATED BY '\r\n';
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_azure_api_work_item_revisions.csv", "_raw_azure_api_work_item_revisions")
+
+	// verify extraction
+	dataflowTester.FlushTabler(&models.AzureWorkItemRevision{})
+	dataflowTester.Subtask(tasks.ExtractApiWorkItemRevisionsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&models.AzureWorkItemRevision{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_azure_work_item_revisions.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+
+	// verify conversion
+	dataflowTester.FlushTabler(&ticket.IssueChangelogs{})
+	dataflowTester.Subtask(tasks.ConvertWorkItemRevisionsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&ticket.IssueChangelogs{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/issue_changelogs.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/apache/incubator-devlake/plugins/azure/models/migrationscripts"
 	"github.com/apache/incubator-devlake/plugins/azure/tasks"
 	"github.com/apache/incubator-devlake/plugins/core"
+	"github.com/apache/incubator-devlake/plugins/core/dal"
 	"github.com/apache/incubator-devlake/plugins/helper"
 	"github.com/spf13/viper"
 	"gorm.io/gorm"
@@ -39,6 +40,8 @@ var _ core.PluginApi = (*Azure)(nil)
 var _ core.PluginModel = (*Azure)(nil)
 var _ core.CloseablePluginTask = (*Azure)(nil)
 var _ core.PluginMigration = (*Azure)(nil)
+var _ core.PluginBlueprintV100 = (*Azure)(nil)
+var _ core.DataSourcePluginBlueprintV200 = (*Azure)(nil)
 
 // PluginEntry exports for Framework to search and load
 var PluginEntry Azure //nolint
@@ -58,8 +61,18 @@ func (plugin Azure) GetTablesInfo() []core.Tabler {
 	return []core.Tabler{
 		&models.AzureBuild{},
 		&models.AzureBuildDefinition{},
+		&models.AzureArea{},
 		&models.AzureConnection{},
+		&models.AzureIteration{},
+		&models.AzurePrComment{},
+		&models.AzurePrCommit{},
+		&models.AzurePullRequest{},
 		&models.AzureRepo{},
+		&models.AzureTimelineRecord{},
+		&models.AzureTransformationRule{},
+		&models.AzureWorkItem{},
+		&models.AzureWorkItemLink{},
+		&models.AzureWorkItemRevision{},
 	}
 }
 
@@ -69,6 +82,33 @@ func (plugin Azure) SubTaskMetas() []core.SubTaskMeta {
 		tasks.ExtractApiRepoMeta,
 		tasks.CollectApiBuildDefinitionMeta,
 		tasks.ExtractApiBuildDefinitionMeta,
+		tasks.CollectApiPullRequestsMeta,
+		tasks.ExtractApiPullRequestsMeta,
+		tasks.CollectApiPullRequestThreadsMeta,
+		tasks.ExtractApiPullRequestThreadsMeta,
+		tasks.CollectApiPullRequestCommitsMeta,
+		tasks.ExtractApiPullRequestCommitsMeta,
+		tasks.CollectApiBuildsMeta,
+		tasks.ExtractApiBuildsMeta,
+		tasks.CollectApiTimelineRecordsMeta,
+		tasks.ExtractApiTimelineRecordsMeta,
+		tasks.CollectApiClassificationNodesMeta,
+		tasks.ExtractApiClassificationNodesMeta,
+		tasks.CollectApiWorkItemsMeta,
+		tasks.ExtractApiWorkItemsMeta,
+		tasks.CollectApiWorkItemRevisionsMeta,
+		tasks.ExtractApiWorkItemRevisionsMeta,
+		tasks.ConvertRepoMeta,
+		tasks.ConvertPullRequestsMeta,
+		tasks.ConvertPullRequestCommentsMeta,
+		tasks.ConvertPullRequestCommitsMeta,
+		tasks.ConvertBuildsMeta,
+		tasks.ConvertTimelineRecordsMeta,
+		tasks.ConvertAreasMeta,
+		tasks.ConvertIterationsMeta,
+		tasks.ConvertWorkItemsMeta,
+		tasks.ConvertWorkItemRevisionsMeta,
+		tasks.ConvertWorkItemLinksMeta,
 	}
 }
 
@@ -93,15 +133,28 @@ func (plugin Azure) PrepareTaskData(taskCtx core.TaskContext, options map[string
 	if err != nil {
 		return nil, err
 	}
+	if _, ok := options["transformationRules"]; !ok && op.TransformationRuleId != 0 {
+		var transformationRule models.AzureTransformationRule
+		err = taskCtx.GetDal().First(&transformationRule, dal.Where("id = ?", op.TransformationRuleId))
+		if err != nil {
+			return nil, errors.BadInput.Wrap(err, "fail to get transformationRule")
+		}
+		op.AzureTransformationRule = &transformationRule
+	}
 
 	apiClient, err := tasks.CreateApiClient(taskCtx, connection)
 	if err != nil {
 		return nil, err
 	}
+	projectId, err := tasks.GetProjectId(apiClient, op.Project)
+	if err != nil {
+		return nil, err
+	}
 	return &tasks.AzureTaskData{
 		Options:    op,
 		ApiClient:  apiClient,
 		Connection: connection,
+		ProjectId:  projectId,
 	}, nil
 }
 
@@ -110,6 +163,14 @@ func (plugin Azure) RootPkgPath() string {
 	return "github.com/apache/incubator-devlake/plugins/azure"
 }
 
+func (plugin Azure) MakePipelinePlan(connectionId uint64, scope []*core.BlueprintScopeV100) (core.PipelinePlan, errors.Error) {
+	return api.MakePipelinePlan(plugin.SubTaskMetas(), connectionId, scope)
+}
+
+func (plugin Azure) MakeDataSourcePipelinePlanV200(connectionId uint64, scopes []*core.BlueprintScopeV200) (core.PipelinePlan, []core.Scope, errors.Error) {
+	return api.MakeDataSourcePipelinePlanV200(plugin.SubTaskMetas(), connectionId, scopes)
+}
+
 func (plugin Azure) ApiResources() map[string]map[string]core.ApiResourceHandler {
 	return map[string]map[string]core.ApiResourceHandler{
 		"test": {
@@ -124,6 +185,22 @@ func (plugin Azure) ApiResources() map[string]map[string]core.ApiResourceHandler
 			"PATCH":  api.PatchConnection,
 			"DELETE": api.DeleteConnection,
 		},
+		"connections/:connectionId/scopes/:repoId": {
+			"GET":   api.GetScope,
+			"PATCH": api.UpdateScope,
+		},
+		"connections/:connectionId/scopes": {
+			"GET": api.GetScopeList,
+			"PUT": api.PutScope,
+		},
+		"transformation_rules": {
+			"POST": api.CreateTransformationRule,
+			"GET":  api.GetTransformationRuleList,
+		},
+		"transformation_rules/:id": {
+			"PATCH": api.UpdateTransformationRule,
+			"GET":   api.GetTransformationRule,
+		},
 	}
 }
 
@@ -0,0 +1,46 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+	"github.com/apache/incubator-devlake/plugins/azure/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/plugins/core"
+)
+
+type addPullRequestsAndBuilds20221216 struct{}
+
+func (*addPullRequestsAndBuilds20221216) Up(basicRes core.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&archived.AzurePullRequest{},
+		&archived.AzurePrComment{},
+		&archived.AzurePrCommit{},
+		&archived.AzureBuild{},
+		&archived.AzureTimelineRecord{},
+	)
+}
+
+func (*addPullRequestsAndBuilds20221216) Version() uint64 {
+	return 20221216000001
+}
+
+func (*addPullRequestsAndBuilds20221216) Name() string {
+	return "add azure pull requests, pr comments, pr commits, builds and timeline records"
+}
@@ -0,0 +1,61 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archived
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/models/migrationscripts/archived"
+)
+
+type AzureWorkItem struct {
+	ConnectionId          uint64 `gorm:"primaryKey"`
+	AzureId               int    `gorm:"primaryKey"`
+	ProjectId             string `gorm:"type:varchar(255);index"`
+	Url                   string `gorm:"type:varchar(255)"`
+	Title                 string
+	Description           string
+	Type                  string `gorm:"type:varchar(100)"`
+	State                 string `gorm:"type:varchar(100)"`
+	StdType               string `gorm:"type:varchar(100)"`
+	StdStatus             string `gorm:"type:varchar(100)"`
+	AreaId                int
+	AreaPath              string `gorm:"type:varchar(255)"`
+	IterationId           int
+	IterationPath         string `gorm:"type:varchar(255)"`
+	ParentId              int
+	Priority              string `gorm:"type:varchar(255)"`
+	Severity              string `gorm:"type:varchar(255)"`
+	StoryPoints           float64
+	OriginalEstimateHours float64
+	CompletedWorkHours    float64
+	RemainingWorkHours    float64
+	CreatorId             string `gorm:"type:varchar(255)"`
+	CreatorName           string `gorm:"type:varchar(255)"`
+	AssigneeId            string `gorm:"type:varchar(255)"`
+	AssigneeName          string `gorm:"type:varchar(255)"`
+	CreatedDate           *time.Time
+	ChangedDate           *time.Time
+	ResolvedDate          *time.Time
+	ClosedDate            *time.Time
+	archived.NoPKModel
+}
+
+func (AzureWorkItem) TableName() string {
+	return "_tool_azure_work_items"
+}
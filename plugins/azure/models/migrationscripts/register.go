@@ -25,5 +25,8 @@ import (
 func All() []core.MigrationScript {
 	return []core.MigrationScript{
 		new(addInitTables20220825),
+		new(addPullRequestsAndBuilds20221216),
+		new(addWorkItems20221217),
+		new(addTransformationRules20221226),
 	}
 }
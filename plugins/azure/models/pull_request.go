@@ -0,0 +1,51 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/models/common"
+)
+
+type AzurePullRequest struct {
+	ConnectionId          uint64 `gorm:"primaryKey"`
+	AzureId               int    `gorm:"primaryKey"`
+	ProjectId             string `gorm:"type:varchar(255);index"`
+	RepositoryId          string `gorm:"type:varchar(255);index"`
+	Title                 string
+	Description           string
+	Status                string `gorm:"type:varchar(100)"`
+	MergeStatus           string `gorm:"type:varchar(100)"`
+	IsDraft               bool
+	Url                   string `gorm:"type:varchar(255)"`
+	CreatedById           string `gorm:"type:varchar(255)"`
+	CreatedByName         string `gorm:"type:varchar(255)"`
+	CreationDate          time.Time
+	ClosedDate            *time.Time
+	SourceRefName         string `gorm:"type:varchar(255)"`
+	TargetRefName         string `gorm:"type:varchar(255)"`
+	LastMergeSourceCommit string `gorm:"type:varchar(40)"`
+	LastMergeTargetCommit string `gorm:"type:varchar(40)"`
+	LastMergeCommit       string `gorm:"type:varchar(40)"`
+	common.NoPKModel
+}
+
+func (AzurePullRequest) TableName() string {
+	return "_tool_azure_pull_requests"
+}
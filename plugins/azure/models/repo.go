@@ -22,18 +22,19 @@ import (
 )
 
 type AzureRepo struct {
-	ConnectionId  uint64 `gorm:"primaryKey"`
-	AzureId       string `gorm:"primaryKey;type:varchar(255)" json:"id"`
-	Name          string `gorm:"type:varchar(255)" json:"name"`
-	Url           string `gorm:"type:varchar(255)" json:"url"`
-	ProjectId     string `gorm:"type:varchar(255);index"`
-	DefaultBranch string `json:"defaultBranch"`
-	Size          int    `json:"size"`
-	RemoteURL     string `json:"remoteUrl"`
-	SshUrl        string `gorm:"type:varchar(255)" json:"sshUrl"`
-	WebUrl        string `gorm:"type:varchar(255)" json:"webUrl"`
-	IsDisabled    bool   `json:"isDisabled"`
-	common.NoPKModel
+	ConnectionId         uint64 `gorm:"primaryKey" mapstructure:"connectionId,omitempty" json:"connectionId"`
+	AzureId              string `gorm:"primaryKey;type:varchar(255)" mapstructure:"id" json:"id"`
+	Name                 string `gorm:"type:varchar(255)" mapstructure:"name,omitempty" json:"name"`
+	Url                  string `gorm:"type:varchar(255)" mapstructure:"url,omitempty" json:"url"`
+	ProjectId            string `gorm:"type:varchar(255);index" mapstructure:"projectId" json:"projectId"`
+	TransformationRuleId uint64 `mapstructure:"transformationRuleId,omitempty" json:"transformationRuleId,omitempty"`
+	DefaultBranch        string `mapstructure:"defaultBranch,omitempty" json:"defaultBranch"`
+	Size                 int    `mapstructure:"size,omitempty" json:"size"`
+	RemoteURL            string `mapstructure:"remoteUrl,omitempty" json:"remoteUrl"`
+	SshUrl               string `gorm:"type:varchar(255)" mapstructure:"sshUrl,omitempty" json:"sshUrl"`
+	WebUrl               string `gorm:"type:varchar(255)" mapstructure:"webUrl,omitempty" json:"webUrl"`
+	IsDisabled           bool   `mapstructure:"isDisabled,omitempty" json:"isDisabled"`
+	common.NoPKModel     `json:"-" mapstructure:"-"`
 }
 
 func (AzureRepo) TableName() string {
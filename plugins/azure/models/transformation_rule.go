@@ -0,0 +1,48 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import "github.com/apache/incubator-devlake/models/common"
+
+type StatusMapping struct {
+	StandardStatus string `mapstructure:"standardStatus" json:"standardStatus"`
+}
+
+// StatusMappings maps states of a work item type to standard statuses
+type StatusMappings map[string]StatusMapping
+
+type TypeMapping struct {
+	StandardType   string         `mapstructure:"standardType" json:"standardType"`
+	StatusMappings StatusMappings `mapstructure:"statusMappings" json:"statusMappings"`
+}
+
+// TypeMappings maps work item types like `User Story` to standard types
+type TypeMappings map[string]TypeMapping
+
+// AzureTransformationRule holds the rules to enrich builds, timeline records and work items while converting them
+type AzureTransformationRule struct {
+	common.Model      `mapstructure:"-"`
+	Name              string       `gorm:"type:varchar(255)" mapstructure:"name,omitempty" json:"name"`
+	DeploymentPattern string       `gorm:"type:varchar(255)" mapstructure:"deploymentPattern" json:"deploymentPattern"`
+	ProductionPattern string       `gorm:"type:varchar(255)" mapstructure:"productionPattern" json:"productionPattern"`
+	TypeMappings      TypeMappings `gorm:"type:json;serializer:json" mapstructure:"typeMappings" json:"typeMappings"`
+}
+
+func (AzureTransformationRule) TableName() string {
+	return "_tool_azure_transformation_rules"
+}
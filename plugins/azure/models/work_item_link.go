@@ -0,0 +1,41 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"github.com/apache/incubator-devlake/models/common"
+)
+
+const (
+	WorkItemLinkCommit      = "commit"
+	WorkItemLinkPullRequest = "pullRequest"
+)
+
+// AzureWorkItemLink is a commit or pull request linked to a work item
+type AzureWorkItemLink struct {
+	ConnectionId uint64 `gorm:"primaryKey"`
+	WorkItemId   int    `gorm:"primaryKey"`
+	LinkType     string `gorm:"primaryKey;type:varchar(100)"`
+	TargetId     string `gorm:"primaryKey;type:varchar(255)"`
+	RepositoryId string `gorm:"type:varchar(255)"`
+	common.NoPKModel
+}
+
+func (AzureWorkItemLink) TableName() string {
+	return "_tool_azure_work_item_links"
+}
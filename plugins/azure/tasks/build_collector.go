@@ -0,0 +1,78 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/plugins/core"
+	"github.com/apache/incubator-devlake/plugins/helper"
+)
+
+const RAW_BUILD_TABLE = "azure_api_builds"
+
+var CollectApiBuildsMeta = core.SubTaskMeta{
+	Name:             "collectApiBuilds",
+	EntryPoint:       CollectApiBuilds,
+	EnabledByDefault: true,
+	Description:      "Collect Builds data from Azure api",
+	DomainTypes:      []string{core.DOMAIN_TYPE_CICD},
+}
+
+func CollectApiBuilds(taskCtx core.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*AzureTaskData)
+
+	collector, err := helper.NewApiCollector(helper.ApiCollectorArgs{
+		RawDataSubTaskArgs: helper.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: AzureApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				Project:      data.Options.Project,
+			},
+			Table: RAW_BUILD_TABLE,
+		},
+		ApiClient: data.ApiClient,
+		PageSize:  100,
+
+		UrlTemplate: "{{ .Params.Project }}/_apis/build/builds?api-version=7.1-preview.7",
+		Query: func(reqData *helper.RequestData) (url.Values, errors.Error) {
+			query := url.Values{}
+			query.Set("$top", fmt.Sprintf("%v", reqData.Pager.Size))
+			setContinuationToken(reqData, query)
+			return query, nil
+		},
+		GetNextPageCustomData: getContinuationToken,
+		ResponseParser: func(res *http.Response) ([]json.RawMessage, errors.Error) {
+			var data struct {
+				Builds []json.RawMessage `json:"value"`
+			}
+			err := helper.UnmarshalResponse(res, &data)
+			return data.Builds, err
+		},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return collector.Execute()
+}
@@ -0,0 +1,121 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/models/domainlayer"
+	"github.com/apache/incubator-devlake/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/plugins/azure/models"
+	"github.com/apache/incubator-devlake/plugins/core"
+	"github.com/apache/incubator-devlake/plugins/core/dal"
+	"github.com/apache/incubator-devlake/plugins/helper"
+)
+
+var ConvertBuildsMeta = core.SubTaskMeta{
+	Name:             "convertBuilds",
+	EntryPoint:       ConvertBuilds,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table azure_builds into domain layer table cicd_pipelines and cicd_pipeline_commits",
+	DomainTypes:      []string{core.DOMAIN_TYPE_CICD},
+}
+
+var resultRule = &devops.ResultRule{
+	Success: []string{"succeeded", "partiallySucceeded", "succeededWithIssues"},
+	Failed:  []string{"failed"},
+	Abort:   []string{"canceled", "abandoned"},
+	Default: "",
+}
+
+func ConvertBuilds(taskCtx core.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*AzureTaskData)
+	deploymentPattern := data.Options.DeploymentPattern
+	productionPattern := data.Options.ProductionPattern
+	regexEnricher := helper.NewRegexEnricher()
+	err := regexEnricher.AddRegexp(deploymentPattern, productionPattern)
+	if err != nil {
+		return err
+	}
+
+	cursor, err := db.Cursor(
+		dal.From(&models.AzureBuild{}),
+		dal.Where("connection_id = ? AND project_id = ?", data.Options.ConnectionId, data.ProjectId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	buildIdGen := didgen.NewDomainIdGenerator(&models.AzureBuild{})
+	repoIdGen := didgen.NewDomainIdGenerator(&models.AzureRepo{})
+
+	converter, err := helper.NewDataConverter(helper.DataConverterArgs{
+		InputRowType: reflect.TypeOf(models.AzureBuild{}),
+		Input:        cursor,
+		RawDataSubTaskArgs: helper.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: AzureApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				Project:      data.Options.Project,
+			},
+			Table: RAW_BUILD_TABLE,
+		},
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			build := inputRow.(*models.AzureBuild)
+			domainPipeline := &devops.CICDPipeline{
+				DomainEntity: domainlayer.DomainEntity{
+					Id: buildIdGen.Generate(data.Options.ConnectionId, build.AzureId),
+				},
+				Name:   build.DefinitionName,
+				Result: devops.GetResult(resultRule, build.Result),
+				Status: devops.GetStatus(&devops.StatusRule{
+					InProgress: []string{"notStarted", "inProgress", "cancelling", "postponed"},
+					Default:    devops.DONE,
+				}, build.Status),
+				FinishedDate: build.FinishTime,
+				DurationSec:  durationSec(build.StartTime, build.FinishTime),
+				CicdScopeId:  repoIdGen.Generate(data.Options.ConnectionId, build.RepositoryId),
+			}
+			if build.QueueTime != nil {
+				domainPipeline.CreatedDate = *build.QueueTime
+			}
+			domainPipeline.Type = regexEnricher.GetEnrichResult(deploymentPattern, build.DefinitionName, devops.DEPLOYMENT)
+			domainPipeline.Environment = regexEnricher.GetEnrichResult(productionPattern, build.DefinitionName, devops.PRODUCTION)
+
+			results := []interface{}{domainPipeline}
+			if build.SourceVersion != "" {
+				results = append(results, &devops.CiCDPipelineCommit{
+					PipelineId: domainPipeline.Id,
+					CommitSha:  build.SourceVersion,
+					Branch:     build.SourceBranch,
+					RepoId:     repoIdGen.Generate(data.Options.ConnectionId, build.RepositoryId),
+				})
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
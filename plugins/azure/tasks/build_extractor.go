@@ -0,0 +1,110 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/plugins/azure/models"
+	"github.com/apache/incubator-devlake/plugins/core"
+	"github.com/apache/incubator-devlake/plugins/helper"
+)
+
+type AzureApiBuild struct {
+	Id          int    `json:"id"`
+	BuildNumber string `json:"buildNumber"`
+	Status      string `json:"status"`
+	Result      string `json:"result"`
+	Reason      string `json:"reason"`
+	Url         string `json:"url"`
+	Definition  struct {
+		Id   int    `json:"id"`
+		Name string `json:"name"`
+	} `json:"definition"`
+	Project struct {
+		Id string `json:"id"`
+	} `json:"project"`
+	SourceBranch  string `json:"sourceBranch"`
+	SourceVersion string `json:"sourceVersion"`
+	Repository    struct {
+		Id   string `json:"id"`
+		Type string `json:"type"`
+	} `json:"repository"`
+	RequestedFor AzureApiUser `json:"requestedFor"`
+	QueueTime    *time.Time   `json:"queueTime"`
+	StartTime    *time.Time   `json:"startTime"`
+	FinishTime   *time.Time   `json:"finishTime"`
+}
+
+var ExtractApiBuildsMeta = core.SubTaskMeta{
+	Name:             "extractApiBuilds",
+	EntryPoint:       ExtractApiBuilds,
+	EnabledByDefault: true,
+	Description:      "Extract raw Builds data into tool layer table azure_builds",
+	DomainTypes:      []string{core.DOMAIN_TYPE_CICD},
+}
+
+func ExtractApiBuilds(taskCtx core.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*AzureTaskData)
+	extractor, err := helper.NewApiExtractor(helper.ApiExtractorArgs{
+		RawDataSubTaskArgs: helper.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: AzureApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				Project:      data.Options.Project,
+			},
+			Table: RAW_BUILD_TABLE,
+		},
+		Extract: func(row *helper.RawData) ([]interface{}, errors.Error) {
+			body := &AzureApiBuild{}
+			err := errors.Convert(json.Unmarshal(row.Data, body))
+			if err != nil {
+				return nil, err
+			}
+			azureBuild := &models.AzureBuild{
+				ConnectionId:   data.Options.ConnectionId,
+				AzureId:        body.Id,
+				ProjectId:      body.Project.Id,
+				DefinitionId:   body.Definition.Id,
+				DefinitionName: body.Definition.Name,
+				BuildNumber:    body.BuildNumber,
+				Status:         body.Status,
+				Result:         body.Result,
+				Reason:         body.Reason,
+				Url:            body.Url,
+				SourceBranch:   body.SourceBranch,
+				SourceVersion:  body.SourceVersion,
+				RepositoryId:   body.Repository.Id,
+				RepositoryType: body.Repository.Type,
+				RequestedFor:   body.RequestedFor.DisplayName,
+				QueueTime:      body.QueueTime,
+				StartTime:      body.StartTime,
+				FinishTime:     body.FinishTime,
+			}
+			return []interface{}{azureBuild}, nil
+		},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return extractor.Execute()
+}
@@ -0,0 +1,69 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/plugins/core"
+	"github.com/apache/incubator-devlake/plugins/helper"
+)
+
+const RAW_CLASSIFICATION_NODE_TABLE = "azure_api_classification_nodes"
+
+var CollectApiClassificationNodesMeta = core.SubTaskMeta{
+	Name:             "collectApiClassificationNodes",
+	EntryPoint:       CollectApiClassificationNodes,
+	EnabledByDefault: true,
+	Description:      "Collect area and iteration trees from Azure api",
+	DomainTypes:      []string{core.DOMAIN_TYPE_TICKET},
+}
+
+func CollectApiClassificationNodes(taskCtx core.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*AzureTaskData)
+
+	collector, err := helper.NewApiCollector(helper.ApiCollectorArgs{
+		RawDataSubTaskArgs: helper.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: AzureApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				Project:      data.Options.Project,
+			},
+			Table: RAW_CLASSIFICATION_NODE_TABLE,
+		},
+		ApiClient: data.ApiClient,
+
+		// returns the root area and the root iteration of the project, along with their descendants
+		UrlTemplate: "{{ .Params.Project }}/_apis/wit/classificationnodes?$depth=100&api-version=7.1-preview.2",
+		ResponseParser: func(res *http.Response) ([]json.RawMessage, errors.Error) {
+			var data struct {
+				Nodes []json.RawMessage `json:"value"`
+			}
+			err := helper.UnmarshalResponse(res, &data)
+			return data.Nodes, err
+		},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return collector.Execute()
+}
@@ -0,0 +1,126 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/plugins/azure/models"
+	"github.com/apache/incubator-devlake/plugins/core"
+	"github.com/apache/incubator-devlake/plugins/helper"
+)
+
+type AzureApiClassificationNode struct {
+	Id            int    `json:"id"`
+	Identifier    string `json:"identifier"`
+	Name          string `json:"name"`
+	StructureType string `json:"structureType"`
+	Path          string `json:"path"`
+	Url           string `json:"url"`
+	Attributes    *struct {
+		StartDate  *time.Time `json:"startDate"`
+		FinishDate *time.Time `json:"finishDate"`
+	} `json:"attributes"`
+	Children []*AzureApiClassificationNode `json:"children"`
+}
+
+var ExtractApiClassificationNodesMeta = core.SubTaskMeta{
+	Name:             "extractApiClassificationNodes",
+	EntryPoint:       ExtractApiClassificationNodes,
+	EnabledByDefault: true,
+	Description:      "Extract raw area and iteration trees into tool layer table azure_areas and azure_iterations",
+	DomainTypes:      []string{core.DOMAIN_TYPE_TICKET},
+}
+
+func ExtractApiClassificationNodes(taskCtx core.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*AzureTaskData)
+	extractor, err := helper.NewApiExtractor(helper.ApiExtractorArgs{
+		RawDataSubTaskArgs: helper.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: AzureApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				Project:      data.Options.Project,
+			},
+			Table: RAW_CLASSIFICATION_NODE_TABLE,
+		},
+		Extract: func(row *helper.RawData) ([]interface{}, errors.Error) {
+			root := &AzureApiClassificationNode{}
+			err := errors.Convert(json.Unmarshal(row.Data, root))
+			if err != nil {
+				return nil, err
+			}
+			results := make([]interface{}, 0)
+			var walk func(node *AzureApiClassificationNode, parentId int)
+			walk = func(node *AzureApiClassificationNode, parentId int) {
+				switch node.StructureType {
+				case "area":
+					results = append(results, &models.AzureArea{
+						ConnectionId: data.Options.ConnectionId,
+						AzureId:      node.Id,
+						ProjectId:    data.ProjectId,
+						ParentId:     parentId,
+						Identifier:   node.Identifier,
+						Name:         node.Name,
+						Path:         normalizeNodePath(node.Path),
+						Url:          node.Url,
+					})
+				case "iteration":
+					iteration := &models.AzureIteration{
+						ConnectionId: data.Options.ConnectionId,
+						AzureId:      node.Id,
+						ProjectId:    data.ProjectId,
+						ParentId:     parentId,
+						Identifier:   node.Identifier,
+						Name:         node.Name,
+						Path:         normalizeNodePath(node.Path),
+						Url:          node.Url,
+					}
+					if node.Attributes != nil {
+						iteration.StartDate = node.Attributes.StartDate
+						iteration.FinishDate = node.Attributes.FinishDate
+					}
+					results = append(results, iteration)
+				}
+				for _, child := range node.Children {
+					walk(child, node.Id)
+				}
+			}
+			walk(root, 0)
+			return results, nil
+		},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return extractor.Execute()
+}
+
+// normalizeNodePath converts paths like `\Project\Iteration\Sprint 1` to `Project\Sprint 1`, which is how work
+// items refer to them by `System.IterationPath` and `System.AreaPath`
+func normalizeNodePath(path string) string {
+	parts := strings.Split(strings.TrimPrefix(path, `\`), `\`)
+	if len(parts) < 2 {
+		return strings.Join(parts, `\`)
+	}
+	return strings.Join(append(parts[:1], parts[2:]...), `\`)
+}
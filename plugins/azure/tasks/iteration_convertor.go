@@ -0,0 +1,127 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/models/domainlayer"
+	"github.com/apache/incubator-devlake/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/plugins/azure/models"
+	"github.com/apache/incubator-devlake/plugins/core"
+	"github.com/apache/incubator-devlake/plugins/core/dal"
+	"github.com/apache/incubator-devlake/plugins/helper"
+)
+
+var ConvertIterationsMeta = core.SubTaskMeta{
+	Name:             "convertIterations",
+	EntryPoint:       ConvertIterations,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table azure_iterations into domain layer table sprints and board_sprints",
+	DomainTypes:      []string{core.DOMAIN_TYPE_TICKET},
+}
+
+func ConvertIterations(taskCtx core.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*AzureTaskData)
+
+	// iterations are shared by all areas of the project, so sprints are put on the board of the root area
+	rootAreaId, err := getRootAreaId(db, data)
+	if err != nil {
+		return err
+	}
+	// the root iteration is the project itself rather than a sprint
+	cursor, err := db.Cursor(
+		dal.From(&models.AzureIteration{}),
+		dal.Where("connection_id = ? AND project_id = ? AND parent_id != 0", data.Options.ConnectionId, data.ProjectId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	iterationIdGen := didgen.NewDomainIdGenerator(&models.AzureIteration{})
+	areaIdGen := didgen.NewDomainIdGenerator(&models.AzureArea{})
+	now := time.Now()
+
+	converter, err := helper.NewDataConverter(helper.DataConverterArgs{
+		InputRowType: reflect.TypeOf(models.AzureIteration{}),
+		Input:        cursor,
+		RawDataSubTaskArgs: helper.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: AzureApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				Project:      data.Options.Project,
+			},
+			Table: RAW_CLASSIFICATION_NODE_TABLE,
+		},
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			iteration := inputRow.(*models.AzureIteration)
+			sprint := &ticket.Sprint{
+				DomainEntity: domainlayer.DomainEntity{
+					Id: iterationIdGen.Generate(data.Options.ConnectionId, iteration.AzureId),
+				},
+				Name:        iteration.Name,
+				Url:         iteration.Url,
+				StartedDate: iteration.StartDate,
+				EndedDate:   iteration.FinishDate,
+			}
+			if iteration.FinishDate != nil && iteration.FinishDate.Before(now) {
+				sprint.Status = "CLOSED"
+				sprint.CompletedDate = iteration.FinishDate
+			} else if iteration.StartDate != nil && iteration.StartDate.After(now) {
+				sprint.Status = "FUTURE"
+			} else if iteration.StartDate != nil {
+				sprint.Status = "ACTIVE"
+			}
+			results := []interface{}{sprint}
+			if rootAreaId != 0 {
+				sprint.OriginalBoardID = areaIdGen.Generate(data.Options.ConnectionId, rootAreaId)
+				results = append(results, &ticket.BoardSprint{
+					BoardId:  sprint.OriginalBoardID,
+					SprintId: sprint.Id,
+				})
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
+
+// getRootAreaId returns id of the root area of the project, or zero if areas were not collected
+func getRootAreaId(db dal.Dal, data *AzureTaskData) (int, errors.Error) {
+	var areas []models.AzureArea
+	err := db.All(
+		&areas,
+		dal.Where("connection_id = ? AND project_id = ? AND parent_id = 0", data.Options.ConnectionId, data.ProjectId),
+	)
+	if err != nil {
+		return 0, err
+	}
+	if len(areas) == 0 {
+		return 0, nil
+	}
+	return areas[0].AzureId, nil
+}
@@ -0,0 +1,102 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/models/domainlayer"
+	"github.com/apache/incubator-devlake/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/plugins/azure/models"
+	"github.com/apache/incubator-devlake/plugins/core"
+	"github.com/apache/incubator-devlake/plugins/core/dal"
+	"github.com/apache/incubator-devlake/plugins/helper"
+)
+
+var ConvertPullRequestCommentsMeta = core.SubTaskMeta{
+	Name:             "convertPullRequestComments",
+	EntryPoint:       ConvertPullRequestComments,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table azure_pr_comments into domain layer table pull_request_comments",
+	DomainTypes:      []string{core.DOMAIN_TYPE_CODE_REVIEW},
+}
+
+func ConvertPullRequestComments(taskCtx core.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*AzureTaskData)
+
+	cursor, err := db.Cursor(
+		dal.Select("_tool_azure_pr_comments.*"),
+		dal.From(&models.AzurePrComment{}),
+		dal.Join(`LEFT JOIN _tool_azure_pull_requests ON _tool_azure_pull_requests.connection_id = _tool_azure_pr_comments.connection_id
+			AND _tool_azure_pull_requests.azure_id = _tool_azure_pr_comments.pull_request_id`),
+		dal.Where("_tool_azure_pr_comments.connection_id = ? AND _tool_azure_pull_requests.project_id = ?", data.Options.ConnectionId, data.ProjectId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	prIdGen := didgen.NewDomainIdGenerator(&models.AzurePullRequest{})
+	commentIdGen := didgen.NewDomainIdGenerator(&models.AzurePrComment{})
+
+	converter, err := helper.NewDataConverter(helper.DataConverterArgs{
+		InputRowType: reflect.TypeOf(models.AzurePrComment{}),
+		Input:        cursor,
+		RawDataSubTaskArgs: helper.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: AzureApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				Project:      data.Options.Project,
+			},
+			Table: RAW_PR_THREAD_TABLE,
+		},
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			comment := inputRow.(*models.AzurePrComment)
+			// system comments are generated by Azure DevOps for events like votes and pushes
+			if comment.CommentType == "system" {
+				return nil, nil
+			}
+			domainComment := &code.PullRequestComment{
+				DomainEntity: domainlayer.DomainEntity{
+					Id: commentIdGen.Generate(data.Options.ConnectionId, comment.PullRequestId, comment.ThreadId, comment.CommentId),
+				},
+				PullRequestId: prIdGen.Generate(data.Options.ConnectionId, comment.PullRequestId),
+				Body:          comment.Content,
+				AccountId:     comment.AuthorId,
+				CreatedDate:   comment.PublishedDate,
+				Position:      comment.Line,
+				Type:          code.NORMAL_COMMENT,
+				Status:        comment.ThreadStatus,
+			}
+			if comment.FilePath != "" {
+				domainComment.Type = code.DIFF_COMMENT
+			}
+			return []interface{}{
+				domainComment,
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
@@ -0,0 +1,94 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/plugins/azure/models"
+	"github.com/apache/incubator-devlake/plugins/core"
+	"github.com/apache/incubator-devlake/plugins/helper"
+)
+
+type AzureApiGitUserDate struct {
+	Name  string    `json:"name"`
+	Email string    `json:"email"`
+	Date  time.Time `json:"date"`
+}
+
+type AzureApiCommit struct {
+	CommitId  string              `json:"commitId"`
+	Author    AzureApiGitUserDate `json:"author"`
+	Committer AzureApiGitUserDate `json:"committer"`
+	Comment   string              `json:"comment"`
+}
+
+var ExtractApiPullRequestCommitsMeta = core.SubTaskMeta{
+	Name:             "extractApiPullRequestCommits",
+	EntryPoint:       ExtractApiPullRequestCommits,
+	EnabledByDefault: true,
+	Description:      "Extract raw PullRequest commits data into tool layer table azure_pr_commits",
+	DomainTypes:      []string{core.DOMAIN_TYPE_CODE_REVIEW},
+}
+
+func ExtractApiPullRequestCommits(taskCtx core.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*AzureTaskData)
+	extractor, err := helper.NewApiExtractor(helper.ApiExtractorArgs{
+		RawDataSubTaskArgs: helper.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: AzureApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				Project:      data.Options.Project,
+			},
+			Table: RAW_PR_COMMIT_TABLE,
+		},
+		Extract: func(row *helper.RawData) ([]interface{}, errors.Error) {
+			pr := &SimplePr{}
+			err := errors.Convert(json.Unmarshal(row.Input, pr))
+			if err != nil {
+				return nil, err
+			}
+			body := &AzureApiCommit{}
+			err = errors.Convert(json.Unmarshal(row.Data, body))
+			if err != nil {
+				return nil, err
+			}
+			azurePrCommit := &models.AzurePrCommit{
+				ConnectionId:   data.Options.ConnectionId,
+				PullRequestId:  pr.AzureId,
+				CommitSha:      body.CommitId,
+				AuthorName:     body.Author.Name,
+				AuthorEmail:    body.Author.Email,
+				AuthoredDate:   body.Author.Date,
+				CommitterName:  body.Committer.Name,
+				CommitterEmail: body.Committer.Email,
+				CommittedDate:  body.Committer.Date,
+				Message:        body.Comment,
+			}
+			return []interface{}{azurePrCommit}, nil
+		},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return extractor.Execute()
+}
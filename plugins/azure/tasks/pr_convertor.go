@@ -0,0 +1,106 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/models/domainlayer"
+	"github.com/apache/incubator-devlake/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/plugins/azure/models"
+	"github.com/apache/incubator-devlake/plugins/core"
+	"github.com/apache/incubator-devlake/plugins/core/dal"
+	"github.com/apache/incubator-devlake/plugins/helper"
+)
+
+var ConvertPullRequestsMeta = core.SubTaskMeta{
+	Name:             "convertPullRequests",
+	EntryPoint:       ConvertPullRequests,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table azure_pull_requests into domain layer table pull_requests",
+	DomainTypes:      []string{core.DOMAIN_TYPE_CODE_REVIEW},
+}
+
+func ConvertPullRequests(taskCtx core.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*AzureTaskData)
+
+	cursor, err := db.Cursor(
+		dal.From(&models.AzurePullRequest{}),
+		dal.Where("connection_id = ? AND project_id = ?", data.Options.ConnectionId, data.ProjectId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	prIdGen := didgen.NewDomainIdGenerator(&models.AzurePullRequest{})
+	repoIdGen := didgen.NewDomainIdGenerator(&models.AzureRepo{})
+
+	converter, err := helper.NewDataConverter(helper.DataConverterArgs{
+		InputRowType: reflect.TypeOf(models.AzurePullRequest{}),
+		Input:        cursor,
+		RawDataSubTaskArgs: helper.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: AzureApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				Project:      data.Options.Project,
+			},
+			Table: RAW_PULL_REQUEST_TABLE,
+		},
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			pr := inputRow.(*models.AzurePullRequest)
+			domainPr := &code.PullRequest{
+				DomainEntity: domainlayer.DomainEntity{
+					Id: prIdGen.Generate(data.Options.ConnectionId, pr.AzureId),
+				},
+				BaseRepoId:     repoIdGen.Generate(data.Options.ConnectionId, pr.RepositoryId),
+				HeadRepoId:     repoIdGen.Generate(data.Options.ConnectionId, pr.RepositoryId),
+				Status:         pr.Status,
+				Title:          pr.Title,
+				Description:    pr.Description,
+				Url:            pr.Url,
+				AuthorName:     pr.CreatedByName,
+				AuthorId:       pr.CreatedById,
+				PullRequestKey: pr.AzureId,
+				CreatedDate:    pr.CreationDate,
+				ClosedDate:     pr.ClosedDate,
+				MergeCommitSha: pr.LastMergeCommit,
+				HeadRef:        strings.TrimPrefix(pr.SourceRefName, "refs/heads/"),
+				BaseRef:        strings.TrimPrefix(pr.TargetRefName, "refs/heads/"),
+				HeadCommitSha:  pr.LastMergeSourceCommit,
+				BaseCommitSha:  pr.LastMergeTargetCommit,
+			}
+			// completed pull requests were merged into the target branch, abandoned ones were closed without merging
+			if pr.Status == "completed" {
+				domainPr.MergedDate = pr.ClosedDate
+			}
+			return []interface{}{
+				domainPr,
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
@@ -0,0 +1,121 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/plugins/azure/models"
+	"github.com/apache/incubator-devlake/plugins/core"
+	"github.com/apache/incubator-devlake/plugins/helper"
+)
+
+type AzureApiCommitRef struct {
+	CommitId string `json:"commitId"`
+}
+
+type AzureApiPullRequest struct {
+	PullRequestId int `json:"pullRequestId"`
+	Repository    struct {
+		Id      string `json:"id"`
+		Name    string `json:"name"`
+		Project struct {
+			Id   string `json:"id"`
+			Name string `json:"name"`
+		} `json:"project"`
+	} `json:"repository"`
+	Status                string             `json:"status"`
+	CreatedBy             AzureApiUser       `json:"createdBy"`
+	CreationDate          time.Time          `json:"creationDate"`
+	ClosedDate            *time.Time         `json:"closedDate"`
+	Title                 string             `json:"title"`
+	Description           string             `json:"description"`
+	SourceRefName         string             `json:"sourceRefName"`
+	TargetRefName         string             `json:"targetRefName"`
+	MergeStatus           string             `json:"mergeStatus"`
+	IsDraft               bool               `json:"isDraft"`
+	LastMergeSourceCommit *AzureApiCommitRef `json:"lastMergeSourceCommit"`
+	LastMergeTargetCommit *AzureApiCommitRef `json:"lastMergeTargetCommit"`
+	LastMergeCommit       *AzureApiCommitRef `json:"lastMergeCommit"`
+	Url                   string             `json:"url"`
+}
+
+var ExtractApiPullRequestsMeta = core.SubTaskMeta{
+	Name:             "extractApiPullRequests",
+	EntryPoint:       ExtractApiPullRequests,
+	EnabledByDefault: true,
+	Description:      "Extract raw PullRequests data into tool layer table azure_pull_requests",
+	DomainTypes:      []string{core.DOMAIN_TYPE_CODE_REVIEW},
+}
+
+func ExtractApiPullRequests(taskCtx core.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*AzureTaskData)
+	extractor, err := helper.NewApiExtractor(helper.ApiExtractorArgs{
+		RawDataSubTaskArgs: helper.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: AzureApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				Project:      data.Options.Project,
+			},
+			Table: RAW_PULL_REQUEST_TABLE,
+		},
+		Extract: func(row *helper.RawData) ([]interface{}, errors.Error) {
+			body := &AzureApiPullRequest{}
+			err := errors.Convert(json.Unmarshal(row.Data, body))
+			if err != nil {
+				return nil, err
+			}
+			azurePr := &models.AzurePullRequest{
+				ConnectionId:  data.Options.ConnectionId,
+				AzureId:       body.PullRequestId,
+				ProjectId:     body.Repository.Project.Id,
+				RepositoryId:  body.Repository.Id,
+				Title:         body.Title,
+				Description:   body.Description,
+				Status:        body.Status,
+				MergeStatus:   body.MergeStatus,
+				IsDraft:       body.IsDraft,
+				Url:           body.Url,
+				CreatedById:   body.CreatedBy.Id,
+				CreatedByName: body.CreatedBy.DisplayName,
+				CreationDate:  body.CreationDate,
+				ClosedDate:    body.ClosedDate,
+				SourceRefName: body.SourceRefName,
+				TargetRefName: body.TargetRefName,
+			}
+			if body.LastMergeSourceCommit != nil {
+				azurePr.LastMergeSourceCommit = body.LastMergeSourceCommit.CommitId
+			}
+			if body.LastMergeTargetCommit != nil {
+				azurePr.LastMergeTargetCommit = body.LastMergeTargetCommit.CommitId
+			}
+			if body.LastMergeCommit != nil {
+				azurePr.LastMergeCommit = body.LastMergeCommit.CommitId
+			}
+			return []interface{}{azurePr}, nil
+		},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return extractor.Execute()
+}
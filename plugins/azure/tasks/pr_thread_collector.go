@@ -0,0 +1,96 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/plugins/azure/models"
+	"github.com/apache/incubator-devlake/plugins/core"
+	"github.com/apache/incubator-devlake/plugins/core/dal"
+	"github.com/apache/incubator-devlake/plugins/helper"
+)
+
+const RAW_PR_THREAD_TABLE = "azure_api_pull_request_threads"
+
+var CollectApiPullRequestThreadsMeta = core.SubTaskMeta{
+	Name:             "collectApiPullRequestThreads",
+	EntryPoint:       CollectApiPullRequestThreads,
+	EnabledByDefault: true,
+	Description:      "Collect PullRequest threads data from Azure api",
+	DomainTypes:      []string{core.DOMAIN_TYPE_CODE_REVIEW},
+}
+
+type SimplePr struct {
+	AzureId      int
+	RepositoryId string
+}
+
+// newPullRequestIterator iterates pull requests of the project for collecting their threads and commits
+func newPullRequestIterator(taskCtx core.SubTaskContext) (*helper.DalCursorIterator, errors.Error) {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*AzureTaskData)
+	cursor, err := db.Cursor(
+		dal.Select("azure_id, repository_id"),
+		dal.From(models.AzurePullRequest{}.TableName()),
+		dal.Where("connection_id = ? AND project_id = ?", data.Options.ConnectionId, data.ProjectId),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return helper.NewDalCursorIterator(db, cursor, reflect.TypeOf(SimplePr{}))
+}
+
+func CollectApiPullRequestThreads(taskCtx core.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*AzureTaskData)
+	iterator, err := newPullRequestIterator(taskCtx)
+	if err != nil {
+		return err
+	}
+
+	collector, err := helper.NewApiCollector(helper.ApiCollectorArgs{
+		RawDataSubTaskArgs: helper.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: AzureApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				Project:      data.Options.Project,
+			},
+			Table: RAW_PR_THREAD_TABLE,
+		},
+		ApiClient: data.ApiClient,
+		Input:     iterator,
+
+		UrlTemplate: "{{ .Params.Project }}/_apis/git/repositories/{{ .Input.RepositoryId }}/pullRequests/{{ .Input.AzureId }}/threads?api-version=7.1-preview.1",
+		ResponseParser: func(res *http.Response) ([]json.RawMessage, errors.Error) {
+			var data struct {
+				Threads []json.RawMessage `json:"value"`
+			}
+			err := helper.UnmarshalResponse(res, &data)
+			return data.Threads, err
+		},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return collector.Execute()
+}
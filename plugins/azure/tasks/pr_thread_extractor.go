@@ -0,0 +1,119 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/plugins/azure/models"
+	"github.com/apache/incubator-devlake/plugins/core"
+	"github.com/apache/incubator-devlake/plugins/helper"
+)
+
+type AzureApiPullRequestThread struct {
+	Id            int    `json:"id"`
+	Status        string `json:"status"`
+	IsDeleted     bool   `json:"isDeleted"`
+	ThreadContext *struct {
+		FilePath       string `json:"filePath"`
+		RightFileStart *struct {
+			Line int `json:"line"`
+		} `json:"rightFileStart"`
+	} `json:"threadContext"`
+	Comments []struct {
+		Id              int          `json:"id"`
+		ParentCommentId int          `json:"parentCommentId"`
+		Author          AzureApiUser `json:"author"`
+		Content         string       `json:"content"`
+		PublishedDate   time.Time    `json:"publishedDate"`
+		CommentType     string       `json:"commentType"`
+		IsDeleted       bool         `json:"isDeleted"`
+	} `json:"comments"`
+}
+
+var ExtractApiPullRequestThreadsMeta = core.SubTaskMeta{
+	Name:             "extractApiPullRequestThreads",
+	EntryPoint:       ExtractApiPullRequestThreads,
+	EnabledByDefault: true,
+	Description:      "Extract raw PullRequest threads data into tool layer table azure_pr_comments",
+	DomainTypes:      []string{core.DOMAIN_TYPE_CODE_REVIEW},
+}
+
+func ExtractApiPullRequestThreads(taskCtx core.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*AzureTaskData)
+	extractor, err := helper.NewApiExtractor(helper.ApiExtractorArgs{
+		RawDataSubTaskArgs: helper.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: AzureApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				Project:      data.Options.Project,
+			},
+			Table: RAW_PR_THREAD_TABLE,
+		},
+		Extract: func(row *helper.RawData) ([]interface{}, errors.Error) {
+			pr := &SimplePr{}
+			err := errors.Convert(json.Unmarshal(row.Input, pr))
+			if err != nil {
+				return nil, err
+			}
+			body := &AzureApiPullRequestThread{}
+			err = errors.Convert(json.Unmarshal(row.Data, body))
+			if err != nil {
+				return nil, err
+			}
+			if body.IsDeleted {
+				return nil, nil
+			}
+			results := make([]interface{}, 0, len(body.Comments))
+			for _, comment := range body.Comments {
+				if comment.IsDeleted {
+					continue
+				}
+				azureComment := &models.AzurePrComment{
+					ConnectionId:    data.Options.ConnectionId,
+					PullRequestId:   pr.AzureId,
+					ThreadId:        body.Id,
+					CommentId:       comment.Id,
+					ParentCommentId: comment.ParentCommentId,
+					ThreadStatus:    body.Status,
+					AuthorId:        comment.Author.Id,
+					AuthorName:      comment.Author.DisplayName,
+					Content:         comment.Content,
+					CommentType:     comment.CommentType,
+					PublishedDate:   comment.PublishedDate,
+				}
+				if body.ThreadContext != nil {
+					azureComment.FilePath = body.ThreadContext.FilePath
+					if body.ThreadContext.RightFileStart != nil {
+						azureComment.Line = body.ThreadContext.RightFileStart.Line
+					}
+				}
+				results = append(results, azureComment)
+			}
+			return results, nil
+		},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return extractor.Execute()
+}
@@ -0,0 +1,48 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/plugins/helper"
+)
+
+// GetProjectId resolves the id of the project, which could be specified by either its name or id
+func GetProjectId(apiClient *helper.ApiAsyncClient, project string) (string, errors.Error) {
+	query := url.Values{}
+	query.Set("api-version", "7.1-preview.4")
+	res, err := apiClient.Get(fmt.Sprintf("_apis/projects/%s", url.PathEscape(project)), query, nil)
+	if err != nil {
+		return "", err
+	}
+	if res.StatusCode != http.StatusOK {
+		return "", errors.HttpStatus(res.StatusCode).New(fmt.Sprintf("unable to get Azure project %s", project))
+	}
+	var body struct {
+		Id string `json:"id"`
+	}
+	err = helper.UnmarshalResponse(res, &body)
+	if err != nil {
+		return "", err
+	}
+	return body.Id, nil
+}
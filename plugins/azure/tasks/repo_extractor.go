@@ -88,17 +88,19 @@ func ExtractApiRepositories(taskCtx core.SubTaskContext) errors.Error {
 			}
 			results := make([]interface{}, 0, 1)
 			azureRepository := &models.AzureRepo{
-				ConnectionId:  data.Options.ConnectionId,
-				AzureId:       body.ID,
-				Name:          body.Name,
-				Url:           body.URL,
-				ProjectId:     body.Project.ID,
-				DefaultBranch: body.DefaultBranch,
-				Size:          body.Size,
-				RemoteURL:     body.RemoteURL,
-				SshUrl:        body.SSHURL,
-				WebUrl:        body.WebURL,
-				IsDisabled:    body.IsDisabled,
+				ConnectionId: data.Options.ConnectionId,
+				AzureId:      body.ID,
+				Name:         body.Name,
+				Url:          body.URL,
+				ProjectId:    body.Project.ID,
+				// repos of a project are collected by the same task, thus share the transformation rule
+				TransformationRuleId: data.Options.TransformationRuleId,
+				DefaultBranch:        body.DefaultBranch,
+				Size:                 body.Size,
+				RemoteURL:            body.RemoteURL,
+				SshUrl:               body.SSHURL,
+				WebUrl:               body.WebURL,
+				IsDisabled:           body.IsDisabled,
 			}
 			data.Repo = azureRepository
 
@@ -0,0 +1,58 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/plugins/helper"
+)
+
+// AzureApiUser is an identity referenced by pull requests, comments and builds
+type AzureApiUser struct {
+	Id          string `json:"id"`
+	DisplayName string `json:"displayName"`
+	UniqueName  string `json:"uniqueName"`
+}
+
+// getContinuationToken returns the token for requesting the next page, and nil for the last page
+func getContinuationToken(_ *helper.RequestData, prevPageResponse *http.Response) (interface{}, errors.Error) {
+	token := prevPageResponse.Header.Get("x-ms-continuationtoken")
+	if token == "" {
+		return nil, nil
+	}
+	return token, nil
+}
+
+// setContinuationToken adds the token returned by the previous page into the query
+func setContinuationToken(reqData *helper.RequestData, query url.Values) {
+	if token, ok := reqData.CustomData.(string); ok {
+		query.Set("continuationToken", token)
+	}
+}
+
+// durationSec returns seconds between the start and finish time, or zero if either was missing
+func durationSec(start, finish *time.Time) uint64 {
+	if start == nil || finish == nil || finish.Before(*start) {
+		return 0
+	}
+	return uint64(finish.Sub(*start).Seconds())
+}
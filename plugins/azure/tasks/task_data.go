@@ -29,10 +29,12 @@ type AzureApiParams struct {
 }
 
 type AzureOptions struct {
-	ConnectionId uint64 `json:"connectionId"`
-	Project      string
-	Since        string
-	Tasks        []string `json:"tasks,omitempty"`
+	ConnectionId                    uint64 `json:"connectionId"`
+	Project                         string
+	Since                           string
+	Tasks                           []string `json:"tasks,omitempty"`
+	TransformationRuleId            uint64   `json:"transformationRuleId"`
+	*models.AzureTransformationRule `mapstructure:"transformationRules" json:"transformationRules"`
 }
 
 type AzureTaskData struct {
@@ -40,6 +42,8 @@ type AzureTaskData struct {
 	ApiClient  *helper.ApiAsyncClient
 	Connection *models.AzureConnection
 	Repo       *models.AzureRepo
+	// ProjectId is the id of the project, tool layer records are filtered by it when being converted
+	ProjectId string
 }
 
 func DecodeAndValidateTaskOptions(options map[string]interface{}) (*AzureOptions, errors.Error) {
@@ -52,5 +56,11 @@ func DecodeAndValidateTaskOptions(options map[string]interface{}) (*AzureOptions
 	if op.ConnectionId == 0 {
 		return nil, errors.BadInput.New("Azure connectionId is invalid")
 	}
+	if op.Project == "" {
+		return nil, errors.BadInput.New("Azure project is required")
+	}
+	if op.AzureTransformationRule == nil {
+		op.AzureTransformationRule = new(models.AzureTransformationRule)
+	}
 	return &op, nil
 }
@@ -0,0 +1,94 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/plugins/azure/models"
+	"github.com/apache/incubator-devlake/plugins/core"
+	"github.com/apache/incubator-devlake/plugins/core/dal"
+	"github.com/apache/incubator-devlake/plugins/helper"
+)
+
+const RAW_TIMELINE_RECORD_TABLE = "azure_api_timeline_records"
+
+var CollectApiTimelineRecordsMeta = core.SubTaskMeta{
+	Name:             "collectApiTimelineRecords",
+	EntryPoint:       CollectApiTimelineRecords,
+	EnabledByDefault: true,
+	Description:      "Collect Build timeline records data from Azure api",
+	DomainTypes:      []string{core.DOMAIN_TYPE_CICD},
+}
+
+type SimpleBuild struct {
+	AzureId int
+}
+
+func CollectApiTimelineRecords(taskCtx core.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*AzureTaskData)
+
+	cursor, err := db.Cursor(
+		dal.Select("azure_id"),
+		dal.From(models.AzureBuild{}.TableName()),
+		dal.Where("connection_id = ? AND project_id = ?", data.Options.ConnectionId, data.ProjectId),
+	)
+	if err != nil {
+		return err
+	}
+	iterator, err := helper.NewDalCursorIterator(db, cursor, reflect.TypeOf(SimpleBuild{}))
+	if err != nil {
+		return err
+	}
+
+	collector, err := helper.NewApiCollector(helper.ApiCollectorArgs{
+		RawDataSubTaskArgs: helper.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: AzureApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				Project:      data.Options.Project,
+			},
+			Table: RAW_TIMELINE_RECORD_TABLE,
+		},
+		ApiClient: data.ApiClient,
+		Input:     iterator,
+
+		UrlTemplate: "{{ .Params.Project }}/_apis/build/builds/{{ .Input.AzureId }}/timeline?api-version=7.1-preview.2",
+		ResponseParser: func(res *http.Response) ([]json.RawMessage, errors.Error) {
+			// builds that have not started yet have no timeline
+			if res.StatusCode == http.StatusNoContent {
+				return nil, nil
+			}
+			var data struct {
+				Records []json.RawMessage `json:"records"`
+			}
+			err := helper.UnmarshalResponse(res, &data)
+			return data.Records, err
+		},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return collector.Execute()
+}
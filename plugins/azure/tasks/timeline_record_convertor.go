@@ -0,0 +1,123 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/models/domainlayer"
+	"github.com/apache/incubator-devlake/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/plugins/azure/models"
+	"github.com/apache/incubator-devlake/plugins/core"
+	"github.com/apache/incubator-devlake/plugins/core/dal"
+	"github.com/apache/incubator-devlake/plugins/helper"
+)
+
+var ConvertTimelineRecordsMeta = core.SubTaskMeta{
+	Name:             "convertTimelineRecords",
+	EntryPoint:       ConvertTimelineRecords,
+	EnabledByDefault: true,
+	Description:      "Convert jobs in tool layer table azure_timeline_records into domain layer table cicd_tasks",
+	DomainTypes:      []string{core.DOMAIN_TYPE_CICD},
+}
+
+type azureBuildJob struct {
+	models.AzureTimelineRecord
+	RepositoryId string
+	QueueTime    *time.Time
+}
+
+func ConvertTimelineRecords(taskCtx core.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*AzureTaskData)
+	deploymentPattern := data.Options.DeploymentPattern
+	productionPattern := data.Options.ProductionPattern
+	regexEnricher := helper.NewRegexEnricher()
+	err := regexEnricher.AddRegexp(deploymentPattern, productionPattern)
+	if err != nil {
+		return err
+	}
+
+	// stages and phases are containers of jobs, and tasks are steps of a job, so only jobs are converted
+	cursor, err := db.Cursor(
+		dal.Select("_tool_azure_timeline_records.*, _tool_azure_builds.repository_id, _tool_azure_builds.queue_time"),
+		dal.From(&models.AzureTimelineRecord{}),
+		dal.Join(`LEFT JOIN _tool_azure_builds ON _tool_azure_builds.connection_id = _tool_azure_timeline_records.connection_id
+			AND _tool_azure_builds.azure_id = _tool_azure_timeline_records.build_id`),
+		dal.Where("_tool_azure_timeline_records.connection_id = ? AND _tool_azure_builds.project_id = ? AND _tool_azure_timeline_records.type = ?",
+			data.Options.ConnectionId, data.ProjectId, "Job"),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	recordIdGen := didgen.NewDomainIdGenerator(&models.AzureTimelineRecord{})
+	buildIdGen := didgen.NewDomainIdGenerator(&models.AzureBuild{})
+	repoIdGen := didgen.NewDomainIdGenerator(&models.AzureRepo{})
+
+	converter, err := helper.NewDataConverter(helper.DataConverterArgs{
+		InputRowType: reflect.TypeOf(azureBuildJob{}),
+		Input:        cursor,
+		RawDataSubTaskArgs: helper.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: AzureApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				Project:      data.Options.Project,
+			},
+			Table: RAW_TIMELINE_RECORD_TABLE,
+		},
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			job := inputRow.(*azureBuildJob)
+			domainTask := &devops.CICDTask{
+				DomainEntity: domainlayer.DomainEntity{
+					Id: recordIdGen.Generate(data.Options.ConnectionId, job.BuildId, job.RecordId),
+				},
+				Name:       job.Name,
+				PipelineId: buildIdGen.Generate(data.Options.ConnectionId, job.BuildId),
+				Result:     devops.GetResult(resultRule, job.Result),
+				Status: devops.GetStatus(&devops.StatusRule{
+					InProgress: []string{"pending", "inProgress"},
+					Default:    devops.DONE,
+				}, job.State),
+				FinishedDate: job.FinishTime,
+				DurationSec:  durationSec(job.StartTime, job.FinishTime),
+				CicdScopeId:  repoIdGen.Generate(data.Options.ConnectionId, job.RepositoryId),
+			}
+			// jobs waiting for an agent have not started yet
+			if job.StartTime != nil {
+				domainTask.StartedDate = *job.StartTime
+			} else if job.QueueTime != nil {
+				domainTask.StartedDate = *job.QueueTime
+			}
+			domainTask.Type = regexEnricher.GetEnrichResult(deploymentPattern, job.Name, devops.DEPLOYMENT)
+			domainTask.Environment = regexEnricher.GetEnrichResult(productionPattern, job.Name, devops.PRODUCTION)
+			return []interface{}{
+				domainTask,
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
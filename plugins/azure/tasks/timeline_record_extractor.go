@@ -0,0 +1,94 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/plugins/azure/models"
+	"github.com/apache/incubator-devlake/plugins/core"
+	"github.com/apache/incubator-devlake/plugins/helper"
+)
+
+type AzureApiTimelineRecord struct {
+	Id         string     `json:"id"`
+	ParentId   string     `json:"parentId"`
+	Type       string     `json:"type"`
+	Name       string     `json:"name"`
+	State      string     `json:"state"`
+	Result     string     `json:"result"`
+	Order      int        `json:"order"`
+	StartTime  *time.Time `json:"startTime"`
+	FinishTime *time.Time `json:"finishTime"`
+}
+
+var ExtractApiTimelineRecordsMeta = core.SubTaskMeta{
+	Name:             "extractApiTimelineRecords",
+	EntryPoint:       ExtractApiTimelineRecords,
+	EnabledByDefault: true,
+	Description:      "Extract raw Build timeline records data into tool layer table azure_timeline_records",
+	DomainTypes:      []string{core.DOMAIN_TYPE_CICD},
+}
+
+func ExtractApiTimelineRecords(taskCtx core.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*AzureTaskData)
+	extractor, err := helper.NewApiExtractor(helper.ApiExtractorArgs{
+		RawDataSubTaskArgs: helper.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: AzureApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				Project:      data.Options.Project,
+			},
+			Table: RAW_TIMELINE_RECORD_TABLE,
+		},
+		Extract: func(row *helper.RawData) ([]interface{}, errors.Error) {
+			build := &SimpleBuild{}
+			err := errors.Convert(json.Unmarshal(row.Input, build))
+			if err != nil {
+				return nil, err
+			}
+			body := &AzureApiTimelineRecord{}
+			err = errors.Convert(json.Unmarshal(row.Data, body))
+			if err != nil {
+				return nil, err
+			}
+			azureRecord := &models.AzureTimelineRecord{
+				ConnectionId: data.Options.ConnectionId,
+				BuildId:      build.AzureId,
+				RecordId:     body.Id,
+				ParentId:     body.ParentId,
+				Type:         body.Type,
+				Name:         body.Name,
+				State:        body.State,
+				Result:       body.Result,
+				RecordOrder:  body.Order,
+				StartTime:    body.StartTime,
+				FinishTime:   body.FinishTime,
+			}
+			return []interface{}{azureRecord}, nil
+		},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return extractor.Execute()
+}
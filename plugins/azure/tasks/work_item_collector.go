@@ -0,0 +1,171 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/plugins/core"
+	"github.com/apache/incubator-devlake/plugins/helper"
+)
+
+const RAW_WORK_ITEM_TABLE = "azure_api_work_items"
+
+// work items can be fetched by at most 200 ids per request
+const workItemBatchSize = 200
+
+// WorkItemIdsBatch is a batch of comma separated work item ids, it is saved as input of the raw data
+type WorkItemIdsBatch struct {
+	helper.QueueIteratorNode
+	Ids string
+}
+
+var CollectApiWorkItemsMeta = core.SubTaskMeta{
+	Name:             "collectApiWorkItems",
+	EntryPoint:       CollectApiWorkItems,
+	EnabledByDefault: true,
+	Description:      "Collect WorkItems data from Azure api",
+	DomainTypes:      []string{core.DOMAIN_TYPE_TICKET, core.DOMAIN_TYPE_CROSS},
+}
+
+func CollectApiWorkItems(taskCtx core.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*AzureTaskData)
+
+	ids, err := queryWorkItemIds(data)
+	if err != nil {
+		return err
+	}
+	taskCtx.GetLogger().Info("found %d work items in project %s", len(ids), data.Options.Project)
+	iterator := helper.NewQueueIterator()
+	for start := 0; start < len(ids); start += workItemBatchSize {
+		end := start + workItemBatchSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+		iterator.Push(&WorkItemIdsBatch{Ids: strings.Join(ids[start:end], ",")})
+	}
+
+	collector, err := helper.NewApiCollector(helper.ApiCollectorArgs{
+		RawDataSubTaskArgs: helper.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: AzureApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				Project:      data.Options.Project,
+			},
+			Table: RAW_WORK_ITEM_TABLE,
+		},
+		ApiClient: data.ApiClient,
+		Input:     iterator,
+
+		UrlTemplate: "{{ .Params.Project }}/_apis/wit/workitems?api-version=7.1-preview.3",
+		Query: func(reqData *helper.RequestData) (url.Values, errors.Error) {
+			query := url.Values{}
+			query.Set("ids", reqData.Input.(*WorkItemIdsBatch).Ids)
+			query.Set("$expand", "all")
+			// work items deleted after being queried are returned as null instead of failing the request
+			query.Set("errorPolicy", "omit")
+			return query, nil
+		},
+		ResponseParser: func(res *http.Response) ([]json.RawMessage, errors.Error) {
+			var data struct {
+				WorkItems []json.RawMessage `json:"value"`
+			}
+			err := helper.UnmarshalResponse(res, &data)
+			if err != nil {
+				return nil, err
+			}
+			workItems := make([]json.RawMessage, 0, len(data.WorkItems))
+			for _, workItem := range data.WorkItems {
+				if string(workItem) != "null" {
+					workItems = append(workItems, workItem)
+				}
+			}
+			return workItems, nil
+		},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return collector.Execute()
+}
+
+// the WIQL api returns at most 20000 work items per query
+const workItemQueryPageSize = 20000
+
+// queryWorkItemIds returns ids of all work items in the project, they are queried page by page in the order of ids
+func queryWorkItemIds(data *AzureTaskData) ([]string, errors.Error) {
+	ids := make([]string, 0)
+	lastId := 0
+	for {
+		pageIds, err := queryWorkItemIdsAfter(data, lastId)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range pageIds {
+			ids = append(ids, fmt.Sprintf("%d", id))
+		}
+		if len(pageIds) < workItemQueryPageSize {
+			return ids, nil
+		}
+		lastId = pageIds[len(pageIds)-1]
+	}
+}
+
+func queryWorkItemIdsAfter(data *AzureTaskData, lastId int) ([]int, errors.Error) {
+	query := url.Values{}
+	query.Set("api-version", "7.1-preview.2")
+	query.Set("$top", fmt.Sprintf("%d", workItemQueryPageSize))
+	res, err := data.ApiClient.Post(
+		fmt.Sprintf("%s/_apis/wit/wiql", url.PathEscape(data.Options.Project)),
+		query,
+		map[string]interface{}{
+			"query": fmt.Sprintf(
+				"SELECT [System.Id] FROM WorkItems WHERE [System.TeamProject] = @project AND [System.Id] > %d ORDER BY [System.Id]",
+				lastId,
+			),
+		},
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, errors.HttpStatus(res.StatusCode).New("unable to query Azure work items")
+	}
+	var body struct {
+		WorkItems []struct {
+			Id int `json:"id"`
+		} `json:"workItems"`
+	}
+	err = helper.UnmarshalResponse(res, &body)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int, 0, len(body.WorkItems))
+	for _, workItem := range body.WorkItems {
+		ids = append(ids, workItem.Id)
+	}
+	return ids, nil
+}
@@ -0,0 +1,153 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/models/domainlayer"
+	"github.com/apache/incubator-devlake/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/plugins/azure/models"
+	"github.com/apache/incubator-devlake/plugins/core"
+	"github.com/apache/incubator-devlake/plugins/core/dal"
+	"github.com/apache/incubator-devlake/plugins/helper"
+)
+
+var ConvertWorkItemsMeta = core.SubTaskMeta{
+	Name:             "convertWorkItems",
+	EntryPoint:       ConvertWorkItems,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table azure_work_items into domain layer table issues, board_issues and sprint_issues",
+	DomainTypes:      []string{core.DOMAIN_TYPE_TICKET},
+}
+
+func ConvertWorkItems(taskCtx core.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*AzureTaskData)
+
+	// work items of an area belong to boards of the area and all its ancestors
+	var areas []models.AzureArea
+	err := db.All(&areas, dal.Where("connection_id = ? AND project_id = ?", data.Options.ConnectionId, data.ProjectId))
+	if err != nil {
+		return err
+	}
+	areaParents := make(map[int]int, len(areas))
+	for _, area := range areas {
+		areaParents[area.AzureId] = area.ParentId
+	}
+	var iterations []models.AzureIteration
+	err = db.All(&iterations, dal.Where("connection_id = ? AND project_id = ? AND parent_id != 0", data.Options.ConnectionId, data.ProjectId))
+	if err != nil {
+		return err
+	}
+	sprints := make(map[int]bool, len(iterations))
+	for _, iteration := range iterations {
+		sprints[iteration.AzureId] = true
+	}
+
+	cursor, err := db.Cursor(
+		dal.From(&models.AzureWorkItem{}),
+		dal.Where("connection_id = ? AND project_id = ?", data.Options.ConnectionId, data.ProjectId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	workItemIdGen := didgen.NewDomainIdGenerator(&models.AzureWorkItem{})
+	areaIdGen := didgen.NewDomainIdGenerator(&models.AzureArea{})
+	iterationIdGen := didgen.NewDomainIdGenerator(&models.AzureIteration{})
+
+	converter, err := helper.NewDataConverter(helper.DataConverterArgs{
+		InputRowType: reflect.TypeOf(models.AzureWorkItem{}),
+		Input:        cursor,
+		RawDataSubTaskArgs: helper.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: AzureApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				Project:      data.Options.Project,
+			},
+			Table: RAW_WORK_ITEM_TABLE,
+		},
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			workItem := inputRow.(*models.AzureWorkItem)
+			// removed work items were never delivered, they should not be counted as issues at all
+			if workItem.State == workItemStateRemoved && workItem.StdStatus == "" {
+				return nil, nil
+			}
+			issue := &ticket.Issue{
+				DomainEntity: domainlayer.DomainEntity{
+					Id: workItemIdGen.Generate(data.Options.ConnectionId, workItem.AzureId),
+				},
+				Url:                     workItem.Url,
+				IssueKey:                fmt.Sprintf("%d", workItem.AzureId),
+				Title:                   workItem.Title,
+				Description:             workItem.Description,
+				Type:                    workItem.StdType,
+				Status:                  workItem.StdStatus,
+				OriginalStatus:          workItem.State,
+				StoryPoint:              int64(workItem.StoryPoints),
+				CreatedDate:             workItem.CreatedDate,
+				UpdatedDate:             workItem.ChangedDate,
+				Priority:                workItem.Priority,
+				Severity:                workItem.Severity,
+				OriginalEstimateMinutes: int64(workItem.OriginalEstimateHours * 60),
+				TimeSpentMinutes:        int64(workItem.CompletedWorkHours * 60),
+				TimeRemainingMinutes:    int64(workItem.RemainingWorkHours * 60),
+				CreatorId:               workItem.CreatorId,
+				CreatorName:             workItem.CreatorName,
+				AssigneeId:              workItem.AssigneeId,
+				AssigneeName:            workItem.AssigneeName,
+			}
+			issue.ResolutionDate = workItem.ClosedDate
+			if issue.ResolutionDate == nil {
+				issue.ResolutionDate = workItem.ResolvedDate
+			}
+			if issue.ResolutionDate != nil && issue.CreatedDate != nil {
+				issue.LeadTimeMinutes = int64(issue.ResolutionDate.Sub(*issue.CreatedDate).Minutes())
+			}
+			if workItem.ParentId != 0 {
+				issue.ParentIssueId = workItemIdGen.Generate(data.Options.ConnectionId, workItem.ParentId)
+			}
+			results := []interface{}{issue}
+			visited := make(map[int]bool)
+			for areaId := workItem.AreaId; areaId != 0 && !visited[areaId]; areaId = areaParents[areaId] {
+				visited[areaId] = true
+				results = append(results, &ticket.BoardIssue{
+					BoardId: areaIdGen.Generate(data.Options.ConnectionId, areaId),
+					IssueId: issue.Id,
+				})
+			}
+			if sprints[workItem.IterationId] {
+				results = append(results, &ticket.SprintIssue{
+					SprintId: iterationIdGen.Generate(data.Options.ConnectionId, workItem.IterationId),
+					IssueId:  issue.Id,
+				})
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
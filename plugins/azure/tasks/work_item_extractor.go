@@ -0,0 +1,220 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/plugins/azure/models"
+	"github.com/apache/incubator-devlake/plugins/core"
+	"github.com/apache/incubator-devlake/plugins/helper"
+)
+
+type AzureApiWorkItem struct {
+	Id     int `json:"id"`
+	Fields struct {
+		AreaId           int           `json:"System.AreaId"`
+		AreaPath         string        `json:"System.AreaPath"`
+		IterationId      int           `json:"System.IterationId"`
+		IterationPath    string        `json:"System.IterationPath"`
+		WorkItemType     string        `json:"System.WorkItemType"`
+		State            string        `json:"System.State"`
+		Title            string        `json:"System.Title"`
+		Description      string        `json:"System.Description"`
+		Parent           int           `json:"System.Parent"`
+		CreatedBy        *AzureApiUser `json:"System.CreatedBy"`
+		AssignedTo       *AzureApiUser `json:"System.AssignedTo"`
+		CreatedDate      *time.Time    `json:"System.CreatedDate"`
+		ChangedDate      *time.Time    `json:"System.ChangedDate"`
+		ResolvedDate     *time.Time    `json:"Microsoft.VSTS.Common.ResolvedDate"`
+		ClosedDate       *time.Time    `json:"Microsoft.VSTS.Common.ClosedDate"`
+		Priority         int           `json:"Microsoft.VSTS.Common.Priority"`
+		Severity         string        `json:"Microsoft.VSTS.Common.Severity"`
+		StoryPoints      float64       `json:"Microsoft.VSTS.Scheduling.StoryPoints"`
+		Effort           float64       `json:"Microsoft.VSTS.Scheduling.Effort"`
+		OriginalEstimate float64       `json:"Microsoft.VSTS.Scheduling.OriginalEstimate"`
+		CompletedWork    float64       `json:"Microsoft.VSTS.Scheduling.CompletedWork"`
+		RemainingWork    float64       `json:"Microsoft.VSTS.Scheduling.RemainingWork"`
+	} `json:"fields"`
+	Relations []struct {
+		Rel        string `json:"rel"`
+		Url        string `json:"url"`
+		Attributes struct {
+			Name string `json:"name"`
+		} `json:"attributes"`
+	} `json:"relations"`
+	Links struct {
+		Html struct {
+			Href string `json:"href"`
+		} `json:"html"`
+	} `json:"_links"`
+}
+
+var ExtractApiWorkItemsMeta = core.SubTaskMeta{
+	Name:             "extractApiWorkItems",
+	EntryPoint:       ExtractApiWorkItems,
+	EnabledByDefault: true,
+	Description:      "Extract raw WorkItems data into tool layer table azure_work_items and azure_work_item_links",
+	DomainTypes:      []string{core.DOMAIN_TYPE_TICKET, core.DOMAIN_TYPE_CROSS},
+}
+
+func ExtractApiWorkItems(taskCtx core.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*AzureTaskData)
+	extractor, err := helper.NewApiExtractor(helper.ApiExtractorArgs{
+		RawDataSubTaskArgs: helper.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: AzureApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				Project:      data.Options.Project,
+			},
+			Table: RAW_WORK_ITEM_TABLE,
+		},
+		Extract: func(row *helper.RawData) ([]interface{}, errors.Error) {
+			body := &AzureApiWorkItem{}
+			err := errors.Convert(json.Unmarshal(row.Data, body))
+			if err != nil {
+				return nil, err
+			}
+			fields := body.Fields
+			workItem := &models.AzureWorkItem{
+				ConnectionId:          data.Options.ConnectionId,
+				AzureId:               body.Id,
+				ProjectId:             data.ProjectId,
+				Url:                   body.Links.Html.Href,
+				Title:                 fields.Title,
+				Description:           fields.Description,
+				Type:                  fields.WorkItemType,
+				State:                 fields.State,
+				StdType:               getStdType(data.Options.AzureTransformationRule, fields.WorkItemType),
+				StdStatus:             getStdStatus(data.Options.AzureTransformationRule, fields.WorkItemType, fields.State),
+				AreaId:                fields.AreaId,
+				AreaPath:              fields.AreaPath,
+				IterationId:           fields.IterationId,
+				IterationPath:         fields.IterationPath,
+				ParentId:              fields.Parent,
+				Severity:              fields.Severity,
+				StoryPoints:           fields.StoryPoints,
+				OriginalEstimateHours: fields.OriginalEstimate,
+				CompletedWorkHours:    fields.CompletedWork,
+				RemainingWorkHours:    fields.RemainingWork,
+				CreatedDate:           fields.CreatedDate,
+				ChangedDate:           fields.ChangedDate,
+				ResolvedDate:          fields.ResolvedDate,
+				ClosedDate:            fields.ClosedDate,
+			}
+			// the Scrum process estimates with effort instead of story points
+			if workItem.StoryPoints == 0 {
+				workItem.StoryPoints = fields.Effort
+			}
+			if fields.Priority != 0 {
+				workItem.Priority = fmt.Sprintf("%d", fields.Priority)
+			}
+			if fields.CreatedBy != nil {
+				workItem.CreatorId = fields.CreatedBy.Id
+				workItem.CreatorName = fields.CreatedBy.DisplayName
+			}
+			if fields.AssignedTo != nil {
+				workItem.AssigneeId = fields.AssignedTo.Id
+				workItem.AssigneeName = fields.AssignedTo.DisplayName
+			}
+			results := []interface{}{workItem}
+			for _, relation := range body.Relations {
+				if relation.Rel != "ArtifactLink" {
+					continue
+				}
+				link := parseArtifactLink(relation.Url)
+				if link == nil {
+					continue
+				}
+				link.ConnectionId = data.Options.ConnectionId
+				link.WorkItemId = body.Id
+				results = append(results, link)
+			}
+			return results, nil
+		},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return extractor.Execute()
+}
+
+// parseArtifactLink parses links like `vstfs:///Git/Commit/{projectId}%2F{repositoryId}%2F{commitSha}` and
+// `vstfs:///Git/PullRequestId/{projectId}%2F{repositoryId}%2F{pullRequestId}`, other artifacts are ignored
+func parseArtifactLink(artifactUrl string) *models.AzureWorkItemLink {
+	var linkType, artifact string
+	if strings.HasPrefix(artifactUrl, "vstfs:///Git/Commit/") {
+		linkType = models.WorkItemLinkCommit
+		artifact = strings.TrimPrefix(artifactUrl, "vstfs:///Git/Commit/")
+	} else if strings.HasPrefix(artifactUrl, "vstfs:///Git/PullRequestId/") {
+		linkType = models.WorkItemLinkPullRequest
+		artifact = strings.TrimPrefix(artifactUrl, "vstfs:///Git/PullRequestId/")
+	} else {
+		return nil
+	}
+	artifact, err := url.PathUnescape(artifact)
+	if err != nil {
+		return nil
+	}
+	parts := strings.Split(artifact, "/")
+	if len(parts) != 3 {
+		return nil
+	}
+	return &models.AzureWorkItemLink{
+		LinkType:     linkType,
+		RepositoryId: parts[1],
+		TargetId:     parts[2],
+	}
+}
+
+// getStdType maps the work item type by the transformation rule, or uses the type in upper case
+func getStdType(rule *models.AzureTransformationRule, workItemType string) string {
+	if mapping, ok := rule.TypeMappings[workItemType]; ok && mapping.StandardType != "" {
+		return strings.ToUpper(mapping.StandardType)
+	}
+	return strings.ToUpper(workItemType)
+}
+
+// workItemStateRemoved is the state of built-in processes for work items which are not going to be done
+const workItemStateRemoved = "Removed"
+
+// getStdStatus maps the state by the transformation rule, or by the states of built-in processes, removed work items
+// have no standard status unless mapped by the rule
+func getStdStatus(rule *models.AzureTransformationRule, workItemType string, state string) string {
+	if mapping, ok := rule.TypeMappings[workItemType].StatusMappings[state]; ok {
+		return mapping.StandardStatus
+	}
+	switch state {
+	case "New", "Proposed", "Approved", "To Do":
+		return ticket.TODO
+	case "Done", "Closed":
+		return ticket.DONE
+	case workItemStateRemoved:
+		return ""
+	default:
+		return ticket.IN_PROGRESS
+	}
+}
@@ -0,0 +1,64 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/plugins/azure/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseArtifactLink(t *testing.T) {
+	link := parseArtifactLink("vstfs:///Git/Commit/30473eea-ca3f-4f40-a711-9cfa2e75e4b0%2F5dc348ab-98a9-4c49-95da-b70b24a62932%2Fc0ffee00c0ffee00c0ffee00c0ffee00c0ffee00")
+	assert.Equal(t, &models.AzureWorkItemLink{
+		LinkType:     models.WorkItemLinkCommit,
+		RepositoryId: "5dc348ab-98a9-4c49-95da-b70b24a62932",
+		TargetId:     "c0ffee00c0ffee00c0ffee00c0ffee00c0ffee00",
+	}, link)
+
+	link = parseArtifactLink("vstfs:///Git/PullRequestId/30473eea-ca3f-4f40-a711-9cfa2e75e4b0%2F5dc348ab-98a9-4c49-95da-b70b24a62932%2F12")
+	assert.Equal(t, &models.AzureWorkItemLink{
+		LinkType:     models.WorkItemLinkPullRequest,
+		RepositoryId: "5dc348ab-98a9-4c49-95da-b70b24a62932",
+		TargetId:     "12",
+	}, link)
+
+	assert.Nil(t, parseArtifactLink("vstfs:///Build/Build/101"))
+	assert.Nil(t, parseArtifactLink("vstfs:///Git/Commit/malformed"))
+}
+
+func Test_normalizeNodePath(t *testing.T) {
+	assert.Equal(t, `test`, normalizeNodePath(`\test\Iteration`))
+	assert.Equal(t, `test\Sprint 1`, normalizeNodePath(`\test\Iteration\Sprint 1`))
+	assert.Equal(t, `test\Backend\API`, normalizeNodePath(`\test\Area\Backend\API`))
+}
+
+func Test_getStdStatus(t *testing.T) {
+	rule := &models.AzureTransformationRule{
+		TypeMappings: models.TypeMappings{
+			"Bug": {StatusMappings: models.StatusMappings{"Removed": {StandardStatus: ticket.DONE}}},
+		},
+	}
+	assert.Equal(t, ticket.TODO, getStdStatus(rule, "User Story", "New"))
+	assert.Equal(t, ticket.IN_PROGRESS, getStdStatus(rule, "User Story", "Active"))
+	assert.Equal(t, ticket.DONE, getStdStatus(rule, "User Story", "Closed"))
+	assert.Equal(t, "", getStdStatus(rule, "User Story", "Removed"))
+	assert.Equal(t, ticket.DONE, getStdStatus(rule, "Bug", "Removed"))
+}
@@ -0,0 +1,104 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"strconv"
+
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/plugins/azure/models"
+	"github.com/apache/incubator-devlake/plugins/core"
+	"github.com/apache/incubator-devlake/plugins/core/dal"
+	"github.com/apache/incubator-devlake/plugins/helper"
+)
+
+var ConvertWorkItemLinksMeta = core.SubTaskMeta{
+	Name:             "convertWorkItemLinks",
+	EntryPoint:       ConvertWorkItemLinks,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table azure_work_item_links into domain layer table issue_commits and pull_request_issues",
+	DomainTypes:      []string{core.DOMAIN_TYPE_CROSS},
+}
+
+func ConvertWorkItemLinks(taskCtx core.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*AzureTaskData)
+
+	cursor, err := db.Cursor(
+		dal.Select("_tool_azure_work_item_links.*"),
+		dal.From(&models.AzureWorkItemLink{}),
+		dal.Join(`LEFT JOIN _tool_azure_work_items ON _tool_azure_work_items.connection_id = _tool_azure_work_item_links.connection_id
+			AND _tool_azure_work_items.azure_id = _tool_azure_work_item_links.work_item_id`),
+		dal.Where("_tool_azure_work_item_links.connection_id = ? AND _tool_azure_work_items.project_id = ?", data.Options.ConnectionId, data.ProjectId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	workItemIdGen := didgen.NewDomainIdGenerator(&models.AzureWorkItem{})
+	prIdGen := didgen.NewDomainIdGenerator(&models.AzurePullRequest{})
+
+	converter, err := helper.NewDataConverter(helper.DataConverterArgs{
+		InputRowType: reflect.TypeOf(models.AzureWorkItemLink{}),
+		Input:        cursor,
+		RawDataSubTaskArgs: helper.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: AzureApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				Project:      data.Options.Project,
+			},
+			Table: RAW_WORK_ITEM_TABLE,
+		},
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			link := inputRow.(*models.AzureWorkItemLink)
+			issueId := workItemIdGen.Generate(data.Options.ConnectionId, link.WorkItemId)
+			switch link.LinkType {
+			case models.WorkItemLinkCommit:
+				return []interface{}{
+					&crossdomain.IssueCommit{
+						IssueId:   issueId,
+						CommitSha: link.TargetId,
+					},
+				}, nil
+			case models.WorkItemLinkPullRequest:
+				pullRequestKey, err := strconv.Atoi(link.TargetId)
+				if err != nil {
+					return nil, errors.Default.Wrap(err, "invalid pull request id of work item link")
+				}
+				return []interface{}{
+					&crossdomain.PullRequestIssue{
+						PullRequestId:  prIdGen.Generate(data.Options.ConnectionId, pullRequestKey),
+						IssueId:        issueId,
+						PullRequestKey: pullRequestKey,
+						IssueKey:       link.WorkItemId,
+					},
+				}, nil
+			}
+			return nil, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
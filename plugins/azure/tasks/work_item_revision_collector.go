@@ -0,0 +1,100 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/plugins/azure/models"
+	"github.com/apache/incubator-devlake/plugins/core"
+	"github.com/apache/incubator-devlake/plugins/core/dal"
+	"github.com/apache/incubator-devlake/plugins/helper"
+)
+
+const RAW_WORK_ITEM_REVISION_TABLE = "azure_api_work_item_revisions"
+
+var CollectApiWorkItemRevisionsMeta = core.SubTaskMeta{
+	Name:             "collectApiWorkItemRevisions",
+	EntryPoint:       CollectApiWorkItemRevisions,
+	EnabledByDefault: true,
+	Description:      "Collect WorkItem revisions data from Azure api",
+	DomainTypes:      []string{core.DOMAIN_TYPE_TICKET},
+}
+
+type SimpleWorkItem struct {
+	AzureId int
+}
+
+func CollectApiWorkItemRevisions(taskCtx core.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*AzureTaskData)
+
+	cursor, err := db.Cursor(
+		dal.Select("azure_id"),
+		dal.From(models.AzureWorkItem{}.TableName()),
+		dal.Where("connection_id = ? AND project_id = ?", data.Options.ConnectionId, data.ProjectId),
+	)
+	if err != nil {
+		return err
+	}
+	iterator, err := helper.NewDalCursorIterator(db, cursor, reflect.TypeOf(SimpleWorkItem{}))
+	if err != nil {
+		return err
+	}
+
+	collector, err := helper.NewApiCollector(helper.ApiCollectorArgs{
+		RawDataSubTaskArgs: helper.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: AzureApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				Project:      data.Options.Project,
+			},
+			Table: RAW_WORK_ITEM_REVISION_TABLE,
+		},
+		ApiClient: data.ApiClient,
+		PageSize:  200,
+		Input:     iterator,
+
+		// updates are revisions with the changed fields only
+		UrlTemplate: "{{ .Params.Project }}/_apis/wit/workItems/{{ .Input.AzureId }}/updates?api-version=7.1-preview.3",
+		Query: func(reqData *helper.RequestData) (url.Values, errors.Error) {
+			query := url.Values{}
+			query.Set("$top", fmt.Sprintf("%v", reqData.Pager.Size))
+			query.Set("$skip", fmt.Sprintf("%v", reqData.Pager.Skip))
+			return query, nil
+		},
+		ResponseParser: func(res *http.Response) ([]json.RawMessage, errors.Error) {
+			var data struct {
+				Updates []json.RawMessage `json:"value"`
+			}
+			err := helper.UnmarshalResponse(res, &data)
+			return data.Updates, err
+		},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return collector.Execute()
+}
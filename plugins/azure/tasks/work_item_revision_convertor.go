@@ -0,0 +1,121 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/models/domainlayer"
+	"github.com/apache/incubator-devlake/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/plugins/azure/models"
+	"github.com/apache/incubator-devlake/plugins/core"
+	"github.com/apache/incubator-devlake/plugins/core/dal"
+	"github.com/apache/incubator-devlake/plugins/helper"
+)
+
+var ConvertWorkItemRevisionsMeta = core.SubTaskMeta{
+	Name:             "convertWorkItemRevisions",
+	EntryPoint:       ConvertWorkItemRevisions,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table azure_work_item_revisions into domain layer table issue_changelogs",
+	DomainTypes:      []string{core.DOMAIN_TYPE_TICKET},
+}
+
+type azureWorkItemRevisionResult struct {
+	models.AzureWorkItemRevision
+	Type string
+}
+
+func ConvertWorkItemRevisions(taskCtx core.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*AzureTaskData)
+
+	cursor, err := db.Cursor(
+		dal.Select("_tool_azure_work_item_revisions.*, _tool_azure_work_items.type"),
+		dal.From(&models.AzureWorkItemRevision{}),
+		dal.Join(`LEFT JOIN _tool_azure_work_items ON _tool_azure_work_items.connection_id = _tool_azure_work_item_revisions.connection_id
+			AND _tool_azure_work_items.azure_id = _tool_azure_work_item_revisions.work_item_id`),
+		dal.Where("_tool_azure_work_item_revisions.connection_id = ? AND _tool_azure_work_items.project_id = ?", data.Options.ConnectionId, data.ProjectId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	revisionIdGen := didgen.NewDomainIdGenerator(&models.AzureWorkItemRevision{})
+	workItemIdGen := didgen.NewDomainIdGenerator(&models.AzureWorkItem{})
+	iterationIdGen := didgen.NewDomainIdGenerator(&models.AzureIteration{})
+
+	converter, err := helper.NewDataConverter(helper.DataConverterArgs{
+		InputRowType: reflect.TypeOf(azureWorkItemRevisionResult{}),
+		Input:        cursor,
+		RawDataSubTaskArgs: helper.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: AzureApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				Project:      data.Options.Project,
+			},
+			Table: RAW_WORK_ITEM_REVISION_TABLE,
+		},
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			revision := inputRow.(*azureWorkItemRevisionResult)
+			changelog := &ticket.IssueChangelogs{
+				DomainEntity: domainlayer.DomainEntity{
+					Id: revisionIdGen.Generate(data.Options.ConnectionId, revision.WorkItemId, revision.Rev, revision.FieldId),
+				},
+				IssueId:           workItemIdGen.Generate(data.Options.ConnectionId, revision.WorkItemId),
+				AuthorId:          revision.RevisedById,
+				AuthorName:        revision.RevisedByName,
+				FieldId:           revision.FieldId,
+				FieldName:         revision.FieldId,
+				OriginalFromValue: revision.OldValue,
+				OriginalToValue:   revision.NewValue,
+				CreatedDate:       revision.RevisedDate,
+			}
+			// use the field names of other ticket plugins, so metrics based on changelogs work for work items
+			switch revision.FieldId {
+			case "System.State":
+				changelog.FieldName = "status"
+				if revision.OldValue != "" {
+					changelog.FromValue = getStdStatus(data.Options.AzureTransformationRule, revision.Type, revision.OldValue)
+				}
+				if revision.NewValue != "" {
+					changelog.ToValue = getStdStatus(data.Options.AzureTransformationRule, revision.Type, revision.NewValue)
+				}
+			case "System.AssignedTo":
+				changelog.FieldName = "assignee"
+			case "System.IterationId":
+				changelog.FieldName = "Sprint"
+				if revision.OldValue != "" {
+					changelog.OriginalFromValue = iterationIdGen.Generate(data.Options.ConnectionId, revision.OldValue)
+				}
+				if revision.NewValue != "" {
+					changelog.OriginalToValue = iterationIdGen.Generate(data.Options.ConnectionId, revision.NewValue)
+				}
+			}
+			return []interface{}{changelog}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
@@ -0,0 +1,133 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/plugins/azure/models"
+	"github.com/apache/incubator-devlake/plugins/core"
+	"github.com/apache/incubator-devlake/plugins/helper"
+)
+
+type AzureApiFieldChange struct {
+	OldValue interface{} `json:"oldValue"`
+	NewValue interface{} `json:"newValue"`
+}
+
+type AzureApiWorkItemUpdate struct {
+	Id          int                            `json:"id"`
+	WorkItemId  int                            `json:"workItemId"`
+	Rev         int                            `json:"rev"`
+	RevisedBy   AzureApiUser                   `json:"revisedBy"`
+	RevisedDate time.Time                      `json:"revisedDate"`
+	Fields      map[string]AzureApiFieldChange `json:"fields"`
+}
+
+// bookkeeping fields changed by every revision
+var ignoredRevisionFields = map[string]bool{
+	"System.Rev":            true,
+	"System.ChangedDate":    true,
+	"System.ChangedBy":      true,
+	"System.RevisedDate":    true,
+	"System.AuthorizedDate": true,
+	"System.AuthorizedAs":   true,
+	"System.PersonId":       true,
+	"System.Watermark":      true,
+}
+
+var ExtractApiWorkItemRevisionsMeta = core.SubTaskMeta{
+	Name:             "extractApiWorkItemRevisions",
+	EntryPoint:       ExtractApiWorkItemRevisions,
+	EnabledByDefault: true,
+	Description:      "Extract raw WorkItem revisions data into tool layer table azure_work_item_revisions",
+	DomainTypes:      []string{core.DOMAIN_TYPE_TICKET},
+}
+
+func ExtractApiWorkItemRevisions(taskCtx core.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*AzureTaskData)
+	extractor, err := helper.NewApiExtractor(helper.ApiExtractorArgs{
+		RawDataSubTaskArgs: helper.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: AzureApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				Project:      data.Options.Project,
+			},
+			Table: RAW_WORK_ITEM_REVISION_TABLE,
+		},
+		Extract: func(row *helper.RawData) ([]interface{}, errors.Error) {
+			body := &AzureApiWorkItemUpdate{}
+			err := errors.Convert(json.Unmarshal(row.Data, body))
+			if err != nil {
+				return nil, err
+			}
+			// revisedDate is when the revision got replaced by the next one, so the change happened at ChangedDate
+			revisedDate := body.RevisedDate
+			if changedDate, ok := body.Fields["System.ChangedDate"].NewValue.(string); ok {
+				if t, err := time.Parse(time.RFC3339, changedDate); err == nil {
+					revisedDate = t
+				}
+			}
+			results := make([]interface{}, 0, len(body.Fields))
+			for fieldId, change := range body.Fields {
+				if ignoredRevisionFields[fieldId] {
+					continue
+				}
+				results = append(results, &models.AzureWorkItemRevision{
+					ConnectionId:  data.Options.ConnectionId,
+					WorkItemId:    body.WorkItemId,
+					Rev:           body.Rev,
+					FieldId:       fieldId,
+					OldValue:      formatFieldValue(change.OldValue),
+					NewValue:      formatFieldValue(change.NewValue),
+					RevisedById:   body.RevisedBy.Id,
+					RevisedByName: body.RevisedBy.DisplayName,
+					RevisedDate:   revisedDate,
+				})
+			}
+			return results, nil
+		},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return extractor.Execute()
+}
+
+// formatFieldValue formats value of a field, identities like `System.AssignedTo` are formatted as their ids
+func formatFieldValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case map[string]interface{}:
+		if id, ok := v["id"].(string); ok {
+			return id
+		}
+		blob, _ := json.Marshal(v)
+		return string(blob)
+	default:
+		return fmt.Sprint(v)
+	}
+}
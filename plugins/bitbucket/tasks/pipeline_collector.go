@@ -18,6 +18,11 @@ limitations under the License.
 package tasks
 
 import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"time"
+
 	"github.com/apache/incubator-devlake/errors"
 	"github.com/apache/incubator-devlake/plugins/core"
 	"github.com/apache/incubator-devlake/plugins/helper"
@@ -35,21 +40,59 @@ var CollectApiPipelinesMeta = core.SubTaskMeta{
 
 func CollectApiPipelines(taskCtx core.SubTaskContext) errors.Error {
 	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_PIPELINE_TABLE)
+	collectorWithState, err := helper.NewApiCollectorWithState(*rawDataSubTaskArgs, data.Since)
+	if err != nil {
+		return err
+	}
+	// pipelines are listed from the latest and those completed before the window are dropped,
+	// which ends the paging once a page is not full
+	timeWindow := &helper.IncrementalTimeWindow{
+		Overlap: 5 * time.Minute,
+	}
+	since := collectorWithState.TimeWindowSince(timeWindow)
 
-	collector, err := helper.NewApiCollector(helper.ApiCollectorArgs{
-		RawDataSubTaskArgs: *rawDataSubTaskArgs,
-		ApiClient:          data.ApiClient,
-		PageSize:           50,
-		Incremental:        false,
-		UrlTemplate:        "repositories/{{ .Params.Owner }}/{{ .Params.Repo }}/pipelines/",
-		Query:              GetQuery,
-		ResponseParser:     GetRawMessageFromResponse,
-		GetTotalPages:      GetTotalPagesFromResponse,
+	err = collectorWithState.InitCollector(helper.ApiCollectorArgs{
+		ApiClient:   data.ApiClient,
+		PageSize:    50,
+		TimeWindow:  timeWindow,
+		UrlTemplate: "repositories/{{ .Params.Owner }}/{{ .Params.Repo }}/pipelines/",
+		Query: func(reqData *helper.RequestData) (url.Values, errors.Error) {
+			query, err := GetQuery(reqData)
+			if err != nil {
+				return nil, err
+			}
+			query.Set("sort", "-created_on")
+			return query, nil
+		},
+		ResponseParser: func(res *http.Response) ([]json.RawMessage, errors.Error) {
+			pipelines, err := GetRawMessageFromResponse(res)
+			if err != nil || since == nil {
+				return pipelines, err
+			}
+			return filterPipelinesCompletedSince(pipelines, *since)
+		},
 	})
-
 	if err != nil {
 		return err
 	}
 
-	return collector.Execute()
+	return collectorWithState.Execute()
+}
+
+// filterPipelinesCompletedSince keeps pipelines still running or completed since the given time
+func filterPipelinesCompletedSince(pipelines []json.RawMessage, since time.Time) ([]json.RawMessage, errors.Error) {
+	filtered := make([]json.RawMessage, 0, len(pipelines))
+	for _, pipeline := range pipelines {
+		var times struct {
+			CompletedOn *time.Time `json:"completed_on"`
+		}
+		err := errors.Convert(json.Unmarshal(pipeline, &times))
+		if err != nil {
+			return nil, err
+		}
+		if times.CompletedOn == nil || !times.CompletedOn.Before(since) {
+			filtered = append(filtered, pipeline)
+		}
+	}
+	return filtered, nil
 }
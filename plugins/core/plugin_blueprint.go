@@ -29,6 +29,8 @@ type PipelineTask struct {
 	SkipOnFail bool                   `json:"skipOnFail"`
 	Subtasks   []string               `json:"subtasks"`
 	Options    map[string]interface{} `json:"options"`
+	// RetryPolicy would be applied when the task failed, falls back to the one in blueprint settings
+	RetryPolicy *RetryPolicy `json:"retryPolicy,omitempty"`
 }
 
 // PipelineStage consist of multiple PipelineTasks, they will be executed in parallel
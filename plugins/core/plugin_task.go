@@ -19,6 +19,8 @@ package core
 
 import (
 	"context"
+	"time"
+
 	"github.com/apache/incubator-devlake/errors"
 )
 
@@ -56,6 +58,33 @@ type SubTaskContext interface {
 	TaskContext() TaskContext
 }
 
+// ResumableSubTaskContext is implemented by SubTaskContext which might be resuming a failed attempt of the subtask,
+// subtasks like collectors could pick up the data persisted by the failed attempt instead of starting over
+type ResumableSubTaskContext interface {
+	SubTaskContext
+	// ResumeSince returns the time when the failed attempt started, or nil if the subtask is running from scratch
+	ResumeSince() *time.Time
+}
+
+// ApiCallsRecorder is implemented by SubTaskContext which keeps track of the api calls made by the subtask,
+// the numbers are persisted along with the subtask so the cost of future runs could be estimated
+type ApiCallsRecorder interface {
+	IncApiCalls(quantity int)
+}
+
+// RawDataCollectionRecorder is implemented by SubTaskContext which keeps track of the raw tables collected by the task,
+// retention policies would be applied to them once all subtasks of the task are finished
+type RawDataCollectionRecorder interface {
+	RecordRawDataCollection(table string, params string)
+}
+
+// ConnectionAwareTaskContext is implemented by TaskContext which knows the connection its task is working on,
+// api clients created with it would share the rate limit of the connection with other tasks
+type ConnectionAwareTaskContext interface {
+	// GetConnectionKey returns `<plugin>#<connectionId>`, or empty string if the task isn't bound to any connection
+	GetConnectionKey() string
+}
+
 // TaskContext This interface define all resources that needed for task execution
 type TaskContext interface {
 	ExecContext
@@ -0,0 +1,35 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/errors"
+)
+
+// RateLimitStore keeps the request quota of connections, all api clients working on a connection draw from it
+// no matter which task or devlake instance they belong to
+type RateLimitStore interface {
+	// Take leases up to `n` requests from the quota of `key`, the quota would be refilled with `limit` requests every
+	// `period` unless the server told otherwise. It returns the number of requests granted and when the quota resets
+	Take(key string, n int, limit int, period time.Duration) (int, time.Time, errors.Error)
+	// Adjust settles `settled` requests leased by the caller which were sent or given up, and lowers the quota of `key`
+	// to the `remaining` requests reported by the server minus the ones still leased by all clients
+	Adjust(key string, remaining int, resetAt time.Time, settled int) errors.Error
+}
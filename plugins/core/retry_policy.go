@@ -0,0 +1,113 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/apache/incubator-devlake/errors"
+)
+
+// DefaultRetryableErrors are the names of errors.Type treated as transient when RetryPolicy.RetryableErrors is empty,
+// unclassified errors fall into "default", which has to be listed explicitly to be retried
+var DefaultRetryableErrors = []string{
+	errors.Timeout.GetName(),
+	errors.Deadlock.GetName(),
+	errors.TokenExpired.GetName(),
+	errors.HttpStatus(429).GetName(),
+	errors.Internal.GetName(),
+	errors.HttpStatus(502).GetName(),
+	errors.HttpStatus(503).GetName(),
+}
+
+// RetryPolicy defines how a failed PipelineTask would be retried before failing the whole pipeline
+type RetryPolicy struct {
+	// MaxAttempts is the total number of runs including the first one, 0 or 1 means no retry
+	MaxAttempts int `json:"maxAttempts"`
+	// BackoffSeconds is the delay before the first retry
+	BackoffSeconds int `json:"backoffSeconds"`
+	// BackoffFactor multiplies the delay after each retry, 2 if omitted
+	BackoffFactor float64 `json:"backoffFactor"`
+	// MaxBackoffSeconds caps the delay, 0 means no cap
+	MaxBackoffSeconds int `json:"maxBackoffSeconds"`
+	// RetryableErrors are the names of errors.Type which should be retried, e.g. "timeout" or "type_http_503"
+	RetryableErrors []string `json:"retryableErrors"`
+}
+
+// Validate checks if the policy is well-formed
+func (p *RetryPolicy) Validate() errors.Error {
+	if p == nil {
+		return nil
+	}
+	if p.MaxAttempts < 0 || p.BackoffSeconds < 0 || p.MaxBackoffSeconds < 0 {
+		return errors.BadInput.New("retryPolicy.maxAttempts, backoffSeconds and maxBackoffSeconds must not be negative")
+	}
+	if p.BackoffFactor != 0 && p.BackoffFactor < 1 {
+		return errors.BadInput.New(fmt.Sprintf("retryPolicy.backoffFactor must be at least 1, got %v", p.BackoffFactor))
+	}
+	return nil
+}
+
+// ShouldRetry tells whether the task should be run again after its `attempt`th run failed with err
+func (p *RetryPolicy) ShouldRetry(attempt int, err error) bool {
+	if p == nil || err == nil || attempt >= p.MaxAttempts {
+		return false
+	}
+	return p.IsRetryable(err)
+}
+
+// IsRetryable tells whether err is one of the retryable error types. The type being checked is the one carried by the
+// outermost error below the subtask wrapper, which is inherited by errors.Default wrappers
+func (p *RetryPolicy) IsRetryable(err error) bool {
+	lakeErr := errors.AsLakeErrorType(err)
+	for lakeErr != nil && lakeErr.GetType() == errors.SubtaskErr {
+		lakeErr = errors.AsLakeErrorType(lakeErr.Unwrap())
+	}
+	name := errors.Default.GetName()
+	if lakeErr != nil {
+		name = lakeErr.GetType().GetName()
+	}
+	retryable := p.RetryableErrors
+	if len(retryable) == 0 {
+		retryable = DefaultRetryableErrors
+	}
+	for _, r := range retryable {
+		if r == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Backoff returns the delay before the next run after the `attempt`th run failed
+func (p *RetryPolicy) Backoff(attempt int) time.Duration {
+	if p == nil || attempt < 1 {
+		return 0
+	}
+	factor := p.BackoffFactor
+	if factor == 0 {
+		factor = 2
+	}
+	seconds := float64(p.BackoffSeconds) * math.Pow(factor, float64(attempt-1))
+	if p.MaxBackoffSeconds > 0 && seconds > float64(p.MaxBackoffSeconds) {
+		seconds = float64(p.MaxBackoffSeconds)
+	}
+	return time.Duration(seconds * float64(time.Second))
+}
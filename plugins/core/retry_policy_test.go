@@ -0,0 +1,95 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/impl/dalgorm"
+	"github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgconn"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := &RetryPolicy{MaxAttempts: 5, BackoffSeconds: 10, MaxBackoffSeconds: 60}
+	assert.Equal(t, 10*time.Second, policy.Backoff(1))
+	assert.Equal(t, 20*time.Second, policy.Backoff(2))
+	assert.Equal(t, 40*time.Second, policy.Backoff(3))
+	assert.Equal(t, 60*time.Second, policy.Backoff(4))
+
+	policy = &RetryPolicy{MaxAttempts: 3, BackoffSeconds: 1, BackoffFactor: 1.5}
+	assert.Equal(t, 1500*time.Millisecond, policy.Backoff(2))
+
+	var noPolicy *RetryPolicy
+	assert.Equal(t, time.Duration(0), noPolicy.Backoff(1))
+}
+
+func TestRetryPolicyShouldRetry(t *testing.T) {
+	deadlock := errors.Convert(fmt.Errorf("Error 1213: Deadlock found when trying to get lock"))
+	expired := errors.Default.Wrap(errors.HttpStatus(401).New("token expired"), "collect failed")
+	unavailable := errors.Default.Wrap(errors.HttpStatus(503).New("service unavailable"), "collect failed")
+	rateLimited := errors.HttpStatus(429).New("too many requests")
+	timeout := errors.Timeout.New("request timed out")
+	badInput := errors.BadInput.New("invalid options")
+	subtaskErr := errors.SubtaskErr.Wrap(expired, "subtask collectIssues ended unexpectedly")
+
+	policy := &RetryPolicy{MaxAttempts: 3}
+	assert.True(t, policy.ShouldRetry(1, unavailable))
+	assert.True(t, policy.ShouldRetry(2, errors.SubtaskErr.Wrap(unavailable, "subtask collectIssues ended unexpectedly")))
+	assert.True(t, policy.ShouldRetry(1, rateLimited))
+	assert.True(t, policy.ShouldRetry(1, timeout))
+	assert.False(t, policy.ShouldRetry(3, unavailable))
+	// unclassified and authentication errors are not transient by default
+	assert.False(t, policy.ShouldRetry(1, deadlock))
+	assert.False(t, policy.ShouldRetry(1, subtaskErr))
+	assert.False(t, policy.ShouldRetry(1, badInput))
+	assert.False(t, policy.ShouldRetry(1, nil))
+
+	// deadlocks and lock wait timeouts reported by the databases are transient
+	mysqlDeadlock := dalgorm.ConvertError(&mysql.MySQLError{Number: 1213, Message: "Deadlock found when trying to get lock"})
+	mysqlLockWait := dalgorm.ConvertError(&mysql.MySQLError{Number: 1205, Message: "Lock wait timeout exceeded"})
+	pgDeadlock := dalgorm.ConvertError(&pgconn.PgError{Code: "40P01", Message: "deadlock detected"})
+	duplicated := dalgorm.ConvertError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry"})
+	assert.True(t, policy.ShouldRetry(1, errors.Default.Wrap(mysqlDeadlock, "failed to save issues")))
+	assert.True(t, policy.ShouldRetry(1, errors.SubtaskErr.Wrap(mysqlLockWait, "subtask convertIssues ended unexpectedly")))
+	assert.True(t, policy.ShouldRetry(1, pgDeadlock))
+	assert.False(t, policy.ShouldRetry(1, duplicated))
+	// 401s caused by expired tokens are transient, the token may be renewed in the meantime
+	tokenExpired := errors.Default.Wrap(errors.TokenExpired.New("the AccessToken has expired"), "collect failed")
+	assert.True(t, policy.ShouldRetry(1, errors.SubtaskErr.Wrap(tokenExpired, "subtask collectIssues ended unexpectedly")))
+	assert.Equal(t, errors.Unauthorized, errors.HttpStatus(401))
+
+	policy = &RetryPolicy{MaxAttempts: 3, RetryableErrors: []string{"unauthorized", "default"}}
+	assert.True(t, policy.ShouldRetry(1, subtaskErr))
+	assert.True(t, policy.ShouldRetry(1, deadlock))
+	assert.False(t, policy.ShouldRetry(1, unavailable))
+
+	var noPolicy *RetryPolicy
+	assert.False(t, noPolicy.ShouldRetry(1, deadlock))
+}
+
+func TestRetryPolicyValidate(t *testing.T) {
+	assert.Nil(t, (*RetryPolicy)(nil).Validate())
+	assert.Nil(t, (&RetryPolicy{MaxAttempts: 3, BackoffFactor: 2}).Validate())
+	assert.NotNil(t, (&RetryPolicy{MaxAttempts: -1}).Validate())
+	assert.NotNil(t, (&RetryPolicy{MaxAttempts: 3, BackoffFactor: 0.5}).Validate())
+}
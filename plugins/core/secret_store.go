@@ -0,0 +1,36 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import "github.com/apache/incubator-devlake/errors"
+
+// SecretStore encrypts secrets before they are persisted, e.g. fields tagged with `encrypt:"yes"`, and decrypts them
+// after being loaded
+type SecretStore interface {
+	Encrypt(plainText string) (string, errors.Error)
+	Decrypt(cipherText string) (string, errors.Error)
+}
+
+// KeyProvider supplies keys to SecretStore, it is modeled after KMS services so the file based one shipped with us
+// could be replaced by a real KMS
+type KeyProvider interface {
+	// ActiveKey returns the id and the material of the key used for encrypting new secrets
+	ActiveKey() (string, []byte, errors.Error)
+	// Key returns the material of the key with the specified id for decrypting existing secrets
+	Key(id string) ([]byte, errors.Error)
+}
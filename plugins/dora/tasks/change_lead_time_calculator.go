@@ -118,6 +118,9 @@ func CalculateChangeLeadTime(taskCtx core.SubTaskContext) errors.Error {
 				projectPrMetric.PrPickupTime = processNegativeValue(int64(firstReview.CreatedDate.Sub(pr.CreatedDate).Minutes()))
 				projectPrMetric.PrReviewTime = processNegativeValue(int64(pr.MergedDate.Sub(firstReview.CreatedDate).Minutes()))
 				projectPrMetric.FirstReviewId = firstReview.ReviewId
+				if firstReview.Type == code.REVIEW {
+					projectPrMetric.FirstReviewId = firstReview.Id
+				}
 			}
 			deployment, err := getDeployment(pr.MergeCommitSha, pr.BaseRepoId, deploymentDiffPairs, db)
 			if err != nil {
@@ -175,21 +178,26 @@ func getFirstCommit(prId string, db dal.Dal) (*code.Commit, errors.Error) {
 	return commit, nil
 }
 
+// getFirstReview returns the first review, including approvals, made by others than the creator of the pr,
+// or the first comment made by others if the data source does not tell reviews from comments
 func getFirstReview(prId string, prCreator string, db dal.Dal) (*code.PullRequestComment, errors.Error) {
-	review := &code.PullRequestComment{}
-	commentClauses := []dal.Clause{
-		dal.From(&code.PullRequestComment{}),
-		dal.Where("pull_request_id = ? and account_id != ?", prId, prCreator),
-		dal.Orderby("created_date ASC"),
+	review, err := getFirstComment(db, dal.Where("pull_request_id = ? and account_id != ? and type = ?", prId, prCreator, code.REVIEW))
+	if review != nil || err != nil {
+		return review, err
 	}
-	err := db.First(review, commentClauses...)
+	return getFirstComment(db, dal.Where("pull_request_id = ? and account_id != ?", prId, prCreator))
+}
+
+func getFirstComment(db dal.Dal, where dal.Clause) (*code.PullRequestComment, errors.Error) {
+	comment := &code.PullRequestComment{}
+	err := db.First(comment, dal.From(&code.PullRequestComment{}), where, dal.Orderby("created_date ASC"))
 	if goerror.Is(err, gorm.ErrRecordNotFound) {
 		return nil, nil
 	}
 	if err != nil {
 		return nil, err
 	}
-	return review, nil
+	return comment, nil
 }
 
 func getDeployment(mergeSha string, repoId string, deploymentPairList []deploymentPair, db dal.Dal) (*deploymentPair, errors.Error) {
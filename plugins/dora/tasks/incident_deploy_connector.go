@@ -77,6 +77,16 @@ func ConnectIncidentToDeployment(taskCtx core.SubTaskContext) errors.Error {
 				},
 				ProjectName: data.Options.ProjectName,
 			}
+			// when the board of the incident is mapped to repos, only deployments of those repos are considered
+			var repoIds []string
+			err = db.Pluck("br.repo_id", &repoIds,
+				dal.From("board_repos br"),
+				dal.Join("left join board_issues bi on bi.board_id = br.board_id"),
+				dal.Where("bi.issue_id = ?", issue.Id),
+			)
+			if err != nil {
+				return nil, err
+			}
 			cicdTask := &devops.CICDTask{}
 			cicdTakClauses := []dal.Clause{
 				dal.From(cicdTask),
@@ -90,8 +100,11 @@ func ConnectIncidentToDeployment(taskCtx core.SubTaskContext) errors.Error {
 								and pm.project_name = ?`,
 					issue.CreatedDate, devops.SUCCESS, devops.PRODUCTION, devops.DEPLOYMENT, "cicd_scopes", data.Options.ProjectName,
 				),
-				dal.Orderby("cicd_tasks.finished_date DESC"),
 			}
+			if len(repoIds) > 0 {
+				cicdTakClauses = append(cicdTakClauses, dal.Where("cicd_tasks.cicd_scope_id IN ?", repoIds))
+			}
+			cicdTakClauses = append(cicdTakClauses, dal.Orderby("cicd_tasks.finished_date DESC"))
 			err = db.First(cicdTask, cicdTakClauses...)
 			if err != nil {
 				if goerror.Is(err, gorm.ErrRecordNotFound) {
@@ -54,14 +54,15 @@ func (plugin Feishu) Init(config *viper.Viper, logger core.Logger, db *gorm.DB)
 			return errors.Convert(err)
 		}
 		if connection.ID != 0 {
-			encodeKey := config.GetString(core.EncodeKeyEnvStr)
 			connection.Endpoint = config.GetString(`FEISHU_ENDPOINT`)
 			connection.AppId = config.GetString(`FEISHU_APPID`)
 			connection.SecretKey = config.GetString(`FEISHU_APPSCRECT`)
-			if connection.Endpoint != `` && connection.AppId != `` && connection.SecretKey != `` && encodeKey != `` {
-				err := helper.UpdateEncryptFields(connection, func(plaintext string) (string, errors.Error) {
-					return core.Encrypt(encodeKey, plaintext)
-				})
+			if connection.Endpoint != `` && connection.AppId != `` && connection.SecretKey != `` {
+				store, err := helper.GetSecretStore(helper.NewDefaultBasicRes(config, logger, db))
+				if err != nil {
+					return err
+				}
+				err = helper.UpdateEncryptFields(connection, store.Encrypt)
 				if err != nil {
 					return err
 				}
@@ -63,7 +63,7 @@ func (plugin GitExtractor) PrepareTaskData(taskCtx core.TaskContext, options map
 		return nil, err
 	}
 	storage := store.NewDatabase(taskCtx, op.Url)
-	repo, err := NewGitRepo(taskCtx.GetLogger(), storage, op)
+	repo, err := NewGitRepo(taskCtx.GetLogger(), storage, op, taskCtx.GetConfig("GIT_EXTRACTOR_CLONE_CACHE_DIR"))
 	if err != nil {
 		return nil, err
 	}
@@ -83,11 +83,12 @@ func (plugin GitExtractor) RootPkgPath() string {
 	return "github.com/apache/incubator-devlake/plugins/gitextractor"
 }
 
-// NewGitRepo create and return a new parser git repo
-func NewGitRepo(logger core.Logger, storage models.Store, op tasks.GitExtractorOptions) (*parser.GitRepo, errors.Error) {
+// NewGitRepo create and return a new parser git repo, remote repos are cloned into cloneCacheDir and
+// fetched on later runs if cloneCacheDir is not empty
+func NewGitRepo(logger core.Logger, storage models.Store, op tasks.GitExtractorOptions, cloneCacheDir string) (*parser.GitRepo, errors.Error) {
 	var err errors.Error
 	var repo *parser.GitRepo
-	p := parser.NewGitRepoCreator(storage, logger)
+	p := parser.NewGitRepoCreator(storage, logger, cloneCacheDir)
 	if strings.HasPrefix(op.Url, "http") {
 		repo, err = p.CloneOverHTTP(op.RepoId, op.Url, op.User, op.Password, op.Proxy)
 	} else if url := strings.TrimPrefix(op.Url, "ssh://"); strings.HasPrefix(url, "git@") {
@@ -43,6 +43,7 @@ func main() {
 	password := flag.String("password", "", "-password")
 	output := flag.String("output", "", "-output")
 	db := flag.String("db", "", "-db")
+	cacheDir := flag.String("cache-dir", "", "-cache-dir")
 	flag.Parse()
 	log := logger.Global.Nested("git extractor")
 	var storage models.Store
@@ -84,7 +85,7 @@ func main() {
 		User:     *user,
 		Password: *password,
 		Proxy:    *proxy,
-	})
+	}, *cacheDir)
 	if err != nil {
 		panic(err)
 	}
@@ -31,5 +31,7 @@ type Store interface {
 	CommitFileComponents(commitFileComponent *code.CommitFileComponent) errors.Error
 	CommitLineChange(commitLineChange *code.CommitLineChange) errors.Error
 	RepoSnapshot(snapshot *code.RepoSnapshot) errors.Error
+	// SetIncrementalMode keeps the records saved by previous runs for the types which are not saved yet
+	SetIncrementalMode(incremental bool)
 	Close() errors.Error
 }
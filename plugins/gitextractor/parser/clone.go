@@ -18,13 +18,19 @@ limitations under the License.
 package parser
 
 import (
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"fmt"
 	"net"
+	neturl "net/url"
 	"os"
+	"path/filepath"
+	"sync"
 
 	"github.com/apache/incubator-devlake/errors"
 	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/transport/ssh"
 	git "github.com/libgit2/git2go/v33"
 	ssh2 "golang.org/x/crypto/ssh"
@@ -35,59 +41,270 @@ import (
 
 const DefaultUser = "git"
 
-func cloneOverSSH(url, dir, passphrase string, pk []byte) errors.Error {
+// cloneCacheLocks holds a mutex for each cached clone, so a repo would not be fetched by two tasks at the same time
+var cloneCacheLocks sync.Map
+
+func newPublicKeys(passphrase string, pk []byte) (*ssh.PublicKeys, errors.Error) {
 	key, err := ssh.NewPublicKeys(DefaultUser, pk, passphrase)
 	if err != nil {
-		return errors.Convert(err)
+		return nil, errors.Convert(err)
 	}
 	key.HostKeyCallbackHelper = ssh.HostKeyCallbackHelper{
 		HostKeyCallback: func(hostname string, remote net.Addr, key ssh2.PublicKey) error {
 			return nil
 		},
 	}
-	_, err = gogit.PlainClone(dir, true, &gogit.CloneOptions{
+	return key, nil
+}
+
+func cloneOverSSH(url, dir, passphrase string, pk []byte) errors.Error {
+	key, err := newPublicKeys(passphrase, pk)
+	if err != nil {
+		return err
+	}
+	_, err = errors.Convert01(gogit.PlainClone(dir, true, &gogit.CloneOptions{
 		URL:  url,
 		Auth: key,
+	}))
+	return err
+}
+
+// fetchOverSSH fetches the cached clone, removes the branches which were deleted from the remote, as go-git does
+// not support pruning while fetching, and moves the local default branch
+func fetchOverSSH(dir, passphrase string, pk []byte) errors.Error {
+	key, err := newPublicKeys(passphrase, pk)
+	if err != nil {
+		return err
+	}
+	repo, err := errors.Convert01(gogit.PlainOpen(dir))
+	if err != nil {
+		return err
+	}
+	fetchErr := repo.Fetch(&gogit.FetchOptions{
+		Auth:  key,
+		Tags:  gogit.AllTags,
+		Force: true,
 	})
+	if fetchErr != nil && fetchErr != gogit.NoErrAlreadyUpToDate {
+		return errors.Convert(fetchErr)
+	}
+	remote, err := errors.Convert01(repo.Remote(gogit.DefaultRemoteName))
 	if err != nil {
-		return errors.Convert(err)
+		return err
 	}
-	return nil
+	remoteRefs, err := errors.Convert01(remote.List(&gogit.ListOptions{Auth: key}))
+	if err != nil {
+		return err
+	}
+	remoteRefNames := make(map[plumbing.ReferenceName]bool, len(remoteRefs))
+	for _, ref := range remoteRefs {
+		remoteRefNames[ref.Name()] = true
+	}
+	localRefs, err := errors.Convert01(repo.References())
+	if err != nil {
+		return err
+	}
+	var deletedRefNames []plumbing.ReferenceName
+	err = errors.Convert(localRefs.ForEach(func(ref *plumbing.Reference) error {
+		for _, refSpec := range remote.Config().Fetch {
+			reverse := refSpec.Reverse()
+			if reverse.Match(ref.Name()) && !remoteRefNames[reverse.Dst(ref.Name())] {
+				deletedRefNames = append(deletedRefNames, ref.Name())
+			}
+		}
+		return nil
+	}))
+	if err != nil {
+		return err
+	}
+	for _, name := range deletedRefNames {
+		err = errors.Convert(repo.Storer.RemoveReference(name))
+		if err != nil {
+			return err
+		}
+	}
+	// move the local default branch created by the clone to where the remote one is
+	head, err := errors.Convert01(repo.Head())
+	if err != nil {
+		return err
+	}
+	remoteHead, err := errors.Convert01(repo.Reference(plumbing.NewRemoteReferenceName(gogit.DefaultRemoteName, head.Name().Short()), true))
+	if err != nil {
+		return err
+	}
+	return errors.Convert(repo.Storer.SetReference(plumbing.NewHashReference(head.Name(), remoteHead.Hash())))
 }
 
 func (l *GitRepoCreator) CloneOverHTTP(repoId, url, user, password, proxy string) (*GitRepo, errors.Error) {
-	return withTempDirectory(func(dir string) (*GitRepo, error) {
-		cloneOptions := &git.CloneOptions{Bare: true}
-		if proxy != "" {
-			cloneOptions.FetchOptions.ProxyOptions.Type = git.ProxyTypeAuto
-			cloneOptions.FetchOptions.ProxyOptions.Url = proxy
-		}
-		if user != "" {
-			auth := fmt.Sprintf("Authorization: Basic %s", base64.StdEncoding.EncodeToString([]byte(user+":"+password)))
-			cloneOptions.FetchOptions.Headers = []string{auth}
-		}
+	url, urlUser, urlPassword, err := splitUrlCredentials(url)
+	if err != nil {
+		return nil, err
+	}
+	if user == "" {
+		user, password = urlUser, urlPassword
+	}
+	fetchOptions := git.FetchOptions{}
+	if proxy != "" {
+		fetchOptions.ProxyOptions.Type = git.ProxyTypeAuto
+		fetchOptions.ProxyOptions.Url = proxy
+	}
+	if user != "" {
+		auth := fmt.Sprintf("Authorization: Basic %s", base64.StdEncoding.EncodeToString([]byte(user+":"+password)))
+		fetchOptions.Headers = []string{auth}
+	}
+	clone := func(dir string) (*GitRepo, error) {
+		cloneOptions := &git.CloneOptions{Bare: true, FetchOptions: fetchOptions}
 		clonedRepo, err := git.Clone(url, dir, cloneOptions)
 		if err != nil {
 			return nil, err
 		}
 		return l.newGitRepo(repoId, clonedRepo), nil
+	}
+	if l.cloneCacheDir == "" {
+		return withTempDirectory(clone)
+	}
+	return l.withCacheDirectory(repoId, url, clone, func(_ string, cachedRepo *git.Repository) error {
+		remote, err := cachedRepo.Remotes.Lookup("origin")
+		if err != nil {
+			return err
+		}
+		defer remote.Free()
+		options := fetchOptions
+		options.Prune = git.FetchPruneOn
+		options.DownloadTags = git.DownloadTagsAll
+		err = remote.Fetch(nil, &options, "")
+		if err != nil {
+			return err
+		}
+		return moveHeadToRemote(cachedRepo)
 	})
 }
 
 func (l *GitRepoCreator) CloneOverSSH(repoId, url, privateKey, passphrase string) (*GitRepo, errors.Error) {
-	return withTempDirectory(func(dir string) (*GitRepo, error) {
-		pk, err := base64.StdEncoding.DecodeString(privateKey)
-		if err != nil {
-			return nil, err
-		}
-		err = cloneOverSSH(url, dir, passphrase, pk)
+	pk, err := base64.StdEncoding.DecodeString(privateKey)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "failed to decode private key")
+	}
+	clone := func(dir string) (*GitRepo, error) {
+		err := cloneOverSSH(url, dir, passphrase, pk)
 		if err != nil {
 			return nil, err
 		}
 		return l.LocalRepo(dir, repoId)
+	}
+	if l.cloneCacheDir == "" {
+		return withTempDirectory(clone)
+	}
+	return l.withCacheDirectory(repoId, url, clone, func(dir string, _ *git.Repository) error {
+		return fetchOverSSH(dir, passphrase, pk)
 	})
 }
 
+// moveHeadToRemote moves the local default branch created by the clone to where the remote one is after fetching,
+// the fetch refspec only updates the remote branches
+func moveHeadToRemote(repo *git.Repository) error {
+	head, err := repo.Head()
+	if err != nil {
+		return err
+	}
+	defer head.Free()
+	remoteHead, err := repo.References.Lookup("refs/remotes/origin/" + head.Shorthand())
+	if err != nil {
+		return err
+	}
+	defer remoteHead.Free()
+	movedHead, err := head.SetTarget(remoteHead.Target(), "gitextractor: move the default branch to origin")
+	if err != nil {
+		return err
+	}
+	movedHead.Free()
+	return nil
+}
+
+// splitUrlCredentials removes the credentials from the url, they are sent by headers instead, so they are neither
+// written into the cached clone nor part of its cache key
+func splitUrlCredentials(url string) (string, string, string, errors.Error) {
+	u, err := neturl.Parse(url)
+	if err != nil {
+		return "", "", "", errors.BadInput.Wrap(err, "failed to parse the url")
+	}
+	if u.User == nil {
+		return url, "", "", nil
+	}
+	user := u.User.Username()
+	password, _ := u.User.Password()
+	u.User = nil
+	return u.String(), user, password, nil
+}
+
+// withCacheDirectory fetches the cached clone of the url if there is one, or clones the url into the cache.
+// Only a cache which could not be opened, e.g. left by an interrupted clone, is removed and cloned again,
+// errors of fetching like network or auth failures are returned as they are, the cache is still good
+func (l *GitRepoCreator) withCacheDirectory(
+	repoId, url string,
+	clone func(dir string) (*GitRepo, error),
+	fetch func(dir string, cachedRepo *git.Repository) error,
+) (*GitRepo, errors.Error) {
+	dir := filepath.Join(l.cloneCacheDir, getCloneCacheKey(url))
+	value, _ := cloneCacheLocks.LoadOrStore(dir, &sync.Mutex{})
+	lock := value.(*sync.Mutex)
+	lock.Lock()
+	if _, err := os.Stat(dir); err == nil {
+		cachedRepo, err := openCachedRepo(dir)
+		if err == nil {
+			err = fetch(dir, cachedRepo)
+			if err != nil {
+				cachedRepo.Free()
+				lock.Unlock()
+				return nil, errors.Default.Wrap(err, fmt.Sprintf("failed to fetch %s into the cached clone %s", url, dir))
+			}
+			l.logger.Info("fetched %s into the cached clone %s", url, dir)
+			repo := l.newGitRepo(repoId, cachedRepo)
+			repo.cleanup = lock.Unlock
+			return repo, nil
+		}
+		l.logger.Warn(err, "the cached clone %s is corrupted, clone it again", dir)
+		if err = os.RemoveAll(dir); err != nil {
+			lock.Unlock()
+			return nil, errors.Convert(err)
+		}
+	}
+	if err := os.MkdirAll(l.cloneCacheDir, 0700); err != nil {
+		lock.Unlock()
+		return nil, errors.Convert(err)
+	}
+	repo, err := clone(dir)
+	if err != nil {
+		_ = os.RemoveAll(dir)
+		lock.Unlock()
+		return nil, errors.Convert(err)
+	}
+	l.logger.Info("cloned %s into the cache %s", url, dir)
+	repo.cleanup = lock.Unlock
+	return repo, nil
+}
+
+// openCachedRepo opens the cached clone, which is corrupted if it could not be opened or lost its origin
+func openCachedRepo(dir string) (*git.Repository, error) {
+	cachedRepo, err := git.OpenRepository(dir)
+	if err != nil {
+		return nil, err
+	}
+	remote, err := cachedRepo.Remotes.Lookup("origin")
+	if err != nil {
+		cachedRepo.Free()
+		return nil, err
+	}
+	remote.Free()
+	return cachedRepo, nil
+}
+
+// getCloneCacheKey returns the directory name of the cached clone, the url must not contain credentials
+func getCloneCacheKey(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return hex.EncodeToString(sum[:])
+}
+
 func withTempDirectory(f func(tempDir string) (*GitRepo, error)) (*GitRepo, errors.Error) {
 	dir, err := os.MkdirTemp("", "gitextractor")
 	if err != nil {
@@ -41,6 +41,10 @@ type GitRepo struct {
 	id      string
 	repo    *git.Repository
 	cleanup func()
+	// collectedCommits are the commits in repo_commits, they are skipped when collecting commits
+	collectedCommits map[string]struct{}
+	// collectedRefs are the commits of refs collected last time, their ancestors are skipped when collecting commits
+	collectedRefs []string
 }
 
 // CollectAll The main parser subtask
@@ -102,26 +106,131 @@ func (r *GitRepo) CountBranches(ctx context.Context) (int, errors.Error) {
 	return count, errors.Convert(err)
 }
 
-// CountCommits count the number of commits in a git repo
+// CountCommits count the number of commits in a git repo which are not collected yet
 func (r *GitRepo) CountCommits(ctx context.Context) (int, errors.Error) {
-	odb, err := r.repo.Odb()
+	count := 0
+	err := r.forEachCommit(ctx, func(commit *git.Commit) error {
+		count++
+		return nil
+	})
+	return count, err
+}
+
+// LoadCollectedCommits loads the commits collected by previous runs, so that only new commits are collected
+func (r *GitRepo) LoadCollectedCommits(db dal.Dal) errors.Error {
+	var commitShas []string
+	err := db.Pluck("commit_sha", &commitShas, dal.From(&code.RepoCommit{}), dal.Where("repo_id = ?", r.id))
 	if err != nil {
-		return 0, errors.Convert(err)
+		return err
 	}
-	count := 0
-	err = odb.ForEach(func(id *git.Oid) error {
+	r.collectedCommits = make(map[string]struct{}, len(commitShas))
+	for _, sha := range commitShas {
+		r.collectedCommits[sha] = struct{}{}
+	}
+	if len(r.collectedCommits) == 0 {
+		return nil
+	}
+	// refs are collected after commits, so commits reachable from them must have been collected
+	r.collectedRefs = nil
+	err = db.Pluck("commit_sha", &r.collectedRefs, dal.From(&code.Ref{}), dal.Where("repo_id = ?", r.id))
+	if err != nil {
+		return err
+	}
+	// keep the commits collected by previous runs
+	r.store.SetIncrementalMode(true)
+	return nil
+}
+
+// forEachCommit walks all the commits in the odb if no commit was collected before, otherwise
+// it walks commits reachable from refs, skipping the ancestors of previously collected refs
+// and the commits in repo_commits
+func (r *GitRepo) forEachCommit(ctx context.Context, f func(commit *git.Commit) error) errors.Error {
+	if walksAllObjects(r.collectedCommits) {
+		odb, err := r.repo.Odb()
+		if err != nil {
+			return errors.Convert(err)
+		}
+		return errors.Convert(odb.ForEach(func(id *git.Oid) error {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			default:
+			}
+			commit, _ := r.repo.LookupCommit(id)
+			if commit == nil {
+				return nil
+			}
+			return f(commit)
+		}))
+	}
+	walk, err := r.repo.Walk()
+	if err != nil {
+		return errors.Convert(err)
+	}
+	defer walk.Free()
+	err = walk.PushGlob("*")
+	if err != nil {
+		return errors.Convert(err)
+	}
+	for _, sha := range getHiddenRefCommits(r.collectedRefs, r.collectedCommits) {
+		oid, err := git.NewOid(sha)
+		if err != nil {
+			continue
+		}
+		// the commit might be gone after a force push
+		if err = walk.Hide(oid); err != nil {
+			r.logger.Debug("unable to hide commit %s: %s", sha, err.Error())
+		}
+	}
+	id := new(git.Oid)
+	for {
 		select {
 		case <-ctx.Done():
-			return ctx.Err()
+			return errors.Convert(ctx.Err())
 		default:
 		}
-		commit, _ := r.repo.LookupCommit(id)
-		if commit != nil {
-			count++
+		err = walk.Next(id)
+		if git.IsErrorCode(err, git.ErrorCodeIterOver) {
+			return nil
 		}
-		return nil
-	})
-	return count, errors.Convert(err)
+		if err != nil {
+			return errors.Convert(err)
+		}
+		if isCommitCollected(r.collectedCommits, id.String()) {
+			continue
+		}
+		commit, err := r.repo.LookupCommit(id)
+		if err != nil {
+			return errors.Convert(err)
+		}
+		err = f(commit)
+		if err != nil {
+			return errors.Convert(err)
+		}
+	}
+}
+
+// walksAllObjects returns true if no commit was collected before, all the commits in the odb are walked then
+func walksAllObjects(collectedCommits map[string]struct{}) bool {
+	return len(collectedCommits) == 0
+}
+
+// getHiddenRefCommits returns the commits of refs collected last time whose ancestors are skipped by the walk,
+// a ref is hidden only if its commit is in repo_commits, or the commits of an unfinished collection would be missed
+func getHiddenRefCommits(collectedRefs []string, collectedCommits map[string]struct{}) []string {
+	hidden := make([]string, 0, len(collectedRefs))
+	for _, sha := range collectedRefs {
+		if _, ok := collectedCommits[sha]; ok {
+			hidden = append(hidden, sha)
+		}
+	}
+	return hidden
+}
+
+// isCommitCollected returns true if the commit is in repo_commits, it is skipped by the walk then
+func isCommitCollected(collectedCommits map[string]struct{}, sha string) bool {
+	_, ok := collectedCommits[sha]
+	return ok
 }
 
 // CollectTags Collect Tags data
@@ -217,20 +326,7 @@ func (r *GitRepo) CollectCommits(subtaskCtx core.SubTaskContext) errors.Error {
 	for _, component := range components {
 		componentMap[component.Name] = regexp.MustCompile(component.PathRegex)
 	}
-	odb, err := errors.Convert01(r.repo.Odb())
-	if err != nil {
-		return err
-	}
-	return errors.Convert(odb.ForEach(func(id *git.Oid) error {
-		select {
-		case <-subtaskCtx.GetContext().Done():
-			return subtaskCtx.GetContext().Err()
-		default:
-		}
-		commit, _ := r.repo.LookupCommit(id)
-		if commit == nil {
-			return nil
-		}
+	err = r.forEachCommit(subtaskCtx.GetContext(), func(commit *git.Commit) error {
 		commitSha := commit.Id().String()
 		r.logger.Debug("process commit: %s", commitSha)
 		c := &code.Commit{
@@ -280,7 +376,13 @@ func (r *GitRepo) CollectCommits(subtaskCtx core.SubTaskContext) errors.Error {
 		}
 		subtaskCtx.IncProgress(1)
 		return nil
-	}))
+	})
+	if err != nil {
+		return err
+	}
+	// refs and diff lines are saved from scratch
+	r.store.SetIncrementalMode(false)
+	return nil
 }
 
 func (r *GitRepo) storeParentCommits(commitSha string, commit *git.Commit) errors.Error {
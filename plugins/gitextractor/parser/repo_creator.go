@@ -32,12 +32,15 @@ const (
 type GitRepoCreator struct {
 	store  models.Store
 	logger core.Logger
+	// cloneCacheDir keeps the clones to be fetched next time, clones are made in temp directories if it is empty
+	cloneCacheDir string
 }
 
-func NewGitRepoCreator(store models.Store, logger core.Logger) *GitRepoCreator {
+func NewGitRepoCreator(store models.Store, logger core.Logger, cloneCacheDir string) *GitRepoCreator {
 	return &GitRepoCreator{
-		store:  store,
-		logger: logger,
+		store:         store,
+		logger:        logger,
+		cloneCacheDir: cloneCacheDir,
 	}
 }
 
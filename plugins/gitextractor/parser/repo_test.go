@@ -0,0 +1,50 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWalksAllObjects(t *testing.T) {
+	assert.True(t, walksAllObjects(nil))
+	assert.True(t, walksAllObjects(map[string]struct{}{}))
+	assert.False(t, walksAllObjects(map[string]struct{}{"a1": {}}))
+}
+
+func TestGetHiddenRefCommits(t *testing.T) {
+	collectedCommits := map[string]struct{}{
+		"a1": {},
+		"b2": {},
+	}
+	// c3 was not collected, e.g. the last collection was interrupted, so its ancestors must be walked again
+	assert.Equal(t, []string{"a1", "b2"}, getHiddenRefCommits([]string{"a1", "c3", "b2"}, collectedCommits))
+	assert.Empty(t, getHiddenRefCommits([]string{"c3"}, collectedCommits))
+	assert.Empty(t, getHiddenRefCommits(nil, collectedCommits))
+}
+
+func TestIsCommitCollected(t *testing.T) {
+	collectedCommits := map[string]struct{}{
+		"a1": {},
+	}
+	assert.True(t, isCommitCollected(collectedCommits, "a1"))
+	assert.False(t, isCommitCollected(collectedCommits, "c3"))
+	assert.False(t, isCommitCollected(nil, "a1"))
+}
@@ -172,6 +172,9 @@ func (c *CsvStore) CommitParents(pp []*code.CommitParent) errors.Error {
 	return nil
 }
 
+// SetIncrementalMode does nothing since csv files are always written from scratch
+func (c *CsvStore) SetIncrementalMode(incremental bool) {}
+
 func (c *CsvStore) Close() errors.Error {
 	if c.repoCommitWriter != nil {
 		c.repoCommitWriter.Close()
@@ -133,6 +133,10 @@ func (d *Database) CommitParents(pp []*code.CommitParent) errors.Error {
 	return nil
 }
 
+func (d *Database) SetIncrementalMode(incremental bool) {
+	d.driver.SetIncrementalMode(incremental)
+}
+
 func (d *Database) Close() errors.Error {
 	return d.driver.Close()
 }
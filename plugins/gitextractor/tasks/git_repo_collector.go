@@ -51,6 +51,9 @@ func (o GitExtractorOptions) Valid() errors.Error {
 
 func CollectGitCommits(subTaskCtx core.SubTaskContext) errors.Error {
 	repo := getGitRepo(subTaskCtx)
+	if err := repo.LoadCollectedCommits(subTaskCtx.GetDal()); err != nil {
+		return err
+	}
 	if count, err := repo.CountCommits(subTaskCtx.GetContext()); err != nil {
 		subTaskCtx.GetLogger().Error(err, "unable to get commit count")
 		subTaskCtx.SetProgress(0, -1)
@@ -68,6 +68,9 @@ func (plugin Github) GetTablesInfo() []core.Tabler {
 		&models.GithubAccountOrg{},
 		&models.GithubCommit{},
 		&models.GithubCommitStat{},
+		&models.GithubDeployment{},
+		&models.GithubDeploymentStatus{},
+		&models.GithubEnvironment{},
 		&models.GithubIssue{},
 		&models.GithubIssueComment{},
 		&models.GithubIssueEvent{},
@@ -126,6 +129,11 @@ func (plugin Github) SubTaskMetas() []core.SubTaskMeta {
 		tasks.CollectJobsMeta,
 		tasks.ExtractJobsMeta,
 		tasks.ConvertJobsMeta,
+		tasks.CollectDeploymentsMeta,
+		tasks.ExtractDeploymentsMeta,
+		tasks.CollectDeploymentStatusesMeta,
+		tasks.ExtractDeploymentStatusesMeta,
+		tasks.ConvertDeploymentsMeta,
 		tasks.EnrichPullRequestIssuesMeta,
 		tasks.ConvertRepoMeta,
 		tasks.ConvertIssuesMeta,
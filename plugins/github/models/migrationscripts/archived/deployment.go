@@ -0,0 +1,46 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archived
+
+import (
+	"github.com/apache/incubator-devlake/models/migrationscripts/archived"
+	"time"
+)
+
+type GithubDeployment struct {
+	ConnectionId    uint64 `gorm:"primaryKey"`
+	GithubId        int    `gorm:"primaryKey;autoIncrement:false"`
+	RepoId          int    `gorm:"index"`
+	CommitSha       string `gorm:"type:varchar(40)"`
+	Ref             string `gorm:"type:varchar(255)"`
+	Task            string `gorm:"type:varchar(255)"`
+	Environment     string `gorm:"type:varchar(255)"`
+	Description     string
+	CreatorId       int
+	CreatorLogin    string `gorm:"type:varchar(255)"`
+	State           string `gorm:"type:varchar(255);comment:State of the latest status, ex. success, failure, in_progress, etc."`
+	StartedAt       *time.Time
+	FinishedAt      *time.Time
+	GithubCreatedAt time.Time
+	GithubUpdatedAt time.Time `gorm:"index"`
+	archived.NoPKModel
+}
+
+func (GithubDeployment) TableName() string {
+	return "_tool_github_deployments"
+}
@@ -32,5 +32,7 @@ func All() []core.MigrationScript {
 		new(addHeadRepoIdFieldInGithubPr),
 		new(addEnableGraphqlForConnection),
 		new(addTransformationRule20221124),
+		new(addDeploymentsAndEnvironments20221218),
+		new(addDeploymentStatuses20221219),
 	}
 }
@@ -78,6 +78,11 @@ func CreateApiClient(taskCtx core.TaskContext, connection *models.GithubConnecti
 			// TODO: consider different token has different rate-limit
 			return rateLimit * len(tokens), 1 * time.Hour, nil
 		},
+		// remaining requests are reported per token, presume all tokens are consumed evenly since they are rotated
+		DynamicRemaining: func(res *http.Response) (int, time.Time, bool) {
+			remaining, resetAt, ok := helper.ParseRateLimitHeaders(res)
+			return remaining * len(tokens), resetAt, ok
+		},
 	}
 	asyncApiClient, err := helper.CreateAsyncApiClient(
 		taskCtx,
@@ -0,0 +1,105 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package tasks
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/apache/incubator-devlake/errors"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/apache/incubator-devlake/plugins/core"
+	"github.com/apache/incubator-devlake/plugins/helper"
+)
+
+const RAW_DEPLOYMENT_TABLE = "github_api_deployments"
+
+var CollectDeploymentsMeta = core.SubTaskMeta{
+	Name:             "collectDeployments",
+	EntryPoint:       CollectDeployments,
+	EnabledByDefault: true,
+	Description:      "Collect Deployments data from Github api",
+	DomainTypes:      []string{core.DOMAIN_TYPE_CICD},
+}
+
+func CollectDeployments(taskCtx core.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*GithubTaskData)
+	collectorWithState, err := helper.NewApiCollectorWithState(helper.RawDataSubTaskArgs{
+		Ctx: taskCtx,
+		Params: GithubApiParams{
+			ConnectionId: data.Options.ConnectionId,
+			Owner:        data.Options.Owner,
+			Repo:         data.Options.Repo,
+		},
+		Table: RAW_DEPLOYMENT_TABLE,
+	}, data.Since)
+	if err != nil {
+		return err
+	}
+
+	err = collectorWithState.InitCollector(helper.ApiCollectorArgs{
+		ApiClient:   data.ApiClient,
+		PageSize:    100,
+		UrlTemplate: "repos/{{ .Params.Owner }}/{{ .Params.Repo }}/deployments",
+		// github doesn't filter deployments by time, they are returned newest first though, so paging
+		// stops at the first page reaching deployments created before the window
+		TimeWindow: &helper.IncrementalTimeWindow{
+			Overlap: 5 * time.Minute,
+		},
+		Query: func(reqData *helper.RequestData) (url.Values, errors.Error) {
+			query := url.Values{}
+			query.Set("page", fmt.Sprintf("%v", reqData.Pager.Page))
+			query.Set("per_page", fmt.Sprintf("%v", reqData.Pager.Size))
+			return query, nil
+		},
+		GetNextPageCustomData: getNextDeploymentsPage,
+		ResponseParser: func(res *http.Response) ([]json.RawMessage, errors.Error) {
+			var items []json.RawMessage
+			err := helper.UnmarshalResponse(res, &items)
+			if err != nil {
+				return nil, err
+			}
+			return items, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return collectorWithState.Execute()
+}
+
+// getNextDeploymentsPage returns nil once the previous page was the last one or reached deployments
+// created before the time window
+func getNextDeploymentsPage(prevReqData *helper.RequestData, prevPageResponse *http.Response) (interface{}, errors.Error) {
+	var deployments []struct {
+		CreatedAt time.Time `json:"created_at"`
+	}
+	err := helper.UnmarshalResponse(prevPageResponse, &deployments)
+	if err != nil {
+		return nil, err
+	}
+	if len(deployments) < prevReqData.Pager.Size {
+		return nil, nil
+	}
+	if prevReqData.Since != nil && deployments[len(deployments)-1].CreatedAt.Before(*prevReqData.Since) {
+		return nil, nil
+	}
+	return prevReqData.Pager.Page + 1, nil
+}
@@ -0,0 +1,213 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package tasks
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/models/domainlayer"
+	"github.com/apache/incubator-devlake/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/plugins/core"
+	"github.com/apache/incubator-devlake/plugins/core/dal"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+	"github.com/apache/incubator-devlake/plugins/helper"
+)
+
+var ConvertDeploymentsMeta = core.SubTaskMeta{
+	Name:             "convertDeployments",
+	EntryPoint:       ConvertDeployments,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table github_deployments into domain layer table cicd_pipelines and cicd_tasks",
+	DomainTypes:      []string{core.DOMAIN_TYPE_CICD},
+}
+
+func ConvertDeployments(taskCtx core.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*GithubTaskData)
+	repoId := data.Repo.GithubId
+	productionPattern := data.Options.ProductionPattern
+	regexEnricher := helper.NewRegexEnricher()
+	err := regexEnricher.AddRegexp(productionPattern)
+	if err != nil {
+		return err
+	}
+
+	// statuses are only available when deployments were collected by the rest api, the graphql collector
+	// fills the state of deployments directly
+	statuses := make([]models.GithubDeploymentStatus, 0)
+	err = db.All(&statuses,
+		dal.Select("s.*"),
+		dal.From("_tool_github_deployment_statuses s"),
+		dal.Join("left join _tool_github_deployments d on d.github_id = s.deployment_id and d.connection_id = s.connection_id"),
+		dal.Where("d.repo_id = ? and d.connection_id = ?", repoId, data.Options.ConnectionId),
+	)
+	if err != nil {
+		return err
+	}
+	statusesByDeployment := make(map[int][]models.GithubDeploymentStatus)
+	for _, status := range statuses {
+		statusesByDeployment[status.DeploymentId] = append(statusesByDeployment[status.DeploymentId], status)
+	}
+
+	cursor, err := db.Cursor(
+		dal.From(&models.GithubDeployment{}),
+		dal.Where("repo_id = ? and connection_id = ?", repoId, data.Options.ConnectionId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+	deploymentIdGen := didgen.NewDomainIdGenerator(&models.GithubDeployment{})
+	repoIdGen := didgen.NewDomainIdGenerator(&models.GithubRepo{})
+	converter, err := helper.NewDataConverter(helper.DataConverterArgs{
+		RawDataSubTaskArgs: helper.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: GithubApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				Owner:        data.Options.Owner,
+				Repo:         data.Options.Repo,
+			},
+			Table: RAW_DEPLOYMENT_TABLE,
+		},
+		InputRowType: reflect.TypeOf(models.GithubDeployment{}),
+		Input:        cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			deployment := inputRow.(*models.GithubDeployment)
+			if deploymentStatuses, ok := statusesByDeployment[deployment.GithubId]; ok {
+				ApplyDeploymentStatuses(deployment, deploymentStatuses)
+			}
+			deploymentId := deploymentIdGen.Generate(data.Options.ConnectionId, deployment.GithubId)
+			name := deployment.Task
+			if deployment.Environment != "" {
+				name = deployment.Task + ":" + deployment.Environment
+			}
+			environment := getDeploymentEnvironment(deployment.Environment)
+			if productionPattern != "" && regexEnricher.GetEnrichResult(productionPattern, deployment.Environment, devops.PRODUCTION) != "" {
+				environment = devops.PRODUCTION
+			}
+			startedDate := deployment.GithubCreatedAt
+			if deployment.StartedAt != nil {
+				startedDate = *deployment.StartedAt
+			}
+
+			domainPipeline := &devops.CICDPipeline{
+				DomainEntity: domainlayer.DomainEntity{Id: deploymentId},
+				Name:         name,
+				Type:         devops.DEPLOYMENT,
+				Environment:  environment,
+				CreatedDate:  deployment.GithubCreatedAt,
+				FinishedDate: deployment.FinishedAt,
+				CicdScopeId:  repoIdGen.Generate(data.Options.ConnectionId, deployment.RepoId),
+			}
+			domainTask := &devops.CICDTask{
+				DomainEntity: domainlayer.DomainEntity{Id: deploymentId},
+				Name:         name,
+				PipelineId:   deploymentId,
+				Type:         devops.DEPLOYMENT,
+				Environment:  environment,
+				StartedDate:  startedDate,
+				FinishedDate: deployment.FinishedAt,
+				CicdScopeId:  domainPipeline.CicdScopeId,
+			}
+			switch deployment.State {
+			case "success":
+				domainTask.Result = devops.SUCCESS
+			case "failure", "error":
+				domainTask.Result = devops.FAILURE
+			case "inactive":
+				domainTask.Result = devops.ABORT
+			}
+			if domainTask.Result != "" {
+				domainTask.Status = devops.DONE
+				if deployment.FinishedAt != nil {
+					domainTask.DurationSec = uint64(deployment.FinishedAt.Sub(startedDate).Seconds())
+				}
+			} else {
+				domainTask.Status = devops.IN_PROGRESS
+			}
+			domainPipeline.Result = domainTask.Result
+			domainPipeline.Status = domainTask.Status
+			if deployment.FinishedAt != nil {
+				domainPipeline.DurationSec = uint64(deployment.FinishedAt.Sub(deployment.GithubCreatedAt).Seconds())
+			}
+
+			domainPipelineCommit := &devops.CiCDPipelineCommit{
+				PipelineId: deploymentId,
+				CommitSha:  deployment.CommitSha,
+				Branch:     deployment.Ref,
+				RepoId:     domainPipeline.CicdScopeId,
+			}
+			return []interface{}{
+				domainPipeline,
+				domainPipelineCommit,
+				domainTask,
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
+
+// ApplyDeploymentStatuses fills the state, start and finish time of the deployment by its statuses. A deployment
+// starts at its first `in_progress` status and finishes at its last `success`, `failure` or `error` status, a later
+// `inactive` status only means it was replaced by a newer deployment and is ignored. A deployment turning `inactive`
+// before finishing was abandoned, it keeps the `inactive` state and finishes then.
+func ApplyDeploymentStatuses(deployment *models.GithubDeployment, statuses []models.GithubDeploymentStatus) {
+	sort.SliceStable(statuses, func(i, j int) bool {
+		return statuses[i].GithubCreatedAt.Before(statuses[j].GithubCreatedAt)
+	})
+	for i := range statuses {
+		status := &statuses[i]
+		state := strings.ToLower(status.State)
+		switch state {
+		case "in_progress":
+			if deployment.StartedAt == nil {
+				deployment.StartedAt = &status.GithubCreatedAt
+			}
+			deployment.FinishedAt = nil
+		case "queued", "pending":
+			deployment.FinishedAt = nil
+		case "success", "failure", "error":
+			deployment.FinishedAt = &status.GithubCreatedAt
+		case "inactive":
+			if deployment.FinishedAt != nil {
+				continue
+			}
+			deployment.FinishedAt = &status.GithubCreatedAt
+		}
+		deployment.State = state
+	}
+}
+
+func getDeploymentEnvironment(name string) string {
+	switch strings.ToLower(name) {
+	case "production", "prod":
+		return devops.PRODUCTION
+	case "staging", "stage":
+		return devops.STAGING
+	case "testing", "test", "qa":
+		return devops.TESTING
+	}
+	return name
+}
@@ -0,0 +1,114 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package tasks
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/apache/incubator-devlake/errors"
+	"net/http"
+	"net/url"
+	"reflect"
+
+	"github.com/apache/incubator-devlake/plugins/core"
+	"github.com/apache/incubator-devlake/plugins/core/dal"
+	"github.com/apache/incubator-devlake/plugins/helper"
+)
+
+const RAW_DEPLOYMENT_STATUS_TABLE = "github_api_deployment_statuses"
+
+var CollectDeploymentStatusesMeta = core.SubTaskMeta{
+	Name:             "collectDeploymentStatuses",
+	EntryPoint:       CollectDeploymentStatuses,
+	EnabledByDefault: true,
+	Description:      "Collect statuses of each deployment from Github api",
+	DomainTypes:      []string{core.DOMAIN_TYPE_CICD},
+}
+
+// terminalDeploymentStates are the states a deployment ends with, see ApplyDeploymentStatuses
+var terminalDeploymentStates = []string{"success", "failure", "error", "inactive"}
+
+type SimpleDeployment struct {
+	GithubId int
+}
+
+func CollectDeploymentStatuses(taskCtx core.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*GithubTaskData)
+	collectorWithState, err := helper.NewApiCollectorWithState(helper.RawDataSubTaskArgs{
+		Ctx: taskCtx,
+		Params: GithubApiParams{
+			ConnectionId: data.Options.ConnectionId,
+			Owner:        data.Options.Owner,
+			Repo:         data.Options.Repo,
+		},
+		Table: RAW_DEPLOYMENT_STATUS_TABLE,
+	}, data.Since)
+	if err != nil {
+		return err
+	}
+	incremental := collectorWithState.CanIncrementCollect()
+
+	clauses := []dal.Clause{
+		dal.Select("d.github_id"),
+		dal.From("_tool_github_deployments d"),
+		dal.Where("d.repo_id = ? and d.connection_id = ?", data.Repo.GithubId, data.Options.ConnectionId),
+	}
+	if incremental {
+		// statuses of a finished deployment never change, only new deployments and the ones still
+		// running are requested again
+		clauses = append(clauses, dal.Where(
+			`NOT EXISTS (SELECT 1 FROM _tool_github_deployment_statuses s
+				WHERE s.connection_id = d.connection_id AND s.deployment_id = d.github_id AND s.state IN ?)`,
+			terminalDeploymentStates,
+		))
+	}
+	cursor, err := db.Cursor(clauses...)
+	if err != nil {
+		return err
+	}
+	iterator, err := helper.NewDalCursorIterator(db, cursor, reflect.TypeOf(SimpleDeployment{}))
+	if err != nil {
+		return err
+	}
+	err = collectorWithState.InitCollector(helper.ApiCollectorArgs{
+		ApiClient:   data.ApiClient,
+		PageSize:    100,
+		Incremental: incremental,
+		Input:       iterator,
+		UrlTemplate: "repos/{{ .Params.Owner }}/{{ .Params.Repo }}/deployments/{{ .Input.GithubId }}/statuses",
+		Query: func(reqData *helper.RequestData) (url.Values, errors.Error) {
+			query := url.Values{}
+			query.Set("page", fmt.Sprintf("%v", reqData.Pager.Page))
+			query.Set("per_page", fmt.Sprintf("%v", reqData.Pager.Size))
+			return query, nil
+		},
+		ResponseParser: func(res *http.Response) ([]json.RawMessage, errors.Error) {
+			var items []json.RawMessage
+			err := helper.UnmarshalResponse(res, &items)
+			if err != nil {
+				return nil, err
+			}
+			return items, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return collectorWithState.Execute()
+}
@@ -0,0 +1,94 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package tasks
+
+import (
+	"encoding/json"
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/plugins/core"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+	"github.com/apache/incubator-devlake/plugins/helper"
+)
+
+var ExtractDeploymentStatusesMeta = core.SubTaskMeta{
+	Name:             "extractDeploymentStatuses",
+	EntryPoint:       ExtractDeploymentStatuses,
+	EnabledByDefault: true,
+	Description:      "Extract raw deployment status data into tool layer table github_deployment_statuses",
+	DomainTypes:      []string{core.DOMAIN_TYPE_CICD},
+}
+
+type DeploymentStatusResponse struct {
+	Id              int                    `json:"id"`
+	State           string                 `json:"state"`
+	Environment     string                 `json:"environment"`
+	Description     string                 `json:"description"`
+	Creator         *GithubAccountResponse `json:"creator"`
+	GithubCreatedAt helper.Iso8601Time     `json:"created_at"`
+	GithubUpdatedAt helper.Iso8601Time     `json:"updated_at"`
+}
+
+func ExtractDeploymentStatuses(taskCtx core.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*GithubTaskData)
+
+	extractor, err := helper.NewApiExtractor(helper.ApiExtractorArgs{
+		RawDataSubTaskArgs: helper.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: GithubApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				Owner:        data.Options.Owner,
+				Repo:         data.Options.Repo,
+			},
+			Table: RAW_DEPLOYMENT_STATUS_TABLE,
+		},
+		Extract: func(row *helper.RawData) ([]interface{}, errors.Error) {
+			input := &SimpleDeployment{}
+			err := errors.Convert(json.Unmarshal(row.Input, input))
+			if err != nil {
+				return nil, err
+			}
+			apiStatus := &DeploymentStatusResponse{}
+			err = errors.Convert(json.Unmarshal(row.Data, apiStatus))
+			if err != nil {
+				return nil, err
+			}
+			if apiStatus.Id == 0 {
+				return nil, nil
+			}
+			githubStatus := &models.GithubDeploymentStatus{
+				ConnectionId:    data.Options.ConnectionId,
+				GithubId:        apiStatus.Id,
+				DeploymentId:    input.GithubId,
+				State:           apiStatus.State,
+				Environment:     apiStatus.Environment,
+				Description:     apiStatus.Description,
+				GithubCreatedAt: apiStatus.GithubCreatedAt.ToTime(),
+				GithubUpdatedAt: apiStatus.GithubUpdatedAt.ToTime(),
+			}
+			if apiStatus.Creator != nil {
+				githubStatus.CreatorId = apiStatus.Creator.Id
+				githubStatus.CreatorLogin = apiStatus.Creator.Login
+			}
+			return []interface{}{githubStatus}, nil
+		},
+	})
+
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
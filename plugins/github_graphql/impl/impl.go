@@ -65,25 +65,25 @@ func (plugin GithubGraphql) SubTaskMetas() []core.SubTaskMeta {
 		tasks.CollectRepoMeta,
 
 		// collect millstones
-		githubTasks.CollectMilestonesMeta,
-		githubTasks.ExtractMilestonesMeta,
+		tasks.CollectMilestoneMeta,
 
 		// collect issue & pr, deps on millstone
 		tasks.CollectIssueMeta,
 		tasks.CollectPrMeta,
 
+		// collect reviews, comments, events and review threads, deps on issue & pr
+		tasks.CollectPrReviewMeta,
+		tasks.CollectIssueTimelineMeta,
+		tasks.CollectPrTimelineMeta,
+
 		// collect workflow run & job
 		githubTasks.CollectRunsMeta,
 		githubTasks.ExtractRunsMeta,
 		tasks.CollectCheckRunMeta,
 
-		// collect others
-		githubTasks.CollectApiCommentsMeta,
-		githubTasks.ExtractApiCommentsMeta,
-		githubTasks.CollectApiEventsMeta,
-		githubTasks.ExtractApiEventsMeta,
-		githubTasks.CollectApiPrReviewCommentsMeta,
-		githubTasks.ExtractApiPrReviewCommentsMeta,
+		// collect deployments & environments
+		tasks.CollectDeploymentMeta,
+		tasks.CollectEnvironmentMeta,
 
 		// collect account, deps on all before
 		tasks.CollectAccountMeta,
@@ -91,6 +91,7 @@ func (plugin GithubGraphql) SubTaskMetas() []core.SubTaskMeta {
 		// convert to domain layer
 		githubTasks.ConvertRunsMeta,
 		githubTasks.ConvertJobsMeta,
+		githubTasks.ConvertDeploymentsMeta,
 		githubTasks.EnrichPullRequestIssuesMeta,
 		githubTasks.ConvertRepoMeta,
 		githubTasks.ConvertIssuesMeta,
@@ -137,6 +138,14 @@ func (plugin GithubGraphql) PrepareTaskData(taskCtx core.TaskContext, options ma
 		return nil, err
 	}
 
+	var since time.Time
+	if op.Since != "" {
+		since, err = errors.Convert01(time.Parse("2006-01-02T15:04:05Z", op.Since))
+		if err != nil {
+			return nil, errors.BadInput.Wrap(err, "invalid value for `since`")
+		}
+	}
+
 	tokens := strings.Split(connection.Token, ",")
 	src := oauth2.StaticTokenSource(
 		&oauth2.Token{AccessToken: tokens[0]},
@@ -171,11 +180,15 @@ func (plugin GithubGraphql) PrepareTaskData(taskCtx core.TaskContext, options ma
 		return nil, errors.Default.Wrap(err, "unable to get github API client instance")
 	}
 
-	return &githubTasks.GithubTaskData{
+	taskData := &githubTasks.GithubTaskData{
 		Options:       &op,
 		ApiClient:     apiClient,
 		GraphqlClient: graphqlClient,
-	}, nil
+	}
+	if !since.IsZero() {
+		taskData.Since = &since
+	}
+	return taskData, nil
 }
 
 func EnrichOptions(taskCtx core.TaskContext,
@@ -0,0 +1,192 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/plugins/core"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+	githubTasks "github.com/apache/incubator-devlake/plugins/github/tasks"
+	"github.com/apache/incubator-devlake/plugins/helper"
+	"github.com/merico-dev/graphql"
+)
+
+const RAW_DEPLOYMENTS_TABLE = "github_graphql_deployments"
+
+// deployments could only be ordered by creation, the ones created a while before the time window might still be
+// getting new statuses
+const deploymentStatusGracePeriod = 24 * time.Hour
+
+type GraphqlQueryDeploymentWrapper struct {
+	RateLimit struct {
+		Cost int
+	}
+	Repository struct {
+		DeploymentList struct {
+			TotalCount  graphql.Int
+			Deployments []GraphqlQueryDeployment `graphql:"nodes"`
+			PageInfo    *helper.GraphqlQueryPageInfo
+		} `graphql:"deployments(first: $pageSize, after: $skipCursor, orderBy: {field: CREATED_AT, direction: DESC})"`
+	} `graphql:"repository(owner: $owner, name: $name)"`
+}
+
+type GraphqlQueryDeployment struct {
+	DatabaseId  int
+	CommitOid   string
+	Task        string
+	Environment string
+	Description string
+	State       string
+	Creator     *GraphqlInlineAccountQuery
+	Ref         *struct {
+		Name string
+	}
+	LatestStatus *GraphqlQueryDeploymentStatus
+	Statuses     struct {
+		Nodes []GraphqlQueryDeploymentStatus
+	} `graphql:"statuses(first: 100)"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type GraphqlQueryDeploymentStatus struct {
+	State     string
+	CreatedAt time.Time
+}
+
+var CollectDeploymentMeta = core.SubTaskMeta{
+	Name:             "CollectDeployment",
+	EntryPoint:       CollectDeployment,
+	EnabledByDefault: true,
+	Description:      "Collect Deployment data from GithubGraphql api",
+	DomainTypes:      []string{core.DOMAIN_TYPE_CICD},
+}
+
+var _ core.SubTaskEntryPoint = CollectDeployment
+
+func CollectDeployment(taskCtx core.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*githubTasks.GithubTaskData)
+
+	collectorWithState, err := helper.NewApiCollectorWithState(helper.RawDataSubTaskArgs{
+		Ctx: taskCtx,
+		Params: githubTasks.GithubApiParams{
+			ConnectionId: data.Options.ConnectionId,
+			Owner:        data.Options.Owner,
+			Repo:         data.Options.Repo,
+		},
+		Table: RAW_DEPLOYMENTS_TABLE,
+	}, data.Since)
+	if err != nil {
+		return err
+	}
+	since := collectorWithState.TimeWindowSince(graphqlTimeWindow)
+	var createdSince *time.Time
+	if since != nil {
+		t := since.Add(-deploymentStatusGracePeriod)
+		createdSince = &t
+	}
+
+	err = collectorWithState.InitGraphQLCollector(helper.GraphqlCollectorArgs{
+		GraphqlClient: data.GraphqlClient,
+		PageSize:      50,
+		TimeWindow:    graphqlTimeWindow,
+		BuildQuery: func(reqData *helper.GraphqlRequestData) (interface{}, map[string]interface{}, error) {
+			query := &GraphqlQueryDeploymentWrapper{}
+			variables := map[string]interface{}{
+				"pageSize":   graphql.Int(reqData.Pager.Size),
+				"skipCursor": (*graphql.String)(reqData.Pager.SkipCursor),
+				"owner":      graphql.String(data.Options.Owner),
+				"name":       graphql.String(data.Options.Repo),
+			}
+			return query, variables, nil
+		},
+		GetPageInfo: func(iQuery interface{}, args *helper.GraphqlCollectorArgs) (*helper.GraphqlQueryPageInfo, error) {
+			query := iQuery.(*GraphqlQueryDeploymentWrapper)
+			deployments := query.Repository.DeploymentList.Deployments
+			if len(deployments) > 0 && isOutdated(deployments[len(deployments)-1].CreatedAt, createdSince) {
+				return &helper.GraphqlQueryPageInfo{}, nil
+			}
+			return query.Repository.DeploymentList.PageInfo, nil
+		},
+		ResponseParser: func(iQuery interface{}, variables map[string]interface{}) ([]interface{}, error) {
+			query := iQuery.(*GraphqlQueryDeploymentWrapper)
+			deployments := query.Repository.DeploymentList.Deployments
+
+			results := make([]interface{}, 0, len(deployments))
+			for _, deployment := range deployments {
+				if isOutdated(deployment.UpdatedAt, since) {
+					continue
+				}
+				results = append(results, convertGithubDeployment(deployment, data.Options.ConnectionId, data.Repo.GithubId))
+				if deployment.Creator != nil {
+					githubUser, err := convertGraphqlPreAccount(*deployment.Creator, data.Repo.GithubId, data.Options.ConnectionId)
+					if err != nil {
+						return nil, err
+					}
+					results = append(results, githubUser)
+				}
+			}
+			return results, nil
+		},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return collectorWithState.Execute()
+}
+
+func convertGithubDeployment(deployment GraphqlQueryDeployment, connectionId uint64, repositoryId int) *models.GithubDeployment {
+	githubDeployment := &models.GithubDeployment{
+		ConnectionId:    connectionId,
+		GithubId:        deployment.DatabaseId,
+		RepoId:          repositoryId,
+		CommitSha:       deployment.CommitOid,
+		Task:            deployment.Task,
+		Environment:     deployment.Environment,
+		Description:     deployment.Description,
+		GithubCreatedAt: deployment.CreatedAt,
+		GithubUpdatedAt: deployment.UpdatedAt,
+	}
+	if deployment.Ref != nil {
+		githubDeployment.Ref = deployment.Ref.Name
+	}
+	if deployment.Creator != nil {
+		githubDeployment.CreatorId = deployment.Creator.Id
+		githubDeployment.CreatorLogin = deployment.Creator.Login
+	}
+	statuses := make([]models.GithubDeploymentStatus, 0, len(deployment.Statuses.Nodes))
+	for _, status := range deployment.Statuses.Nodes {
+		statuses = append(statuses, models.GithubDeploymentStatus{
+			State:           status.State,
+			GithubCreatedAt: status.CreatedAt,
+		})
+	}
+	githubTasks.ApplyDeploymentStatuses(githubDeployment, statuses)
+	// keep the same states as statuses of the rest api, like `success` and `in_progress`
+	if deployment.LatestStatus != nil {
+		githubDeployment.State = strings.ToLower(deployment.LatestStatus.State)
+	} else if len(statuses) == 0 {
+		githubDeployment.State = strings.ToLower(deployment.State)
+	}
+	return githubDeployment
+}
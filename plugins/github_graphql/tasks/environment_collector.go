@@ -0,0 +1,109 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/plugins/core"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+	githubTasks "github.com/apache/incubator-devlake/plugins/github/tasks"
+	"github.com/apache/incubator-devlake/plugins/helper"
+	"github.com/merico-dev/graphql"
+)
+
+const RAW_ENVIRONMENTS_TABLE = "github_graphql_environments"
+
+type GraphqlQueryEnvironmentWrapper struct {
+	RateLimit struct {
+		Cost int
+	}
+	Repository struct {
+		EnvironmentList struct {
+			TotalCount   graphql.Int
+			Environments []struct {
+				DatabaseId int
+				Name       string
+			} `graphql:"nodes"`
+			PageInfo *helper.GraphqlQueryPageInfo
+		} `graphql:"environments(first: $pageSize, after: $skipCursor)"`
+	} `graphql:"repository(owner: $owner, name: $name)"`
+}
+
+var CollectEnvironmentMeta = core.SubTaskMeta{
+	Name:             "CollectEnvironment",
+	EntryPoint:       CollectEnvironment,
+	EnabledByDefault: true,
+	Description:      "Collect Environment data from GithubGraphql api",
+	DomainTypes:      []string{core.DOMAIN_TYPE_CICD},
+}
+
+var _ core.SubTaskEntryPoint = CollectEnvironment
+
+func CollectEnvironment(taskCtx core.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*githubTasks.GithubTaskData)
+
+	// environments are few and don't have update time, so they are always fully collected
+	collector, err := helper.NewGraphqlCollector(helper.GraphqlCollectorArgs{
+		RawDataSubTaskArgs: helper.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: githubTasks.GithubApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				Owner:        data.Options.Owner,
+				Repo:         data.Options.Repo,
+			},
+			Table: RAW_ENVIRONMENTS_TABLE,
+		},
+		GraphqlClient: data.GraphqlClient,
+		PageSize:      100,
+		BuildQuery: func(reqData *helper.GraphqlRequestData) (interface{}, map[string]interface{}, error) {
+			query := &GraphqlQueryEnvironmentWrapper{}
+			variables := map[string]interface{}{
+				"pageSize":   graphql.Int(reqData.Pager.Size),
+				"skipCursor": (*graphql.String)(reqData.Pager.SkipCursor),
+				"owner":      graphql.String(data.Options.Owner),
+				"name":       graphql.String(data.Options.Repo),
+			}
+			return query, variables, nil
+		},
+		GetPageInfo: func(iQuery interface{}, args *helper.GraphqlCollectorArgs) (*helper.GraphqlQueryPageInfo, error) {
+			query := iQuery.(*GraphqlQueryEnvironmentWrapper)
+			return query.Repository.EnvironmentList.PageInfo, nil
+		},
+		ResponseParser: func(iQuery interface{}, variables map[string]interface{}) ([]interface{}, error) {
+			query := iQuery.(*GraphqlQueryEnvironmentWrapper)
+			environments := query.Repository.EnvironmentList.Environments
+
+			results := make([]interface{}, 0, len(environments))
+			for _, environment := range environments {
+				results = append(results, &models.GithubEnvironment{
+					ConnectionId: data.Options.ConnectionId,
+					GithubId:     environment.DatabaseId,
+					RepoId:       data.Repo.GithubId,
+					Name:         environment.Name,
+				})
+			}
+			return results, nil
+		},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return collector.Execute()
+}
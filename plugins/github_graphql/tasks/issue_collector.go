@@ -41,7 +41,7 @@ type GraphqlQueryIssueWrapper struct {
 			TotalCount graphql.Int
 			Issues     []GraphqlQueryIssue `graphql:"nodes"`
 			PageInfo   *helper.GraphqlQueryPageInfo
-		} `graphql:"issues(first: $pageSize, after: $skipCursor)"`
+		} `graphql:"issues(first: $pageSize, after: $skipCursor, orderBy: {field: UPDATED_AT, direction: DESC})"`
 	} `graphql:"repository(owner: $owner, name: $name)"`
 }
 
@@ -96,18 +96,25 @@ func CollectIssue(taskCtx core.SubTaskContext) errors.Error {
 		return nil
 	}
 
-	collector, err := helper.NewGraphqlCollector(helper.GraphqlCollectorArgs{
-		RawDataSubTaskArgs: helper.RawDataSubTaskArgs{
-			Ctx: taskCtx,
-			Params: githubTasks.GithubApiParams{
-				ConnectionId: data.Options.ConnectionId,
-				Owner:        data.Options.Owner,
-				Repo:         data.Options.Repo,
-			},
-			Table: RAW_ISSUES_TABLE,
+	collectorWithState, err := helper.NewApiCollectorWithState(helper.RawDataSubTaskArgs{
+		Ctx: taskCtx,
+		Params: githubTasks.GithubApiParams{
+			ConnectionId: data.Options.ConnectionId,
+			Owner:        data.Options.Owner,
+			Repo:         data.Options.Repo,
 		},
+		Table: RAW_ISSUES_TABLE,
+	}, data.Since)
+	if err != nil {
+		return err
+	}
+	// issues are listed from the latest updated one, so we can stop once they were updated before the time window
+	since := collectorWithState.TimeWindowSince(graphqlTimeWindow)
+
+	err = collectorWithState.InitGraphQLCollector(helper.GraphqlCollectorArgs{
 		GraphqlClient: data.GraphqlClient,
 		PageSize:      100,
+		TimeWindow:    graphqlTimeWindow,
 		BuildQuery: func(reqData *helper.GraphqlRequestData) (interface{}, map[string]interface{}, error) {
 			query := &GraphqlQueryIssueWrapper{}
 			variables := map[string]interface{}{
@@ -120,6 +127,10 @@ func CollectIssue(taskCtx core.SubTaskContext) errors.Error {
 		},
 		GetPageInfo: func(iQuery interface{}, args *helper.GraphqlCollectorArgs) (*helper.GraphqlQueryPageInfo, error) {
 			query := iQuery.(*GraphqlQueryIssueWrapper)
+			issues := query.Repository.IssueList.Issues
+			if len(issues) > 0 && isOutdated(issues[len(issues)-1].UpdatedAt, since) {
+				return &helper.GraphqlQueryPageInfo{}, nil
+			}
 			return query.Repository.IssueList.PageInfo, nil
 		},
 		ResponseParser: func(iQuery interface{}, variables map[string]interface{}) ([]interface{}, error) {
@@ -128,6 +139,9 @@ func CollectIssue(taskCtx core.SubTaskContext) errors.Error {
 
 			results := make([]interface{}, 0, 1)
 			for _, issue := range issues {
+				if isOutdated(issue.UpdatedAt, since) {
+					break
+				}
 				githubIssue, err := convertGithubIssue(milestoneMap, issue, data.Options.ConnectionId, data.Repo.GithubId)
 				if err != nil {
 					return nil, err
@@ -161,7 +175,7 @@ func CollectIssue(taskCtx core.SubTaskContext) errors.Error {
 		return err
 	}
 
-	return collector.Execute()
+	return collectorWithState.Execute()
 }
 
 // create a milestone map for numberId to databaseId
@@ -0,0 +1,239 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/plugins/core"
+	"github.com/apache/incubator-devlake/plugins/core/dal"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+	githubTasks "github.com/apache/incubator-devlake/plugins/github/tasks"
+	"github.com/apache/incubator-devlake/plugins/helper"
+	"github.com/merico-dev/graphql"
+)
+
+const RAW_ISSUE_TIMELINE_TABLE = "github_graphql_issue_timeline"
+
+type GraphqlQueryIssueTimelineWrapper struct {
+	RateLimit struct {
+		Cost int
+	}
+	Repository struct {
+		Issue struct {
+			DatabaseId    int
+			TimelineItems struct {
+				PageInfo *helper.GraphqlQueryPageInfo
+				Items    []GraphqlQueryIssueTimelineItem `graphql:"nodes"`
+			} `graphql:"timelineItems(first: $pageSize, after: $skipCursor, itemTypes: [ISSUE_COMMENT, CLOSED_EVENT, REOPENED_EVENT, ASSIGNED_EVENT, UNASSIGNED_EVENT, LABELED_EVENT, UNLABELED_EVENT, MILESTONED_EVENT, DEMILESTONED_EVENT, RENAMED_TITLE_EVENT])"`
+		} `graphql:"issue(number: $number)"`
+	} `graphql:"repository(owner: $owner, name: $name)"`
+}
+
+type GraphqlQueryIssueTimelineItem struct {
+	Typename          string                 `graphql:"__typename"`
+	IssueComment      GraphqlQueryComment    `graphql:"... on IssueComment"`
+	ClosedEvent       GraphqlQueryIssueEvent `graphql:"... on ClosedEvent"`
+	ReopenedEvent     GraphqlQueryIssueEvent `graphql:"... on ReopenedEvent"`
+	AssignedEvent     GraphqlQueryIssueEvent `graphql:"... on AssignedEvent"`
+	UnassignedEvent   GraphqlQueryIssueEvent `graphql:"... on UnassignedEvent"`
+	LabeledEvent      GraphqlQueryIssueEvent `graphql:"... on LabeledEvent"`
+	UnlabeledEvent    GraphqlQueryIssueEvent `graphql:"... on UnlabeledEvent"`
+	MilestonedEvent   GraphqlQueryIssueEvent `graphql:"... on MilestonedEvent"`
+	DemilestonedEvent GraphqlQueryIssueEvent `graphql:"... on DemilestonedEvent"`
+	RenamedTitleEvent GraphqlQueryIssueEvent `graphql:"... on RenamedTitleEvent"`
+}
+
+type GraphqlQueryComment struct {
+	DatabaseId int
+	Body       string
+	Author     *GraphqlInlineAccountQuery
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+type GraphqlQueryIssueEvent struct {
+	// timeline events don't expose databaseId, it is extracted from the node id
+	Id        string
+	Actor     *GraphqlInlineAccountQuery
+	CreatedAt time.Time
+}
+
+type SimpleIssue struct {
+	Number   int
+	GithubId int
+}
+
+var CollectIssueTimelineMeta = core.SubTaskMeta{
+	Name:             "CollectIssueTimeline",
+	EntryPoint:       CollectIssueTimeline,
+	EnabledByDefault: true,
+	Description:      "Collect comments and events of issues from GithubGraphql api",
+	DomainTypes:      []string{core.DOMAIN_TYPE_TICKET},
+}
+
+var _ core.SubTaskEntryPoint = CollectIssueTimeline
+
+func CollectIssueTimeline(taskCtx core.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*githubTasks.GithubTaskData)
+
+	collectorWithState, err := helper.NewApiCollectorWithState(helper.RawDataSubTaskArgs{
+		Ctx: taskCtx,
+		Params: githubTasks.GithubApiParams{
+			ConnectionId: data.Options.ConnectionId,
+			Owner:        data.Options.Owner,
+			Repo:         data.Options.Repo,
+		},
+		Table: RAW_ISSUE_TIMELINE_TABLE,
+	}, data.Since)
+	if err != nil {
+		return err
+	}
+
+	// new comments or events would update the issue, so only issues updated within the time window are collected
+	clauses := []dal.Clause{
+		dal.Select("number, github_id"),
+		dal.From(models.GithubIssue{}.TableName()),
+		dal.Where("repo_id = ? and connection_id=?", data.Repo.GithubId, data.Options.ConnectionId),
+	}
+	if since := collectorWithState.TimeWindowSince(graphqlTimeWindow); since != nil {
+		clauses = append(clauses, dal.Where("github_updated_at >= ?", *since))
+	}
+	cursor, err := db.Cursor(clauses...)
+	if err != nil {
+		return err
+	}
+	iterator, err := helper.NewDalCursorIterator(db, cursor, reflect.TypeOf(SimpleIssue{}))
+	if err != nil {
+		return err
+	}
+
+	err = collectorWithState.InitGraphQLCollector(helper.GraphqlCollectorArgs{
+		Input:         iterator,
+		GraphqlClient: data.GraphqlClient,
+		PageSize:      100,
+		TimeWindow:    graphqlTimeWindow,
+		BuildQuery: func(reqData *helper.GraphqlRequestData) (interface{}, map[string]interface{}, error) {
+			issue := reqData.Input.(*SimpleIssue)
+			query := &GraphqlQueryIssueTimelineWrapper{}
+			variables := map[string]interface{}{
+				"pageSize":   graphql.Int(reqData.Pager.Size),
+				"skipCursor": (*graphql.String)(reqData.Pager.SkipCursor),
+				"owner":      graphql.String(data.Options.Owner),
+				"name":       graphql.String(data.Options.Repo),
+				"number":     graphql.Int(issue.Number),
+			}
+			return query, variables, nil
+		},
+		GetPageInfo: func(iQuery interface{}, args *helper.GraphqlCollectorArgs) (*helper.GraphqlQueryPageInfo, error) {
+			query := iQuery.(*GraphqlQueryIssueTimelineWrapper)
+			return query.Repository.Issue.TimelineItems.PageInfo, nil
+		},
+		ResponseParser: func(iQuery interface{}, variables map[string]interface{}) ([]interface{}, error) {
+			query := iQuery.(*GraphqlQueryIssueTimelineWrapper)
+			issueId := query.Repository.Issue.DatabaseId
+			items := query.Repository.Issue.TimelineItems.Items
+
+			results := make([]interface{}, 0, len(items))
+			for _, item := range items {
+				var author *GraphqlInlineAccountQuery
+				switch item.Typename {
+				case "IssueComment":
+					comment := item.IssueComment
+					githubIssueComment := &models.GithubIssueComment{
+						ConnectionId:    data.Options.ConnectionId,
+						GithubId:        comment.DatabaseId,
+						IssueId:         issueId,
+						Body:            comment.Body,
+						GithubCreatedAt: comment.CreatedAt,
+						GithubUpdatedAt: comment.UpdatedAt,
+					}
+					if comment.Author != nil {
+						githubIssueComment.AuthorUsername = comment.Author.Login
+						githubIssueComment.AuthorUserId = comment.Author.Id
+					}
+					author = comment.Author
+					results = append(results, githubIssueComment)
+				default:
+					eventType, event := item.event()
+					if eventType == "" {
+						continue
+					}
+					githubId, err := getDatabaseIdFromNodeId(event.Id)
+					if err != nil {
+						return nil, err
+					}
+					githubIssueEvent := &models.GithubIssueEvent{
+						ConnectionId:    data.Options.ConnectionId,
+						GithubId:        githubId,
+						IssueId:         issueId,
+						Type:            eventType,
+						GithubCreatedAt: event.CreatedAt,
+					}
+					if event.Actor != nil {
+						githubIssueEvent.AuthorUsername = event.Actor.Login
+					}
+					author = event.Actor
+					results = append(results, githubIssueEvent)
+				}
+				if author != nil {
+					githubUser, err := convertGraphqlPreAccount(*author, data.Repo.GithubId, data.Options.ConnectionId)
+					if err != nil {
+						return nil, err
+					}
+					results = append(results, githubUser)
+				}
+			}
+			return results, nil
+		},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return collectorWithState.Execute()
+}
+
+// event returns the event along with its type named by the rest api, or an empty type if it is not an event
+func (item GraphqlQueryIssueTimelineItem) event() (string, GraphqlQueryIssueEvent) {
+	switch item.Typename {
+	case "ClosedEvent":
+		return "closed", item.ClosedEvent
+	case "ReopenedEvent":
+		return "reopened", item.ReopenedEvent
+	case "AssignedEvent":
+		return "assigned", item.AssignedEvent
+	case "UnassignedEvent":
+		return "unassigned", item.UnassignedEvent
+	case "LabeledEvent":
+		return "labeled", item.LabeledEvent
+	case "UnlabeledEvent":
+		return "unlabeled", item.UnlabeledEvent
+	case "MilestonedEvent":
+		return "milestoned", item.MilestonedEvent
+	case "DemilestonedEvent":
+		return "demilestoned", item.DemilestonedEvent
+	case "RenamedTitleEvent":
+		return "renamed", item.RenamedTitleEvent
+	}
+	return "", GraphqlQueryIssueEvent{}
+}
@@ -0,0 +1,161 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/plugins/core"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+	githubTasks "github.com/apache/incubator-devlake/plugins/github/tasks"
+	"github.com/apache/incubator-devlake/plugins/helper"
+	"github.com/merico-dev/graphql"
+)
+
+const RAW_MILESTONES_TABLE = "github_graphql_milestones"
+
+type GraphqlQueryMilestoneWrapper struct {
+	RateLimit struct {
+		Cost int
+	}
+	Repository struct {
+		MilestoneList struct {
+			TotalCount graphql.Int
+			Milestones []GraphqlQueryMilestone `graphql:"nodes"`
+			PageInfo   *helper.GraphqlQueryPageInfo
+		} `graphql:"milestones(first: $pageSize, after: $skipCursor, orderBy: {field: UPDATED_AT, direction: DESC})"`
+	} `graphql:"repository(owner: $owner, name: $name)"`
+}
+
+type GraphqlQueryMilestone struct {
+	// milestones don't expose databaseId, it is extracted from the node id
+	Id         string
+	Number     int
+	Title      string
+	State      string
+	Url        string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	ClosedAt   *time.Time
+	OpenIssues struct {
+		TotalCount int
+	} `graphql:"openIssues: issues(states: OPEN)"`
+	ClosedIssues struct {
+		TotalCount int
+	} `graphql:"closedIssues: issues(states: CLOSED)"`
+}
+
+var CollectMilestoneMeta = core.SubTaskMeta{
+	Name:             "CollectMilestone",
+	EntryPoint:       CollectMilestone,
+	EnabledByDefault: true,
+	Description:      "Collect Milestone data from GithubGraphql api",
+	DomainTypes:      []string{core.DOMAIN_TYPE_TICKET},
+}
+
+var _ core.SubTaskEntryPoint = CollectMilestone
+
+func CollectMilestone(taskCtx core.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*githubTasks.GithubTaskData)
+
+	collectorWithState, err := helper.NewApiCollectorWithState(helper.RawDataSubTaskArgs{
+		Ctx: taskCtx,
+		Params: githubTasks.GithubApiParams{
+			ConnectionId: data.Options.ConnectionId,
+			Owner:        data.Options.Owner,
+			Repo:         data.Options.Repo,
+		},
+		Table: RAW_MILESTONES_TABLE,
+	}, data.Since)
+	if err != nil {
+		return err
+	}
+	// milestones are listed from the latest updated one, so we can stop once they were updated before the time window
+	since := collectorWithState.TimeWindowSince(graphqlTimeWindow)
+
+	err = collectorWithState.InitGraphQLCollector(helper.GraphqlCollectorArgs{
+		GraphqlClient: data.GraphqlClient,
+		PageSize:      100,
+		TimeWindow:    graphqlTimeWindow,
+		BuildQuery: func(reqData *helper.GraphqlRequestData) (interface{}, map[string]interface{}, error) {
+			query := &GraphqlQueryMilestoneWrapper{}
+			variables := map[string]interface{}{
+				"pageSize":   graphql.Int(reqData.Pager.Size),
+				"skipCursor": (*graphql.String)(reqData.Pager.SkipCursor),
+				"owner":      graphql.String(data.Options.Owner),
+				"name":       graphql.String(data.Options.Repo),
+			}
+			return query, variables, nil
+		},
+		GetPageInfo: func(iQuery interface{}, args *helper.GraphqlCollectorArgs) (*helper.GraphqlQueryPageInfo, error) {
+			query := iQuery.(*GraphqlQueryMilestoneWrapper)
+			milestones := query.Repository.MilestoneList.Milestones
+			if len(milestones) > 0 && isOutdated(milestones[len(milestones)-1].UpdatedAt, since) {
+				return &helper.GraphqlQueryPageInfo{}, nil
+			}
+			return query.Repository.MilestoneList.PageInfo, nil
+		},
+		ResponseParser: func(iQuery interface{}, variables map[string]interface{}) ([]interface{}, error) {
+			query := iQuery.(*GraphqlQueryMilestoneWrapper)
+			milestones := query.Repository.MilestoneList.Milestones
+
+			results := make([]interface{}, 0, len(milestones))
+			for _, milestone := range milestones {
+				if isOutdated(milestone.UpdatedAt, since) {
+					break
+				}
+				githubMilestone, err := convertGithubMilestone(milestone, data.Options.ConnectionId, data.Repo.GithubId)
+				if err != nil {
+					return nil, err
+				}
+				results = append(results, githubMilestone)
+			}
+			return results, nil
+		},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return collectorWithState.Execute()
+}
+
+func convertGithubMilestone(milestone GraphqlQueryMilestone, connectionId uint64, repositoryId int) (*models.GithubMilestone, errors.Error) {
+	milestoneId, err := getDatabaseIdFromNodeId(milestone.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &models.GithubMilestone{
+		ConnectionId: connectionId,
+		MilestoneId:  milestoneId,
+		RepoId:       repositoryId,
+		Number:       milestone.Number,
+		URL:          milestone.Url,
+		Title:        milestone.Title,
+		OpenIssues:   milestone.OpenIssues.TotalCount,
+		ClosedIssues: milestone.ClosedIssues.TotalCount,
+		// keep the same state as the rest api, which is `open` or `closed`
+		State:     strings.ToLower(milestone.State),
+		CreatedAt: milestone.CreatedAt,
+		UpdatedAt: milestone.UpdatedAt,
+		ClosedAt:  milestone.ClosedAt,
+	}, nil
+}
@@ -39,7 +39,7 @@ type GraphqlQueryPrWrapper struct {
 			PageInfo   *helper.GraphqlQueryPageInfo
 			Prs        []GraphqlQueryPr `graphql:"nodes"`
 			TotalCount graphql.Int
-		} `graphql:"pullRequests(first: $pageSize, after: $skipCursor)"`
+		} `graphql:"pullRequests(first: $pageSize, after: $skipCursor, orderBy: {field: UPDATED_AT, direction: DESC})"`
 	} `graphql:"repository(owner: $owner, name: $name)"`
 }
 
@@ -77,21 +77,6 @@ type GraphqlQueryPr struct {
 		Nodes      []GraphqlQueryCommit `graphql:"nodes"`
 		TotalCount graphql.Int
 	} `graphql:"commits(first: 100)"`
-	Reviews struct {
-		TotalCount graphql.Int
-		Nodes      []GraphqlQueryReview `graphql:"nodes"`
-	} `graphql:"reviews(first: 100)"`
-}
-
-type GraphqlQueryReview struct {
-	Body       string
-	Author     *GraphqlInlineAccountQuery
-	State      string `json:"state"`
-	DatabaseId int    `json:"databaseId"`
-	Commit     struct {
-		Oid string
-	}
-	SubmittedAt *time.Time `json:"submittedAt"`
 }
 
 type GraphqlQueryCommit struct {
@@ -144,18 +129,25 @@ func CollectPr(taskCtx core.SubTaskContext) errors.Error {
 		}
 	}
 
-	collector, err := helper.NewGraphqlCollector(helper.GraphqlCollectorArgs{
-		RawDataSubTaskArgs: helper.RawDataSubTaskArgs{
-			Ctx: taskCtx,
-			Params: githubTasks.GithubApiParams{
-				ConnectionId: data.Options.ConnectionId,
-				Owner:        data.Options.Owner,
-				Repo:         data.Options.Repo,
-			},
-			Table: RAW_PRS_TABLE,
+	collectorWithState, err := helper.NewApiCollectorWithState(helper.RawDataSubTaskArgs{
+		Ctx: taskCtx,
+		Params: githubTasks.GithubApiParams{
+			ConnectionId: data.Options.ConnectionId,
+			Owner:        data.Options.Owner,
+			Repo:         data.Options.Repo,
 		},
+		Table: RAW_PRS_TABLE,
+	}, data.Since)
+	if err != nil {
+		return errors.Convert(err)
+	}
+	// prs are listed from the latest updated one, so we can stop once they were updated before the time window
+	since := collectorWithState.TimeWindowSince(graphqlTimeWindow)
+
+	err = collectorWithState.InitGraphQLCollector(helper.GraphqlCollectorArgs{
 		GraphqlClient: data.GraphqlClient,
 		PageSize:      30,
+		TimeWindow:    graphqlTimeWindow,
 		/*
 			(Optional) Return query string for request, or you can plug them into UrlTemplate directly
 		*/
@@ -171,6 +163,10 @@ func CollectPr(taskCtx core.SubTaskContext) errors.Error {
 		},
 		GetPageInfo: func(iQuery interface{}, args *helper.GraphqlCollectorArgs) (*helper.GraphqlQueryPageInfo, error) {
 			query := iQuery.(*GraphqlQueryPrWrapper)
+			prs := query.Repository.PullRequests.Prs
+			if len(prs) > 0 && isOutdated(prs[len(prs)-1].UpdatedAt, since) {
+				return &helper.GraphqlQueryPageInfo{}, nil
+			}
 			return query.Repository.PullRequests.PageInfo, nil
 		},
 		ResponseParser: func(iQuery interface{}, variables map[string]interface{}) ([]interface{}, error) {
@@ -179,7 +175,9 @@ func CollectPr(taskCtx core.SubTaskContext) errors.Error {
 
 			results := make([]interface{}, 0, 1)
 			for _, rawL := range prs {
-				//If this is a pr, ignore
+				if isOutdated(rawL.UpdatedAt, since) {
+					break
+				}
 				githubPr, err := convertGithubPullRequest(rawL, data.Options.ConnectionId, data.Repo.GithubId)
 				if err != nil {
 					return nil, err
@@ -215,43 +213,6 @@ func CollectPr(taskCtx core.SubTaskContext) errors.Error {
 				}
 				results = append(results, githubPr)
 
-				for _, apiPullRequestReview := range rawL.Reviews.Nodes {
-					if apiPullRequestReview.State != "PENDING" {
-						githubReviewer := &models.GithubReviewer{
-							ConnectionId:  data.Options.ConnectionId,
-							PullRequestId: githubPr.GithubId,
-						}
-
-						githubPrReview := &models.GithubPrReview{
-							ConnectionId:   data.Options.ConnectionId,
-							GithubId:       apiPullRequestReview.DatabaseId,
-							Body:           apiPullRequestReview.Body,
-							State:          apiPullRequestReview.State,
-							CommitSha:      apiPullRequestReview.Commit.Oid,
-							GithubSubmitAt: apiPullRequestReview.SubmittedAt,
-
-							PullRequestId: githubPr.GithubId,
-						}
-
-						if apiPullRequestReview.Author != nil {
-							githubReviewer.GithubId = apiPullRequestReview.Author.Id
-							githubReviewer.Login = apiPullRequestReview.Author.Login
-
-							githubPrReview.AuthorUserId = apiPullRequestReview.Author.Id
-							githubPrReview.AuthorUsername = apiPullRequestReview.Author.Login
-
-							githubUser, err := convertGraphqlPreAccount(*apiPullRequestReview.Author, data.Repo.GithubId, data.Options.ConnectionId)
-							if err != nil {
-								return nil, err
-							}
-							results = append(results, githubUser)
-						}
-
-						results = append(results, githubReviewer)
-						results = append(results, githubPrReview)
-					}
-				}
-
 				for _, apiPullRequestCommit := range rawL.Commits.Nodes {
 					githubCommit, err := convertPullRequestCommit(apiPullRequestCommit)
 					if err != nil {
@@ -287,7 +248,7 @@ func CollectPr(taskCtx core.SubTaskContext) errors.Error {
 		return errors.Convert(err)
 	}
 
-	return collector.Execute()
+	return collectorWithState.Execute()
 }
 
 func convertGithubPullRequest(pull GraphqlQueryPr, connId uint64, repoId int) (*models.GithubPullRequest, errors.Error) {
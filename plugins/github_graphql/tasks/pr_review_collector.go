@@ -0,0 +1,179 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/plugins/core"
+	"github.com/apache/incubator-devlake/plugins/core/dal"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+	githubTasks "github.com/apache/incubator-devlake/plugins/github/tasks"
+	"github.com/apache/incubator-devlake/plugins/helper"
+	"github.com/merico-dev/graphql"
+)
+
+const RAW_PR_REVIEW_TABLE = "github_graphql_pr_reviews"
+
+type GraphqlQueryPrReviewWrapper struct {
+	RateLimit struct {
+		Cost int
+	}
+	Repository struct {
+		PullRequest struct {
+			DatabaseId int
+			Reviews    struct {
+				PageInfo *helper.GraphqlQueryPageInfo
+				Nodes    []GraphqlQueryReview `graphql:"nodes"`
+			} `graphql:"reviews(first: $pageSize, after: $skipCursor)"`
+		} `graphql:"pullRequest(number: $number)"`
+	} `graphql:"repository(owner: $owner, name: $name)"`
+}
+
+type GraphqlQueryReview struct {
+	Body       string
+	Author     *GraphqlInlineAccountQuery
+	State      string `json:"state"`
+	DatabaseId int    `json:"databaseId"`
+	Commit     struct {
+		Oid string
+	}
+	SubmittedAt *time.Time `json:"submittedAt"`
+}
+
+var CollectPrReviewMeta = core.SubTaskMeta{
+	Name:             "CollectPrReview",
+	EntryPoint:       CollectPrReview,
+	EnabledByDefault: true,
+	Description:      "Collect reviews of prs from GithubGraphql api",
+	DomainTypes:      []string{core.DOMAIN_TYPE_CODE_REVIEW},
+}
+
+var _ core.SubTaskEntryPoint = CollectPrReview
+
+func CollectPrReview(taskCtx core.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*githubTasks.GithubTaskData)
+
+	collectorWithState, err := helper.NewApiCollectorWithState(helper.RawDataSubTaskArgs{
+		Ctx: taskCtx,
+		Params: githubTasks.GithubApiParams{
+			ConnectionId: data.Options.ConnectionId,
+			Owner:        data.Options.Owner,
+			Repo:         data.Options.Repo,
+		},
+		Table: RAW_PR_REVIEW_TABLE,
+	}, data.Since)
+	if err != nil {
+		return err
+	}
+
+	// new reviews would update the pr, so only prs updated within the time window are collected
+	clauses := []dal.Clause{
+		dal.Select("number, github_id"),
+		dal.From(models.GithubPullRequest{}.TableName()),
+		dal.Where("repo_id = ? and connection_id=?", data.Repo.GithubId, data.Options.ConnectionId),
+	}
+	if since := collectorWithState.TimeWindowSince(graphqlTimeWindow); since != nil {
+		clauses = append(clauses, dal.Where("github_updated_at >= ?", *since))
+	}
+	cursor, err := db.Cursor(clauses...)
+	if err != nil {
+		return err
+	}
+	iterator, err := helper.NewDalCursorIterator(db, cursor, reflect.TypeOf(githubTasks.SimplePr{}))
+	if err != nil {
+		return err
+	}
+
+	err = collectorWithState.InitGraphQLCollector(helper.GraphqlCollectorArgs{
+		Input:         iterator,
+		GraphqlClient: data.GraphqlClient,
+		PageSize:      100,
+		TimeWindow:    graphqlTimeWindow,
+		BuildQuery: func(reqData *helper.GraphqlRequestData) (interface{}, map[string]interface{}, error) {
+			pr := reqData.Input.(*githubTasks.SimplePr)
+			query := &GraphqlQueryPrReviewWrapper{}
+			variables := map[string]interface{}{
+				"pageSize":   graphql.Int(reqData.Pager.Size),
+				"skipCursor": (*graphql.String)(reqData.Pager.SkipCursor),
+				"owner":      graphql.String(data.Options.Owner),
+				"name":       graphql.String(data.Options.Repo),
+				"number":     graphql.Int(pr.Number),
+			}
+			return query, variables, nil
+		},
+		GetPageInfo: func(iQuery interface{}, args *helper.GraphqlCollectorArgs) (*helper.GraphqlQueryPageInfo, error) {
+			query := iQuery.(*GraphqlQueryPrReviewWrapper)
+			return query.Repository.PullRequest.Reviews.PageInfo, nil
+		},
+		ResponseParser: func(iQuery interface{}, variables map[string]interface{}) ([]interface{}, error) {
+			query := iQuery.(*GraphqlQueryPrReviewWrapper)
+			prId := query.Repository.PullRequest.DatabaseId
+			reviews := query.Repository.PullRequest.Reviews.Nodes
+
+			results := make([]interface{}, 0, len(reviews))
+			for _, apiPullRequestReview := range reviews {
+				if apiPullRequestReview.State == "PENDING" {
+					continue
+				}
+				githubReviewer := &models.GithubReviewer{
+					ConnectionId:  data.Options.ConnectionId,
+					PullRequestId: prId,
+				}
+
+				githubPrReview := &models.GithubPrReview{
+					ConnectionId:   data.Options.ConnectionId,
+					GithubId:       apiPullRequestReview.DatabaseId,
+					Body:           apiPullRequestReview.Body,
+					State:          apiPullRequestReview.State,
+					CommitSha:      apiPullRequestReview.Commit.Oid,
+					GithubSubmitAt: apiPullRequestReview.SubmittedAt,
+
+					PullRequestId: prId,
+				}
+
+				if apiPullRequestReview.Author != nil {
+					githubReviewer.GithubId = apiPullRequestReview.Author.Id
+					githubReviewer.Login = apiPullRequestReview.Author.Login
+
+					githubPrReview.AuthorUserId = apiPullRequestReview.Author.Id
+					githubPrReview.AuthorUsername = apiPullRequestReview.Author.Login
+
+					githubUser, err := convertGraphqlPreAccount(*apiPullRequestReview.Author, data.Repo.GithubId, data.Options.ConnectionId)
+					if err != nil {
+						return nil, err
+					}
+					results = append(results, githubUser)
+				}
+
+				results = append(results, githubReviewer)
+				results = append(results, githubPrReview)
+			}
+			return results, nil
+		},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return collectorWithState.Execute()
+}
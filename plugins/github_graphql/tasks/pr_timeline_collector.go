@@ -0,0 +1,210 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/plugins/core"
+	"github.com/apache/incubator-devlake/plugins/core/dal"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+	githubTasks "github.com/apache/incubator-devlake/plugins/github/tasks"
+	"github.com/apache/incubator-devlake/plugins/helper"
+	"github.com/merico-dev/graphql"
+)
+
+const RAW_PR_TIMELINE_TABLE = "github_graphql_pr_timeline"
+
+type GraphqlQueryPrTimelineWrapper struct {
+	RateLimit struct {
+		Cost int
+	}
+	Repository struct {
+		PullRequest struct {
+			DatabaseId    int
+			TimelineItems struct {
+				PageInfo *helper.GraphqlQueryPageInfo
+				Items    []GraphqlQueryPrTimelineItem `graphql:"nodes"`
+			} `graphql:"timelineItems(first: $pageSize, after: $skipCursor, itemTypes: [ISSUE_COMMENT, PULL_REQUEST_REVIEW_THREAD])"`
+		} `graphql:"pullRequest(number: $number)"`
+	} `graphql:"repository(owner: $owner, name: $name)"`
+}
+
+type GraphqlQueryPrTimelineItem struct {
+	Typename     string                     `graphql:"__typename"`
+	IssueComment GraphqlQueryComment        `graphql:"... on IssueComment"`
+	ReviewThread GraphqlQueryPrReviewThread `graphql:"... on PullRequestReviewThread"`
+}
+
+type GraphqlQueryPrReviewThread struct {
+	Comments struct {
+		Nodes []GraphqlQueryPrReviewComment
+	} `graphql:"comments(first: 100)"`
+}
+
+type GraphqlQueryPrReviewComment struct {
+	DatabaseId int
+	Body       string
+	Author     *GraphqlInlineAccountQuery
+	Commit     *struct {
+		Oid string
+	}
+	PullRequestReview *struct {
+		DatabaseId int
+	}
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+var CollectPrTimelineMeta = core.SubTaskMeta{
+	Name:             "CollectPrTimeline",
+	EntryPoint:       CollectPrTimeline,
+	EnabledByDefault: true,
+	Description:      "Collect comments and review threads of prs from GithubGraphql api",
+	DomainTypes:      []string{core.DOMAIN_TYPE_CODE_REVIEW},
+}
+
+var _ core.SubTaskEntryPoint = CollectPrTimeline
+
+func CollectPrTimeline(taskCtx core.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*githubTasks.GithubTaskData)
+
+	collectorWithState, err := helper.NewApiCollectorWithState(helper.RawDataSubTaskArgs{
+		Ctx: taskCtx,
+		Params: githubTasks.GithubApiParams{
+			ConnectionId: data.Options.ConnectionId,
+			Owner:        data.Options.Owner,
+			Repo:         data.Options.Repo,
+		},
+		Table: RAW_PR_TIMELINE_TABLE,
+	}, data.Since)
+	if err != nil {
+		return err
+	}
+
+	// new comments would update the pr, so only prs updated within the time window are collected
+	clauses := []dal.Clause{
+		dal.Select("number, github_id"),
+		dal.From(models.GithubPullRequest{}.TableName()),
+		dal.Where("repo_id = ? and connection_id=?", data.Repo.GithubId, data.Options.ConnectionId),
+	}
+	if since := collectorWithState.TimeWindowSince(graphqlTimeWindow); since != nil {
+		clauses = append(clauses, dal.Where("github_updated_at >= ?", *since))
+	}
+	cursor, err := db.Cursor(clauses...)
+	if err != nil {
+		return err
+	}
+	iterator, err := helper.NewDalCursorIterator(db, cursor, reflect.TypeOf(githubTasks.SimplePr{}))
+	if err != nil {
+		return err
+	}
+
+	err = collectorWithState.InitGraphQLCollector(helper.GraphqlCollectorArgs{
+		Input:         iterator,
+		GraphqlClient: data.GraphqlClient,
+		PageSize:      50,
+		TimeWindow:    graphqlTimeWindow,
+		BuildQuery: func(reqData *helper.GraphqlRequestData) (interface{}, map[string]interface{}, error) {
+			pr := reqData.Input.(*githubTasks.SimplePr)
+			query := &GraphqlQueryPrTimelineWrapper{}
+			variables := map[string]interface{}{
+				"pageSize":   graphql.Int(reqData.Pager.Size),
+				"skipCursor": (*graphql.String)(reqData.Pager.SkipCursor),
+				"owner":      graphql.String(data.Options.Owner),
+				"name":       graphql.String(data.Options.Repo),
+				"number":     graphql.Int(pr.Number),
+			}
+			return query, variables, nil
+		},
+		GetPageInfo: func(iQuery interface{}, args *helper.GraphqlCollectorArgs) (*helper.GraphqlQueryPageInfo, error) {
+			query := iQuery.(*GraphqlQueryPrTimelineWrapper)
+			return query.Repository.PullRequest.TimelineItems.PageInfo, nil
+		},
+		ResponseParser: func(iQuery interface{}, variables map[string]interface{}) ([]interface{}, error) {
+			query := iQuery.(*GraphqlQueryPrTimelineWrapper)
+			prId := query.Repository.PullRequest.DatabaseId
+			items := query.Repository.PullRequest.TimelineItems.Items
+
+			results := make([]interface{}, 0, len(items))
+			for _, item := range items {
+				switch item.Typename {
+				case "IssueComment":
+					comment := item.IssueComment
+					githubPrComment := &models.GithubPrComment{
+						ConnectionId:    data.Options.ConnectionId,
+						GithubId:        comment.DatabaseId,
+						PullRequestId:   prId,
+						Body:            comment.Body,
+						GithubCreatedAt: comment.CreatedAt,
+						GithubUpdatedAt: comment.UpdatedAt,
+						Type:            "NORMAL",
+					}
+					if comment.Author != nil {
+						githubPrComment.AuthorUsername = comment.Author.Login
+						githubPrComment.AuthorUserId = comment.Author.Id
+						githubUser, err := convertGraphqlPreAccount(*comment.Author, data.Repo.GithubId, data.Options.ConnectionId)
+						if err != nil {
+							return nil, err
+						}
+						results = append(results, githubUser)
+					}
+					results = append(results, githubPrComment)
+				case "PullRequestReviewThread":
+					for _, comment := range item.ReviewThread.Comments.Nodes {
+						githubPrComment := &models.GithubPrComment{
+							ConnectionId:    data.Options.ConnectionId,
+							GithubId:        comment.DatabaseId,
+							PullRequestId:   prId,
+							Body:            comment.Body,
+							GithubCreatedAt: comment.CreatedAt,
+							GithubUpdatedAt: comment.UpdatedAt,
+							Type:            "DIFF",
+						}
+						if comment.Commit != nil {
+							githubPrComment.CommitSha = comment.Commit.Oid
+						}
+						if comment.PullRequestReview != nil {
+							githubPrComment.ReviewId = comment.PullRequestReview.DatabaseId
+						}
+						if comment.Author != nil {
+							githubPrComment.AuthorUsername = comment.Author.Login
+							githubPrComment.AuthorUserId = comment.Author.Id
+							githubUser, err := convertGraphqlPreAccount(*comment.Author, data.Repo.GithubId, data.Options.ConnectionId)
+							if err != nil {
+								return nil, err
+							}
+							results = append(results, githubUser)
+						}
+						results = append(results, githubPrComment)
+					}
+				}
+			}
+			return results, nil
+		},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return collectorWithState.Execute()
+}
@@ -59,6 +59,7 @@ var CollectRepoMeta = core.SubTaskMeta{
 	Name:             "CollectRepo",
 	EntryPoint:       CollectRepo,
 	EnabledByDefault: true,
+	Required:         true,
 	Description:      "Collect Repo data from GithubGraphql api",
 	DomainTypes:      []string{core.DOMAIN_TYPE_CODE, core.DOMAIN_TYPE_TICKET, core.DOMAIN_TYPE_CICD, core.DOMAIN_TYPE_CODE_REVIEW, core.DOMAIN_TYPE_CROSS},
 }
@@ -0,0 +1,115 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/base64"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/plugins/helper"
+)
+
+// graphqlTimeWindow is used by incremental collections, records are listed from the latest updated one, and the
+// collection stops once records were updated before the window
+var graphqlTimeWindow = &helper.IncrementalTimeWindow{
+	Overlap: 5 * time.Minute,
+}
+
+// isOutdated returns true if the record was updated before the time window, nil since means collecting everything
+func isOutdated(updatedAt time.Time, since *time.Time) bool {
+	return since != nil && updatedAt.Before(*since)
+}
+
+var legacyNodeIdPattern = regexp.MustCompile(`^\d+:[A-Za-z]+?(\d+)$`)
+
+// getDatabaseIdFromNodeId extracts the REST api id from graphql node id, for objects like milestones and timeline
+// events which don't expose `databaseId`. Both the legacy format `base64("05:Milestone123")` and the next format
+// `MI_` + `base64url(msgpack([0, repositoryId, 123]))` are supported
+func getDatabaseIdFromNodeId(nodeId string) (int, errors.Error) {
+	if index := strings.Index(nodeId, "_"); index > 0 {
+		blob, err := base64.RawURLEncoding.DecodeString(nodeId[index+1:])
+		if err != nil {
+			return 0, errors.BadInput.Wrap(err, "invalid node id "+nodeId)
+		}
+		values, err := decodeMsgpackIntArray(blob)
+		if err != nil || len(values) == 0 {
+			return 0, errors.BadInput.New("unsupported node id " + nodeId)
+		}
+		return int(values[len(values)-1]), nil
+	}
+	blob, err := base64.StdEncoding.DecodeString(nodeId)
+	if err != nil {
+		return 0, errors.BadInput.Wrap(err, "invalid node id "+nodeId)
+	}
+	groups := legacyNodeIdPattern.FindStringSubmatch(string(blob))
+	if groups == nil {
+		return 0, errors.BadInput.New("unsupported node id " + nodeId)
+	}
+	id, err := strconv.Atoi(groups[1])
+	if err != nil {
+		return 0, errors.BadInput.Wrap(err, "invalid node id "+nodeId)
+	}
+	return id, nil
+}
+
+// decodeMsgpackIntArray decodes a msgpack array of unsigned integers, which is the payload of next format node ids
+func decodeMsgpackIntArray(blob []byte) ([]uint64, errors.Error) {
+	if len(blob) == 0 || blob[0]&0xf0 != 0x90 {
+		return nil, errors.Default.New("not a msgpack fixarray")
+	}
+	size := int(blob[0] & 0x0f)
+	values := make([]uint64, 0, size)
+	offset := 1
+	for i := 0; i < size; i++ {
+		if offset >= len(blob) {
+			return nil, errors.Default.New("unexpected end of msgpack")
+		}
+		head := blob[offset]
+		offset++
+		var width int
+		switch {
+		case head <= 0x7f:
+			values = append(values, uint64(head))
+			continue
+		case head == 0xcc:
+			width = 1
+		case head == 0xcd:
+			width = 2
+		case head == 0xce:
+			width = 4
+		case head == 0xcf:
+			width = 8
+		default:
+			return nil, errors.Default.New("unsupported msgpack type")
+		}
+		if offset+width > len(blob) {
+			return nil, errors.Default.New("unexpected end of msgpack")
+		}
+		var value uint64
+		for _, b := range blob[offset : offset+width] {
+			value = value<<8 | uint64(b)
+		}
+		values = append(values, value)
+		offset += width
+	}
+	return values, nil
+}
@@ -0,0 +1,47 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetDatabaseIdFromNodeId(t *testing.T) {
+	// legacy format
+	id, err := getDatabaseIdFromNodeId("MDU6TWlsZXN0b25lODQ1MjM3MQ==")
+	assert.Nil(t, err)
+	assert.Equal(t, 8452371, id)
+	id, err = getDatabaseIdFromNodeId("MDExOkNsb3NlZEV2ZW50NzI4MTkzMDQ1MA==")
+	assert.Nil(t, err)
+	assert.Equal(t, 7281930450, id)
+
+	// next format
+	id, err = getDatabaseIdFromNodeId("MI_kwDOCS4fWs0E0g")
+	assert.Nil(t, err)
+	assert.Equal(t, 1234, id)
+	id, err = getDatabaseIdFromNodeId("CE_kgDPAAAAAbIcDlI")
+	assert.Nil(t, err)
+	assert.Equal(t, 7283150418, id)
+
+	_, err = getDatabaseIdFromNodeId("MI_!!!")
+	assert.NotNil(t, err)
+	_, err = getDatabaseIdFromNodeId("bm90IGEgbm9kZSBpZA==")
+	assert.NotNil(t, err)
+}
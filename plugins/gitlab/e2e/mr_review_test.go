@@ -0,0 +1,76 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package e2e
+
+import (
+	"github.com/apache/incubator-devlake/models/common"
+	"testing"
+
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/plugins/gitlab/impl"
+	"github.com/apache/incubator-devlake/plugins/gitlab/models"
+	"github.com/apache/incubator-devlake/plugins/gitlab/tasks"
+)
+
+func TestGitlabMrReviewDataFlow(t *testing.T) {
+
+	var gitlab impl.Gitlab
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "gitlab", gitlab)
+
+	taskData := &tasks.GitlabTaskData{
+		Options: &tasks.GitlabOptions{
+			ConnectionId:             1,
+			ProjectId:                12345678,
+			GitlabTransformationRule: new(models.GitlabTransformationRule),
+		},
+	}
+	// import raw data table
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_gitlab_api_merge_requests_for_mr_review_test.csv",
+		"_raw_gitlab_api_merge_requests")
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_gitlab_api_merge_request_notes_for_mr_review_test.csv",
+		"_raw_gitlab_api_merge_request_notes")
+
+	// verify extraction
+	dataflowTester.FlushTabler(&models.GitlabMergeRequest{})
+	dataflowTester.FlushTabler(&models.GitlabMrLabel{})
+	dataflowTester.FlushTabler(&models.GitlabReviewer{})
+	dataflowTester.Subtask(tasks.ExtractApiMergeRequestsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&models.GitlabReviewer{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_gitlab_reviewers.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+
+	dataflowTester.FlushTabler(&models.GitlabMrNote{})
+	dataflowTester.FlushTabler(&models.GitlabMrComment{})
+	dataflowTester.Subtask(tasks.ExtractApiMrNotesMeta, taskData)
+
+	// verify conversion, approvals are converted from the `approved this merge request` notes along with their time
+	dataflowTester.FlushTabler(&code.PullRequestComment{})
+	dataflowTester.Subtask(tasks.ConvertMrCommentMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&code.PullRequestComment{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/pull_request_comments_for_mr_review_test.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+
+	dataflowTester.FlushTabler(&code.PullRequestReviewer{})
+	dataflowTester.Subtask(tasks.ConvertMrReviewersMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&code.PullRequestReviewer{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/pull_request_reviewers.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+}
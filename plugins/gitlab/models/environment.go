@@ -0,0 +1,44 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package models
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/models/common"
+)
+
+type GitlabEnvironment struct {
+	ConnectionId uint64 `gorm:"primaryKey"`
+
+	GitlabId    int    `gorm:"primaryKey"`
+	ProjectId   int    `gorm:"index"`
+	Name        string `gorm:"type:varchar(255)"`
+	Slug        string `gorm:"type:varchar(255)"`
+	ExternalUrl string `gorm:"type:varchar(255)"`
+	State       string `gorm:"type:varchar(100)"`
+	Tier        string `gorm:"type:varchar(100);comment:production, staging, testing, development or other"`
+
+	GitlabCreatedAt *time.Time
+	GitlabUpdatedAt *time.Time
+
+	common.NoPKModel
+}
+
+func (GitlabEnvironment) TableName() string {
+	return "_tool_gitlab_environments"
+}
@@ -0,0 +1,62 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+	"github.com/apache/incubator-devlake/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/plugins/core"
+)
+
+type gitlabReviewer20221221 struct {
+	ConnectionId uint64 `gorm:"primaryKey"`
+
+	GitlabId       int    `gorm:"primaryKey"`
+	MergeRequestId int    `gorm:"primaryKey"`
+	ProjectId      int    `gorm:"index"`
+	Name           string `gorm:"type:varchar(255)"`
+	Username       string `gorm:"type:varchar(255)"`
+	State          string `gorm:"type:varchar(255)"`
+	AvatarUrl      string `gorm:"type:varchar(255)"`
+	WebUrl         string `gorm:"type:varchar(255)"`
+	archived.NoPKModel
+}
+
+func (gitlabReviewer20221221) TableName() string {
+	return "_tool_gitlab_reviewers"
+}
+
+type addReviewersPk20221221 struct{}
+
+func (*addReviewersPk20221221) Up(basicRes core.BasicRes) errors.Error {
+	// reviewers were keyed by user only, the same user reviewing another merge request overwrote the former one, they
+	// would be collected again along with merge requests
+	err := basicRes.GetDal().DropTables(&gitlabReviewer20221221{})
+	if err != nil {
+		return errors.Default.Wrap(err, "delete table _tool_gitlab_reviewers error")
+	}
+	return migrationhelper.AutoMigrateTables(basicRes, &gitlabReviewer20221221{})
+}
+
+func (*addReviewersPk20221221) Version() uint64 {
+	return 20221221000001
+}
+
+func (*addReviewersPk20221221) Name() string {
+	return "add merge_request_id to primary key of _tool_gitlab_reviewers"
+}
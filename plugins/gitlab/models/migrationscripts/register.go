@@ -30,5 +30,7 @@ func All() []core.MigrationScript {
 		new(addPipelineProjects),
 		new(fixDurationToFloat8),
 		new(addTransformationRule20221125),
+		new(addDeploymentsAndEnvironments20221220),
+		new(addReviewersPk20221221),
 	}
 }
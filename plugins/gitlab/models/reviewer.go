@@ -25,7 +25,7 @@ type GitlabReviewer struct {
 	ConnectionId uint64 `gorm:"primaryKey"`
 
 	GitlabId       int    `gorm:"primaryKey"`
-	MergeRequestId int    `gorm:"index"`
+	MergeRequestId int    `gorm:"primaryKey"`
 	ProjectId      int    `gorm:"index"`
 	Name           string `gorm:"type:varchar(255)"`
 	Username       string `gorm:"type:varchar(255)"`
@@ -0,0 +1,176 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package tasks
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/models/domainlayer"
+	"github.com/apache/incubator-devlake/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/plugins/core"
+	"github.com/apache/incubator-devlake/plugins/core/dal"
+	gitlabModels "github.com/apache/incubator-devlake/plugins/gitlab/models"
+	"github.com/apache/incubator-devlake/plugins/helper"
+)
+
+var ConvertDeploymentMeta = core.SubTaskMeta{
+	Name:             "convertDeployments",
+	EntryPoint:       ConvertDeployments,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table gitlab_deployment into domain layer table cicd_pipelines, cicd_pipeline_commits and cicd_tasks",
+	DomainTypes:      []string{core.DOMAIN_TYPE_CICD},
+}
+
+type deploymentWithTier struct {
+	gitlabModels.GitlabDeployment
+	Tier string
+}
+
+func ConvertDeployments(taskCtx core.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*GitlabTaskData)
+
+	cursor, err := db.Cursor(
+		dal.Select("d.*, e.tier"),
+		dal.From("_tool_gitlab_deployments d"),
+		dal.Join("left join _tool_gitlab_environments e on e.gitlab_id = d.environment_id and e.connection_id = d.connection_id"),
+		dal.Where("d.project_id = ? and d.connection_id = ?", data.Options.ProjectId, data.Options.ConnectionId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	deploymentIdGen := didgen.NewDomainIdGenerator(&gitlabModels.GitlabDeployment{})
+	projectIdGen := didgen.NewDomainIdGenerator(&gitlabModels.GitlabProject{})
+	converter, err := helper.NewDataConverter(helper.DataConverterArgs{
+		InputRowType: reflect.TypeOf(deploymentWithTier{}),
+		Input:        cursor,
+		RawDataSubTaskArgs: helper.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: GitlabApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				ProjectId:    data.Options.ProjectId,
+			},
+			Table: RAW_DEPLOYMENT_TABLE,
+		},
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			deployment := inputRow.(*deploymentWithTier)
+			deploymentId := deploymentIdGen.Generate(data.Options.ConnectionId, deployment.GitlabId)
+			projectId := projectIdGen.Generate(data.Options.ConnectionId, deployment.ProjectId)
+
+			name := deployment.EnvironmentName
+			if deployment.DeployableName != "" {
+				name = deployment.DeployableName + ":" + deployment.EnvironmentName
+			}
+			environment := getEnvironmentByTier(deployment.Tier, deployment.EnvironmentName)
+			result := devops.GetResult(&devops.ResultRule{
+				Failed:  []string{"failed"},
+				Abort:   []string{"canceled", "skipped"},
+				Manual:  []string{"blocked"},
+				Success: []string{"success"},
+				Default: "",
+			}, deployment.Status)
+			status := devops.GetStatus(&devops.StatusRule{
+				InProgress: []string{"created", "running"},
+				Manual:     []string{"blocked"},
+				Default:    devops.DONE,
+			}, deployment.Status)
+			startedAt := deployment.GitlabCreatedAt
+			if deployment.StartedAt != nil {
+				startedAt = deployment.StartedAt
+			}
+			finishedAt := deployment.FinishedAt
+			if finishedAt == nil && status == devops.DONE {
+				finishedAt = deployment.GitlabUpdatedAt
+			}
+
+			domainPipeline := &devops.CICDPipeline{
+				DomainEntity: domainlayer.DomainEntity{Id: deploymentId},
+				Name:         name,
+				Result:       result,
+				Status:       status,
+				Type:         devops.DEPLOYMENT,
+				Environment:  environment,
+				CreatedDate:  *deployment.GitlabCreatedAt,
+				FinishedDate: finishedAt,
+				CicdScopeId:  projectId,
+			}
+			domainPipelineCommit := &devops.CiCDPipelineCommit{
+				PipelineId: deploymentId,
+				CommitSha:  deployment.Sha,
+				Branch:     deployment.Ref,
+				RepoId:     projectId,
+			}
+			domainTask := &devops.CICDTask{
+				DomainEntity: domainlayer.DomainEntity{Id: deploymentId},
+				Name:         name,
+				PipelineId:   deploymentId,
+				Result:       result,
+				Status:       status,
+				Type:         devops.DEPLOYMENT,
+				Environment:  environment,
+				DurationSec:  uint64(deployment.Duration),
+				StartedDate:  *startedAt,
+				FinishedDate: finishedAt,
+				CicdScopeId:  projectId,
+			}
+			if finishedAt != nil {
+				domainPipeline.DurationSec = uint64(finishedAt.Sub(*deployment.GitlabCreatedAt).Seconds())
+				if domainTask.DurationSec == 0 {
+					domainTask.DurationSec = uint64(finishedAt.Sub(*startedAt).Seconds())
+				}
+			}
+
+			return []interface{}{
+				domainPipeline,
+				domainPipelineCommit,
+				domainTask,
+			}, nil
+		},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
+
+// getEnvironmentByTier maps the deployment tier of gitlab environments to the environments of domain layer, the
+// environment name is used when the tier is unknown, like environments were not collected
+func getEnvironmentByTier(tier string, name string) string {
+	environment := tier
+	if environment == "" {
+		environment = strings.ToLower(name)
+	}
+	switch environment {
+	case "production":
+		return devops.PRODUCTION
+	case "staging":
+		return devops.STAGING
+	case "testing":
+		return devops.TESTING
+	}
+	if tier == "" {
+		return name
+	}
+	return tier
+}
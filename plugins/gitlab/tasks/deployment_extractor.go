@@ -0,0 +1,120 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/plugins/core"
+	"github.com/apache/incubator-devlake/plugins/gitlab/models"
+	"github.com/apache/incubator-devlake/plugins/helper"
+)
+
+type ApiDeployment struct {
+	Id     int    `json:"id"`
+	Iid    int    `json:"iid"`
+	Ref    string `json:"ref"`
+	Sha    string `json:"sha"`
+	Status string `json:"status"`
+	User   *struct {
+		Id       int    `json:"id"`
+		Username string `json:"username"`
+	} `json:"user"`
+	Environment *struct {
+		Id   int    `json:"id"`
+		Name string `json:"name"`
+	} `json:"environment"`
+	Deployable *struct {
+		Id         int                 `json:"id"`
+		Name       string              `json:"name"`
+		Duration   float64             `json:"duration"`
+		StartedAt  *helper.Iso8601Time `json:"started_at"`
+		FinishedAt *helper.Iso8601Time `json:"finished_at"`
+		Pipeline   struct {
+			Id int `json:"id"`
+		} `json:"pipeline"`
+	} `json:"deployable"`
+
+	CreatedAt *helper.Iso8601Time `json:"created_at"`
+	UpdatedAt *helper.Iso8601Time `json:"updated_at"`
+}
+
+var ExtractApiDeploymentsMeta = core.SubTaskMeta{
+	Name:             "extractApiDeployments",
+	EntryPoint:       ExtractApiDeployments,
+	EnabledByDefault: true,
+	Description:      "Extract raw deployment data into tool layer table _tool_gitlab_deployments",
+	DomainTypes:      []string{core.DOMAIN_TYPE_CICD},
+}
+
+func ExtractApiDeployments(taskCtx core.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_DEPLOYMENT_TABLE)
+
+	extractor, err := helper.NewApiExtractor(helper.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *helper.RawData) ([]interface{}, errors.Error) {
+			apiDeployment := &ApiDeployment{}
+			err := errors.Convert(json.Unmarshal(row.Data, apiDeployment))
+			if err != nil {
+				return nil, err
+			}
+
+			gitlabDeployment := convertDeployment(apiDeployment)
+			gitlabDeployment.ConnectionId = data.Options.ConnectionId
+			gitlabDeployment.ProjectId = data.Options.ProjectId
+
+			return []interface{}{gitlabDeployment}, nil
+		},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return extractor.Execute()
+}
+
+func convertDeployment(deployment *ApiDeployment) *models.GitlabDeployment {
+	gitlabDeployment := &models.GitlabDeployment{
+		GitlabId:        deployment.Id,
+		Iid:             deployment.Iid,
+		Status:          deployment.Status,
+		Ref:             deployment.Ref,
+		Sha:             deployment.Sha,
+		GitlabCreatedAt: helper.Iso8601TimeToTime(deployment.CreatedAt),
+		GitlabUpdatedAt: helper.Iso8601TimeToTime(deployment.UpdatedAt),
+	}
+	if deployment.User != nil {
+		gitlabDeployment.UserId = deployment.User.Id
+		gitlabDeployment.Username = deployment.User.Username
+	}
+	if deployment.Environment != nil {
+		gitlabDeployment.EnvironmentId = deployment.Environment.Id
+		gitlabDeployment.EnvironmentName = deployment.Environment.Name
+	}
+	// deployments created through the api directly have no job behind them
+	if deployment.Deployable != nil {
+		gitlabDeployment.DeployableId = deployment.Deployable.Id
+		gitlabDeployment.DeployableName = deployment.Deployable.Name
+		gitlabDeployment.PipelineId = deployment.Deployable.Pipeline.Id
+		gitlabDeployment.Duration = deployment.Deployable.Duration
+		gitlabDeployment.StartedAt = helper.Iso8601TimeToTime(deployment.Deployable.StartedAt)
+		gitlabDeployment.FinishedAt = helper.Iso8601TimeToTime(deployment.Deployable.FinishedAt)
+	}
+	return gitlabDeployment
+}
@@ -0,0 +1,82 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/plugins/core"
+	"github.com/apache/incubator-devlake/plugins/gitlab/models"
+	"github.com/apache/incubator-devlake/plugins/helper"
+)
+
+type ApiEnvironment struct {
+	Id          int    `json:"id"`
+	Name        string `json:"name"`
+	Slug        string `json:"slug"`
+	ExternalUrl string `json:"external_url"`
+	State       string `json:"state"`
+	Tier        string `json:"tier"`
+
+	CreatedAt *helper.Iso8601Time `json:"created_at"`
+	UpdatedAt *helper.Iso8601Time `json:"updated_at"`
+}
+
+var ExtractApiEnvironmentsMeta = core.SubTaskMeta{
+	Name:             "extractApiEnvironments",
+	EntryPoint:       ExtractApiEnvironments,
+	EnabledByDefault: true,
+	Description:      "Extract raw environment data into tool layer table _tool_gitlab_environments",
+	DomainTypes:      []string{core.DOMAIN_TYPE_CICD},
+}
+
+func ExtractApiEnvironments(taskCtx core.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_ENVIRONMENT_TABLE)
+
+	extractor, err := helper.NewApiExtractor(helper.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *helper.RawData) ([]interface{}, errors.Error) {
+			apiEnvironment := &ApiEnvironment{}
+			err := errors.Convert(json.Unmarshal(row.Data, apiEnvironment))
+			if err != nil {
+				return nil, err
+			}
+
+			gitlabEnvironment := &models.GitlabEnvironment{
+				ConnectionId:    data.Options.ConnectionId,
+				GitlabId:        apiEnvironment.Id,
+				ProjectId:       data.Options.ProjectId,
+				Name:            apiEnvironment.Name,
+				Slug:            apiEnvironment.Slug,
+				ExternalUrl:     apiEnvironment.ExternalUrl,
+				State:           apiEnvironment.State,
+				Tier:            apiEnvironment.Tier,
+				GitlabCreatedAt: helper.Iso8601TimeToTime(apiEnvironment.CreatedAt),
+				GitlabUpdatedAt: helper.Iso8601TimeToTime(apiEnvironment.UpdatedAt),
+			}
+
+			return []interface{}{gitlabEnvironment}, nil
+		},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return extractor.Execute()
+}
@@ -18,8 +18,13 @@ limitations under the License.
 package tasks
 
 import (
+	"net/url"
+	"reflect"
+	"time"
+
 	"github.com/apache/incubator-devlake/errors"
 	"github.com/apache/incubator-devlake/plugins/core"
+	"github.com/apache/incubator-devlake/plugins/core/dal"
 	"github.com/apache/incubator-devlake/plugins/helper"
 )
 
@@ -33,23 +38,85 @@ var CollectApiJobsMeta = core.SubTaskMeta{
 	DomainTypes:      []string{core.DOMAIN_TYPE_CICD},
 }
 
+// CollectApiJobs collects all jobs of the project page by page on a full collection. The jobs api takes no time
+// filter, so an incremental collection requests jobs of the pipelines updated within the time window instead, the
+// updated_at of a pipeline changes along with its jobs
 func CollectApiJobs(taskCtx core.SubTaskContext) errors.Error {
 	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_JOB_TABLE)
+	collectorWithState, err := helper.NewApiCollectorWithState(*rawDataSubTaskArgs, data.Since)
+	if err != nil {
+		return err
+	}
+	timeWindow := &helper.IncrementalTimeWindow{
+		Overlap: 5 * time.Minute,
+	}
 
-	collector, err := helper.NewApiCollector(helper.ApiCollectorArgs{
-		RawDataSubTaskArgs: *rawDataSubTaskArgs,
-		ApiClient:          data.ApiClient,
-		PageSize:           100,
-		Incremental:        false,
-		UrlTemplate:        "projects/{{ .Params.ProjectId }}/jobs",
-		Query:              GetQuery,
-		ResponseParser:     GetRawMessageFromResponse,
-		AfterResponse:      ignoreHTTPStatus403, // ignore 403 for CI/CD disable
-	})
+	if !collectorWithState.CanIncrementCollect() {
+		err = collectorWithState.InitCollector(helper.ApiCollectorArgs{
+			ApiClient:      data.ApiClient,
+			PageSize:       100,
+			TimeWindow:     timeWindow,
+			UrlTemplate:    "projects/{{ .Params.ProjectId }}/jobs",
+			Query:          GetQuery,
+			ResponseParser: GetRawMessageFromResponse,
+			AfterResponse:  ignoreHTTPStatus403, // ignore 403 for CI/CD disable
+		})
+		if err != nil {
+			return err
+		}
+		return collectorWithState.Execute()
+	}
 
+	iterator, err := GetPipelinesIterator(taskCtx, collectorWithState.TimeWindowSince(timeWindow))
 	if err != nil {
 		return err
 	}
+	defer iterator.Close()
+
+	err = collectorWithState.InitCollector(helper.ApiCollectorArgs{
+		ApiClient:   data.ApiClient,
+		PageSize:    100,
+		Input:       iterator,
+		TimeWindow:  timeWindow,
+		UrlTemplate: "projects/{{ .Params.ProjectId }}/pipelines/{{ .Input.GitlabId }}/jobs",
+		Query: func(reqData *helper.RequestData) (url.Values, errors.Error) {
+			query, err := GetQuery(reqData)
+			if err != nil {
+				return nil, err
+			}
+			query.Set("include_retried", "true")
+			return query, nil
+		},
+		GetTotalPages:  GetTotalPagesFromResponse,
+		ResponseParser: GetRawMessageFromResponse,
+		AfterResponse:  ignoreHTTPStatus403, // ignore 403 for CI/CD disable
+	})
+	if err != nil {
+		return err
+	}
+
+	return collectorWithState.Execute()
+}
+
+// GetPipelinesIterator iterates pipelines of the project updated since the given time, or all of them if it is nil
+func GetPipelinesIterator(taskCtx core.SubTaskContext, since *time.Time) (*helper.DalCursorIterator, errors.Error) {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*GitlabTaskData)
+	clauses := []dal.Clause{
+		dal.Select("gp.gitlab_id"),
+		dal.From("_tool_gitlab_pipelines gp"),
+		dal.Where(
+			`gp.project_id = ? and gp.connection_id = ?`,
+			data.Options.ProjectId, data.Options.ConnectionId,
+		),
+	}
+	if since != nil {
+		clauses = append(clauses, dal.Where("gp.gitlab_updated_at >= ?", *since))
+	}
+	cursor, err := db.Cursor(clauses...)
+	if err != nil {
+		return nil, err
+	}
 
-	return collector.Execute()
+	return helper.NewDalCursorIterator(db, cursor, reflect.TypeOf(GitlabInput{}))
 }
@@ -68,6 +68,8 @@ func ExtractApiMergeRequestsNotes(taskCtx core.SubTaskContext) errors.Error {
 				return nil, err
 			}
 			results := make([]interface{}, 0, 2)
+			// approvals are taken from these system notes since they tell when the approval was made, which the
+			// approvals api doesn't, approval rules are not collected
 			if !toolMrNote.IsSystem || toolMrNote.Body == "approved this merge request" || toolMrNote.Body == "unapproved this merge request" {
 				toolMrComment := &models.GitlabMrComment{
 					GitlabId:        toolMrNote.GitlabId,
@@ -18,15 +18,13 @@ limitations under the License.
 package tasks
 
 import (
-	goerror "errors"
 	"fmt"
+	"net/url"
+	"time"
+
 	"github.com/apache/incubator-devlake/errors"
 	"github.com/apache/incubator-devlake/plugins/core"
-	"github.com/apache/incubator-devlake/plugins/core/dal"
-	"github.com/apache/incubator-devlake/plugins/gitlab/models"
 	"github.com/apache/incubator-devlake/plugins/helper"
-	"gorm.io/gorm"
-	"net/url"
 )
 
 const RAW_PIPELINE_TABLE = "gitlab_api_pipeline"
@@ -40,39 +38,23 @@ var CollectApiPipelinesMeta = core.SubTaskMeta{
 }
 
 func CollectApiPipelines(taskCtx core.SubTaskContext) errors.Error {
-	db := taskCtx.GetDal()
 	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_PIPELINE_TABLE)
-
-	since := data.Since
-	incremental := false
-	// user didn't specify a time range to sync, try load from database
-	if since == nil {
-		var latestUpdated models.GitlabPipeline
-		clause := []dal.Clause{
-			dal.Orderby("gitlab_updated_at DESC"),
-		}
-		err := db.First(&latestUpdated, clause...)
-		if err != nil && !goerror.Is(err, gorm.ErrRecordNotFound) {
-			return errors.Default.Wrap(err, "failed to get latest gitlab pipeline record")
-		}
-		if latestUpdated.GitlabId > 0 {
-			since = latestUpdated.GitlabUpdatedAt
-			incremental = true
-		}
+	collectorWithState, err := helper.NewApiCollectorWithState(*rawDataSubTaskArgs, data.Since)
+	if err != nil {
+		return err
 	}
 
-	collector, err := helper.NewApiCollector(helper.ApiCollectorArgs{
-		RawDataSubTaskArgs: *rawDataSubTaskArgs,
-		ApiClient:          data.ApiClient,
-		Concurrency:        5,
-		PageSize:           100,
-		Incremental:        incremental,
-		UrlTemplate:        "projects/{{ .Params.ProjectId }}/pipelines",
+	err = collectorWithState.InitCollector(helper.ApiCollectorArgs{
+		ApiClient:   data.ApiClient,
+		Concurrency: 5,
+		PageSize:    100,
+		UrlTemplate: "projects/{{ .Params.ProjectId }}/pipelines",
+		TimeWindow: &helper.IncrementalTimeWindow{
+			QueryParam: "updated_after",
+			Overlap:    5 * time.Minute,
+		},
 		Query: func(reqData *helper.RequestData) (url.Values, errors.Error) {
 			query := url.Values{}
-			if since != nil {
-				query.Set("updated_after", since.String())
-			}
 			query.Set("with_stats", "true")
 			query.Set("sort", "asc")
 			query.Set("page", fmt.Sprintf("%v", reqData.Pager.Page))
@@ -82,10 +64,9 @@ func CollectApiPipelines(taskCtx core.SubTaskContext) errors.Error {
 		ResponseParser: GetRawMessageFromResponse,
 		AfterResponse:  ignoreHTTPStatus403, // ignore 403 for CI/CD disable
 	})
-
 	if err != nil {
 		return err
 	}
 
-	return collector.Execute()
+	return collectorWithState.Execute()
 }
@@ -43,6 +43,7 @@ type ApiAsyncClient struct {
 	maxRetry     int
 	scheduler    *WorkerScheduler
 	numOfWorkers int
+	limiter      *ConnectionRateLimiter
 }
 
 const defaultTimeout = 120 * time.Second
@@ -106,6 +107,15 @@ func CreateAsyncApiClient(
 		requests,
 		duration,
 	)
+	var limiter *ConnectionRateLimiter
+	// clients working on the same connection draw from one quota, no matter which pipeline or process they belong to
+	if connectionCtx, ok := taskCtx.(core.ConnectionAwareTaskContext); ok && connectionCtx.GetConnectionKey() != "" {
+		store, storeErr := GetRateLimitStore(taskCtx)
+		if storeErr != nil {
+			return nil, storeErr
+		}
+		limiter = NewConnectionRateLimiter(store, connectionCtx.GetConnectionKey(), requests, duration, rateLimiter.DynamicRemaining)
+	}
 	scheduler, err := NewWorkerScheduler(
 		taskCtx.GetContext(),
 		numOfWorkers,
@@ -123,6 +133,7 @@ func CreateAsyncApiClient(
 		retry,
 		scheduler,
 		numOfWorkers,
+		limiter,
 	}, nil
 }
 
@@ -155,7 +166,17 @@ func (apiClient *ApiAsyncClient) DoAsync(
 		var respBody []byte
 
 		apiClient.logger.Debug("endpoint: %s  method: %s  header: %s  body: %s query: %s", path, method, header, body, query)
+		if apiClient.limiter != nil {
+			if err := apiClient.limiter.Wait(apiClient.scheduler.ctx); err != nil {
+				return err
+			}
+		}
 		res, err = apiClient.Do(method, path, query, body, header)
+		if err == nil && apiClient.limiter != nil {
+			if e := apiClient.limiter.Observe(res); e != nil {
+				apiClient.logger.Warn(e, "failed to adjust rate limit quota")
+			}
+		}
 		// make sure response body is read successfully, or we might have to retry
 		if err == nil {
 			// make sure response.Body stream will be closed to avoid running out of file handle
@@ -178,7 +199,11 @@ func (apiClient *ApiAsyncClient) DoAsync(
 			needRetry = true
 		} else if res.StatusCode >= HttpMinStatusRetryCode {
 			needRetry = true
-			err = errors.HttpStatus(res.StatusCode).New(fmt.Sprintf("Http DoAsync error: %s", body))
+			errType := errors.HttpStatus(res.StatusCode)
+			if res.StatusCode == http.StatusUnauthorized && isTokenExpired(res) {
+				errType = errors.TokenExpired
+			}
+			err = errType.New(fmt.Sprintf("Http DoAsync error: %s", body))
 		}
 
 		//  if it needs retry, check and retry
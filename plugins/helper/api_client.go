@@ -296,6 +296,21 @@ func (apiClient *ApiClient) Do(
 	return res, nil
 }
 
+// isTokenExpired tells whether the 401 response is caused by an expired token rather than an invalid one, according to
+// the `WWW-Authenticate` header or the error message in the body, e.g. `{"error_description":"Token is expired"}`
+func isTokenExpired(res *http.Response) bool {
+	if strings.Contains(strings.ToLower(res.Header.Get("WWW-Authenticate")), "expired") {
+		return true
+	}
+	if res.Body == nil {
+		return false
+	}
+	body, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	res.Body = io.NopCloser(bytes.NewBuffer(body))
+	return err == nil && bytes.Contains(bytes.ToLower(body), []byte("expired"))
+}
+
 // Get FIXME ...
 func (apiClient *ApiClient) Get(
 	path string,
@@ -26,6 +26,7 @@ import (
 	"net/http"
 	"net/url"
 	"text/template"
+	"time"
 
 	"github.com/apache/incubator-devlake/plugins/core"
 	"github.com/apache/incubator-devlake/plugins/core/dal"
@@ -45,6 +46,10 @@ type RequestData struct {
 	Params    interface{}
 	Input     interface{}
 	InputJSON []byte
+	// Since is the start of the incremental time window, nil means records should be collected regardless of time
+	Since *time.Time
+	// CustomData is returned by `GetNextPageCustomData` from the response of the previous page, like a cursor
+	CustomData interface{}
 }
 
 // AsyncResponseHandler FIXME ...
@@ -65,6 +70,9 @@ type ApiCollectorArgs struct {
 	PageSize int
 	// Incremental indicate if this is a incremental collection, the existing data won't get deleted if it was true
 	Incremental bool `comment:""`
+	// TimeWindow declares how to request records updated within a time window, `ApiCollectorStateManager` would
+	// decide the window and whether to collect incrementally based on state of the last successful collection
+	TimeWindow *IncrementalTimeWindow
 	// ApiClient is a asynchronize api request client with qps
 	ApiClient RateLimitedApiClient
 	// Input helps us collect data based on previous collected data, like collecting changelogs based on jira
@@ -73,6 +81,10 @@ type ApiCollectorArgs struct {
 	// GetTotalPages is to tell `ApiCollector` total number of pages based on response of the first page.
 	// so `ApiCollector` could collect those pages in parallel for us
 	GetTotalPages func(res *http.Response, args *ApiCollectorArgs) (int, errors.Error)
+	// GetNextPageCustomData is for APIs paging with a cursor returned by the previous page, like a continuation
+	// token. Pages would be requested one after another, the returned value can be accessed by `Query` and `Header`
+	// through `RequestData.CustomData`, and returning nil means there are no more pages
+	GetNextPageCustomData func(prevReqData *RequestData, prevPageResponse *http.Response) (interface{}, errors.Error)
 	// Concurrency specify qps for api that doesn't return total number of pages/records
 	// NORMALLY, DO NOT SPECIFY THIS PARAMETER, unless you know what it means
 	Concurrency    int
@@ -87,6 +99,11 @@ type ApiCollector struct {
 	*RawDataSubTask
 	args        *ApiCollectorArgs
 	urlTemplate *template.Template
+	// since is the start of the time window decided by ApiCollectorStateManager
+	since *time.Time
+	// persistedPages holds number of records of pages persisted by the failed attempt we are resuming,
+	// keyed by the normalized input and then the page number
+	persistedPages map[string]map[int]int
 }
 
 // NewApiCollector allocates a new ApiCollector with the given args.
@@ -122,6 +139,9 @@ func NewApiCollector(args ApiCollectorArgs) (*ApiCollector, errors.Error) {
 	} else if apiCollector.GetAfterResponse() == nil {
 		apiCollector.SetAfterResponse(func(res *http.Response) errors.Error {
 			if res.StatusCode == http.StatusUnauthorized {
+				if isTokenExpired(res) {
+					return errors.TokenExpired.New("authentication failed, the AccessToken has expired")
+				}
 				return errors.Unauthorized.New("authentication failed, please check your AccessToken")
 			}
 			return nil
@@ -142,11 +162,37 @@ func (collector *ApiCollector) Execute() errors.Error {
 		return errors.Default.Wrap(err, "error auto-migrating collector")
 	}
 
-	// flush data if not incremental collection
-	if !collector.args.Incremental {
-		err = db.Delete(&RawData{}, dal.From(collector.table), dal.Where("params = ?", collector.params))
+	// pick up pages persisted by the failed attempt if we are resuming, or flush data if not incremental collection
+	var resumeSince *time.Time
+	if resumable, ok := collector.args.Ctx.(core.ResumableSubTaskContext); ok {
+		resumeSince = resumable.ResumeSince()
+	}
+	if resumeSince != nil {
+		// only pages persisted by the failed attempt are kept, older ones are flushed as usual
+		if !collector.args.Incremental {
+			err = db.Delete(
+				&RawData{},
+				dal.From(collector.table),
+				dal.Where("params = ? AND created_at < ?", collector.params, *resumeSince),
+			)
+			if err != nil {
+				return errors.Default.Wrap(err, "error deleting data from collector")
+			}
+		}
+		err = collector.loadPersistedPages(*resumeSince)
 		if err != nil {
-			return errors.Default.Wrap(err, "error deleting data from collector")
+			return errors.Default.Wrap(err, "error loading pages persisted by previous attempt")
+		}
+	} else {
+		if !collector.args.Incremental {
+			err = db.Delete(&RawData{}, dal.From(collector.table), dal.Where("params = ?", collector.params))
+			if err != nil {
+				return errors.Default.Wrap(err, "error deleting data from collector")
+			}
+		}
+		err = collector.recordCollection()
+		if err != nil {
+			return errors.Default.Wrap(err, "error recording collection")
 		}
 	}
 
@@ -194,6 +240,62 @@ func (collector *ApiCollector) Execute() errors.Error {
 	return err
 }
 
+// loadPersistedPages loads pages saved into the raw table since the failed attempt started, so they won't be
+// requested again
+func (collector *ApiCollector) loadPersistedPages(since time.Time) errors.Error {
+	db := collector.args.Ctx.GetDal()
+	cursor, err := db.Cursor(
+		dal.Select("input, page"),
+		dal.From(collector.table),
+		dal.Where("params = ? AND created_at >= ?", collector.params, since),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+	collector.persistedPages = make(map[string]map[int]int)
+	total := 0
+	for cursor.Next() {
+		row := &RawData{}
+		err = db.Fetch(cursor, row)
+		if err != nil {
+			return err
+		}
+		input := normalizeRawInput(row.Input)
+		if collector.persistedPages[input] == nil {
+			collector.persistedPages[input] = make(map[int]int)
+		}
+		if collector.persistedPages[input][row.Page] == 0 {
+			total++
+		}
+		collector.persistedPages[input][row.Page]++
+	}
+	collector.args.Ctx.GetLogger().Info("resuming collection, %d pages were persisted since %v", total, since)
+	return nil
+}
+
+// persistedPageCount returns number of records of the page if it was persisted by the failed attempt
+func (collector *ApiCollector) persistedPageCount(reqData *RequestData) (int, bool) {
+	if collector.persistedPages == nil {
+		return 0, false
+	}
+	count, ok := collector.persistedPages[normalizeRawInput(reqData.InputJSON)][reqData.Pager.Page]
+	return count, ok
+}
+
+// normalizeRawInput re-encodes the json input, since database might reformat json columns
+func normalizeRawInput(input []byte) string {
+	var v interface{}
+	if json.Unmarshal(input, &v) != nil {
+		return string(input)
+	}
+	normalized, err := json.Marshal(v)
+	if err != nil {
+		return string(input)
+	}
+	return string(normalized)
+}
+
 func (collector *ApiCollector) exec(input interface{}) {
 	inputJson, err := json.Marshal(input)
 	if err != nil {
@@ -206,7 +308,9 @@ func (collector *ApiCollector) exec(input interface{}) {
 		Page: 1,
 		Size: collector.args.PageSize,
 	}
-	if collector.args.PageSize <= 0 {
+	if collector.args.GetNextPageCustomData != nil {
+		collector.fetchPagesSequentially(reqData)
+	} else if collector.args.PageSize <= 0 {
 		collector.fetchAsync(reqData, nil)
 	} else if collector.args.GetTotalPages != nil {
 		collector.fetchPagesDetermined(reqData)
@@ -243,6 +347,33 @@ func (collector *ApiCollector) fetchPagesDetermined(reqData *RequestData) {
 	})
 }
 
+// fetchPagesSequentially fetches data of all pages for APIs that page with a cursor returned by the previous page
+func (collector *ApiCollector) fetchPagesSequentially(reqData *RequestData) {
+	collector.fetchAsync(reqData, func(count int, body []byte, res *http.Response) errors.Error {
+		customData, err := collector.args.GetNextPageCustomData(reqData, res)
+		if err != nil {
+			return errors.Default.Wrap(err, "fetchPagesSequentially get next page custom data failed")
+		}
+		if customData == nil {
+			return nil
+		}
+		collector.args.ApiClient.NextTick(func() errors.Error {
+			collector.fetchPagesSequentially(&RequestData{
+				Pager: &Pager{
+					Page: reqData.Pager.Page + 1,
+					Skip: reqData.Pager.Skip + reqData.Pager.Size,
+					Size: reqData.Pager.Size,
+				},
+				Input:      reqData.Input,
+				InputJSON:  reqData.InputJSON,
+				CustomData: customData,
+			})
+			return nil
+		})
+		return nil
+	})
+}
+
 // fetchPagesUndetermined fetches data of all pages for APIs that do NOT return paging information
 func (collector *ApiCollector) fetchPagesUndetermined(reqData *RequestData) {
 	//logger := collector.args.Ctx.GetLogger()
@@ -278,6 +409,18 @@ func (collector *ApiCollector) fetchPagesUndetermined(reqData *RequestData) {
 		}
 		var collect func() errors.Error
 		collect = func() errors.Error {
+			// fast-forward through pages persisted by the failed attempt
+			for {
+				count, persisted := collector.persistedPageCount(&reqDataCopy)
+				if !persisted {
+					break
+				}
+				if count < collector.args.PageSize {
+					return nil
+				}
+				reqDataCopy.Pager.Skip += collector.args.PageSize * concurrency
+				reqDataCopy.Pager.Page += concurrency
+			}
 			collector.fetchAsync(&reqDataCopy, func(count int, body []byte, res *http.Response) errors.Error {
 				if count < collector.args.PageSize {
 					return nil
@@ -301,6 +444,7 @@ func (collector *ApiCollector) generateUrl(pager *Pager, input interface{}) (str
 		Pager:  pager,
 		Params: collector.args.Params,
 		Input:  input,
+		Since:  collector.since,
 	})
 	if err != nil {
 		return "", errors.Convert(err)
@@ -326,6 +470,13 @@ func (collector *ApiCollector) fetchAsync(reqData *RequestData, handler func(int
 			Skip: 0,
 		}
 	}
+	_, persisted := collector.persistedPageCount(reqData)
+	if persisted && handler == nil {
+		// nothing to do with the page
+		collector.args.Ctx.IncProgress(1)
+		return
+	}
+	reqData.Since = collector.since
 	apiUrl, err := collector.generateUrl(reqData.Pager, reqData.Input)
 	if err != nil {
 		panic(err)
@@ -337,6 +488,12 @@ func (collector *ApiCollector) fetchAsync(reqData *RequestData, handler func(int
 			panic(err)
 		}
 	}
+	if collector.since != nil && collector.args.TimeWindow != nil && collector.args.TimeWindow.QueryParam != "" {
+		if apiQuery == nil {
+			apiQuery = url.Values{}
+		}
+		apiQuery.Set(collector.args.TimeWindow.QueryParam, collector.args.TimeWindow.format(*collector.since))
+	}
 	var reqBody interface{}
 	if collector.args.RequestBody != nil {
 		reqBody = collector.args.RequestBody(reqData)
@@ -357,6 +514,9 @@ func (collector *ApiCollector) fetchAsync(reqData *RequestData, handler func(int
 	responseHandler := func(res *http.Response) errors.Error {
 		defer logger.Debug("fetchAsync >>> done for %s %v %v", apiUrl, apiQuery, collector.args.RequestBody)
 		logger := collector.args.Ctx.GetLogger()
+		if recorder, ok := collector.args.Ctx.(core.ApiCallsRecorder); ok {
+			recorder.IncApiCalls(1)
+		}
 		// read body to buffer
 		body, err := io.ReadAll(res.Body)
 		if err != nil {
@@ -375,6 +535,12 @@ func (collector *ApiCollector) fetchAsync(reqData *RequestData, handler func(int
 			collector.args.Ctx.IncProgress(1)
 			return nil
 		}
+		if persisted {
+			// the page was requested only for the handler, records were saved by the failed attempt
+			collector.args.Ctx.IncProgress(1)
+			res.Body = io.NopCloser(bytes.NewBuffer(body))
+			return handler(count, body, res)
+		}
 		db := collector.args.Ctx.GetDal()
 		urlString := res.Request.URL.String()
 		rows := make([]*RawData, count)
@@ -384,6 +550,7 @@ func (collector *ApiCollector) fetchAsync(reqData *RequestData, handler func(int
 				Data:   msg,
 				Url:    urlString,
 				Input:  reqData.InputJSON,
+				Page:   reqData.Pager.Page,
 			}
 		}
 		err = db.Create(rows, dal.From(collector.table))
@@ -24,9 +24,11 @@ import (
 	"net/http"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/apache/incubator-devlake/helpers/unithelper"
 	"github.com/apache/incubator-devlake/mocks"
+	"github.com/apache/incubator-devlake/plugins/core/dal"
 	"github.com/apache/incubator-devlake/plugins/helper/common"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -36,6 +38,7 @@ func TestFetchPageUndetermined(t *testing.T) {
 	mockDal := new(mocks.Dal)
 	mockDal.On("AutoMigrate", mock.Anything, mock.Anything).Return(nil).Once()
 	mockDal.On("Delete", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+	mockDal.On("Create", mock.AnythingOfType("*models.RawDataCollection"), mock.Anything).Return(nil).Once()
 	mockDal.On("Create", mock.Anything, mock.Anything).Return(nil).Once()
 
 	mockCtx := unithelper.DummySubTaskContext(mockDal)
@@ -99,3 +102,81 @@ func TestFetchPageUndetermined(t *testing.T) {
 
 	mockDal.AssertExpectations(t)
 }
+
+func TestExecuteResumingFlushesEarlierRuns(t *testing.T) {
+	since := time.Date(2022, 12, 1, 0, 0, 0, 0, time.UTC)
+	mockRows := new(mocks.Rows)
+	mockRows.On("Next").Return(false)
+	mockRows.On("Close").Return(nil)
+	mockDal := new(mocks.Dal)
+	mockDal.On("AutoMigrate", mock.Anything, mock.Anything).Return(nil).Once()
+	mockDal.On("Cursor", mock.Anything).Return(mockRows, nil).Once()
+	// raw data of the earlier runs should be deleted, pages persisted by the failed attempt should be kept
+	mockDal.On("Delete", mock.Anything, mock.MatchedBy(func(clauses []dal.Clause) bool {
+		for _, clause := range clauses {
+			if clause.Type == dal.WhereClause {
+				data := clause.Data.(dal.DalClause)
+				return data.Expr == "params = ? AND created_at < ?" && data.Params[1] == since
+			}
+		}
+		return false
+	})).Return(nil).Once()
+
+	mockCtx := new(mocks.ResumableSubTaskContext)
+	mockCtx.On("GetDal").Return(mockDal)
+	mockCtx.On("GetLogger").Return(unithelper.DummyLogger())
+	mockCtx.On("SetProgress", mock.Anything, mock.Anything)
+	mockCtx.On("GetName").Return("test")
+	mockCtx.On("ResumeSince").Return(&since)
+
+	mockInput := new(mocks.Iterator)
+	mockInput.On("HasNext").Return(false)
+	mockInput.On("Close").Return(nil)
+
+	mockApi := new(mocks.RateLimitedApiClient)
+	mockApi.On("HasError").Return(false)
+	mockApi.On("WaitAsync").Return(nil)
+	mockApi.On("GetAfterFunction", mock.Anything).Return(nil)
+	mockApi.On("SetAfterFunction", mock.Anything).Return()
+
+	collector, err := NewApiCollector(ApiCollectorArgs{
+		RawDataSubTaskArgs: RawDataSubTaskArgs{
+			Ctx:    mockCtx,
+			Table:  "whatever rawtable",
+			Params: struct{ Name string }{Name: "testparams"},
+		},
+		ApiClient:      mockApi,
+		Input:          mockInput,
+		UrlTemplate:    "whatever url",
+		ResponseParser: GetRawMessageArrayFromResponse,
+	})
+
+	assert.Nil(t, err)
+	assert.Nil(t, collector.Execute())
+
+	mockDal.AssertExpectations(t)
+}
+
+func TestIsTokenExpired(t *testing.T) {
+	res := &http.Response{
+		StatusCode: http.StatusUnauthorized,
+		Header:     http.Header{},
+		Body:       ioutil.NopCloser(bytes.NewBufferString(`{"error":"invalid_token","error_description":"Token is expired. You can either do re-authorization or token refresh."}`)),
+	}
+	assert.True(t, isTokenExpired(res))
+	// the body is still readable afterwards
+	body, err := ioutil.ReadAll(res.Body)
+	assert.Nil(t, err)
+	assert.Contains(t, string(body), "invalid_token")
+
+	res = &http.Response{StatusCode: http.StatusUnauthorized, Header: http.Header{}}
+	res.Header.Set("WWW-Authenticate", `Bearer error="invalid_token", error_description="The access token expired"`)
+	assert.True(t, isTokenExpired(res))
+
+	res = &http.Response{
+		StatusCode: http.StatusUnauthorized,
+		Header:     http.Header{},
+		Body:       ioutil.NopCloser(bytes.NewBufferString(`{"message":"Bad credentials"}`)),
+	}
+	assert.False(t, isTokenExpired(res))
+}
@@ -29,6 +29,8 @@ import (
 type ApiCollectorStateManager struct {
 	RawDataSubTaskArgs
 	*ApiCollector
+	// graphqlCollector is used instead of the ApiCollector if initialized by InitGraphQLCollector
+	graphqlCollector *GraphqlCollector
 	LatestState      models.CollectorLatestState
 	CreatedDateAfter *time.Time
 	ExecuteStart     time.Time
@@ -65,23 +67,86 @@ func NewApiCollectorWithState(args RawDataSubTaskArgs, createdDateAfter *time.Ti
 // CanIncrementCollect return if the old data can support collect incrementally.
 // only when latest collection is success &&
 // (m.LatestState.CreatedDateAfter == nil means all data have been collected ||
-// CreatedDateAfter at this time exists and later than in the LatestState)
+// CreatedDateAfter at this time exists and not earlier than in the LatestState)
 // if CreatedDateAfter at this time not exists, collect incrementally only when "m.LatestState.CreatedDateAfter == nil"
 func (m ApiCollectorStateManager) CanIncrementCollect() bool {
 	return m.LatestState.LatestSuccessStart != nil &&
-		(m.LatestState.CreatedDateAfter == nil || m.CreatedDateAfter != nil && m.CreatedDateAfter.After(*m.LatestState.CreatedDateAfter))
+		(m.LatestState.CreatedDateAfter == nil || m.CreatedDateAfter != nil && !m.CreatedDateAfter.Before(*m.LatestState.CreatedDateAfter))
+}
+
+// IncrementalTimeWindow declares how to request records updated within a time window, with which the
+// ApiCollectorStateManager would collect incrementally without any custom code in the plugin
+type IncrementalTimeWindow struct {
+	// QueryParam is the name of the query parameter accepting start of the window, i.e. `updated_after`.
+	// Leave it empty if the api takes the window in other ways, i.e. as part of a jql, and read
+	// `RequestData.Since` in `Query`, `UrlTemplate` or `RequestBody` instead
+	QueryParam string
+	// TimeFormat is the layout of the QueryParam value, time.RFC3339 would be used if omitted
+	TimeFormat string
+	// Overlap moves start of the window backward, so records updated during last collection would not be missed
+	Overlap time.Duration
+	// ClockSkew is the tolerated time difference between DevLake and the remote server
+	ClockSkew time.Duration
+}
+
+func (w *IncrementalTimeWindow) format(since time.Time) string {
+	if w.TimeFormat == "" {
+		return since.Format(time.RFC3339)
+	}
+	return since.Format(w.TimeFormat)
+}
+
+// TimeWindowSince returns start of the time window for the collection, records updated before it were collected by
+// the last successful collection. On a full collection, CreatedDateAfter is used since records created after it
+// could not be updated before it
+func (m ApiCollectorStateManager) TimeWindowSince(window *IncrementalTimeWindow) *time.Time {
+	if !m.CanIncrementCollect() {
+		return m.CreatedDateAfter
+	}
+	since := m.LatestState.LatestSuccessStart.Add(-window.Overlap - window.ClockSkew)
+	return &since
 }
 
 // InitCollector init the embedded collector
 func (m *ApiCollectorStateManager) InitCollector(args ApiCollectorArgs) (err errors.Error) {
 	args.RawDataSubTaskArgs = m.RawDataSubTaskArgs
+	if args.TimeWindow != nil {
+		args.Incremental = m.CanIncrementCollect()
+	}
 	m.ApiCollector, err = NewApiCollector(args)
-	return err
+	if err != nil {
+		return err
+	}
+	if args.TimeWindow != nil {
+		m.ApiCollector.since = m.TimeWindowSince(args.TimeWindow)
+	}
+	return nil
+}
+
+// InitGraphQLCollector init the graphql collector, which would be executed instead of the embedded ApiCollector
+func (m *ApiCollectorStateManager) InitGraphQLCollector(args GraphqlCollectorArgs) (err errors.Error) {
+	args.RawDataSubTaskArgs = m.RawDataSubTaskArgs
+	if args.TimeWindow != nil {
+		args.Incremental = m.CanIncrementCollect()
+	}
+	m.graphqlCollector, err = NewGraphqlCollector(args)
+	if err != nil {
+		return err
+	}
+	if args.TimeWindow != nil {
+		m.graphqlCollector.since = m.TimeWindowSince(args.TimeWindow)
+	}
+	return nil
 }
 
 // Execute the embedded collector and record execute state
 func (m ApiCollectorStateManager) Execute() errors.Error {
-	err := m.ApiCollector.Execute()
+	var err errors.Error
+	if m.graphqlCollector != nil {
+		err = m.graphqlCollector.Execute()
+	} else {
+		err = m.ApiCollector.Execute()
+	}
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,91 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeWindowSince(t *testing.T) {
+	createdDateAfter := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	latestSuccessStart := time.Date(2022, 12, 1, 8, 0, 0, 0, time.UTC)
+	window := &IncrementalTimeWindow{
+		QueryParam: "updated_after",
+		Overlap:    5 * time.Minute,
+		ClockSkew:  time.Minute,
+	}
+
+	// first collection starts from CreatedDateAfter
+	m := ApiCollectorStateManager{CreatedDateAfter: &createdDateAfter}
+	assert.Equal(t, &createdDateAfter, m.TimeWindowSince(window))
+
+	// collect everything if CreatedDateAfter was not specified
+	m = ApiCollectorStateManager{}
+	assert.Nil(t, m.TimeWindowSince(window))
+
+	// incremental collection starts from the last successful collection minus margins
+	m = ApiCollectorStateManager{
+		CreatedDateAfter: &createdDateAfter,
+		LatestState: models.CollectorLatestState{
+			CreatedDateAfter:   &createdDateAfter,
+			LatestSuccessStart: &latestSuccessStart,
+		},
+	}
+	expected := time.Date(2022, 12, 1, 7, 54, 0, 0, time.UTC)
+	assert.Equal(t, &expected, m.TimeWindowSince(window))
+	assert.Equal(t, "2022-12-01T07:54:00Z", window.format(expected))
+
+	// CreatedDateAfter moved backward, full collection is required
+	earlier := createdDateAfter.AddDate(-1, 0, 0)
+	m.CreatedDateAfter = &earlier
+	assert.Equal(t, &earlier, m.TimeWindowSince(window))
+}
+
+func TestCanIncrementCollect(t *testing.T) {
+	createdDateAfter := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	latestSuccessStart := time.Date(2022, 12, 1, 8, 0, 0, 0, time.UTC)
+	m := ApiCollectorStateManager{CreatedDateAfter: &createdDateAfter}
+	// never collected successfully
+	assert.False(t, m.CanIncrementCollect())
+
+	m.LatestState = models.CollectorLatestState{
+		CreatedDateAfter:   &createdDateAfter,
+		LatestSuccessStart: &latestSuccessStart,
+	}
+	// the same CreatedDateAfter as the last collection, which is the case for every scheduled run of a blueprint,
+	// everything required was collected by then
+	assert.True(t, m.CanIncrementCollect())
+	later := createdDateAfter.AddDate(0, 1, 0)
+	m.CreatedDateAfter = &later
+	assert.True(t, m.CanIncrementCollect())
+	earlier := createdDateAfter.AddDate(0, -1, 0)
+	m.CreatedDateAfter = &earlier
+	assert.False(t, m.CanIncrementCollect())
+	m.CreatedDateAfter = nil
+	assert.False(t, m.CanIncrementCollect())
+
+	// everything was collected last time
+	m.LatestState.CreatedDateAfter = nil
+	assert.True(t, m.CanIncrementCollect())
+	m.CreatedDateAfter = &createdDateAfter
+	assert.True(t, m.CanIncrementCollect())
+}
@@ -31,6 +31,9 @@ type ApiRateLimitCalculator struct {
 	Method                 string
 	ApiPath                string
 	DynamicRateLimit       func(res *http.Response) (int, time.Duration, errors.Error)
+	// DynamicRemaining extracts the remaining requests and the reset time from every response to adjust the quota
+	// shared by the connection, ParseRateLimitHeaders would be used if omitted
+	DynamicRemaining func(res *http.Response) (int, time.Time, bool)
 }
 
 // Calculate FIXME ...
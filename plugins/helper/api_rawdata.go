@@ -23,6 +23,7 @@ import (
 	"github.com/apache/incubator-devlake/errors"
 	"time"
 
+	"github.com/apache/incubator-devlake/models"
 	"github.com/apache/incubator-devlake/plugins/core"
 	"gorm.io/datatypes"
 )
@@ -34,6 +35,7 @@ type RawData struct {
 	Data      []byte
 	Url       string
 	Input     datatypes.JSON
+	Page      int
 	CreatedAt time.Time
 }
 
@@ -91,3 +93,19 @@ func (r *RawDataSubTask) GetTable() string {
 func (r *RawDataSubTask) GetParams() string {
 	return r.params
 }
+
+// recordCollection marks the start of a new collection into the raw table, raw data are retained by collections
+func (r *RawDataSubTask) recordCollection() errors.Error {
+	err := r.args.Ctx.GetDal().Create(&models.RawDataCollection{
+		RawDataTable:  r.table,
+		RawDataParams: r.params,
+		BeganAt:       time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+	if recorder, ok := r.args.Ctx.(core.RawDataCollectionRecorder); ok {
+		recorder.RecordRawDataCollection(r.table, r.params)
+	}
+	return nil
+}
@@ -37,6 +37,8 @@ type BatchSaveDivider struct {
 	batchSize int
 	table     string
 	params    string
+	// incrementalMode keeps the records saved by previous collections
+	incrementalMode bool
 }
 
 // NewBatchSaveDivider create a new BatchInsertDivider instance
@@ -53,6 +55,11 @@ func NewBatchSaveDivider(basicRes core.BasicRes, batchSize int, table string, pa
 	}
 }
 
+// SetIncrementalMode prevents outdated records from being deleted, for collectors saving records without extractors
+func (d *BatchSaveDivider) SetIncrementalMode(incrementalMode bool) {
+	d.incrementalMode = incrementalMode
+}
+
 // ForType returns a `BatchSave` instance for specific type
 func (d *BatchSaveDivider) ForType(rowType reflect.Type) (*BatchSave, errors.Error) {
 	// get the cache for the specific type
@@ -75,13 +82,15 @@ func (d *BatchSaveDivider) ForType(rowType reflect.Type) (*BatchSave, errors.Err
 			return nil, errors.Default.New(fmt.Sprintf("type %s must have RawDataOrigin embeded", rowElemType.Name()))
 		}
 		// all good, delete outdated records before we insertion
-		d.log.Debug("deleting outdate records for %s", rowElemType.Name())
-		err = d.db.Delete(
-			row,
-			dal.Where("_raw_data_table = ? AND _raw_data_params = ?", d.table, d.params),
-		)
-		if err != nil {
-			return nil, err
+		if !d.incrementalMode {
+			d.log.Debug("deleting outdate records for %s", rowElemType.Name())
+			err = d.db.Delete(
+				row,
+				dal.Where("_raw_data_table = ? AND _raw_data_params = ?", d.table, d.params),
+			)
+			if err != nil {
+				return nil, err
+			}
 		}
 	}
 	return batch, nil
@@ -70,7 +70,7 @@ type RestConnection struct {
 
 // ConnectionApiHelper is used to write the CURD of connection
 type ConnectionApiHelper struct {
-	encKey    string
+	basicRes  core.BasicRes
 	log       core.Logger
 	db        dal.Dal
 	validator *validator.Validate
@@ -85,7 +85,7 @@ func NewConnectionHelper(
 		vld = validator.New()
 	}
 	return &ConnectionApiHelper{
-		encKey:    basicRes.GetConfig(core.EncodeKeyEnvStr),
+		basicRes:  basicRes,
 		log:       basicRes.GetLogger(),
 		db:        basicRes.GetDal(),
 		validator: vld,
@@ -177,18 +177,20 @@ func (c *ConnectionApiHelper) save(connection interface{}) errors.Error {
 }
 
 func (c *ConnectionApiHelper) decrypt(connection interface{}) {
-	err := UpdateEncryptFields(connection, func(encrypted string) (string, errors.Error) {
-		return core.Decrypt(c.encKey, encrypted)
-	})
+	store, err := GetSecretStore(c.basicRes)
+	if err == nil {
+		err = UpdateEncryptFields(connection, store.Decrypt)
+	}
 	if err != nil {
 		c.log.Error(err, "failed to decrypt")
 	}
 }
 
 func (c *ConnectionApiHelper) encrypt(connection interface{}) {
-	err := UpdateEncryptFields(connection, func(plaintext string) (string, errors.Error) {
-		return core.Encrypt(c.encKey, plaintext)
-	})
+	store, err := GetSecretStore(c.basicRes)
+	if err == nil {
+		err = UpdateEncryptFields(connection, store.Encrypt)
+	}
 	if err != nil {
 		c.log.Error(err, "failed to encrypt")
 	}
@@ -216,7 +218,9 @@ func UpdateEncryptFields(val interface{}, update func(in string) (string, errors
 				return err
 			}
 		} else if field.Type.Kind() == reflect.Ptr && field.Type.Elem().Kind() == reflect.Struct {
-			fmt.Printf("field : %v\n", e.Field(i).Interface())
+			if e.Field(i).IsNil() {
+				continue
+			}
 			err := UpdateEncryptFields(e.Field(i).Interface(), update)
 			if err != nil {
 				return err
@@ -234,3 +238,31 @@ func UpdateEncryptFields(val interface{}, update func(in string) (string, errors
 	}
 	return nil
 }
+
+// HasEncryptFields checks if type t or any struct embedded in it has fields tagged with `encrypt:"yes|true"`
+func HasEncryptFields(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		switch field.Type.Kind() {
+		case reflect.Struct, reflect.Ptr:
+			if HasEncryptFields(field.Type) {
+				return true
+			}
+		case reflect.String:
+			tagValue := field.Tag.Get("encrypt")
+			if tagValue == "yes" || tagValue == "true" {
+				return true
+			}
+		}
+	}
+	return false
+}
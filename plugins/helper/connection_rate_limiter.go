@@ -0,0 +1,223 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helper
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/plugins/core"
+)
+
+// number of requests taken from the RateLimitStore at once, to save round trips to the store
+const rateLimitLeaseSize = 10
+
+// the longest time to wait before checking the quota again, it might be adjusted by other clients in the meantime
+const rateLimitMaxWait = time.Minute
+
+// GetConnectionKey returns the key identifying the connection the task is working on, tasks without
+// `connectionId` option aren't bound to any connection and empty string would be returned
+func GetConnectionKey(plugin string, options map[string]interface{}) string {
+	var connectionId string
+	switch id := options["connectionId"].(type) {
+	case float64:
+		connectionId = strconv.FormatFloat(id, 'f', -1, 64)
+	case int:
+		connectionId = strconv.Itoa(id)
+	case uint64:
+		connectionId = strconv.FormatUint(id, 10)
+	case string:
+		connectionId = id
+	}
+	if connectionId == "" || connectionId == "0" {
+		return ""
+	}
+	return fmt.Sprintf("%s#%s", plugin, connectionId)
+}
+
+// ConnectionRateLimiter draws requests from the quota of a connection kept in a core.RateLimitStore, and corrects
+// the quota with the rate limit information returned by the server. Observations are kept in memory and settled
+// with the store when new requests are leased, or right away when the server reports fewer remaining requests than
+// expected, which means other clients are drawing from the quota
+type ConnectionRateLimiter struct {
+	store     core.RateLimitStore
+	key       string
+	limit     int
+	period    time.Duration
+	remaining func(res *http.Response) (int, time.Time, bool)
+	mu        sync.Mutex
+	leased    int
+	settled   int // requests sent or given up out of the leases since the last settlement
+	expected  int // remaining requests expected by the server, -1 before the first observation
+	observed  bool
+	// the latest observation not settled yet
+	observedRemaining int
+	observedResetAt   time.Time
+}
+
+// NewConnectionRateLimiter creates a ConnectionRateLimiter for the connection `key` allowing `limit` requests every
+// `period`, `remaining` extracts the remaining quota from responses, ParseRateLimitHeaders would be used if nil
+func NewConnectionRateLimiter(
+	store core.RateLimitStore,
+	key string,
+	limit int,
+	period time.Duration,
+	remaining func(res *http.Response) (int, time.Time, bool),
+) *ConnectionRateLimiter {
+	if remaining == nil {
+		remaining = ParseRateLimitHeaders
+	}
+	return &ConnectionRateLimiter{
+		store:     store,
+		key:       key,
+		limit:     limit,
+		period:    period,
+		remaining: remaining,
+		expected:  -1,
+	}
+}
+
+// Wait blocks until a request is allowed by the quota of the connection or ctx is done
+func (l *ConnectionRateLimiter) Wait(ctx context.Context) errors.Error {
+	for {
+		l.mu.Lock()
+		if l.leased > 0 {
+			l.use()
+			l.mu.Unlock()
+			return nil
+		}
+		l.mu.Unlock()
+		// the quota is corrected before renewing the lease, so the store grants no more than the server allows
+		err := l.settle()
+		if err != nil {
+			return err
+		}
+		granted, resetAt, err := l.store.Take(l.key, rateLimitLeaseSize, l.limit, l.period)
+		if err != nil {
+			return err
+		}
+		if granted > 0 {
+			l.mu.Lock()
+			l.leased += granted
+			l.use()
+			l.mu.Unlock()
+			return nil
+		}
+		wait := time.Until(resetAt)
+		if wait > rateLimitMaxWait {
+			wait = rateLimitMaxWait
+		}
+		select {
+		case <-ctx.Done():
+			return errors.Convert(ctx.Err())
+		case <-time.After(wait):
+		}
+	}
+}
+
+// use takes one request out of the leases, l.mu must be held
+func (l *ConnectionRateLimiter) use() {
+	l.leased--
+	l.settled++
+	if l.expected > 0 {
+		l.expected--
+	}
+}
+
+// Observe records the quota reported by the response, it is settled with the store right away only if the server
+// allows fewer requests than expected
+func (l *ConnectionRateLimiter) Observe(res *http.Response) errors.Error {
+	remaining, resetAt, ok := l.remaining(res)
+	if !ok {
+		return nil
+	}
+	l.mu.Lock()
+	// the leases beyond what the server allows are given up
+	if l.leased > remaining {
+		l.settled += l.leased - remaining
+		l.leased = remaining
+	}
+	drained := l.expected >= 0 && remaining < l.expected
+	l.expected = remaining
+	l.observed = true
+	l.observedRemaining = remaining
+	l.observedResetAt = resetAt
+	l.mu.Unlock()
+	if !drained {
+		return nil
+	}
+	return l.settle()
+}
+
+// settle corrects the quota in the store with the latest observation, the store is called without holding l.mu
+func (l *ConnectionRateLimiter) settle() errors.Error {
+	l.mu.Lock()
+	if !l.observed {
+		l.mu.Unlock()
+		return nil
+	}
+	remaining, resetAt, settled := l.observedRemaining, l.observedResetAt, l.settled
+	l.observed = false
+	l.settled = 0
+	l.mu.Unlock()
+	err := l.store.Adjust(l.key, remaining, resetAt, settled)
+	if err != nil {
+		// keep the settlement for the next try, unless a newer observation came in meanwhile
+		l.mu.Lock()
+		l.settled += settled
+		if !l.observed {
+			l.observed = true
+			l.observedRemaining = remaining
+			l.observedResetAt = resetAt
+		}
+		l.mu.Unlock()
+	}
+	return err
+}
+
+// ParseRateLimitHeaders extracts the remaining quota from the `X-RateLimit-Remaining` and `X-RateLimit-Reset` headers
+// (or the ones without `X-` prefix), the reset header could be either epoch seconds or seconds from now
+func ParseRateLimitHeaders(res *http.Response) (int, time.Time, bool) {
+	remaining, err := strconv.Atoi(firstHeader(res.Header, "X-RateLimit-Remaining", "RateLimit-Remaining"))
+	if err != nil {
+		return 0, time.Time{}, false
+	}
+	reset, err := strconv.ParseInt(firstHeader(res.Header, "X-RateLimit-Reset", "RateLimit-Reset"), 10, 64)
+	if err != nil {
+		return 0, time.Time{}, false
+	}
+	// values smaller than a year are deltas
+	if reset < 365*24*3600 {
+		return remaining, time.Now().Add(time.Duration(reset) * time.Second), true
+	}
+	return remaining, time.Unix(reset, 0), true
+}
+
+func firstHeader(header http.Header, names ...string) string {
+	for _, name := range names {
+		if value := header.Get(name); value != "" {
+			return value
+		}
+	}
+	return ""
+}
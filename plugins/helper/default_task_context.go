@@ -170,8 +170,11 @@ func (c *defaultExecContext) fork(name string) *defaultExecContext {
 // DefaultTaskContext is TaskContext default implementation
 type DefaultTaskContext struct {
 	*defaultExecContext
-	subtasks    map[string]bool
-	subtaskCtxs map[string]*DefaultSubTaskContext
+	subtasks      map[string]bool
+	subtaskCtxs   map[string]*DefaultSubTaskContext
+	connectionKey string
+	// collected are raw tables and params collected by subtasks of the task
+	collected []CollectedRawData
 }
 
 // SetProgress FIXME ...
@@ -191,6 +194,8 @@ type DefaultSubTaskContext struct {
 	*defaultExecContext
 	taskCtx          *DefaultTaskContext
 	LastProgressTime time.Time
+	resumeSince      *time.Time
+	apiCalls         int64
 }
 
 // SetProgress FIXME ...
@@ -226,6 +231,8 @@ func NewDefaultTaskContext(
 		newDefaultExecContext(ctx, cfg, logger, db, name, nil, progress),
 		subtasks,
 		make(map[string]*DefaultSubTaskContext),
+		"",
+		nil,
 	}
 }
 
@@ -241,6 +248,8 @@ func (c *DefaultTaskContext) SubTaskContext(subtask string) (core.SubTaskContext
 					c.defaultExecContext.fork(subtask),
 					c,
 					time.Time{},
+					nil,
+					0,
 				}
 			}
 			c.defaultExecContext.mu.Unlock()
@@ -269,6 +278,8 @@ func NewStandaloneSubTaskContext(
 		newDefaultExecContext(ctx, cfg, logger, db, name, data, nil),
 		nil,
 		time.Time{},
+		nil,
+		0,
 	}
 }
 
@@ -279,6 +290,18 @@ func (c *DefaultTaskContext) SetData(data interface{}) {
 
 var _ core.TaskContext = (*DefaultTaskContext)(nil)
 
+// SetConnectionKey binds the task to the connection identified by `key`, see GetConnectionKey
+func (c *DefaultTaskContext) SetConnectionKey(key string) {
+	c.connectionKey = key
+}
+
+// GetConnectionKey returns the key of the connection the task is working on
+func (c *DefaultTaskContext) GetConnectionKey() string {
+	return c.connectionKey
+}
+
+var _ core.ConnectionAwareTaskContext = (*DefaultTaskContext)(nil)
+
 // TaskContext FIXME ...
 func (c *DefaultSubTaskContext) TaskContext() core.TaskContext {
 	if c.taskCtx == nil {
@@ -287,4 +310,61 @@ func (c *DefaultSubTaskContext) TaskContext() core.TaskContext {
 	return c.taskCtx
 }
 
-var _ core.SubTaskContext = (*DefaultSubTaskContext)(nil)
+// ResumeSince returns the time when the failed attempt being resumed started
+func (c *DefaultSubTaskContext) ResumeSince() *time.Time {
+	return c.resumeSince
+}
+
+// SetResumeSince marks the subtask as resuming the failed attempt started at `since`
+func (c *DefaultSubTaskContext) SetResumeSince(since *time.Time) {
+	c.resumeSince = since
+}
+
+var _ core.ResumableSubTaskContext = (*DefaultSubTaskContext)(nil)
+
+// IncApiCalls increases the number of api calls made by the subtask
+func (c *DefaultSubTaskContext) IncApiCalls(quantity int) {
+	atomic.AddInt64(&c.apiCalls, int64(quantity))
+}
+
+// GetApiCalls returns the number of api calls made by the subtask so far
+func (c *DefaultSubTaskContext) GetApiCalls() int {
+	return int(atomic.LoadInt64(&c.apiCalls))
+}
+
+var _ core.ApiCallsRecorder = (*DefaultSubTaskContext)(nil)
+
+// GetConnectionKey returns the key of the connection the task is working on
+func (c *DefaultSubTaskContext) GetConnectionKey() string {
+	if c.taskCtx == nil {
+		return ""
+	}
+	return c.taskCtx.connectionKey
+}
+
+var _ core.ConnectionAwareTaskContext = (*DefaultSubTaskContext)(nil)
+
+// GetCollectedRawData returns raw tables and params collected by subtasks of the task so far
+func (c *DefaultTaskContext) GetCollectedRawData() []CollectedRawData {
+	c.defaultExecContext.mu.Lock()
+	defer c.defaultExecContext.mu.Unlock()
+	return append([]CollectedRawData(nil), c.collected...)
+}
+
+// RecordRawDataCollection records that the subtask collected raw data of `params` into `table`
+func (c *DefaultSubTaskContext) RecordRawDataCollection(table string, params string) {
+	if c.taskCtx == nil {
+		return
+	}
+	collected := CollectedRawData{Table: table, Params: params}
+	c.taskCtx.defaultExecContext.mu.Lock()
+	defer c.taskCtx.defaultExecContext.mu.Unlock()
+	for _, existing := range c.taskCtx.collected {
+		if existing == collected {
+			return
+		}
+	}
+	c.taskCtx.collected = append(c.taskCtx.collected, collected)
+}
+
+var _ core.RawDataCollectionRecorder = (*DefaultSubTaskContext)(nil)
@@ -26,6 +26,7 @@ import (
 	"github.com/merico-dev/graphql"
 	"net/http"
 	"reflect"
+	"time"
 )
 
 // CursorPager contains pagination information for a graphql request
@@ -40,6 +41,8 @@ type GraphqlRequestData struct {
 	Params    interface{}
 	Input     interface{}
 	InputJSON []byte
+	// Since is the start of the incremental time window, nil means records should be collected regardless of time
+	Since *time.Time
 }
 
 // GraphqlQueryPageInfo contains the pagination data
@@ -58,6 +61,12 @@ type GraphqlCollectorArgs struct {
 	BuildQuery func(reqData *GraphqlRequestData) (query interface{}, variables map[string]interface{}, err error)
 	// PageSize tells ApiCollector the page size
 	PageSize int
+	// Incremental indicate if this is a incremental collection, the existing data won't get deleted if it was true
+	Incremental bool
+	// TimeWindow tells `ApiCollectorStateManager` to decide the window and whether to collect incrementally based
+	// on state of the last successful collection. QueryParam and TimeFormat are not used by graphql queries, read
+	// `GraphqlRequestData.Since` in `BuildQuery` instead, or stop paging once records are older than the window
+	TimeWindow *IncrementalTimeWindow
 	// GraphqlClient is a asynchronize api request client with qps
 	GraphqlClient *GraphqlAsyncClient
 	// Input helps us collect data based on previous collected data, like collecting changelogs based on jira
@@ -79,6 +88,8 @@ type GraphqlCollector struct {
 	*RawDataSubTask
 	args         *GraphqlCollectorArgs
 	workerErrors []error
+	// since is the start of the time window decided by ApiCollectorStateManager
+	since *time.Time
 }
 
 // NewGraphqlCollector allocates a new GraphqlCollector with the given args.
@@ -135,11 +146,19 @@ func (collector *GraphqlCollector) Execute() errors.Error {
 	}
 
 	// flush data if not incremental collection
-	err = db.Delete(&RawData{}, dal.From(collector.table), dal.Where("params = ?", collector.params))
+	if !collector.args.Incremental {
+		err = db.Delete(&RawData{}, dal.From(collector.table), dal.Where("params = ?", collector.params))
+		if err != nil {
+			return errors.Default.Wrap(err, "error deleting from collector table")
+		}
+	}
+	err = collector.recordCollection()
 	if err != nil {
-		return errors.Default.Wrap(err, "error deleting from collector table")
+		return errors.Default.Wrap(err, "error recording collection")
 	}
 	divider := NewBatchSaveDivider(collector.args.Ctx, collector.args.BatchSize, collector.table, collector.params)
+	// records are saved by the collector directly, so they can not be extracted again from the raw data
+	divider.SetIncrementalMode(collector.args.Incremental)
 
 	collector.args.Ctx.SetProgress(0, -1)
 	if collector.args.Input != nil {
@@ -201,6 +220,7 @@ func (collector *GraphqlCollector) exec(divider *BatchSaveDivider, input interfa
 	reqData := new(GraphqlRequestData)
 	reqData.Input = input
 	reqData.InputJSON = inputJson
+	reqData.Since = collector.since
 	reqData.Pager = &CursorPager{
 		SkipCursor: nil,
 		Size:       collector.args.PageSize,
@@ -233,6 +253,7 @@ func (collector *GraphqlCollector) fetchOneByOne(divider *BatchSaveDivider, reqD
 					},
 					Input:     reqData.Input,
 					InputJSON: reqData.InputJSON,
+					Since:     reqData.Since,
 				}
 				collector.fetchAsync(divider, reqDataTemp, fetchNextPage)
 				return nil
@@ -258,6 +279,9 @@ func (collector *GraphqlCollector) fetchAsync(divider *BatchSaveDivider, reqData
 
 	logger := collector.args.Ctx.GetLogger()
 	dataErrors, err := collector.args.GraphqlClient.Query(query, variables)
+	if recorder, ok := collector.args.Ctx.(core.ApiCallsRecorder); ok {
+		recorder.IncApiCalls(1)
+	}
 	if err != nil {
 		collector.checkError(errors.Default.Wrap(err, `graphql query failed`))
 		return
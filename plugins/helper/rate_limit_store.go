@@ -0,0 +1,211 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helper
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/models"
+	"github.com/apache/incubator-devlake/plugins/core"
+	"github.com/apache/incubator-devlake/plugins/core/dal"
+)
+
+// RateLimitStoreEnvStr specifies the RateLimitStore to be used, `memory` by default. Use `db` when multiple
+// devlake instances or temporal workers are collecting from the same connections
+const RateLimitStoreEnvStr = "RATE_LIMIT_STORE"
+
+const (
+	RATE_LIMIT_STORE_MEMORY = "memory"
+	RATE_LIMIT_STORE_DB     = "db"
+)
+
+var rateLimitStore core.RateLimitStore
+var rateLimitStoreLock sync.Mutex
+
+// GetRateLimitStore returns the RateLimitStore in use, it would be created according to the configuration on first call
+func GetRateLimitStore(basicRes core.BasicRes) (core.RateLimitStore, errors.Error) {
+	rateLimitStoreLock.Lock()
+	defer rateLimitStoreLock.Unlock()
+	if rateLimitStore == nil {
+		switch kind := strings.ToLower(basicRes.GetConfig(RateLimitStoreEnvStr)); kind {
+		case "", RATE_LIMIT_STORE_MEMORY:
+			rateLimitStore = NewMemoryRateLimitStore()
+		case RATE_LIMIT_STORE_DB:
+			rateLimitStore = NewDbRateLimitStore(basicRes.GetDal())
+		default:
+			return nil, errors.BadInput.New(fmt.Sprintf("unknown %s: %s", RateLimitStoreEnvStr, kind))
+		}
+	}
+	return rateLimitStore, nil
+}
+
+// takeQuota leases up to `n` requests from `quota` and refills it when the reset time has passed
+func takeQuota(quota *models.RateLimitQuota, n int, limit int, period time.Duration, now time.Time) int {
+	if !now.Before(quota.ResetAt) {
+		quota.Remaining = limit
+		quota.ResetAt = now.Add(period)
+		quota.Leased = 0
+	}
+	granted := n
+	if quota.Remaining < granted {
+		granted = quota.Remaining
+	}
+	if granted < 0 {
+		granted = 0
+	}
+	quota.Remaining -= granted
+	quota.Leased += granted
+	quota.UpdatedAt = now
+	return granted
+}
+
+// adjustQuota settles the requests sent or given up by a client, and lowers `quota` to the `remaining` requests
+// reported by the server minus the ones still leased. The quota is never raised by the server until it resets, since
+// responses of concurrent requests may arrive out of order
+func adjustQuota(quota *models.RateLimitQuota, remaining int, resetAt time.Time, settled int, now time.Time) {
+	quota.Leased -= settled
+	if quota.Leased < 0 {
+		quota.Leased = 0
+	}
+	available := remaining - quota.Leased
+	if available < 0 {
+		available = 0
+	}
+	if !now.Before(quota.ResetAt) {
+		quota.Remaining = available
+		quota.ResetAt = resetAt
+		quota.Leased = 0
+	} else if available < quota.Remaining {
+		quota.Remaining = available
+		quota.ResetAt = resetAt
+	}
+	quota.UpdatedAt = now
+}
+
+// MemoryRateLimitStore keeps quotas in memory, they are shared by all tasks of the process
+type MemoryRateLimitStore struct {
+	mu     sync.Mutex
+	quotas map[string]*models.RateLimitQuota
+}
+
+// NewMemoryRateLimitStore creates a new MemoryRateLimitStore
+func NewMemoryRateLimitStore() *MemoryRateLimitStore {
+	return &MemoryRateLimitStore{quotas: make(map[string]*models.RateLimitQuota)}
+}
+
+// Take leases up to `n` requests from the quota of `key`
+func (s *MemoryRateLimitStore) Take(key string, n int, limit int, period time.Duration) (int, time.Time, errors.Error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	quota := s.quotas[key]
+	if quota == nil {
+		quota = &models.RateLimitQuota{ConnectionKey: key}
+		s.quotas[key] = quota
+	}
+	granted := takeQuota(quota, n, limit, period, time.Now())
+	return granted, quota.ResetAt, nil
+}
+
+// Adjust lowers the quota of `key` with the numbers reported by the server
+func (s *MemoryRateLimitStore) Adjust(key string, remaining int, resetAt time.Time, settled int) errors.Error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	quota := s.quotas[key]
+	if quota == nil {
+		quota = &models.RateLimitQuota{ConnectionKey: key}
+		s.quotas[key] = quota
+	}
+	adjustQuota(quota, remaining, resetAt, settled, time.Now())
+	return nil
+}
+
+var _ core.RateLimitStore = (*MemoryRateLimitStore)(nil)
+
+// DbRateLimitStore keeps quotas in the `_devlake_rate_limit_quotas` table, so they could be shared across processes
+type DbRateLimitStore struct {
+	db dal.Dal
+}
+
+// NewDbRateLimitStore creates a new DbRateLimitStore
+func NewDbRateLimitStore(db dal.Dal) *DbRateLimitStore {
+	return &DbRateLimitStore{db: db}
+}
+
+// Take leases up to `n` requests from the quota of `key`
+func (s *DbRateLimitStore) Take(key string, n int, limit int, period time.Duration) (granted int, resetAt time.Time, err errors.Error) {
+	now := time.Now()
+	quota, err := s.update(&models.RateLimitQuota{
+		ConnectionKey: key,
+		Remaining:     limit,
+		ResetAt:       now.Add(period),
+		UpdatedAt:     now,
+	}, func(quota *models.RateLimitQuota) {
+		granted = takeQuota(quota, n, limit, period, now)
+	})
+	if err != nil {
+		return 0, now, err
+	}
+	return granted, quota.ResetAt, nil
+}
+
+// Adjust lowers the quota of `key` with the numbers reported by the server
+func (s *DbRateLimitStore) Adjust(key string, remaining int, resetAt time.Time, settled int) errors.Error {
+	now := time.Now()
+	_, err := s.update(&models.RateLimitQuota{
+		ConnectionKey: key,
+		Remaining:     remaining,
+		ResetAt:       resetAt,
+		UpdatedAt:     now,
+	}, func(quota *models.RateLimitQuota) {
+		adjustQuota(quota, remaining, resetAt, settled, now)
+	})
+	return err
+}
+
+// update creates the quota row from `initial` if it doesn't exist, then changes it by `fn` with the row locked
+func (s *DbRateLimitStore) update(initial *models.RateLimitQuota, fn func(quota *models.RateLimitQuota)) (quota *models.RateLimitQuota, err errors.Error) {
+	err = s.db.CreateIfNotExist(initial)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "failed to create rate limit quota")
+	}
+	tx := s.db.Begin()
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+	quota = &models.RateLimitQuota{}
+	err = tx.First(quota, dal.Where("connection_key = ?", initial.ConnectionKey), dal.Lock(true, false))
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "failed to lock rate limit quota")
+	}
+	fn(quota)
+	err = tx.Update(quota)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "failed to update rate limit quota")
+	}
+	return quota, nil
+}
+
+var _ core.RateLimitStore = (*DbRateLimitStore)(nil)
@@ -0,0 +1,158 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helper
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTakeQuota(t *testing.T) {
+	now := time.Now()
+	quota := &models.RateLimitQuota{ConnectionKey: "github#1"}
+	// empty quota would be filled on first take
+	assert.Equal(t, 10, takeQuota(quota, 10, 25, time.Hour, now))
+	assert.Equal(t, 10, takeQuota(quota, 10, 25, time.Hour, now))
+	assert.Equal(t, 5, takeQuota(quota, 10, 25, time.Hour, now))
+	assert.Equal(t, 0, takeQuota(quota, 10, 25, time.Hour, now))
+	// refilled after reset
+	assert.Equal(t, 10, takeQuota(quota, 10, 25, time.Hour, now.Add(time.Hour)))
+	assert.Equal(t, 15, quota.Remaining)
+}
+
+func TestGetConnectionKey(t *testing.T) {
+	assert.Equal(t, "github#1", GetConnectionKey("github", map[string]interface{}{"connectionId": float64(1)}))
+	assert.Equal(t, "jira#2", GetConnectionKey("jira", map[string]interface{}{"connectionId": 2}))
+	assert.Equal(t, "", GetConnectionKey("dora", map[string]interface{}{"projectName": "p"}))
+	assert.Equal(t, "", GetConnectionKey("github", map[string]interface{}{"connectionId": float64(0)}))
+}
+
+func TestConnectionRateLimiter(t *testing.T) {
+	store := NewMemoryRateLimitStore()
+	l1 := NewConnectionRateLimiter(store, "github#1", 12, time.Hour, nil)
+	l2 := NewConnectionRateLimiter(store, "github#1", 12, time.Hour, nil)
+	ctx := context.Background()
+	// l1 leases 10 requests, leaving 2 for l2
+	assert.Nil(t, l1.Wait(ctx))
+	assert.Nil(t, l2.Wait(ctx))
+	assert.Nil(t, l2.Wait(ctx))
+
+	// l2 has to wait until the quota resets
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	assert.NotNil(t, l2.Wait(ctx))
+
+	// server reporting more remaining requests doesn't raise the quota
+	res := &http.Response{Header: http.Header{}}
+	res.Header.Set("X-RateLimit-Remaining", "100")
+	res.Header.Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10))
+	assert.Nil(t, l1.Observe(res))
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.NotNil(t, l2.Wait(ctx))
+	// the observation is kept in memory until it is worth settling
+	assert.Equal(t, 12, store.quotas["github#1"].Leased)
+
+	// the server allows fewer requests than l1 expects, so the requests sent by l1 and the leases beyond the
+	// remaining ones are settled right away
+	res.Header.Set("X-RateLimit-Remaining", "4")
+	assert.Nil(t, l1.Observe(res))
+	assert.Equal(t, 4, l1.leased)
+	assert.Equal(t, 6, store.quotas["github#1"].Leased)
+	assert.Equal(t, 0, store.quotas["github#1"].Remaining)
+
+	// requests sent by l1 itself are expected to lower the remaining ones, nothing to settle
+	assert.Nil(t, l1.Wait(context.Background()))
+	res.Header.Set("X-RateLimit-Remaining", "3")
+	assert.Nil(t, l1.Observe(res))
+	assert.Equal(t, 6, store.quotas["github#1"].Leased)
+	assert.Equal(t, 1, l1.settled)
+}
+
+func TestConnectionRateLimiterSettlesOnLease(t *testing.T) {
+	store := NewMemoryRateLimitStore()
+	l := NewConnectionRateLimiter(store, "github#1", 100, time.Hour, nil)
+	ctx := context.Background()
+	for i := 0; i < rateLimitLeaseSize; i++ {
+		assert.Nil(t, l.Wait(ctx))
+	}
+	res := &http.Response{Header: http.Header{}}
+	// other clients sent 5 requests
+	res.Header.Set("X-RateLimit-Remaining", "85")
+	res.Header.Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10))
+	assert.Nil(t, l.Observe(res))
+	assert.Equal(t, 10, store.quotas["github#1"].Leased)
+	assert.Equal(t, 90, store.quotas["github#1"].Remaining)
+
+	// the observation is settled before the next lease is taken
+	assert.Nil(t, l.Wait(ctx))
+	assert.Equal(t, 10, store.quotas["github#1"].Leased)
+	assert.Equal(t, 75, store.quotas["github#1"].Remaining)
+	assert.Equal(t, 9, l.leased)
+}
+
+func TestAdjustQuota(t *testing.T) {
+	now := time.Now()
+	resetAt := now.Add(time.Hour)
+	quota := &models.RateLimitQuota{ConnectionKey: "github#1"}
+	assert.Equal(t, 10, takeQuota(quota, 10, 100, time.Hour, now))
+
+	// 3 of the leased requests were sent, the other 7 are deducted from what the server reports
+	adjustQuota(quota, 95, resetAt, 3, now)
+	assert.Equal(t, 7, quota.Leased)
+	assert.Equal(t, 88, quota.Remaining)
+
+	// never raised by the server within the window
+	adjustQuota(quota, 99, resetAt, 0, now)
+	assert.Equal(t, 88, quota.Remaining)
+
+	adjustQuota(quota, 50, resetAt, 7, now)
+	assert.Equal(t, 0, quota.Leased)
+	assert.Equal(t, 50, quota.Remaining)
+
+	adjustQuota(quota, 2, resetAt, 0, now)
+	assert.Equal(t, 2, quota.Remaining)
+
+	// taken over from the server once the window is over
+	adjustQuota(quota, 100, resetAt.Add(time.Hour), 0, resetAt)
+	assert.Equal(t, 100, quota.Remaining)
+	assert.Equal(t, resetAt.Add(time.Hour), quota.ResetAt)
+}
+
+func TestParseRateLimitHeaders(t *testing.T) {
+	res := &http.Response{Header: http.Header{}}
+	_, _, ok := ParseRateLimitHeaders(res)
+	assert.False(t, ok)
+
+	res.Header.Set("RateLimit-Remaining", "42")
+	res.Header.Set("RateLimit-Reset", "60")
+	remaining, resetAt, ok := ParseRateLimitHeaders(res)
+	assert.True(t, ok)
+	assert.Equal(t, 42, remaining)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), resetAt, 5*time.Second)
+
+	res.Header.Set("X-RateLimit-Reset", "1670000000")
+	_, resetAt, _ = ParseRateLimitHeaders(res)
+	assert.Equal(t, time.Unix(1670000000, 0), resetAt)
+}
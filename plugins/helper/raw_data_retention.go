@@ -0,0 +1,185 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helper
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/models"
+	"github.com/apache/incubator-devlake/plugins/core/dal"
+)
+
+// CollectedRawData identifies raw data of a scope collected into a raw table
+type CollectedRawData struct {
+	Table  string
+	Params string
+}
+
+// CompactRawData applies retention policies to raw tables collected by the task, it is run by the runner once all
+// subtasks are finished. Raw data out of the retention window would be deleted unless some extracted records still
+// refer to them, so extractors could always be rerun on retained data without losing anything
+func CompactRawData(taskCtx *DefaultTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	logger := taskCtx.GetLogger()
+	var referringTables []string
+	for _, collected := range taskCtx.GetCollectedRawData() {
+		policy, err := LoadRawDataRetentionPolicy(db, taskCtx.GetName(), collected.Table)
+		if err != nil {
+			return err
+		}
+		if policy == nil {
+			logger.Debug("no retention policy for %s, skipping", collected.Table)
+			continue
+		}
+		// tables might be added by migrations at any time, so they are loaded for every compaction
+		if referringTables == nil {
+			referringTables, err = getRawDataReferringTables(db)
+			if err != nil {
+				return err
+			}
+		}
+		logger.Info("compacting %s with policy: keep %d collections, %d days", collected.Table, policy.KeepCollections, policy.KeepDays)
+		err = purgeRawData(db, collected.Table, collected.Params, policy.KeepCollections, policy.KeepDays, referringTables)
+		if err != nil {
+			return errors.Default.Wrap(err, fmt.Sprintf("error compacting %s", collected.Table))
+		}
+	}
+	return nil
+}
+
+// LoadRawDataRetentionPolicy returns the policy of the raw table, or the one of the plugin if there is no table
+// specific policy, nil would be returned if neither exists
+func LoadRawDataRetentionPolicy(db dal.Dal, pluginName string, rawTable string) (*models.RawDataRetentionPolicy, errors.Error) {
+	var policies []models.RawDataRetentionPolicy
+	err := db.All(
+		&policies,
+		dal.Where("(plugin = ? AND raw_data_table = '') OR raw_data_table = ?", pluginName, rawTable),
+	)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error loading raw data retention policies")
+	}
+	var policy *models.RawDataRetentionPolicy
+	for i := range policies {
+		if policy == nil || policies[i].RawDataTable != "" {
+			policy = &policies[i]
+		}
+	}
+	return policy, nil
+}
+
+// PurgeRawData deletes raw data of the scope that are out of the retention window. The latest collection is always
+// kept, and so are rows still referred by extracted records
+func PurgeRawData(db dal.Dal, rawTable string, params string, keepCollections int, keepDays int) errors.Error {
+	referringTables, err := getRawDataReferringTables(db)
+	if err != nil {
+		return err
+	}
+	return purgeRawData(db, rawTable, params, keepCollections, keepDays, referringTables)
+}
+
+func purgeRawData(db dal.Dal, rawTable string, params string, keepCollections int, keepDays int, referringTables []string) errors.Error {
+	var collections []models.RawDataCollection
+	err := db.All(
+		&collections,
+		dal.Where("raw_data_table = ? AND raw_data_params = ?", rawTable, params),
+		dal.Orderby("began_at DESC"),
+	)
+	if err != nil {
+		return errors.Default.Wrap(err, "error loading raw data collections")
+	}
+	cutoff := rawDataRetentionCutoff(collections, keepCollections, keepDays, time.Now())
+	if cutoff == nil {
+		return nil
+	}
+	if len(referringTables) == 0 {
+		return nil
+	}
+	subQueries := make([]string, len(referringTables))
+	where := []interface{}{params, *cutoff}
+	for i, table := range referringTables {
+		subQueries[i] = fmt.Sprintf(
+			"SELECT _raw_data_id FROM %s WHERE _raw_data_table = ? AND _raw_data_params = ? AND _raw_data_id IS NOT NULL",
+			table,
+		)
+		where = append(where, rawTable, params)
+	}
+	err = db.Delete(
+		&RawData{},
+		dal.From(rawTable),
+		dal.Where(
+			fmt.Sprintf("params = ? AND created_at < ? AND id NOT IN (%s)", strings.Join(subQueries, " UNION ")),
+			where...,
+		),
+	)
+	if err != nil {
+		return errors.Default.Wrap(err, fmt.Sprintf("error deleting raw data from %s", rawTable))
+	}
+	return db.Delete(
+		&models.RawDataCollection{},
+		dal.Where("raw_data_table = ? AND raw_data_params = ? AND began_at < ?", rawTable, params, *cutoff),
+	)
+}
+
+// rawDataRetentionCutoff returns the time before which raw data could be purged, collections must be sorted from
+// the latest to the earliest. A row is retained if it belongs to any of the latest `keepCollections` collections,
+// or it was created in the last `keepDays` days, or it belongs to the latest collection
+func rawDataRetentionCutoff(collections []models.RawDataCollection, keepCollections int, keepDays int, now time.Time) *time.Time {
+	if len(collections) == 0 || (keepCollections <= 0 && keepDays <= 0) {
+		return nil
+	}
+	cutoff := collections[0].BeganAt
+	if keepCollections > 0 {
+		if keepCollections >= len(collections) {
+			return nil
+		}
+		cutoff = collections[keepCollections-1].BeganAt
+	}
+	if keepDays > 0 {
+		if daysCutoff := now.AddDate(0, 0, -keepDays); daysCutoff.Before(cutoff) {
+			cutoff = daysCutoff
+		}
+	}
+	return &cutoff
+}
+
+// getRawDataReferringTables returns all tables carrying `RawDataOrigin`
+func getRawDataReferringTables(db dal.Dal) ([]string, errors.Error) {
+	tables, err := db.AllTables()
+	if err != nil {
+		return nil, err
+	}
+	referringTables := make([]string, 0)
+	for _, table := range tables {
+		if strings.HasPrefix(table, "_raw_") {
+			continue
+		}
+		columns, err := dal.GetColumnNames(db, dal.DefaultTabler{Name: table}, func(columnMeta dal.ColumnMeta) bool {
+			return columnMeta.Name() == "_raw_data_id"
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(columns) > 0 {
+			referringTables = append(referringTables, table)
+		}
+	}
+	return referringTables, nil
+}
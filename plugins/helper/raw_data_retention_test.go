@@ -0,0 +1,76 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helper
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/helpers/unithelper"
+	"github.com/apache/incubator-devlake/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRawDataRetentionCutoff(t *testing.T) {
+	now := time.Date(2022, 12, 9, 0, 0, 0, 0, time.UTC)
+	collections := []models.RawDataCollection{
+		{BeganAt: now.AddDate(0, 0, -1)},
+		{BeganAt: now.AddDate(0, 0, -8)},
+		{BeganAt: now.AddDate(0, 0, -15)},
+	}
+
+	// nothing to purge without policy or collections
+	assert.Nil(t, rawDataRetentionCutoff(collections, 0, 0, now))
+	assert.Nil(t, rawDataRetentionCutoff(nil, 1, 1, now))
+
+	// keep last N collections
+	assert.Equal(t, now.AddDate(0, 0, -8), *rawDataRetentionCutoff(collections, 2, 0, now))
+	assert.Nil(t, rawDataRetentionCutoff(collections, 3, 0, now))
+
+	// keep N days
+	assert.Equal(t, now.AddDate(0, 0, -10), *rawDataRetentionCutoff(collections, 0, 10, now))
+
+	// both, whichever keeps more
+	assert.Equal(t, now.AddDate(0, 0, -10), *rawDataRetentionCutoff(collections, 1, 10, now))
+	assert.Equal(t, now.AddDate(0, 0, -8), *rawDataRetentionCutoff(collections, 2, 3, now))
+
+	// the latest collection is always kept
+	assert.Equal(t, now.AddDate(0, 0, -15), *rawDataRetentionCutoff(collections[2:], 0, 3, now))
+}
+
+func TestRecordRawDataCollection(t *testing.T) {
+	taskCtx := NewDefaultTaskContext(context.Background(), nil, unithelper.DummyLogger(), nil, "jira", map[string]bool{
+		"collectIssues":     true,
+		"collectChangelogs": true,
+	}, nil).(*DefaultTaskContext)
+	issuesCtx, err := taskCtx.SubTaskContext("collectIssues")
+	assert.Nil(t, err)
+	changelogsCtx, err := taskCtx.SubTaskContext("collectChangelogs")
+	assert.Nil(t, err)
+
+	issuesCtx.(*DefaultSubTaskContext).RecordRawDataCollection("_raw_jira_api_issues", `{"BoardId":1}`)
+	changelogsCtx.(*DefaultSubTaskContext).RecordRawDataCollection("_raw_jira_api_changelogs", `{"BoardId":1}`)
+	// collected twice by the same task
+	issuesCtx.(*DefaultSubTaskContext).RecordRawDataCollection("_raw_jira_api_issues", `{"BoardId":1}`)
+
+	assert.Equal(t, []CollectedRawData{
+		{Table: "_raw_jira_api_issues", Params: `{"BoardId":1}`},
+		{Table: "_raw_jira_api_changelogs", Params: `{"BoardId":1}`},
+	}, taskCtx.GetCollectedRawData())
+}
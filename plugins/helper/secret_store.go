@@ -0,0 +1,230 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helper
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/plugins/core"
+)
+
+// SecretStoreEnvStr specifies the SecretStore to be used, `aes` by default
+const SecretStoreEnvStr = "SECRET_STORE"
+
+// SecretKeyringFileEnvStr specifies the keyring file for the `keyring` SecretStore
+const SecretKeyringFileEnvStr = "SECRET_KEYRING_FILE"
+
+const (
+	SECRET_STORE_AES     = "aes"
+	SECRET_STORE_KEYRING = "keyring"
+)
+
+// prefix of secrets encrypted by KeyringSecretStore, followed by the key id and the ciphertext
+const keyringCipherPrefix = "keyring:"
+
+var secretStore core.SecretStore
+var secretStoreLock sync.RWMutex
+
+// GetSecretStore returns the SecretStore in use, it would be created according to the configuration on first call
+func GetSecretStore(basicRes core.BasicRes) (core.SecretStore, errors.Error) {
+	secretStoreLock.RLock()
+	store := secretStore
+	secretStoreLock.RUnlock()
+	if store != nil {
+		return store, nil
+	}
+	secretStoreLock.Lock()
+	defer secretStoreLock.Unlock()
+	if secretStore == nil {
+		store, err := NewSecretStore(basicRes)
+		if err != nil {
+			return nil, err
+		}
+		secretStore = store
+	}
+	return secretStore, nil
+}
+
+// ReplaceSecretStore switches to newStore after `migrate` re-encrypted existing secrets successfully, secrets
+// could not be accessed through GetSecretStore in the meantime
+func ReplaceSecretStore(
+	basicRes core.BasicRes,
+	newStore core.SecretStore,
+	migrate func(oldStore, newStore core.SecretStore) errors.Error,
+) errors.Error {
+	secretStoreLock.Lock()
+	defer secretStoreLock.Unlock()
+	oldStore := secretStore
+	if oldStore == nil {
+		var err errors.Error
+		oldStore, err = NewSecretStore(basicRes)
+		if err != nil {
+			return err
+		}
+	}
+	err := migrate(oldStore, newStore)
+	if err != nil {
+		return err
+	}
+	secretStore = newStore
+	return nil
+}
+
+// NewSecretStore creates the SecretStore specified by SECRET_STORE, the AES store using ENCODE_KEY is the default
+func NewSecretStore(basicRes core.BasicRes) (core.SecretStore, errors.Error) {
+	aesStore := NewAesSecretStore(basicRes.GetConfig(core.EncodeKeyEnvStr))
+	storeType := strings.ToLower(basicRes.GetConfig(SecretStoreEnvStr))
+	switch storeType {
+	case "", SECRET_STORE_AES:
+		return aesStore, nil
+	case SECRET_STORE_KEYRING:
+		provider, err := NewFileKeyProvider(basicRes.GetConfig(SecretKeyringFileEnvStr))
+		if err != nil {
+			return nil, err
+		}
+		// secrets encrypted before switching to keyring remain readable until they are rotated
+		return NewKeyringSecretStore(provider, aesStore), nil
+	default:
+		return nil, errors.BadInput.New(fmt.Sprintf("unknown %s: %s", SecretStoreEnvStr, storeType))
+	}
+}
+
+// AesSecretStore encrypts secrets by AES with a single key
+type AesSecretStore struct {
+	encKey string
+}
+
+// NewAesSecretStore creates an AesSecretStore with the key
+func NewAesSecretStore(encKey string) *AesSecretStore {
+	return &AesSecretStore{encKey: encKey}
+}
+
+// Encrypt the plainText
+func (s *AesSecretStore) Encrypt(plainText string) (string, errors.Error) {
+	return core.Encrypt(s.encKey, plainText)
+}
+
+// Decrypt the cipherText
+func (s *AesSecretStore) Decrypt(cipherText string) (string, errors.Error) {
+	return core.Decrypt(s.encKey, cipherText)
+}
+
+var _ core.SecretStore = (*AesSecretStore)(nil)
+
+// KeyringSecretStore encrypts secrets by AES with the active key of the KeyProvider, the key id is kept along with
+// the ciphertext so secrets encrypted by previous keys remain readable as long as the keys are provided
+type KeyringSecretStore struct {
+	provider core.KeyProvider
+	legacy   core.SecretStore
+}
+
+// NewKeyringSecretStore creates a KeyringSecretStore, ciphertexts without key id would be decrypted by legacy if it
+// is not nil
+func NewKeyringSecretStore(provider core.KeyProvider, legacy core.SecretStore) *KeyringSecretStore {
+	return &KeyringSecretStore{provider: provider, legacy: legacy}
+}
+
+// Encrypt the plainText with the active key
+func (s *KeyringSecretStore) Encrypt(plainText string) (string, errors.Error) {
+	keyId, key, err := s.provider.ActiveKey()
+	if err != nil {
+		return "", err
+	}
+	cipherText, err := core.Encrypt(string(key), plainText)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s%s:%s", keyringCipherPrefix, keyId, cipherText), nil
+}
+
+// Decrypt the cipherText with the key it was encrypted by
+func (s *KeyringSecretStore) Decrypt(cipherText string) (string, errors.Error) {
+	if !strings.HasPrefix(cipherText, keyringCipherPrefix) {
+		if s.legacy == nil {
+			return "", errors.Default.New("secret was not encrypted by keyring")
+		}
+		return s.legacy.Decrypt(cipherText)
+	}
+	parts := strings.SplitN(strings.TrimPrefix(cipherText, keyringCipherPrefix), ":", 2)
+	if len(parts) != 2 {
+		return "", errors.Default.New("malformed keyring secret")
+	}
+	key, err := s.provider.Key(parts[0])
+	if err != nil {
+		return "", err
+	}
+	return core.Decrypt(string(key), parts[1])
+}
+
+var _ core.SecretStore = (*KeyringSecretStore)(nil)
+
+// FileKeyProvider is a KeyProvider reading keys from a json file like:
+//
+//	{"active": "2022q4", "keys": {"2022q3": "...", "2022q4": "..."}}
+//
+// keys are rotated by adding a new key to the file, making it active and re-encrypting all secrets, the retired
+// key could be removed from the file afterward
+type FileKeyProvider struct {
+	Active string            `json:"active"`
+	Keys   map[string]string `json:"keys"`
+}
+
+// NewFileKeyProvider loads keys from the file
+func NewFileKeyProvider(path string) (*FileKeyProvider, errors.Error) {
+	if path == "" {
+		return nil, errors.BadInput.New(fmt.Sprintf("%s is required for the keyring secret store", SecretKeyringFileEnvStr))
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, fmt.Sprintf("failed to read keyring file %s", path))
+	}
+	provider := &FileKeyProvider{}
+	err = json.Unmarshal(content, provider)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, fmt.Sprintf("failed to parse keyring file %s", path))
+	}
+	if _, _, err := provider.ActiveKey(); err != nil {
+		return nil, err
+	}
+	return provider, nil
+}
+
+// ActiveKey returns the active key
+func (p *FileKeyProvider) ActiveKey() (string, []byte, errors.Error) {
+	key, err := p.Key(p.Active)
+	if err != nil {
+		return "", nil, errors.Default.Wrap(err, "invalid active key")
+	}
+	return p.Active, key, nil
+}
+
+// Key returns the key with the specified id
+func (p *FileKeyProvider) Key(id string) ([]byte, errors.Error) {
+	key, ok := p.Keys[id]
+	if !ok || key == "" {
+		return nil, errors.NotFound.New(fmt.Sprintf("key %s not found in keyring", id))
+	}
+	return []byte(key), nil
+}
+
+var _ core.KeyProvider = (*FileKeyProvider)(nil)
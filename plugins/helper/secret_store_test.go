@@ -0,0 +1,101 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helper
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/apache/incubator-devlake/plugins/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAesSecretStore(t *testing.T) {
+	store := NewAesSecretStore("old-key")
+	cipherText, err := store.Encrypt("secret")
+	assert.Nil(t, err)
+
+	// compatible with secrets encrypted before SecretStore was introduced
+	plainText, err := core.Decrypt("old-key", cipherText)
+	assert.Nil(t, err)
+	assert.Equal(t, "secret", plainText)
+
+	_, err = NewAesSecretStore("new-key").Decrypt(cipherText)
+	assert.NotNil(t, err)
+}
+
+func TestKeyringSecretStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keyring.json")
+	assert.Nil(t, os.WriteFile(path, []byte(`{"active": "k1", "keys": {"k1": "key-one"}}`), 0600))
+	provider, err := NewFileKeyProvider(path)
+	assert.Nil(t, err)
+	legacy := NewAesSecretStore("old-key")
+	store := NewKeyringSecretStore(provider, legacy)
+
+	cipherText, err := store.Encrypt("secret")
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(cipherText, "keyring:k1:"))
+	plainText, err := store.Decrypt(cipherText)
+	assert.Nil(t, err)
+	assert.Equal(t, "secret", plainText)
+
+	// secrets encrypted by the legacy store remain readable
+	legacyCipherText, err := legacy.Encrypt("legacy secret")
+	assert.Nil(t, err)
+	plainText, err = store.Decrypt(legacyCipherText)
+	assert.Nil(t, err)
+	assert.Equal(t, "legacy secret", plainText)
+
+	// rotate to k2, secrets encrypted by k1 remain readable as long as k1 is in the keyring
+	assert.Nil(t, os.WriteFile(path, []byte(`{"active": "k2", "keys": {"k1": "key-one", "k2": "key-two"}}`), 0600))
+	provider, err = NewFileKeyProvider(path)
+	assert.Nil(t, err)
+	rotated := NewKeyringSecretStore(provider, nil)
+	plainText, err = rotated.Decrypt(cipherText)
+	assert.Nil(t, err)
+	assert.Equal(t, "secret", plainText)
+	rotatedCipherText, err := rotated.Encrypt(plainText)
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(rotatedCipherText, "keyring:k2:"))
+	_, err = rotated.Decrypt(legacyCipherText)
+	assert.NotNil(t, err)
+}
+
+func TestNewFileKeyProvider(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keyring.json")
+	assert.Nil(t, os.WriteFile(path, []byte(`{"active": "k2", "keys": {"k1": "key-one"}}`), 0600))
+	_, err := NewFileKeyProvider(path)
+	assert.NotNil(t, err)
+	_, err = NewFileKeyProvider("")
+	assert.NotNil(t, err)
+}
+
+func TestHasEncryptFields(t *testing.T) {
+	type conn struct {
+		RestConnection
+		BasicAuth
+	}
+	type plain struct {
+		RestConnection
+	}
+	assert.True(t, HasEncryptFields(reflect.TypeOf(&conn{})))
+	assert.False(t, HasEncryptFields(reflect.TypeOf(&plain{})))
+}
@@ -18,14 +18,17 @@ limitations under the License.
 package api
 
 import (
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/apache/incubator-devlake/errors"
 	"github.com/apache/incubator-devlake/plugins/core"
 	"github.com/apache/incubator-devlake/plugins/core/dal"
 	"github.com/apache/incubator-devlake/plugins/helper"
 	"github.com/apache/incubator-devlake/plugins/jenkins/models"
+	"github.com/apache/incubator-devlake/plugins/jenkins/tasks"
 	"github.com/mitchellh/mapstructure"
 	"gorm.io/gorm"
 )
@@ -67,8 +70,18 @@ func PutScope(input *core.ApiResourceInput) (*core.ApiResourceOutput, errors.Err
 		} else {
 			keeper[job.FullName] = struct{}{}
 		}
+		if tasks.IsFolder(job.Class) {
+			return nil, errors.BadInput.New(fmt.Sprintf("%s is a folder, please add the jobs inside it instead", job.FullName))
+		}
 		job.ConnectionId = connectionId
-
+		// jobs could be nested in folders, i.e. "path1/path2/job name"
+		if i := strings.LastIndex(job.FullName, `/`); i >= 0 {
+			job.Name = job.FullName[i+1:]
+			job.Path = `job/` + strings.Join(strings.Split(job.FullName[:i], `/`), `/job/`) + `/`
+		} else {
+			job.Name = job.FullName
+			job.Path = ``
+		}
 	}
 	err = basicRes.GetDal().CreateOrUpdate(jobs.Data)
 	if err != nil {
@@ -196,8 +209,10 @@ func extractParam(params map[string]string) (uint64, string, errors.Error) {
 	if connectionId == 0 {
 		return 0, "", errors.BadInput.New("invalid connectionId")
 	}
-	if params["fullName"] == "" {
+	// fullName is a wildcard param to match jobs nested in folders, which always starts with a slash
+	fullName := strings.TrimPrefix(params["fullName"], "/")
+	if fullName == "" {
 		return 0, "", errors.BadInput.New("invalid fullName")
 	}
-	return connectionId, params["fullName"], nil
+	return connectionId, fullName, nil
 }
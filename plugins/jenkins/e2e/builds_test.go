@@ -149,3 +149,91 @@ func TestJenkinsBuildsDataFlow(t *testing.T) {
 		),
 	)
 }
+
+func TestJenkinsMultibranchBuildsDataFlow(t *testing.T) {
+
+	var jenkins impl.Jenkins
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "jenkins", jenkins)
+
+	taskData := &tasks.JenkinsTaskData{
+		Options: &tasks.JenkinsOptions{
+			ConnectionId:              1,
+			JobName:                   `devlake`,
+			JobFullName:               `merico-dev/devlake`,
+			JobPath:                   `job/merico-dev/`,
+			Class:                     tasks.WORKFLOW_MULTI_BRANCH_PROJECT,
+			JenkinsTransformationRule: &models.JenkinsTransformationRule{},
+		},
+	}
+
+	dataflowTester.FlushTabler(&models.JenkinsBuild{})
+	dataflowTester.FlushTabler(&models.JenkinsBuildCommit{})
+
+	// builds of all branch jobs are collected into the multibranch pipeline
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_jenkins_api_builds_multibranch.csv", "_raw_jenkins_api_builds")
+
+	dataflowTester.Subtask(tasks.ExtractApiBuildsMeta, taskData)
+	dataflowTester.VerifyTable(
+		models.JenkinsBuild{},
+		"./snapshot_tables/_tool_jenkins_builds_multibranch.csv",
+		e2ehelper.ColumnWithRawData(
+			"connection_id",
+			"full_name",
+			"full_display_name",
+			"job_name",
+			"job_path",
+			"duration",
+			"queue_duration",
+			"number",
+			"result",
+			"timestamp",
+			"start_time",
+			"building",
+			"branch",
+		),
+	)
+	dataflowTester.VerifyTable(
+		models.JenkinsBuildCommit{},
+		"./snapshot_tables/_tool_jenkins_build_commits_multibranch.csv",
+		e2ehelper.ColumnWithRawData(
+			"connection_id",
+			"build_name",
+			"commit_sha",
+			"branch",
+			"repo_url",
+		),
+	)
+
+	dataflowTester.FlushTabler(&devops.CICDTask{})
+	dataflowTester.FlushTabler(&devops.CICDPipeline{})
+	dataflowTester.FlushTabler(&devops.CiCDPipelineCommit{})
+	dataflowTester.Subtask(tasks.ConvertBuildsToCICDMeta, taskData)
+	dataflowTester.Subtask(tasks.ConvertBuildReposMeta, taskData)
+	dataflowTester.VerifyTable(
+		devops.CICDPipeline{},
+		"./snapshot_tables/cicd_pipelines_multibranch.csv",
+		e2ehelper.ColumnWithRawData(
+			"name",
+			"result",
+			"status",
+			"type",
+			"duration_sec",
+			"queued_duration_sec",
+			"environment",
+			"created_date",
+			"finished_date",
+			"cicd_scope_id",
+		),
+	)
+	dataflowTester.VerifyTable(
+		devops.CiCDPipelineCommit{},
+		"./snapshot_tables/cicd_pipeline_commits_multibranch.csv",
+		e2ehelper.ColumnWithRawData(
+			"pipeline_id",
+			"commit_sha",
+			"repo_id",
+			"repo_url",
+			"branch",
+		),
+	)
+}
@@ -87,6 +87,7 @@ func TestJenkinsStagesDataFlow(t *testing.T) {
 			"status",
 			"type",
 			"duration_sec",
+			"wait_duration_sec",
 			"started_date",
 			"finished_date",
 			"environment",
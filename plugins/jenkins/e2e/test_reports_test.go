@@ -0,0 +1,91 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/plugins/jenkins/impl"
+	"github.com/apache/incubator-devlake/plugins/jenkins/models"
+	"github.com/apache/incubator-devlake/plugins/jenkins/tasks"
+)
+
+func TestJenkinsTestReportsDataFlow(t *testing.T) {
+	var jenkins impl.Jenkins
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "jenkins", jenkins)
+
+	taskData := &tasks.JenkinsTaskData{
+		Options: &tasks.JenkinsOptions{
+			ConnectionId:              1,
+			JobName:                   `devlake`,
+			JobFullName:               `merico-dev/devlake`,
+			JobPath:                   `job/merico-dev/`,
+			Class:                     tasks.WORKFLOW_MULTI_BRANCH_PROJECT,
+			JenkinsTransformationRule: &models.JenkinsTransformationRule{},
+		},
+	}
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_jenkins_api_test_reports.csv", "_raw_jenkins_api_test_reports")
+	dataflowTester.FlushTabler(&models.JenkinsBuild{})
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_jenkins_builds_multibranch.csv", models.JenkinsBuild{})
+
+	// verify extraction
+	dataflowTester.FlushTabler(&models.JenkinsTestCase{})
+	dataflowTester.Subtask(tasks.ExtractApiTestReportsMeta, taskData)
+	dataflowTester.VerifyTable(
+		models.JenkinsTestCase{},
+		"./snapshot_tables/_tool_jenkins_test_cases.csv",
+		e2ehelper.ColumnWithRawData(
+			"connection_id",
+			"build_name",
+			"class_name",
+			"name",
+			"suite_name",
+			"status",
+			"duration",
+			"skipped",
+			"error_details",
+			"flaky_failures",
+			"rerun_failures",
+		),
+	)
+
+	// verify conversion
+	dataflowTester.FlushTabler(&devops.CICDTestResult{})
+	dataflowTester.Subtask(tasks.ConvertTestResultsMeta, taskData)
+	dataflowTester.VerifyTable(
+		devops.CICDTestResult{},
+		"./snapshot_tables/cicd_test_results.csv",
+		e2ehelper.ColumnWithRawData(
+			"id",
+			"pipeline_id",
+			"cicd_scope_id",
+			"suite_name",
+			"class_name",
+			"name",
+			"result",
+			"duration_sec",
+			"error_message",
+			"rerun_count",
+			"is_flaky",
+		),
+	)
+}
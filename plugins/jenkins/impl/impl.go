@@ -73,6 +73,7 @@ func (plugin Jenkins) GetTablesInfo() []core.Tabler {
 		&models.JenkinsResponse{},
 		&models.JenkinsStage{},
 		&models.JenkinsTask{},
+		&models.JenkinsTestCase{},
 	}
 }
 
@@ -89,10 +90,13 @@ func (plugin Jenkins) SubTaskMetas() []core.SubTaskMeta {
 		tasks.ExtractApiBuildsMeta,
 		tasks.CollectApiStagesMeta,
 		tasks.ExtractApiStagesMeta,
+		tasks.CollectApiTestReportsMeta,
+		tasks.ExtractApiTestReportsMeta,
 		tasks.EnrichApiBuildWithStagesMeta,
 		tasks.ConvertBuildsToCICDMeta,
 		tasks.ConvertStagesMeta,
 		tasks.ConvertBuildReposMeta,
+		tasks.ConvertTestResultsMeta,
 	}
 }
 func (plugin Jenkins) PrepareTaskData(taskCtx core.TaskContext, options map[string]interface{}) (interface{}, errors.Error) {
@@ -186,7 +190,7 @@ func (plugin Jenkins) ApiResources() map[string]map[string]core.ApiResourceHandl
 			"DELETE": api.DeleteConnection,
 			"GET":    api.GetConnection,
 		},
-		"connections/:connectionId/scopes/:fullName": {
+		"connections/:connectionId/scopes/*fullName": {
 			"GET":   api.GetScope,
 			"PATCH": api.UpdateScope,
 		},
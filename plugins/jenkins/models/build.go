@@ -31,6 +31,7 @@ type JenkinsBuild struct {
 	JobName           string    `gorm:"index;type:varchar(255)"`
 	JobPath           string    `gorm:"index;type:varchar(255)"`
 	Duration          float64   // build time
+	QueueDuration     float64   // time waiting in queue before build started
 	FullName          string    `gorm:"primaryKey;type:varchar(255)"` // "path/job name#7"
 	FullDisplayName   string    `gorm:"type:varchar(255)"`            // "path » job name #7"
 	EstimatedDuration float64   // EstimatedDuration
@@ -43,6 +44,7 @@ type JenkinsBuild struct {
 	TriggeredBy       string    `gorm:"type:varchar(255)"`
 	Building          bool
 	HasStages         bool
+	Branch            string `gorm:"type:varchar(255)"` // branch of the build if the job is a multibranch pipeline
 }
 
 func (JenkinsBuild) TableName() string {
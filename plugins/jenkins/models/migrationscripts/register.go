@@ -32,5 +32,7 @@ func All() []core.MigrationScript {
 		new(changeIndexOfJobPath),
 		new(addTransformationRule20221128),
 		new(addFullNameForBuilds),
+		new(addBranchForBuilds),
+		new(addTestCasesAndQueueDuration),
 	}
 }
@@ -56,6 +56,7 @@ type AssignedLabels struct {
 
 type ApiBuildResponse struct {
 	Class             string    `json:"_class"`
+	Url               string    `json:"url"`
 	Number            int64     `json:"number"`
 	Result            string    `json:"result"`
 	Building          bool      `json:"building"`
@@ -77,6 +78,7 @@ type Action struct {
 	MercurialRevisionNumber string            `json:"mercurialRevisionNumber"`
 	RemoteUrls              []string          `json:"remoteUrls"`
 	Causes                  []Cause           `json:"causes"`
+	QueuingDurationMillis   float64           `json:"queuingDurationMillis"`
 }
 type ChangeSet struct {
 	Class     string     `json:"_class"`
@@ -115,3 +117,23 @@ type Cause struct {
 	UpstreamProject  string `json:"upstreamProject"`
 	UpstreamURL      string `json:"upstreamUrl"`
 }
+
+type TestSuite struct {
+	Name  string     `json:"name"`
+	Cases []TestCase `json:"cases"`
+}
+
+type TestCase struct {
+	ClassName     string        `json:"className"`
+	Name          string        `json:"name"`
+	Status        string        `json:"status"`
+	Duration      float64       `json:"duration"`
+	Skipped       bool          `json:"skipped"`
+	ErrorDetails  string        `json:"errorDetails"`
+	FlakyFailures []TestFailure `json:"flakyFailures"`
+	RerunFailures []TestFailure `json:"rerunFailures"`
+}
+
+type TestFailure struct {
+	Message string `json:"message"`
+}
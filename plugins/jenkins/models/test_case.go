@@ -0,0 +1,41 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import "github.com/apache/incubator-devlake/models/common"
+
+// JenkinsTestCase db entity for a junit test case reported by a jenkins build, the same case may be run by
+// several suites of a build. Lengths of the primary key columns are kept within the index limit of mysql
+type JenkinsTestCase struct {
+	ConnectionId  uint64  `gorm:"primaryKey"`
+	BuildName     string  `gorm:"primaryKey;type:varchar(255)"`
+	SuiteName     string  `gorm:"primaryKey;type:varchar(100)"`
+	ClassName     string  `gorm:"primaryKey;type:varchar(255)"`
+	Name          string  `gorm:"primaryKey;type:varchar(150)"`
+	Status        string  `gorm:"type:varchar(100)"`
+	Duration      float64 // in seconds
+	Skipped       bool
+	ErrorDetails  string
+	FlakyFailures int // failed runs before passing on rerun
+	RerunFailures int // failed reruns of a failing test
+	common.NoPKModel
+}
+
+func (JenkinsTestCase) TableName() string {
+	return "_tool_jenkins_test_cases"
+}
@@ -102,13 +102,14 @@ func ConvertBuildsToCICD(taskCtx core.SubTaskContext) (err errors.Error) {
 				DomainEntity: domainlayer.DomainEntity{
 					Id: buildIdGen.Generate(jenkinsBuild.ConnectionId, jenkinsBuild.FullName),
 				},
-				Name:         jenkinsBuild.FullDisplayName,
-				Result:       jenkinsPipelineResult,
-				Status:       jenkinsPipelineStatus,
-				FinishedDate: jenkinsPipelineFinishedDate,
-				DurationSec:  uint64(durationSec),
-				CreatedDate:  jenkinsBuild.StartTime,
-				CicdScopeId:  jobIdGen.Generate(jenkinsBuild.ConnectionId, data.Options.JobFullName),
+				Name:              jenkinsBuild.FullDisplayName,
+				Result:            jenkinsPipelineResult,
+				Status:            jenkinsPipelineStatus,
+				FinishedDate:      jenkinsPipelineFinishedDate,
+				DurationSec:       uint64(durationSec),
+				QueuedDurationSec: uint64(jenkinsBuild.QueueDuration / 1000),
+				CreatedDate:       jenkinsBuild.StartTime,
+				CicdScopeId:       jobIdGen.Generate(jenkinsBuild.ConnectionId, data.Options.JobFullName),
 			}
 			jenkinsPipeline.RawDataOrigin = jenkinsBuild.RawDataOrigin
 			results = append(results, jenkinsPipeline)
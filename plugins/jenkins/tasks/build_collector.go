@@ -20,11 +20,13 @@ package tasks
 import (
 	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
 	"github.com/apache/incubator-devlake/errors"
 	"github.com/apache/incubator-devlake/plugins/core"
 	"github.com/apache/incubator-devlake/plugins/helper"
-	"net/http"
-	"net/url"
 )
 
 const RAW_BUILD_TABLE = "jenkins_api_builds"
@@ -44,18 +46,30 @@ type SimpleJob struct {
 
 func CollectApiBuilds(taskCtx core.SubTaskContext) errors.Error {
 	data := taskCtx.GetData().(*JenkinsTaskData)
+	isMultiBranch := data.Options.Class == WORKFLOW_MULTI_BRANCH_PROJECT
 
-	collector, err := helper.NewApiCollector(helper.ApiCollectorArgs{
-		RawDataSubTaskArgs: helper.RawDataSubTaskArgs{
-			Params: JenkinsApiParams{
-				ConnectionId: data.Options.ConnectionId,
-				FullName:     data.Options.JobFullName,
-			},
-			Ctx:   taskCtx,
-			Table: RAW_BUILD_TABLE,
+	collectorWithState, err := helper.NewApiCollectorWithState(helper.RawDataSubTaskArgs{
+		Params: JenkinsApiParams{
+			ConnectionId: data.Options.ConnectionId,
+			FullName:     data.Options.JobFullName,
 		},
+		Ctx:   taskCtx,
+		Table: RAW_BUILD_TABLE,
+	}, data.CreatedDateAfter)
+	if err != nil {
+		return err
+	}
+	// jenkins takes no time filter, builds are listed from the latest and those finished before the window are
+	// dropped, which ends the paging once a page is not full
+	timeWindow := &helper.IncrementalTimeWindow{
+		Overlap: 5 * time.Minute,
+	}
+	since := collectorWithState.TimeWindowSince(timeWindow)
+
+	err = collectorWithState.InitCollector(helper.ApiCollectorArgs{
 		ApiClient:   data.ApiClient,
 		PageSize:    100,
+		TimeWindow:  timeWindow,
 		UrlTemplate: fmt.Sprintf("%sjob/%s/api/json", data.Options.JobPath, data.Options.JobName),
 		/*
 			(Optional) Return query string for request, or you can plug them into UrlTemplate directly
@@ -63,26 +77,60 @@ func CollectApiBuilds(taskCtx core.SubTaskContext) errors.Error {
 		Query: func(reqData *helper.RequestData) (url.Values, errors.Error) {
 			query := url.Values{}
 			treeValue := fmt.Sprintf(
-				"allBuilds[number,timestamp,duration,building,estimatedDuration,fullDisplayName,result,actions[lastBuiltRevision[SHA1,branch[name]],remoteUrls,mercurialRevisionNumber,causes[*]],changeSet[kind,revisions[revision]]]{%d,%d}",
+				"allBuilds[number,timestamp,duration,building,estimatedDuration,fullDisplayName,result,actions[lastBuiltRevision[SHA1,branch[name]],remoteUrls,mercurialRevisionNumber,causes[*],queuingDurationMillis],changeSet[kind,revisions[revision]]]{%d,%d}",
 				reqData.Pager.Skip, reqData.Pager.Skip+reqData.Pager.Size)
+			if isMultiBranch {
+				// builds live in the branch jobs of a multibranch pipeline, the url tells which branch a build belongs to
+				treeValue = fmt.Sprintf(
+					"jobs[allBuilds[url,number,timestamp,duration,building,estimatedDuration,fullDisplayName,result,actions[lastBuiltRevision[SHA1,branch[name]],remoteUrls,mercurialRevisionNumber,causes[*],queuingDurationMillis],changeSet[kind,revisions[revision]]]{%d,%d}]",
+					reqData.Pager.Skip, reqData.Pager.Skip+reqData.Pager.Size)
+			}
 			query.Set("tree", treeValue)
 			return query, nil
 		},
 		ResponseParser: func(res *http.Response) ([]json.RawMessage, errors.Error) {
 			var data struct {
 				Builds []json.RawMessage `json:"allBuilds"`
+				Jobs   []struct {
+					Builds []json.RawMessage `json:"allBuilds"`
+				} `json:"jobs"`
 			}
 			err := helper.UnmarshalResponse(res, &data)
 			if err != nil {
 				return nil, err
 			}
-			return data.Builds, nil
+			for _, job := range data.Jobs {
+				data.Builds = append(data.Builds, job.Builds...)
+			}
+			if since == nil {
+				return data.Builds, nil
+			}
+			return filterBuildsFinishedSince(data.Builds, *since)
 		},
 	})
-
 	if err != nil {
 		return err
 	}
 
-	return collector.Execute()
+	return collectorWithState.Execute()
+}
+
+// filterBuildsFinishedSince keeps builds still running or finished since the given time
+func filterBuildsFinishedSince(builds []json.RawMessage, since time.Time) ([]json.RawMessage, errors.Error) {
+	filtered := make([]json.RawMessage, 0, len(builds))
+	for _, build := range builds {
+		var times struct {
+			Timestamp int64
+			Duration  int64
+			Building  bool
+		}
+		err := errors.Convert(json.Unmarshal(build, &times))
+		if err != nil {
+			return nil, err
+		}
+		if times.Building || times.Timestamp+times.Duration >= since.UnixMilli() {
+			filtered = append(filtered, build)
+		}
+	}
+	return filtered, nil
 }
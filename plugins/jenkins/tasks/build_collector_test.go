@@ -0,0 +1,41 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterBuildsFinishedSince(t *testing.T) {
+	since := time.Date(2022, 12, 1, 8, 0, 0, 0, time.UTC)
+	builds := []json.RawMessage{
+		// started before the window but still running
+		json.RawMessage(`{"number":4,"timestamp":1669874400000,"duration":0,"building":true}`),
+		// started before the window and finished within it
+		json.RawMessage(`{"number":3,"timestamp":1669878000000,"duration":3600000,"building":false}`),
+		// finished before the window
+		json.RawMessage(`{"number":2,"timestamp":1669870800000,"duration":600000,"building":false}`),
+	}
+	filtered, err := filterBuildsFinishedSince(builds, since)
+	assert.Nil(t, err)
+	assert.Equal(t, builds[:2], filtered)
+}
@@ -42,7 +42,7 @@ func ConvertBuildRepos(taskCtx core.SubTaskContext) errors.Error {
 	data := taskCtx.GetData().(*JenkinsTaskData)
 
 	clauses := []dal.Clause{
-		dal.Select("*"),
+		dal.Select("_tool_jenkins_build_commits.*"),
 		dal.From(&models.JenkinsBuildCommit{}),
 		dal.Join(`left join _tool_jenkins_builds tjb 
 						on _tool_jenkins_build_commits.build_name = tjb.full_name 
@@ -20,6 +20,7 @@ package tasks
 import (
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"strings"
 	"time"
 
@@ -75,8 +76,27 @@ func ExtractApiBuilds(taskCtx core.SubTaskContext) errors.Error {
 				Building:          body.Building,
 				StartTime:         time.Unix(body.Timestamp/1000, 0),
 			}
+			if data.Options.Class == WORKFLOW_MULTI_BRANCH_PROJECT {
+				// builds of all branches are aggregated into the multibranch pipeline with the branch recorded
+				branchJobName, err := getBranchJobName(body.Url)
+				if err != nil {
+					return nil, err
+				}
+				build.FullName = fmt.Sprintf(`%s/%s#%d`, data.Options.JobFullName, branchJobName, body.Number)
+				build.Branch, err = errors.Convert01(url.PathUnescape(branchJobName))
+				if err != nil {
+					return nil, err
+				}
+			}
+			for _, a := range body.Actions {
+				// reported by TimeInQueueAction, kept after the queue item itself expires
+				if a.QueuingDurationMillis > 0 {
+					build.QueueDuration = a.QueuingDurationMillis
+				}
+			}
 			vcs := body.ChangeSet.Kind
-			if vcs == "git" || vcs == "hg" {
+			// builds of multibranch pipelines always check out their branch, even if nothing changed since the last build
+			if vcs == "git" || vcs == "hg" || build.Branch != "" {
 				for _, a := range body.Actions {
 					sha := ""
 					branch := ""
@@ -90,6 +110,9 @@ func ExtractApiBuilds(taskCtx core.SubTaskContext) errors.Error {
 					if len(a.LastBuiltRevision.Branches) > 0 {
 						branch = a.LastBuiltRevision.Branches[0].Name
 					}
+					if build.Branch != "" {
+						branch = build.Branch
+					}
 					for _, url := range a.RemoteUrls {
 						if url != "" {
 							buildCommitRemoteUrl := models.JenkinsBuildCommit{
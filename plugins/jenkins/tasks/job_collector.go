@@ -33,6 +33,7 @@ var CollectApiJobsMeta = core.SubTaskMeta{
 	Name:             "collectApiJobs",
 	EntryPoint:       CollectApiJobs,
 	EnabledByDefault: true,
+	Required:         true,
 	Description:      "Collect jobs data from jenkins api",
 	DomainTypes:      []string{core.DOMAIN_TYPE_CICD},
 }
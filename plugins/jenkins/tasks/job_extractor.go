@@ -32,6 +32,7 @@ var ExtractApiJobsMeta = core.SubTaskMeta{
 	Name:             "extractApiJobs",
 	EntryPoint:       ExtractApiJobs,
 	EnabledByDefault: true,
+	Required:         true,
 	Description:      "Extract raw jobs data into tool layer table jenkins_jobs",
 	DomainTypes:      []string{core.DOMAIN_TYPE_CICD},
 }
@@ -80,6 +81,7 @@ func ExtractApiJobs(taskCtx core.SubTaskContext) errors.Error {
 				results = append(results, &upDownJob)
 			}
 			data.Options.JobFullName = body.FullName
+			data.Options.Class = body.Class
 			results = append(results, job)
 
 			return results, nil
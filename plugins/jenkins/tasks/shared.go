@@ -18,12 +18,55 @@ limitations under the License.
 package tasks
 
 import (
+	"fmt"
 	"net/http"
+	"net/url"
+	"strings"
 
 	"github.com/apache/incubator-devlake/errors"
 	"github.com/apache/incubator-devlake/plugins/helper"
 )
 
+const (
+	WORKFLOW_MULTI_BRANCH_PROJECT = "org.jenkinsci.plugins.workflow.multibranch.WorkflowMultiBranchProject"
+	FOLDER                        = "com.cloudbees.hudson.plugins.folder.Folder"
+	ORGANIZATION_FOLDER           = "jenkins.branch.OrganizationFolder"
+)
+
+// IsFolder returns true if the job of the class only holds other jobs and has no builds of its own
+func IsFolder(class string) bool {
+	return class == FOLDER || class == ORGANIZATION_FOLDER
+}
+
+// getBuildPath returns the url path of a build by its full name,
+// i.e. "path1/path2/job name#7" => "job/path1/job/path2/job/job%20name/7/"
+func getBuildPath(fullName string) string {
+	jobFullName, number := fullName, ""
+	if i := strings.LastIndex(fullName, "#"); i >= 0 {
+		jobFullName, number = fullName[:i], fullName[i+1:]
+	}
+	names := strings.Split(jobFullName, "/")
+	for i, name := range names {
+		names[i] = url.PathEscape(name)
+	}
+	return fmt.Sprintf("job/%s/%s/", strings.Join(names, "/job/"), number)
+}
+
+// getBranchJobName returns the name of the branch job by the url of a build of a multibranch pipeline,
+// i.e. "https://jenkins/job/repo/job/feature%252Ffoo/7/" => "feature%2Ffoo", the branch name is "feature/foo"
+func getBranchJobName(buildUrl string) (string, errors.Error) {
+	u, err := url.Parse(buildUrl)
+	if err != nil {
+		return "", errors.Convert(err)
+	}
+	segments := strings.Split(strings.TrimSuffix(u.EscapedPath(), "/"), "/")
+	if len(segments) < 3 || segments[len(segments)-3] != "job" {
+		return "", errors.Default.New(fmt.Sprintf("unexpected build url %s", buildUrl))
+	}
+	name, err := url.PathUnescape(segments[len(segments)-2])
+	return name, errors.Convert(err)
+}
+
 func ignoreHTTPStatus404(res *http.Response) errors.Error {
 	if res.StatusCode == http.StatusUnauthorized {
 		return errors.Unauthorized.New("authentication failed, please check your AccessToken")
@@ -0,0 +1,43 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetBuildPath(t *testing.T) {
+	assert.Equal(t, "job/devlake/7/", getBuildPath("devlake#7"))
+	assert.Equal(t, "job/path1/job/path2/job/job%20name/7/", getBuildPath("path1/path2/job name#7"))
+	// branch jobs of multibranch pipelines keep the encoded branch name
+	assert.Equal(t, "job/merico-dev/job/devlake/job/feature%252Fdora/1/", getBuildPath("merico-dev/devlake/feature%2Fdora#1"))
+}
+
+func TestGetBranchJobName(t *testing.T) {
+	name, err := getBranchJobName("https://jenkins.merico.cn/job/merico-dev/job/devlake/job/main/2/")
+	assert.Nil(t, err)
+	assert.Equal(t, "main", name)
+	name, err = getBranchJobName("https://jenkins.merico.cn/job/devlake/job/feature%252Fdora/1/")
+	assert.Nil(t, err)
+	assert.Equal(t, "feature%2Fdora", name)
+
+	_, err = getBranchJobName("https://jenkins.merico.cn/job/devlake/")
+	assert.NotNil(t, err)
+}
@@ -19,7 +19,6 @@ package tasks
 
 import (
 	"encoding/json"
-	"fmt"
 	"net/http"
 	"net/url"
 	"reflect"
@@ -46,6 +45,11 @@ type SimpleBuild struct {
 	FullName string
 }
 
+// Path returns the url path of the build, branch jobs of multibranch pipelines included
+func (build SimpleBuild) Path() string {
+	return getBuildPath(build.FullName)
+}
+
 func CollectApiStages(taskCtx core.SubTaskContext) errors.Error {
 	db := taskCtx.GetDal()
 	data := taskCtx.GetData().(*JenkinsTaskData)
@@ -82,7 +86,7 @@ func CollectApiStages(taskCtx core.SubTaskContext) errors.Error {
 		},
 		ApiClient:   data.ApiClient,
 		Input:       iterator,
-		UrlTemplate: fmt.Sprintf("%sjob/%s/{{ .Input.Number }}/wfapi/describe", data.Options.JobPath, data.Options.JobName),
+		UrlTemplate: "{{ .Input.Path }}wfapi/describe",
 		/*
 			(Optional) Return query string for request, or you can plug them into UrlTemplate directly
 		*/
@@ -123,21 +123,28 @@ func ConvertStages(taskCtx core.SubTaskContext) (err errors.Error) {
 				jenkinsTaskStatus = devops.IN_PROGRESS
 			}
 
+			// the pause may be reported longer than the stage itself for stages still waiting on input
+			executionMillis := body.DurationMillis - body.PauseDurationMillis
+			if executionMillis < 0 {
+				executionMillis = 0
+			}
 			startedDate := time.Unix(body.StartTimeMillis/1000, 0)
 			finishedDate := startedDate.Add(time.Duration(durationSec * int64(time.Second)))
 			jenkinsTaskFinishedDate = &finishedDate
+			// time paused on input steps, i.e. waiting for approval, is recorded apart from the execution time
 			jenkinsTask := &devops.CICDTask{
 				DomainEntity: domainlayer.DomainEntity{
 					Id: stageIdGen.Generate(body.ConnectionId, body.BuildName, body.ID),
 				},
-				Name:         body.Name,
-				PipelineId:   buildIdGen.Generate(body.ConnectionId, body.BuildName),
-				Result:       jenkinsTaskResult,
-				Status:       jenkinsTaskStatus,
-				DurationSec:  uint64(body.DurationMillis / 1000),
-				StartedDate:  time.Unix(durationSec, 0),
-				FinishedDate: jenkinsTaskFinishedDate,
-				CicdScopeId:  jobIdGen.Generate(body.ConnectionId, data.Options.JobFullName),
+				Name:            body.Name,
+				PipelineId:      buildIdGen.Generate(body.ConnectionId, body.BuildName),
+				Result:          jenkinsTaskResult,
+				Status:          jenkinsTaskStatus,
+				DurationSec:     uint64(executionMillis / 1000),
+				WaitDurationSec: uint64(body.PauseDurationMillis / 1000),
+				StartedDate:     time.Unix(durationSec, 0),
+				FinishedDate:    jenkinsTaskFinishedDate,
+				CicdScopeId:     jobIdGen.Generate(body.ConnectionId, data.Options.JobFullName),
 			}
 			jenkinsTask.Type = regexEnricher.GetEnrichResult(deploymentPattern, jenkinsTask.Name, devops.DEPLOYMENT)
 			jenkinsTask.Environment = regexEnricher.GetEnrichResult(productionPattern, jenkinsTask.Name, devops.PRODUCTION)
@@ -37,6 +37,7 @@ type JenkinsOptions struct {
 	JobFullName                       string `json:"JobFullName"` // "path1/path2/job name"
 	JobName                           string `json:"jobName"`     // "job name"
 	JobPath                           string `json:"jobPath"`     // "job/path1/job/path2"
+	Class                             string `json:"class"`
 	CreatedDateAfter                  string
 	Tasks                             []string `json:"tasks,omitempty"`
 	*models.JenkinsTransformationRule `mapstructure:"transformationRules" json:"transformationRules"`
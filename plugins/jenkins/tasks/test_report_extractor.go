@@ -0,0 +1,85 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/plugins/core"
+	"github.com/apache/incubator-devlake/plugins/helper"
+	"github.com/apache/incubator-devlake/plugins/jenkins/models"
+)
+
+var ExtractApiTestReportsMeta = core.SubTaskMeta{
+	Name:             "extractApiTestReports",
+	EntryPoint:       ExtractApiTestReports,
+	EnabledByDefault: true,
+	Description:      "Extract raw test reports data into tool layer table jenkins_test_cases",
+	DomainTypes:      []string{core.DOMAIN_TYPE_CICD},
+}
+
+func ExtractApiTestReports(taskCtx core.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*JenkinsTaskData)
+	extractor, err := helper.NewApiExtractor(helper.ApiExtractorArgs{
+		RawDataSubTaskArgs: helper.RawDataSubTaskArgs{
+			Params: JenkinsApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				FullName:     data.Options.JobFullName,
+			},
+			Ctx:   taskCtx,
+			Table: RAW_TEST_REPORT_TABLE,
+		},
+		Extract: func(row *helper.RawData) ([]interface{}, errors.Error) {
+			body := &models.TestSuite{}
+			err := errors.Convert(json.Unmarshal(row.Data, body))
+			if err != nil {
+				return nil, err
+			}
+			input := &SimpleBuild{}
+			err = errors.Convert(json.Unmarshal(row.Input, input))
+			if err != nil {
+				return nil, err
+			}
+
+			results := make([]interface{}, 0, len(body.Cases))
+			for _, c := range body.Cases {
+				results = append(results, &models.JenkinsTestCase{
+					ConnectionId:  data.Options.ConnectionId,
+					BuildName:     input.FullName,
+					ClassName:     c.ClassName,
+					Name:          c.Name,
+					SuiteName:     body.Name,
+					Status:        c.Status,
+					Duration:      c.Duration,
+					Skipped:       c.Skipped,
+					ErrorDetails:  c.ErrorDetails,
+					FlakyFailures: len(c.FlakyFailures),
+					RerunFailures: len(c.RerunFailures),
+				})
+			}
+			return results, nil
+		},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return extractor.Execute()
+}
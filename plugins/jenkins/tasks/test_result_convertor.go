@@ -0,0 +1,121 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/models/domainlayer"
+	"github.com/apache/incubator-devlake/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/plugins/core"
+	"github.com/apache/incubator-devlake/plugins/core/dal"
+	"github.com/apache/incubator-devlake/plugins/helper"
+	"github.com/apache/incubator-devlake/plugins/jenkins/models"
+)
+
+var ConvertTestResultsMeta = core.SubTaskMeta{
+	Name:             "convertTestResults",
+	EntryPoint:       ConvertTestResults,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table jenkins_test_cases into domain layer table cicd_test_results",
+	DomainTypes:      []string{core.DOMAIN_TYPE_CICD},
+}
+
+func ConvertTestResults(taskCtx core.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*JenkinsTaskData)
+
+	clauses := []dal.Clause{
+		dal.Select("_tool_jenkins_test_cases.*"),
+		dal.From(&models.JenkinsTestCase{}),
+		dal.Join(`left join _tool_jenkins_builds tjb
+						on _tool_jenkins_test_cases.build_name = tjb.full_name
+						and _tool_jenkins_test_cases.connection_id = tjb.connection_id`),
+		dal.Where(`_tool_jenkins_test_cases.connection_id = ?
+							and tjb.job_path = ? and tjb.job_name = ?`,
+			data.Options.ConnectionId, data.Options.JobPath, data.Options.JobName),
+	}
+	cursor, err := db.Cursor(clauses...)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	testCaseIdGen := didgen.NewDomainIdGenerator(&models.JenkinsTestCase{})
+	buildIdGen := didgen.NewDomainIdGenerator(&models.JenkinsBuild{})
+	jobIdGen := didgen.NewDomainIdGenerator(&models.JenkinsJob{})
+
+	converter, err := helper.NewDataConverter(helper.DataConverterArgs{
+		InputRowType: reflect.TypeOf(models.JenkinsTestCase{}),
+		Input:        cursor,
+		RawDataSubTaskArgs: helper.RawDataSubTaskArgs{
+			Params: JenkinsApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				FullName:     data.Options.JobFullName,
+			},
+			Ctx:   taskCtx,
+			Table: RAW_TEST_REPORT_TABLE,
+		},
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			testCase := inputRow.(*models.JenkinsTestCase)
+			result := getTestResult(testCase)
+			testResult := &devops.CICDTestResult{
+				DomainEntity: domainlayer.DomainEntity{
+					Id: testCaseIdGen.Generate(testCase.ConnectionId, testCase.BuildName, testCase.SuiteName, testCase.ClassName, testCase.Name),
+				},
+				PipelineId:   buildIdGen.Generate(testCase.ConnectionId, testCase.BuildName),
+				CicdScopeId:  jobIdGen.Generate(testCase.ConnectionId, data.Options.JobFullName),
+				SuiteName:    testCase.SuiteName,
+				ClassName:    testCase.ClassName,
+				Name:         testCase.Name,
+				Result:       result,
+				DurationSec:  testCase.Duration,
+				ErrorMessage: testCase.ErrorDetails,
+				RerunCount:   testCase.FlakyFailures + testCase.RerunFailures,
+				// passed only after failing at least once on the same build
+				IsFlaky: result == devops.SUCCESS && testCase.FlakyFailures > 0,
+			}
+			return []interface{}{
+				testResult,
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
+
+// getTestResult maps junit case status (PASSED, FIXED, FAILED, REGRESSION, SKIPPED) into domain result
+func getTestResult(testCase *models.JenkinsTestCase) string {
+	if testCase.Skipped {
+		return devops.SKIPPED
+	}
+	switch testCase.Status {
+	case "PASSED", "FIXED":
+		return devops.SUCCESS
+	case "FAILED", "REGRESSION":
+		return devops.FAILURE
+	case "SKIPPED":
+		return devops.SKIPPED
+	}
+	return testCase.Status
+}
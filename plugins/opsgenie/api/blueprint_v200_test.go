@@ -0,0 +1,112 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/mocks"
+	"github.com/apache/incubator-devlake/models/common"
+	"github.com/apache/incubator-devlake/models/domainlayer"
+	"github.com/apache/incubator-devlake/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/plugins/core"
+	"github.com/apache/incubator-devlake/plugins/helper"
+	"github.com/apache/incubator-devlake/plugins/opsgenie/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestMakeDataSourcePipelinePlanV200(t *testing.T) {
+	mockMeta := mocks.NewPluginMeta(t)
+	mockMeta.On("RootPkgPath").Return("github.com/apache/incubator-devlake/plugins/opsgenie")
+	err := core.RegisterPlugin("opsgenie", mockMeta)
+	assert.Nil(t, err)
+	bs := &core.BlueprintScopeV200{
+		Entities: []string{"TICKET"},
+		Id:       "b8b3d2e6-5b0a-4d5c-8ef5-8d1f2b0e6c9a",
+		Name:     "",
+	}
+	bpScopes := make([]*core.BlueprintScopeV200, 0)
+	bpScopes = append(bpScopes, bs)
+
+	connection := &models.OpsgenieConnection{
+		RestConnection: helper.RestConnection{
+			BaseConnection: helper.BaseConnection{
+				Name: "opsgenie",
+				Model: common.Model{
+					ID: 1,
+				},
+			},
+		},
+	}
+	basicRes = NewMockBasicRes()
+	plan := make(core.PipelinePlan, len(bpScopes))
+	plan, err = makeDataSourcePipelinePlanV200(nil, plan, bpScopes, connection)
+	assert.Nil(t, err)
+	basicRes = NewMockBasicRes()
+	scopes, err := makeScopesV200(bpScopes, connection)
+	assert.Nil(t, err)
+
+	expectPlan := core.PipelinePlan{
+		core.PipelineStage{
+			{
+				Plugin:     "opsgenie",
+				Subtasks:   []string{},
+				SkipOnFail: false,
+				Options: map[string]interface{}{
+					"connectionId": uint64(1),
+					"serviceId":    "b8b3d2e6-5b0a-4d5c-8ef5-8d1f2b0e6c9a",
+					"name":         "Checkout",
+					"teamId":       "3c5c2a11-9c4e-4f7a-a0b1-6b8e3d9f2a10",
+				},
+			},
+		},
+	}
+	assert.Equal(t, expectPlan, plan)
+
+	expectScopes := make([]core.Scope, 0)
+	board := &ticket.Board{
+		DomainEntity: domainlayer.DomainEntity{
+			Id: "opsgenie:OpsgenieService:1:b8b3d2e6-5b0a-4d5c-8ef5-8d1f2b0e6c9a",
+		},
+		Name: "Checkout",
+	}
+	expectScopes = append(expectScopes, board)
+	assert.Equal(t, expectScopes, scopes)
+}
+
+func NewMockBasicRes() *mocks.BasicRes {
+	service := &models.OpsgenieService{
+		ConnectionId: 1,
+		Id:           "b8b3d2e6-5b0a-4d5c-8ef5-8d1f2b0e6c9a",
+		Name:         "Checkout",
+		TeamId:       "3c5c2a11-9c4e-4f7a-a0b1-6b8e3d9f2a10",
+	}
+	mockRes := new(mocks.BasicRes)
+	mockDal := new(mocks.Dal)
+
+	mockDal.On("First", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		dst := args.Get(0).(*models.OpsgenieService)
+		*dst = *service
+	}).Return(nil).Once()
+
+	mockRes.On("GetDal").Return(mockDal)
+	mockRes.On("GetConfig", mock.Anything).Return("")
+
+	return mockRes
+}
@@ -0,0 +1,151 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/plugins/core"
+	"github.com/apache/incubator-devlake/plugins/helper"
+	"github.com/apache/incubator-devlake/plugins/opsgenie/models"
+)
+
+// @Summary test opsgenie connection
+// @Description Test Opsgenie Connection
+// @Tags plugins/opsgenie
+// @Param body body models.TestConnectionRequest true "json body"
+// @Success 200  {object} shared.ApiBody "Success"
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /plugins/opsgenie/test [POST]
+func TestConnection(input *core.ApiResourceInput) (*core.ApiResourceOutput, errors.Error) {
+	var params models.TestConnectionRequest
+	err := helper.Decode(input.Body, &params, vld)
+	if err != nil {
+		return nil, err
+	}
+	apiClient, err := helper.NewApiClient(
+		context.TODO(),
+		params.Endpoint,
+		map[string]string{
+			"Authorization": fmt.Sprintf("GenieKey %s", params.Token),
+		},
+		3*time.Second,
+		params.Proxy,
+		basicRes,
+	)
+	if err != nil {
+		return nil, err
+	}
+	// reading alerts is what the collection needs, so it is also what the key is tested against
+	response, err := apiClient.Get("v2/alerts", map[string][]string{"limit": {"1"}}, nil)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, errors.HttpStatus(response.StatusCode).New("unexpected status code when testing connection")
+	}
+	return &core.ApiResourceOutput{Body: nil, Status: http.StatusOK}, nil
+}
+
+// @Summary create opsgenie connection
+// @Description Create Opsgenie connection
+// @Tags plugins/opsgenie
+// @Param body body models.OpsgenieConnection true "json body"
+// @Success 200  {object} models.OpsgenieConnection
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /plugins/opsgenie/connections [POST]
+func PostConnections(input *core.ApiResourceInput) (*core.ApiResourceOutput, errors.Error) {
+	connection := &models.OpsgenieConnection{}
+	err := connectionHelper.Create(connection, input)
+	if err != nil {
+		return nil, err
+	}
+	return &core.ApiResourceOutput{Body: connection, Status: http.StatusOK}, nil
+}
+
+// @Summary patch opsgenie connection
+// @Description Patch Opsgenie connection
+// @Tags plugins/opsgenie
+// @Param body body models.OpsgenieConnection true "json body"
+// @Success 200  {object} models.OpsgenieConnection
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /plugins/opsgenie/connections/{connectionId} [PATCH]
+func PatchConnection(input *core.ApiResourceInput) (*core.ApiResourceOutput, errors.Error) {
+	connection := &models.OpsgenieConnection{}
+	err := connectionHelper.Patch(connection, input)
+	if err != nil {
+		return nil, err
+	}
+	return &core.ApiResourceOutput{Body: connection, Status: http.StatusOK}, nil
+}
+
+// @Summary delete opsgenie connection
+// @Description Delete Opsgenie connection
+// @Tags plugins/opsgenie
+// @Success 200  {object} models.OpsgenieConnection
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /plugins/opsgenie/connections/{connectionId} [DELETE]
+func DeleteConnection(input *core.ApiResourceInput) (*core.ApiResourceOutput, errors.Error) {
+	connection := &models.OpsgenieConnection{}
+	err := connectionHelper.First(connection, input.Params)
+	if err != nil {
+		return nil, err
+	}
+	err = connectionHelper.Delete(connection)
+	return &core.ApiResourceOutput{Body: connection}, err
+}
+
+// @Summary list opsgenie connections
+// @Description List Opsgenie connections
+// @Tags plugins/opsgenie
+// @Success 200  {object} models.OpsgenieConnection
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /plugins/opsgenie/connections [GET]
+func ListConnections(input *core.ApiResourceInput) (*core.ApiResourceOutput, errors.Error) {
+	var connections []models.OpsgenieConnection
+	err := connectionHelper.List(&connections)
+	if err != nil {
+		return nil, err
+	}
+	return &core.ApiResourceOutput{Body: connections}, nil
+}
+
+// @Summary get opsgenie connection
+// @Description Get Opsgenie connection
+// @Tags plugins/opsgenie
+// @Success 200  {object} models.OpsgenieConnection
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /plugins/opsgenie/connections/{connectionId} [GET]
+func GetConnection(input *core.ApiResourceInput) (*core.ApiResourceOutput, errors.Error) {
+	connection := &models.OpsgenieConnection{}
+	err := connectionHelper.First(connection, input.Params)
+	if err != nil {
+		return nil, err
+	}
+	return &core.ApiResourceOutput{Body: connection}, nil
+}
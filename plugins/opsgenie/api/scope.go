@@ -0,0 +1,167 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/plugins/core"
+	"github.com/apache/incubator-devlake/plugins/core/dal"
+	"github.com/apache/incubator-devlake/plugins/helper"
+	"github.com/apache/incubator-devlake/plugins/opsgenie/models"
+	"github.com/mitchellh/mapstructure"
+	"gorm.io/gorm"
+)
+
+type req struct {
+	Data []*models.OpsgenieService `json:"data"`
+}
+
+// PutScope create or update opsgenie service
+// @Summary create or update opsgenie service
+// @Description Create or update opsgenie service
+// @Tags plugins/opsgenie
+// @Accept application/json
+// @Param connectionId path int false "connection ID"
+// @Param scope body req true "json"
+// @Success 200  {object} []models.OpsgenieService
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/opsgenie/connections/{connectionId}/scopes [PUT]
+func PutScope(input *core.ApiResourceInput) (*core.ApiResourceOutput, errors.Error) {
+	connectionId, _ := strconv.ParseUint(input.Params["connectionId"], 10, 64)
+	if connectionId == 0 {
+		return nil, errors.BadInput.New("invalid connectionId")
+	}
+	var services req
+	err := errors.Convert(mapstructure.Decode(input.Body, &services))
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "decoding Opsgenie service error")
+	}
+	keeper := make(map[string]struct{})
+	for _, service := range services.Data {
+		if _, ok := keeper[service.Id]; ok {
+			return nil, errors.BadInput.New("duplicated item")
+		} else {
+			keeper[service.Id] = struct{}{}
+		}
+		service.ConnectionId = connectionId
+	}
+	err = basicRes.GetDal().CreateOrUpdate(services.Data)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error on saving OpsgenieService")
+	}
+	return &core.ApiResourceOutput{Body: services.Data, Status: http.StatusOK}, nil
+}
+
+// UpdateScope patch to opsgenie service
+// @Summary patch to opsgenie service
+// @Description patch to opsgenie service
+// @Tags plugins/opsgenie
+// @Accept application/json
+// @Param connectionId path int false "connection ID"
+// @Param serviceId path string false "service ID"
+// @Param scope body models.OpsgenieService true "json"
+// @Success 200  {object} models.OpsgenieService
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/opsgenie/connections/{connectionId}/scopes/{serviceId} [PATCH]
+func UpdateScope(input *core.ApiResourceInput) (*core.ApiResourceOutput, errors.Error) {
+	connectionId, serviceId, err := extractParam(input.Params)
+	if err != nil {
+		return nil, err
+	}
+	var service models.OpsgenieService
+	err = basicRes.GetDal().First(&service, dal.Where("connection_id = ? AND id = ?", connectionId, serviceId))
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "getting OpsgenieService error")
+	}
+	err = helper.DecodeMapStruct(input.Body, &service)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "patch opsgenie service error")
+	}
+	err = basicRes.GetDal().Update(&service)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error on saving OpsgenieService")
+	}
+	return &core.ApiResourceOutput{Body: service, Status: http.StatusOK}, nil
+}
+
+// GetScopeList get Opsgenie services
+// @Summary get Opsgenie services
+// @Description get Opsgenie services
+// @Tags plugins/opsgenie
+// @Param connectionId path int false "connection ID"
+// @Param pageSize query int false "page size, default 50"
+// @Param page query int false "page size, default 1"
+// @Success 200  {object} []models.OpsgenieService
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/opsgenie/connections/{connectionId}/scopes/ [GET]
+func GetScopeList(input *core.ApiResourceInput) (*core.ApiResourceOutput, errors.Error) {
+	var services []models.OpsgenieService
+	connectionId, _ := strconv.ParseUint(input.Params["connectionId"], 10, 64)
+	if connectionId == 0 {
+		return nil, errors.BadInput.New("invalid path params")
+	}
+	limit, offset := helper.GetLimitOffset(input.Query, "pageSize", "page")
+	err := basicRes.GetDal().All(&services, dal.Where("connection_id = ?", connectionId), dal.Limit(limit), dal.Offset(offset))
+	if err != nil {
+		return nil, err
+	}
+	return &core.ApiResourceOutput{Body: services, Status: http.StatusOK}, nil
+}
+
+// GetScope get one Opsgenie service
+// @Summary get one Opsgenie service
+// @Description get one Opsgenie service
+// @Tags plugins/opsgenie
+// @Param connectionId path int false "connection ID"
+// @Param serviceId path string false "service ID"
+// @Success 200  {object} models.OpsgenieService
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/opsgenie/connections/{connectionId}/scopes/{serviceId} [GET]
+func GetScope(input *core.ApiResourceInput) (*core.ApiResourceOutput, errors.Error) {
+	var service models.OpsgenieService
+	connectionId, serviceId, err := extractParam(input.Params)
+	if err != nil {
+		return nil, err
+	}
+	err = basicRes.GetDal().First(&service, dal.Where("connection_id = ? AND id = ?", connectionId, serviceId))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.NotFound.New("record not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &core.ApiResourceOutput{Body: service, Status: http.StatusOK}, nil
+}
+
+func extractParam(params map[string]string) (uint64, string, errors.Error) {
+	connectionId, _ := strconv.ParseUint(params["connectionId"], 10, 64)
+	if connectionId == 0 {
+		return 0, "", errors.BadInput.New("invalid connectionId")
+	}
+	if params["serviceId"] == "" {
+		return 0, "", errors.BadInput.New("invalid serviceId")
+	}
+	return connectionId, params["serviceId"], nil
+}
@@ -0,0 +1,97 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/models/common"
+	"github.com/apache/incubator-devlake/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/plugins/opsgenie/impl"
+	"github.com/apache/incubator-devlake/plugins/opsgenie/models"
+	"github.com/apache/incubator-devlake/plugins/opsgenie/tasks"
+)
+
+func TestIncidentDataFlow(t *testing.T) {
+	var plugin impl.Opsgenie
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "opsgenie", plugin)
+
+	taskData := &tasks.OpsgenieTaskData{
+		Options: &tasks.OpsgenieOptions{
+			ConnectionId: 1,
+			ServiceId:    "b8b3d2e6-5b0a-4d5c-8ef5-8d1f2b0e6c9a",
+		},
+	}
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_opsgenie_api_incidents.csv", "_raw_opsgenie_api_incidents")
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_opsgenie_api_incident_logs.csv", "_raw_opsgenie_api_incident_logs")
+
+	// verify incident extraction, incidents of other services must be skipped
+	dataflowTester.FlushTabler(&models.OpsgenieIncident{})
+	dataflowTester.Subtask(tasks.ExtractIncidentsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(
+		models.OpsgenieIncident{},
+		e2ehelper.TableOptions{
+			CSVRelPath:  "./snapshot_tables/_tool_opsgenie_incidents.csv",
+			IgnoreTypes: []any{common.NoPKModel{}},
+		},
+	)
+
+	// verify incident log extraction
+	dataflowTester.FlushTabler(&models.OpsgenieIncidentLog{})
+	dataflowTester.Subtask(tasks.ExtractIncidentLogsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(
+		models.OpsgenieIncidentLog{},
+		e2ehelper.TableOptions{
+			CSVRelPath:  "./snapshot_tables/_tool_opsgenie_incident_logs.csv",
+			IgnoreTypes: []any{common.NoPKModel{}},
+		},
+	)
+
+	// verify incident conversion
+	dataflowTester.FlushTabler(&ticket.Issue{})
+	dataflowTester.FlushTabler(&ticket.BoardIssue{})
+	dataflowTester.Subtask(tasks.ConvertIncidentsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(
+		ticket.Issue{},
+		e2ehelper.TableOptions{
+			CSVRelPath:  "./snapshot_tables/issues_incident.csv",
+			IgnoreTypes: []any{common.NoPKModel{}},
+		},
+	)
+	dataflowTester.VerifyTableWithOptions(
+		ticket.BoardIssue{},
+		e2ehelper.TableOptions{
+			CSVRelPath:  "./snapshot_tables/board_issues_incident.csv",
+			IgnoreTypes: []any{common.NoPKModel{}},
+		},
+	)
+
+	// verify incident log conversion
+	dataflowTester.FlushTabler(&ticket.IssueChangelogs{})
+	dataflowTester.Subtask(tasks.ConvertIncidentLogsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(
+		ticket.IssueChangelogs{},
+		e2ehelper.TableOptions{
+			CSVRelPath:  "./snapshot_tables/issue_changelogs_incident.csv",
+			IgnoreTypes: []any{common.NoPKModel{}},
+		},
+	)
+}
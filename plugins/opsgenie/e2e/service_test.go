@@ -0,0 +1,66 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/models/common"
+	"github.com/apache/incubator-devlake/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/plugins/opsgenie/impl"
+	"github.com/apache/incubator-devlake/plugins/opsgenie/models"
+	"github.com/apache/incubator-devlake/plugins/opsgenie/tasks"
+)
+
+func TestServiceDataFlow(t *testing.T) {
+	var plugin impl.Opsgenie
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "opsgenie", plugin)
+
+	taskData := &tasks.OpsgenieTaskData{
+		Options: &tasks.OpsgenieOptions{
+			ConnectionId: 1,
+			ServiceId:    "b8b3d2e6-5b0a-4d5c-8ef5-8d1f2b0e6c9a",
+		},
+	}
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_opsgenie_api_services.csv", "_raw_opsgenie_api_services")
+
+	// verify service extraction
+	dataflowTester.FlushTabler(&models.OpsgenieService{})
+	dataflowTester.Subtask(tasks.ExtractServiceMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(
+		models.OpsgenieService{},
+		e2ehelper.TableOptions{
+			CSVRelPath:  "./snapshot_tables/_tool_opsgenie_services.csv",
+			IgnoreTypes: []any{common.NoPKModel{}},
+		},
+	)
+
+	// verify service conversion
+	dataflowTester.FlushTabler(&ticket.Board{})
+	dataflowTester.Subtask(tasks.ConvertServiceMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(
+		ticket.Board{},
+		e2ehelper.TableOptions{
+			CSVRelPath:  "./snapshot_tables/boards.csv",
+			IgnoreTypes: []any{common.NoPKModel{}},
+		},
+	)
+}
@@ -0,0 +1,167 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package impl
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/plugins/core"
+	"github.com/apache/incubator-devlake/plugins/helper"
+	"github.com/apache/incubator-devlake/plugins/opsgenie/api"
+	"github.com/apache/incubator-devlake/plugins/opsgenie/models"
+	"github.com/apache/incubator-devlake/plugins/opsgenie/models/migrationscripts"
+	"github.com/apache/incubator-devlake/plugins/opsgenie/tasks"
+	"github.com/spf13/viper"
+	"gorm.io/gorm"
+)
+
+// make sure interface is implemented
+var _ core.PluginMeta = (*Opsgenie)(nil)
+var _ core.PluginInit = (*Opsgenie)(nil)
+var _ core.PluginTask = (*Opsgenie)(nil)
+var _ core.PluginApi = (*Opsgenie)(nil)
+var _ core.PluginModel = (*Opsgenie)(nil)
+var _ core.PluginMigration = (*Opsgenie)(nil)
+var _ core.DataSourcePluginBlueprintV200 = (*Opsgenie)(nil)
+var _ core.CloseablePluginTask = (*Opsgenie)(nil)
+
+type Opsgenie struct{}
+
+func (plugin Opsgenie) Description() string {
+	return "To collect and enrich data from Opsgenie"
+}
+
+func (plugin Opsgenie) Init(config *viper.Viper, logger core.Logger, db *gorm.DB) errors.Error {
+	api.Init(config, logger, db)
+	return nil
+}
+
+func (plugin Opsgenie) GetTablesInfo() []core.Tabler {
+	return []core.Tabler{
+		&models.OpsgenieConnection{},
+		&models.OpsgenieService{},
+		&models.OpsgenieIncident{},
+		&models.OpsgenieIncidentLog{},
+		&models.OpsgenieAlert{},
+		&models.OpsgenieAlertLog{},
+	}
+}
+
+func (plugin Opsgenie) SubTaskMetas() []core.SubTaskMeta {
+	return []core.SubTaskMeta{
+		tasks.CollectServiceMeta,
+		tasks.ExtractServiceMeta,
+		tasks.ConvertServiceMeta,
+		tasks.CollectIncidentsMeta,
+		tasks.ExtractIncidentsMeta,
+		tasks.CollectIncidentLogsMeta,
+		tasks.ExtractIncidentLogsMeta,
+		tasks.ConvertIncidentsMeta,
+		tasks.ConvertIncidentLogsMeta,
+		tasks.CollectAlertsMeta,
+		tasks.ExtractAlertsMeta,
+		tasks.CollectAlertLogsMeta,
+		tasks.ExtractAlertLogsMeta,
+		tasks.ConvertAlertsMeta,
+		tasks.ConvertAlertLogsMeta,
+	}
+}
+
+func (plugin Opsgenie) PrepareTaskData(taskCtx core.TaskContext, options map[string]interface{}) (interface{}, errors.Error) {
+	op, err := tasks.DecodeAndValidateTaskOptions(options)
+	if err != nil {
+		return nil, err
+	}
+	connectionHelper := helper.NewConnectionHelper(
+		taskCtx,
+		nil,
+	)
+	connection := &models.OpsgenieConnection{}
+	err = connectionHelper.FirstById(connection, op.ConnectionId)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "unable to get Opsgenie connection by the given connection ID")
+	}
+	apiClient, err := tasks.NewOpsgenieApiClient(taskCtx, connection)
+	if err != nil {
+		return nil, err
+	}
+	taskData := &tasks.OpsgenieTaskData{
+		Options:   op,
+		ApiClient: apiClient,
+	}
+	if op.CreatedDateAfter != "" {
+		createdDateAfter, err := errors.Convert01(time.Parse("2006-01-02T15:04:05Z", op.CreatedDateAfter))
+		if err != nil {
+			return nil, errors.BadInput.Wrap(err, "invalid value for `createdDateAfter`")
+		}
+		taskData.CreatedDateAfter = &createdDateAfter
+	}
+	return taskData, nil
+}
+
+// PkgPath information lost when compiled as plugin(.so)
+func (plugin Opsgenie) RootPkgPath() string {
+	return "github.com/apache/incubator-devlake/plugins/opsgenie"
+}
+
+func (plugin Opsgenie) MigrationScripts() []core.MigrationScript {
+	return migrationscripts.All()
+}
+
+func (plugin Opsgenie) MakeDataSourcePipelinePlanV200(connectionId uint64, scopes []*core.BlueprintScopeV200) (core.PipelinePlan, []core.Scope, errors.Error) {
+	return api.MakeDataSourcePipelinePlanV200(plugin.SubTaskMetas(), connectionId, scopes)
+}
+
+func (plugin Opsgenie) ApiResources() map[string]map[string]core.ApiResourceHandler {
+	return map[string]map[string]core.ApiResourceHandler{
+		"test": {
+			"POST": api.TestConnection,
+		},
+		"connections": {
+			"POST": api.PostConnections,
+			"GET":  api.ListConnections,
+		},
+		"connections/:connectionId": {
+			"GET":    api.GetConnection,
+			"PATCH":  api.PatchConnection,
+			"DELETE": api.DeleteConnection,
+		},
+		"connections/:connectionId/scopes/:serviceId": {
+			"GET":   api.GetScope,
+			"PATCH": api.UpdateScope,
+		},
+		"connections/:connectionId/scopes": {
+			"GET": api.GetScopeList,
+			"PUT": api.PutScope,
+		},
+		"connections/:connectionId/proxy/rest/*path": {
+			"GET": api.Proxy,
+		},
+	}
+}
+
+func (plugin Opsgenie) Close(taskCtx core.TaskContext) errors.Error {
+	data, ok := taskCtx.GetData().(*tasks.OpsgenieTaskData)
+	if !ok {
+		return errors.Default.New(fmt.Sprintf("GetData failed when try to close %+v", taskCtx))
+	}
+	data.ApiClient.Release()
+	return nil
+}
@@ -0,0 +1,55 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"github.com/apache/incubator-devlake/models/common"
+	"time"
+)
+
+const (
+	AlertStatusOpen   = "open"
+	AlertStatusClosed = "closed"
+)
+
+type OpsgenieAlert struct {
+	common.NoPKModel
+	ConnectionId      uint64 `gorm:"primaryKey"`
+	ServiceId         string `gorm:"primaryKey;type:varchar(255)"`
+	Id                string `gorm:"primaryKey;type:varchar(255)"`
+	TinyId            string `gorm:"type:varchar(255)"`
+	Alias             string
+	Message           string
+	Status            string `gorm:"type:varchar(100)"` // open or closed
+	Acknowledged      bool
+	Priority          string `gorm:"type:varchar(100)"` // P1 to P5
+	Owner             string `gorm:"type:varchar(255)"`
+	OwnerTeamId       string `gorm:"type:varchar(255)"`
+	Source            string
+	Count             int
+	AckTime           int64  // milliseconds from creation to acknowledgement
+	CloseTime         int64  // milliseconds from creation to close
+	AcknowledgedBy    string `gorm:"type:varchar(255)"`
+	ClosedBy          string `gorm:"type:varchar(255)"`
+	OpsgenieCreatedAt time.Time
+	OpsgenieUpdatedAt time.Time
+}
+
+func (OpsgenieAlert) TableName() string {
+	return "_tool_opsgenie_alerts"
+}
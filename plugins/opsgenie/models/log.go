@@ -0,0 +1,55 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"github.com/apache/incubator-devlake/models/common"
+	"time"
+)
+
+// OpsgenieAlertLog is an entry of the activity log of an alert, Offset orders the entries of the same alert
+type OpsgenieAlertLog struct {
+	common.NoPKModel
+	ConnectionId      uint64 `gorm:"primaryKey"`
+	AlertId           string `gorm:"primaryKey;type:varchar(255)"`
+	Offset            string `gorm:"primaryKey;type:varchar(255)"`
+	Log               string
+	Type              string `gorm:"type:varchar(100)"`
+	Owner             string `gorm:"type:varchar(255)"`
+	OpsgenieCreatedAt time.Time
+}
+
+func (OpsgenieAlertLog) TableName() string {
+	return "_tool_opsgenie_alert_logs"
+}
+
+// OpsgenieIncidentLog is an entry of the activity log of an incident
+type OpsgenieIncidentLog struct {
+	common.NoPKModel
+	ConnectionId      uint64 `gorm:"primaryKey"`
+	IncidentId        string `gorm:"primaryKey;type:varchar(255)"`
+	Offset            string `gorm:"primaryKey;type:varchar(255)"`
+	Log               string
+	Type              string `gorm:"type:varchar(100)"`
+	Owner             string `gorm:"type:varchar(255)"`
+	OpsgenieCreatedAt time.Time
+}
+
+func (OpsgenieIncidentLog) TableName() string {
+	return "_tool_opsgenie_incident_logs"
+}
@@ -0,0 +1,35 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import "github.com/apache/incubator-devlake/models/common"
+
+// OpsgenieService is the scope of the plugin, incidents impacting the service and alerts of its team are collected
+type OpsgenieService struct {
+	ConnectionId     uint64 `gorm:"primaryKey" mapstructure:"connectionId,omitempty" json:"connectionId"`
+	Id               string `gorm:"primaryKey;type:varchar(255)" mapstructure:"serviceId" json:"serviceId"`
+	Name             string `gorm:"type:varchar(255)" mapstructure:"name,omitempty" json:"name"`
+	Description      string `mapstructure:"description,omitempty" json:"description"`
+	TeamId           string `gorm:"type:varchar(255)" mapstructure:"teamId,omitempty" json:"teamId"`
+	Url              string `mapstructure:"url,omitempty" json:"url"`
+	common.NoPKModel `json:"-" mapstructure:"-"`
+}
+
+func (OpsgenieService) TableName() string {
+	return "_tool_opsgenie_services"
+}
@@ -0,0 +1,75 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/plugins/core"
+	"github.com/apache/incubator-devlake/plugins/core/dal"
+	"github.com/apache/incubator-devlake/plugins/helper"
+	"github.com/apache/incubator-devlake/plugins/opsgenie/models"
+)
+
+const RAW_ALERT_TABLE = "opsgenie_api_alerts"
+
+var CollectAlertsMeta = core.SubTaskMeta{
+	Name:             "collectAlerts",
+	EntryPoint:       CollectAlerts,
+	EnabledByDefault: true,
+	Description:      "Collect alerts from Opsgenie api",
+	DomainTypes:      []string{core.DOMAIN_TYPE_TICKET},
+}
+
+func CollectAlerts(taskCtx core.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*OpsgenieTaskData)
+	// alerts belong to teams rather than services, so alerts of the team owning the service are collected
+	service := &models.OpsgenieService{}
+	err := db.First(service, dal.Where("connection_id = ? AND id = ?", data.Options.ConnectionId, data.Options.ServiceId))
+	if err != nil {
+		return err
+	}
+	if service.TeamId == "" {
+		taskCtx.GetLogger().Info("service %s is not owned by any team, no alert would be collected", data.Options.ServiceId)
+		return nil
+	}
+	collector, err := helper.NewApiCollector(helper.ApiCollectorArgs{
+		RawDataSubTaskArgs: helper.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: OpsgenieApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				ServiceId:    data.Options.ServiceId,
+			},
+			Table: RAW_ALERT_TABLE,
+		},
+		ApiClient:   data.ApiClient,
+		PageSize:    100,
+		UrlTemplate: "v2/alerts",
+		Query: func(reqData *helper.RequestData) (url.Values, errors.Error) {
+			return listQuery(data, reqData, fmt.Sprintf("teams:%s", service.TeamId))
+		},
+		ResponseParser: parseListResponse,
+	})
+	if err != nil {
+		return err
+	}
+	return collector.Execute()
+}
@@ -0,0 +1,108 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/models/domainlayer"
+	"github.com/apache/incubator-devlake/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/plugins/core"
+	"github.com/apache/incubator-devlake/plugins/core/dal"
+	"github.com/apache/incubator-devlake/plugins/helper"
+	"github.com/apache/incubator-devlake/plugins/opsgenie/models"
+)
+
+var ConvertAlertsMeta = core.SubTaskMeta{
+	Name:             "convertAlerts",
+	EntryPoint:       ConvertAlerts,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table _tool_opsgenie_alerts into domain layer table issues and board_issues",
+	DomainTypes:      []string{core.DOMAIN_TYPE_TICKET},
+}
+
+// ISSUE_TYPE_ALERT is the type of issues converted from alerts, incidents are what MTTR and change failure rate
+// count, while alerts raising the incidents would be counted twice as incidents
+const ISSUE_TYPE_ALERT = "ALERT"
+
+func ConvertAlerts(taskCtx core.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*OpsgenieTaskData)
+	cursor, err := db.Cursor(
+		dal.From(&models.OpsgenieAlert{}),
+		dal.Where("connection_id = ? AND service_id = ?", data.Options.ConnectionId, data.Options.ServiceId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+	alertIdGen := didgen.NewDomainIdGenerator(&models.OpsgenieAlert{})
+	boardId := didgen.NewDomainIdGenerator(&models.OpsgenieService{}).Generate(data.Options.ConnectionId, data.Options.ServiceId)
+	converter, err := helper.NewDataConverter(helper.DataConverterArgs{
+		RawDataSubTaskArgs: helper.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: OpsgenieApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				ServiceId:    data.Options.ServiceId,
+			},
+			Table: RAW_ALERT_TABLE,
+		},
+		InputRowType: reflect.TypeOf(models.OpsgenieAlert{}),
+		Input:        cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			alert := inputRow.(*models.OpsgenieAlert)
+			issue := &ticket.Issue{
+				DomainEntity: domainlayer.DomainEntity{
+					Id: alertIdGen.Generate(alert.ConnectionId, alert.Id),
+				},
+				IssueKey:       alert.TinyId,
+				Title:          alert.Message,
+				Type:           ISSUE_TYPE_ALERT,
+				Status:         ticket.TODO,
+				OriginalStatus: alert.Status,
+				Priority:       alert.Priority,
+				AssigneeName:   alert.Owner,
+				CreatedDate:    &alert.OpsgenieCreatedAt,
+				UpdatedDate:    &alert.OpsgenieUpdatedAt,
+			}
+			if alert.Status == models.AlertStatusClosed {
+				issue.Status = ticket.DONE
+				// closeTime of the report is the duration from creation to close in milliseconds
+				resolutionDate := alert.OpsgenieCreatedAt.Add(time.Duration(alert.CloseTime) * time.Millisecond)
+				issue.ResolutionDate = &resolutionDate
+				issue.LeadTimeMinutes = int64(resolutionDate.Sub(alert.OpsgenieCreatedAt).Minutes())
+			} else if alert.Acknowledged {
+				issue.Status = ticket.IN_PROGRESS
+			}
+			return []interface{}{
+				issue,
+				&ticket.BoardIssue{
+					BoardId: boardId,
+					IssueId: issue.Id,
+				},
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return converter.Execute()
+}
@@ -0,0 +1,134 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/plugins/core"
+	"github.com/apache/incubator-devlake/plugins/core/dal"
+	"github.com/apache/incubator-devlake/plugins/helper"
+	"github.com/apache/incubator-devlake/plugins/opsgenie/models"
+)
+
+var ExtractAlertsMeta = core.SubTaskMeta{
+	Name:             "extractAlerts",
+	EntryPoint:       ExtractAlerts,
+	EnabledByDefault: true,
+	Description:      "Extract raw alerts data into tool layer table _tool_opsgenie_alerts",
+	DomainTypes:      []string{core.DOMAIN_TYPE_TICKET},
+}
+
+type ApiAlertResponse struct {
+	Id           string `json:"id"`
+	TinyId       string `json:"tinyId"`
+	Alias        string `json:"alias"`
+	Message      string `json:"message"`
+	Status       string `json:"status"`
+	Acknowledged bool   `json:"acknowledged"`
+	Priority     string `json:"priority"`
+	Owner        string `json:"owner"`
+	OwnerTeamId  string `json:"ownerTeamId"`
+	Source       string `json:"source"`
+	Count        int    `json:"count"`
+	Teams        []struct {
+		Id string `json:"id"`
+	} `json:"teams"`
+	Report struct {
+		AckTime        int64  `json:"ackTime"`
+		CloseTime      int64  `json:"closeTime"`
+		AcknowledgedBy string `json:"acknowledgedBy"`
+		ClosedBy       string `json:"closedBy"`
+	} `json:"report"`
+	CreatedAt helper.Iso8601Time `json:"createdAt"`
+	UpdatedAt helper.Iso8601Time `json:"updatedAt"`
+}
+
+func ExtractAlerts(taskCtx core.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*OpsgenieTaskData)
+	// alerts belong to teams rather than services, so alerts of the team owning the service are kept
+	service := &models.OpsgenieService{}
+	err := db.First(service, dal.Where("connection_id = ? AND id = ?", data.Options.ConnectionId, data.Options.ServiceId))
+	if err != nil {
+		return err
+	}
+	extractor, err := helper.NewApiExtractor(helper.ApiExtractorArgs{
+		RawDataSubTaskArgs: helper.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: OpsgenieApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				ServiceId:    data.Options.ServiceId,
+			},
+			Table: RAW_ALERT_TABLE,
+		},
+		Extract: func(row *helper.RawData) ([]interface{}, errors.Error) {
+			body := &ApiAlertResponse{}
+			err := errors.Convert(json.Unmarshal(row.Data, body))
+			if err != nil {
+				return nil, err
+			}
+			if !isAlertOfTeam(body, service.TeamId) {
+				return nil, nil
+			}
+			return []interface{}{
+				&models.OpsgenieAlert{
+					ConnectionId:      data.Options.ConnectionId,
+					ServiceId:         data.Options.ServiceId,
+					Id:                body.Id,
+					TinyId:            body.TinyId,
+					Alias:             body.Alias,
+					Message:           body.Message,
+					Status:            body.Status,
+					Acknowledged:      body.Acknowledged,
+					Priority:          body.Priority,
+					Owner:             body.Owner,
+					OwnerTeamId:       body.OwnerTeamId,
+					Source:            body.Source,
+					Count:             body.Count,
+					AckTime:           body.Report.AckTime,
+					CloseTime:         body.Report.CloseTime,
+					AcknowledgedBy:    body.Report.AcknowledgedBy,
+					ClosedBy:          body.Report.ClosedBy,
+					OpsgenieCreatedAt: body.CreatedAt.ToTime(),
+					OpsgenieUpdatedAt: body.UpdatedAt.ToTime(),
+				},
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
+
+func isAlertOfTeam(alert *ApiAlertResponse, teamId string) bool {
+	if teamId == "" {
+		return false
+	}
+	if alert.OwnerTeamId == teamId {
+		return true
+	}
+	for _, team := range alert.Teams {
+		if team.Id == teamId {
+			return true
+		}
+	}
+	return false
+}
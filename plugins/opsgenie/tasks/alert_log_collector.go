@@ -0,0 +1,76 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/plugins/core"
+	"github.com/apache/incubator-devlake/plugins/core/dal"
+	"github.com/apache/incubator-devlake/plugins/helper"
+	"github.com/apache/incubator-devlake/plugins/opsgenie/models"
+)
+
+const RAW_ALERT_LOG_TABLE = "opsgenie_api_alert_logs"
+
+var CollectAlertLogsMeta = core.SubTaskMeta{
+	Name:             "collectAlertLogs",
+	EntryPoint:       CollectAlertLogs,
+	EnabledByDefault: true,
+	Description:      "Collect activity logs of alerts from Opsgenie api",
+	DomainTypes:      []string{core.DOMAIN_TYPE_TICKET},
+}
+
+func CollectAlertLogs(taskCtx core.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*OpsgenieTaskData)
+	cursor, err := db.Cursor(
+		dal.Select("id"),
+		dal.From(&models.OpsgenieAlert{}),
+		dal.Where("connection_id = ? AND service_id = ?", data.Options.ConnectionId, data.Options.ServiceId),
+	)
+	if err != nil {
+		return err
+	}
+	iterator, err := helper.NewDalCursorIterator(db, cursor, reflect.TypeOf(SimpleIdInput{}))
+	if err != nil {
+		return err
+	}
+	collector, err := helper.NewApiCollector(helper.ApiCollectorArgs{
+		RawDataSubTaskArgs: helper.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: OpsgenieApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				ServiceId:    data.Options.ServiceId,
+			},
+			Table: RAW_ALERT_LOG_TABLE,
+		},
+		ApiClient:             data.ApiClient,
+		PageSize:              100,
+		Input:                 iterator,
+		UrlTemplate:           "v2/alerts/{{ .Input.Id }}/logs",
+		Query:                 logsQuery,
+		GetNextPageCustomData: getNextOffset,
+		ResponseParser:        parseListResponse,
+	})
+	if err != nil {
+		return err
+	}
+	return collector.Execute()
+}
@@ -0,0 +1,119 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/models/domainlayer"
+	"github.com/apache/incubator-devlake/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/plugins/core"
+	"github.com/apache/incubator-devlake/plugins/core/dal"
+	"github.com/apache/incubator-devlake/plugins/helper"
+	"github.com/apache/incubator-devlake/plugins/opsgenie/models"
+)
+
+var ConvertAlertLogsMeta = core.SubTaskMeta{
+	Name:             "convertAlertLogs",
+	EntryPoint:       ConvertAlertLogs,
+	EnabledByDefault: true,
+	Description:      "Convert alert logs into domain layer table issue_changelogs",
+	DomainTypes:      []string{core.DOMAIN_TYPE_TICKET},
+}
+
+const alertStatusAcknowledged = "acknowledged"
+
+// the status an alert ends up in after each type of log, other types don't change the status
+var alertLogStatuses = map[string]string{
+	"alertAcknowledged":   alertStatusAcknowledged,
+	"alertUnacknowledged": models.AlertStatusOpen,
+	"alertClosed":         models.AlertStatusClosed,
+}
+
+var alertStdStatuses = map[string]string{
+	models.AlertStatusOpen:   ticket.TODO,
+	alertStatusAcknowledged:  ticket.IN_PROGRESS,
+	models.AlertStatusClosed: ticket.DONE,
+}
+
+func ConvertAlertLogs(taskCtx core.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*OpsgenieTaskData)
+	logTypes := make([]string, 0, len(alertLogStatuses))
+	for logType := range alertLogStatuses {
+		logTypes = append(logTypes, logType)
+	}
+	cursor, err := db.Cursor(
+		dal.Select("l.*"),
+		dal.From("_tool_opsgenie_alert_logs l"),
+		dal.Join("JOIN _tool_opsgenie_alerts a ON a.connection_id = l.connection_id AND a.id = l.alert_id"),
+		dal.Where("a.connection_id = ? AND a.service_id = ? AND l.type IN ?", data.Options.ConnectionId, data.Options.ServiceId, logTypes),
+		dal.Orderby("l.alert_id, l.opsgenie_created_at"),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+	// logs are ordered by time, so the status before each log is the one the previous log left
+	lastStatuses := map[string]string{}
+	idGen := didgen.NewDomainIdGenerator(&models.OpsgenieAlertLog{})
+	alertIdGen := didgen.NewDomainIdGenerator(&models.OpsgenieAlert{})
+	converter, err := helper.NewDataConverter(helper.DataConverterArgs{
+		RawDataSubTaskArgs: helper.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: OpsgenieApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				ServiceId:    data.Options.ServiceId,
+			},
+			Table: RAW_ALERT_LOG_TABLE,
+		},
+		InputRowType: reflect.TypeOf(models.OpsgenieAlertLog{}),
+		Input:        cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			alertLog := inputRow.(*models.OpsgenieAlertLog)
+			toStatus := alertLogStatuses[alertLog.Type]
+			fromStatus, ok := lastStatuses[alertLog.AlertId]
+			if !ok {
+				fromStatus = models.AlertStatusOpen
+			}
+			lastStatuses[alertLog.AlertId] = toStatus
+			return []interface{}{
+				&ticket.IssueChangelogs{
+					DomainEntity: domainlayer.DomainEntity{
+						Id: idGen.Generate(alertLog.ConnectionId, alertLog.AlertId, alertLog.Offset),
+					},
+					IssueId:           alertIdGen.Generate(alertLog.ConnectionId, alertLog.AlertId),
+					AuthorName:        alertLog.Owner,
+					FieldId:           alertLog.Type,
+					FieldName:         "status",
+					OriginalFromValue: fromStatus,
+					OriginalToValue:   toStatus,
+					FromValue:         alertStdStatuses[fromStatus],
+					ToValue:           alertStdStatuses[toStatus],
+					CreatedDate:       alertLog.OpsgenieCreatedAt,
+				},
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return converter.Execute()
+}
@@ -0,0 +1,62 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/plugins/core"
+	"github.com/apache/incubator-devlake/plugins/helper"
+)
+
+const RAW_INCIDENT_TABLE = "opsgenie_api_incidents"
+
+var CollectIncidentsMeta = core.SubTaskMeta{
+	Name:             "collectIncidents",
+	EntryPoint:       CollectIncidents,
+	EnabledByDefault: true,
+	Description:      "Collect incidents from Opsgenie api",
+	DomainTypes:      []string{core.DOMAIN_TYPE_TICKET},
+}
+
+func CollectIncidents(taskCtx core.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*OpsgenieTaskData)
+	collector, err := helper.NewApiCollector(helper.ApiCollectorArgs{
+		RawDataSubTaskArgs: helper.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: OpsgenieApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				ServiceId:    data.Options.ServiceId,
+			},
+			Table: RAW_INCIDENT_TABLE,
+		},
+		ApiClient:   data.ApiClient,
+		PageSize:    100,
+		UrlTemplate: "v1/incidents",
+		Query: func(reqData *helper.RequestData) (url.Values, errors.Error) {
+			return listQuery(data, reqData, fmt.Sprintf("impactedServices:%s", data.Options.ServiceId))
+		},
+		ResponseParser: parseListResponse,
+	})
+	if err != nil {
+		return err
+	}
+	return collector.Execute()
+}
@@ -0,0 +1,104 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/models/domainlayer"
+	"github.com/apache/incubator-devlake/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/plugins/core"
+	"github.com/apache/incubator-devlake/plugins/core/dal"
+	"github.com/apache/incubator-devlake/plugins/helper"
+	"github.com/apache/incubator-devlake/plugins/opsgenie/models"
+)
+
+var ConvertIncidentsMeta = core.SubTaskMeta{
+	Name:             "convertIncidents",
+	EntryPoint:       ConvertIncidents,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table _tool_opsgenie_incidents into domain layer table issues and board_issues",
+	DomainTypes:      []string{core.DOMAIN_TYPE_TICKET},
+}
+
+func ConvertIncidents(taskCtx core.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*OpsgenieTaskData)
+	cursor, err := db.Cursor(
+		dal.From(&models.OpsgenieIncident{}),
+		dal.Where("connection_id = ? AND service_id = ?", data.Options.ConnectionId, data.Options.ServiceId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+	incidentIdGen := didgen.NewDomainIdGenerator(&models.OpsgenieIncident{})
+	boardId := didgen.NewDomainIdGenerator(&models.OpsgenieService{}).Generate(data.Options.ConnectionId, data.Options.ServiceId)
+	converter, err := helper.NewDataConverter(helper.DataConverterArgs{
+		RawDataSubTaskArgs: helper.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: OpsgenieApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				ServiceId:    data.Options.ServiceId,
+			},
+			Table: RAW_INCIDENT_TABLE,
+		},
+		InputRowType: reflect.TypeOf(models.OpsgenieIncident{}),
+		Input:        cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			incident := inputRow.(*models.OpsgenieIncident)
+			issue := &ticket.Issue{
+				DomainEntity: domainlayer.DomainEntity{
+					Id: incidentIdGen.Generate(incident.ConnectionId, incident.Id),
+				},
+				Url:            incident.Url,
+				IssueKey:       incident.TinyId,
+				Title:          incident.Message,
+				Description:    incident.Description,
+				Type:           ticket.INCIDENT,
+				Status:         ticket.TODO,
+				OriginalStatus: incident.Status,
+				Priority:       incident.Priority,
+				CreatedDate:    &incident.OpsgenieCreatedAt,
+				UpdatedDate:    &incident.OpsgenieUpdatedAt,
+			}
+			if incident.Status == models.IncidentStatusResolved || incident.Status == models.IncidentStatusClosed {
+				issue.Status = ticket.DONE
+				// the end of the impact is when the incident got resolved, closing it may happen much later
+				issue.ResolutionDate = incident.ImpactEndDate
+				if issue.ResolutionDate == nil {
+					issue.ResolutionDate = &incident.OpsgenieUpdatedAt
+				}
+				issue.LeadTimeMinutes = int64(issue.ResolutionDate.Sub(incident.OpsgenieCreatedAt).Minutes())
+			}
+			return []interface{}{
+				issue,
+				&ticket.BoardIssue{
+					BoardId: boardId,
+					IssueId: issue.Id,
+				},
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return converter.Execute()
+}
@@ -0,0 +1,107 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/plugins/core"
+	"github.com/apache/incubator-devlake/plugins/helper"
+	"github.com/apache/incubator-devlake/plugins/opsgenie/models"
+)
+
+var ExtractIncidentsMeta = core.SubTaskMeta{
+	Name:             "extractIncidents",
+	EntryPoint:       ExtractIncidents,
+	EnabledByDefault: true,
+	Description:      "Extract raw incidents data into tool layer table _tool_opsgenie_incidents",
+	DomainTypes:      []string{core.DOMAIN_TYPE_TICKET},
+}
+
+type ApiIncidentResponse struct {
+	Id               string              `json:"id"`
+	TinyId           string              `json:"tinyId"`
+	Message          string              `json:"message"`
+	Description      string              `json:"description"`
+	Status           string              `json:"status"`
+	Priority         string              `json:"priority"`
+	OwnerTeam        string              `json:"ownerTeam"`
+	ImpactedServices []string            `json:"impactedServices"`
+	ImpactStartDate  *helper.Iso8601Time `json:"impactStartDate"`
+	ImpactEndDate    *helper.Iso8601Time `json:"impactEndDate"`
+	CreatedAt        helper.Iso8601Time  `json:"createdAt"`
+	UpdatedAt        helper.Iso8601Time  `json:"updatedAt"`
+	Links            struct {
+		Web string `json:"web"`
+	} `json:"links"`
+}
+
+func ExtractIncidents(taskCtx core.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*OpsgenieTaskData)
+	extractor, err := helper.NewApiExtractor(helper.ApiExtractorArgs{
+		RawDataSubTaskArgs: helper.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: OpsgenieApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				ServiceId:    data.Options.ServiceId,
+			},
+			Table: RAW_INCIDENT_TABLE,
+		},
+		Extract: func(row *helper.RawData) ([]interface{}, errors.Error) {
+			body := &ApiIncidentResponse{}
+			err := errors.Convert(json.Unmarshal(row.Data, body))
+			if err != nil {
+				return nil, err
+			}
+			// the list api returns incidents of all services, only those impacting the current one are kept
+			impacted := false
+			for _, serviceId := range body.ImpactedServices {
+				if serviceId == data.Options.ServiceId {
+					impacted = true
+					break
+				}
+			}
+			if !impacted {
+				return nil, nil
+			}
+			return []interface{}{
+				&models.OpsgenieIncident{
+					ConnectionId:      data.Options.ConnectionId,
+					ServiceId:         data.Options.ServiceId,
+					Id:                body.Id,
+					TinyId:            body.TinyId,
+					Message:           body.Message,
+					Description:       body.Description,
+					Status:            body.Status,
+					Priority:          body.Priority,
+					OwnerTeamId:       body.OwnerTeam,
+					Url:               body.Links.Web,
+					ImpactStartDate:   helper.Iso8601TimeToTime(body.ImpactStartDate),
+					ImpactEndDate:     helper.Iso8601TimeToTime(body.ImpactEndDate),
+					OpsgenieCreatedAt: body.CreatedAt.ToTime(),
+					OpsgenieUpdatedAt: body.UpdatedAt.ToTime(),
+				},
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
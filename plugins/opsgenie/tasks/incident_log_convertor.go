@@ -0,0 +1,129 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/models/domainlayer"
+	"github.com/apache/incubator-devlake/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/plugins/core"
+	"github.com/apache/incubator-devlake/plugins/core/dal"
+	"github.com/apache/incubator-devlake/plugins/helper"
+	"github.com/apache/incubator-devlake/plugins/opsgenie/models"
+)
+
+var ConvertIncidentLogsMeta = core.SubTaskMeta{
+	Name:             "convertIncidentLogs",
+	EntryPoint:       ConvertIncidentLogs,
+	EnabledByDefault: true,
+	Description:      "Convert incident logs into domain layer table issue_changelogs",
+	DomainTypes:      []string{core.DOMAIN_TYPE_TICKET},
+}
+
+const incidentLogTypeAction = "incidentAction"
+
+// actions are only told by the log message, i.e. `Incident resolved`, other actions don't change the status
+var incidentActionStatuses = map[string]string{
+	"incident resolved": models.IncidentStatusResolved,
+	"incident closed":   models.IncidentStatusClosed,
+	"incident reopened": models.IncidentStatusOpen,
+}
+
+var incidentStdStatuses = map[string]string{
+	models.IncidentStatusOpen:     ticket.TODO,
+	models.IncidentStatusResolved: ticket.DONE,
+	models.IncidentStatusClosed:   ticket.DONE,
+}
+
+func ConvertIncidentLogs(taskCtx core.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*OpsgenieTaskData)
+	cursor, err := db.Cursor(
+		dal.Select("l.*"),
+		dal.From("_tool_opsgenie_incident_logs l"),
+		dal.Join("JOIN _tool_opsgenie_incidents i ON i.connection_id = l.connection_id AND i.id = l.incident_id"),
+		dal.Where("i.connection_id = ? AND i.service_id = ? AND l.type = ?", data.Options.ConnectionId, data.Options.ServiceId, incidentLogTypeAction),
+		dal.Orderby("l.incident_id, l.opsgenie_created_at"),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+	// logs are ordered by time, so the status before each log is the one the previous log left
+	lastStatuses := map[string]string{}
+	idGen := didgen.NewDomainIdGenerator(&models.OpsgenieIncidentLog{})
+	incidentIdGen := didgen.NewDomainIdGenerator(&models.OpsgenieIncident{})
+	converter, err := helper.NewDataConverter(helper.DataConverterArgs{
+		RawDataSubTaskArgs: helper.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: OpsgenieApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				ServiceId:    data.Options.ServiceId,
+			},
+			Table: RAW_INCIDENT_LOG_TABLE,
+		},
+		InputRowType: reflect.TypeOf(models.OpsgenieIncidentLog{}),
+		Input:        cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			incidentLog := inputRow.(*models.OpsgenieIncidentLog)
+			toStatus := getIncidentActionStatus(incidentLog.Log)
+			if toStatus == "" {
+				return nil, nil
+			}
+			fromStatus, ok := lastStatuses[incidentLog.IncidentId]
+			if !ok {
+				fromStatus = models.IncidentStatusOpen
+			}
+			lastStatuses[incidentLog.IncidentId] = toStatus
+			return []interface{}{
+				&ticket.IssueChangelogs{
+					DomainEntity: domainlayer.DomainEntity{
+						Id: idGen.Generate(incidentLog.ConnectionId, incidentLog.IncidentId, incidentLog.Offset),
+					},
+					IssueId:           incidentIdGen.Generate(incidentLog.ConnectionId, incidentLog.IncidentId),
+					AuthorName:        incidentLog.Owner,
+					FieldId:           incidentLog.Type,
+					FieldName:         "status",
+					OriginalFromValue: fromStatus,
+					OriginalToValue:   toStatus,
+					FromValue:         incidentStdStatuses[fromStatus],
+					ToValue:           incidentStdStatuses[toStatus],
+					CreatedDate:       incidentLog.OpsgenieCreatedAt,
+				},
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return converter.Execute()
+}
+
+func getIncidentActionStatus(log string) string {
+	log = strings.ToLower(log)
+	for action, status := range incidentActionStatuses {
+		if strings.HasPrefix(log, action) {
+			return status
+		}
+	}
+	return ""
+}
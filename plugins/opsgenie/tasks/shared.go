@@ -0,0 +1,106 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/plugins/helper"
+)
+
+// all list apis of Opsgenie wrap the records with `data` and page with `paging`
+type apiListResponse struct {
+	Data   []json.RawMessage `json:"data"`
+	Paging struct {
+		Next string `json:"next"`
+	} `json:"paging"`
+}
+
+// ApiLogResponse is an entry of the activity log of an alert or an incident
+type ApiLogResponse struct {
+	Log       string             `json:"log"`
+	Type      string             `json:"type"`
+	Owner     string             `json:"owner"`
+	CreatedAt helper.Iso8601Time `json:"createdAt"`
+	Offset    string             `json:"offset"`
+}
+
+type SimpleIdInput struct {
+	Id string
+}
+
+func parseListResponse(res *http.Response) ([]json.RawMessage, errors.Error) {
+	var body apiListResponse
+	err := helper.UnmarshalResponse(res, &body)
+	if err != nil {
+		return nil, err
+	}
+	return body.Data, nil
+}
+
+// getNextOffset returns the offset of the next page of logs, which is a token rather than a number
+func getNextOffset(_ *helper.RequestData, prevPageResponse *http.Response) (interface{}, errors.Error) {
+	var body apiListResponse
+	err := helper.UnmarshalResponse(prevPageResponse, &body)
+	if err != nil {
+		return nil, err
+	}
+	if body.Paging.Next == "" {
+		return nil, nil
+	}
+	next, err := errors.Convert01(url.Parse(body.Paging.Next))
+	if err != nil {
+		return nil, err
+	}
+	offset := next.Query().Get("offset")
+	if offset == "" {
+		return nil, nil
+	}
+	return offset, nil
+}
+
+// logsQuery builds the query of the logs api of alerts and incidents
+func logsQuery(reqData *helper.RequestData) (url.Values, errors.Error) {
+	query := url.Values{}
+	query.Set("identifierType", "id")
+	query.Set("order", "asc")
+	query.Set("limit", fmt.Sprintf("%v", reqData.Pager.Size))
+	if offset, ok := reqData.CustomData.(string); ok {
+		query.Set("offset", offset)
+	}
+	return query, nil
+}
+
+// listQuery builds the query of the list api of alerts and incidents, filter narrows the records to the service,
+// and created_date_after narrows them by the `createdAt` field which Opsgenie compares in milliseconds
+func listQuery(data *OpsgenieTaskData, reqData *helper.RequestData, filter string) (url.Values, errors.Error) {
+	query := url.Values{}
+	query.Set("sort", "createdAt")
+	query.Set("order", "asc")
+	query.Set("offset", fmt.Sprintf("%v", reqData.Pager.Skip))
+	query.Set("limit", fmt.Sprintf("%v", reqData.Pager.Size))
+	if data.CreatedDateAfter != nil {
+		filter = fmt.Sprintf("%s AND createdAt>=%d", filter, data.CreatedDateAfter.UnixMilli())
+	}
+	query.Set("query", filter)
+	return query, nil
+}
@@ -0,0 +1,57 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/plugins/helper"
+)
+
+type OpsgenieApiParams struct {
+	ConnectionId uint64
+	ServiceId    string
+}
+
+type OpsgenieOptions struct {
+	ConnectionId     uint64   `json:"connectionId"`
+	ServiceId        string   `json:"serviceId"`
+	CreatedDateAfter string   `json:"createdDateAfter" mapstructure:"createdDateAfter,omitempty"`
+	Tasks            []string `json:"tasks,omitempty"`
+}
+
+type OpsgenieTaskData struct {
+	Options          *OpsgenieOptions
+	ApiClient        *helper.ApiAsyncClient
+	CreatedDateAfter *time.Time
+}
+
+func DecodeAndValidateTaskOptions(options map[string]interface{}) (*OpsgenieOptions, errors.Error) {
+	var op OpsgenieOptions
+	if err := helper.Decode(options, &op, nil); err != nil {
+		return nil, err
+	}
+	if op.ConnectionId == 0 {
+		return nil, errors.BadInput.New("connectionId is invalid")
+	}
+	if op.ServiceId == "" {
+		return nil, errors.BadInput.New("serviceId is required for Opsgenie execution")
+	}
+	return &op, nil
+}
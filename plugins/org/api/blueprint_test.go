@@ -0,0 +1,48 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/plugins/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMakePipelinePlan(t *testing.T) {
+	subtaskMetas := []core.SubTaskMeta{
+		{Name: "collectScimUsers", EnabledByDefault: true, DomainTypes: []string{core.DOMAIN_TYPE_CROSS}},
+		{Name: "convertDirectoryUsers", EnabledByDefault: true, DomainTypes: []string{core.DOMAIN_TYPE_CROSS}},
+	}
+	scope := []*core.BlueprintScopeV100{
+		{Entities: []string{core.DOMAIN_TYPE_CROSS}},
+	}
+	plan, err := MakePipelinePlan(subtaskMetas, 3, scope)
+	assert.Nil(t, err)
+	assert.Equal(t, core.PipelinePlan{
+		core.PipelineStage{
+			{
+				Plugin:   "org",
+				Subtasks: []string{"collectScimUsers", "convertDirectoryUsers"},
+				Options: map[string]interface{}{
+					"connectionId": uint64(3),
+				},
+			},
+		},
+	}, plan)
+}
@@ -0,0 +1,165 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/plugins/core"
+	"github.com/apache/incubator-devlake/plugins/helper"
+	"github.com/apache/incubator-devlake/plugins/org/models"
+	"github.com/apache/incubator-devlake/plugins/org/tasks"
+)
+
+// TestConnection tests the access to a directory service
+// @Summary test directory connection
+// @Description Test LDAP or SCIM directory connection
+// @Tags plugins/org
+// @Param body body models.TestConnectionRequest true "json body"
+// @Success 200  {object} shared.ApiBody "Success"
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /plugins/org/test [POST]
+func (h *Handlers) TestConnection(input *core.ApiResourceInput) (*core.ApiResourceOutput, errors.Error) {
+	var params models.TestConnectionRequest
+	err := helper.Decode(input.Body, &params, h.validator)
+	if err != nil {
+		return nil, err
+	}
+	if params.Type == models.DIRECTORY_TYPE_LDAP {
+		conn, err := tasks.DialLdap(params.Endpoint, params.BindDn, params.BindPassword)
+		if err != nil {
+			return nil, err
+		}
+		conn.Close()
+		return &core.ApiResourceOutput{Body: nil, Status: http.StatusOK}, nil
+	}
+	apiClient, err := helper.NewApiClient(
+		context.TODO(),
+		params.Endpoint,
+		map[string]string{
+			"Authorization": fmt.Sprintf("Bearer %s", params.Token),
+		},
+		3*time.Second,
+		params.Proxy,
+		h.basicRes,
+	)
+	if err != nil {
+		return nil, err
+	}
+	response, err := apiClient.Get("Users", map[string][]string{"count": {"1"}}, nil)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, errors.HttpStatus(response.StatusCode).New("unexpected status code when testing connection")
+	}
+	return &core.ApiResourceOutput{Body: nil, Status: http.StatusOK}, nil
+}
+
+// PostConnections creates a directory connection
+// @Summary create directory connection
+// @Description Create LDAP or SCIM directory connection
+// @Tags plugins/org
+// @Param body body models.DirectoryConnection true "json body"
+// @Success 200  {object} models.DirectoryConnection
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /plugins/org/connections [POST]
+func (h *Handlers) PostConnections(input *core.ApiResourceInput) (*core.ApiResourceOutput, errors.Error) {
+	connection := &models.DirectoryConnection{}
+	err := h.connectionHelper.Create(connection, input)
+	if err != nil {
+		return nil, err
+	}
+	return &core.ApiResourceOutput{Body: connection, Status: http.StatusOK}, nil
+}
+
+// PatchConnection updates a directory connection
+// @Summary patch directory connection
+// @Description Patch LDAP or SCIM directory connection
+// @Tags plugins/org
+// @Param body body models.DirectoryConnection true "json body"
+// @Success 200  {object} models.DirectoryConnection
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /plugins/org/connections/{connectionId} [PATCH]
+func (h *Handlers) PatchConnection(input *core.ApiResourceInput) (*core.ApiResourceOutput, errors.Error) {
+	connection := &models.DirectoryConnection{}
+	err := h.connectionHelper.Patch(connection, input)
+	if err != nil {
+		return nil, err
+	}
+	return &core.ApiResourceOutput{Body: connection, Status: http.StatusOK}, nil
+}
+
+// DeleteConnection deletes a directory connection, users and teams synced from it are kept
+// @Summary delete directory connection
+// @Description Delete LDAP or SCIM directory connection
+// @Tags plugins/org
+// @Success 200  {object} models.DirectoryConnection
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /plugins/org/connections/{connectionId} [DELETE]
+func (h *Handlers) DeleteConnection(input *core.ApiResourceInput) (*core.ApiResourceOutput, errors.Error) {
+	connection := &models.DirectoryConnection{}
+	err := h.connectionHelper.First(connection, input.Params)
+	if err != nil {
+		return nil, err
+	}
+	err = h.connectionHelper.Delete(connection)
+	return &core.ApiResourceOutput{Body: connection}, err
+}
+
+// ListConnections lists all directory connections
+// @Summary list directory connections
+// @Description List LDAP or SCIM directory connections
+// @Tags plugins/org
+// @Success 200  {object} models.DirectoryConnection
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /plugins/org/connections [GET]
+func (h *Handlers) ListConnections(input *core.ApiResourceInput) (*core.ApiResourceOutput, errors.Error) {
+	var connections []models.DirectoryConnection
+	err := h.connectionHelper.List(&connections)
+	if err != nil {
+		return nil, err
+	}
+	return &core.ApiResourceOutput{Body: connections}, nil
+}
+
+// GetConnection returns a directory connection
+// @Summary get directory connection
+// @Description Get LDAP or SCIM directory connection
+// @Tags plugins/org
+// @Success 200  {object} models.DirectoryConnection
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /plugins/org/connections/{connectionId} [GET]
+func (h *Handlers) GetConnection(input *core.ApiResourceInput) (*core.ApiResourceOutput, errors.Error) {
+	connection := &models.DirectoryConnection{}
+	err := h.connectionHelper.First(connection, input.Params)
+	if err != nil {
+		return nil, err
+	}
+	return &core.ApiResourceOutput{Body: connection}, nil
+}
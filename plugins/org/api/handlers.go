@@ -24,17 +24,28 @@ import (
 
 	"github.com/apache/incubator-devlake/plugins/core"
 	"github.com/apache/incubator-devlake/plugins/core/dal"
+	"github.com/apache/incubator-devlake/plugins/helper"
+	"github.com/go-playground/validator/v10"
 	"github.com/gocarina/gocsv"
 )
 
 const maxMemory = 32 << 20 // 32 MB
 
 type Handlers struct {
-	store store
+	store            store
+	basicRes         core.BasicRes
+	validator        *validator.Validate
+	connectionHelper *helper.ConnectionApiHelper
 }
 
 func NewHandlers(db dal.Dal, basicRes core.BasicRes) *Handlers {
-	return &Handlers{store: NewDbStore(db, basicRes)}
+	vld := validator.New()
+	return &Handlers{
+		store:            NewDbStore(db, basicRes),
+		basicRes:         basicRes,
+		validator:        vld,
+		connectionHelper: helper.NewConnectionHelper(basicRes, vld),
+	}
 }
 
 func (h *Handlers) unmarshal(r *http.Request, items interface{}) errors.Error {
@@ -18,13 +18,16 @@ limitations under the License.
 package api
 
 import (
-	"github.com/apache/incubator-devlake/errors"
+	"fmt"
 	"reflect"
 
+	"github.com/apache/incubator-devlake/errors"
+
 	"github.com/apache/incubator-devlake/models/domainlayer/crossdomain"
 	"github.com/apache/incubator-devlake/plugins/core"
 	"github.com/apache/incubator-devlake/plugins/core/dal"
 	"github.com/apache/incubator-devlake/plugins/helper"
+	"github.com/apache/incubator-devlake/plugins/org/models"
 )
 
 type store interface {
@@ -33,7 +36,10 @@ type store interface {
 	findAllAccounts() ([]account, errors.Error)
 	findAllUserAccounts() ([]userAccount, errors.Error)
 	findAllProjectMapping() ([]projectMapping, errors.Error)
+	findUserAccountCandidates(status string, limit, offset int) ([]userAccountCandidate, int64, errors.Error)
+	reviewUserAccountCandidate(review *userAccountCandidateReview) errors.Error
 	deleteAll(i interface{}) errors.Error
+	deleteAllUploaded(i interface{}) errors.Error
 	save(items []interface{}) errors.Error
 }
 
@@ -105,10 +111,79 @@ func (d *dbStore) findAllProjectMapping() ([]projectMapping, errors.Error) {
 	var pm *projectMapping
 	return pm.fromDomainLayer(mapping), nil
 }
+func (d *dbStore) findUserAccountCandidates(status string, limit, offset int) ([]userAccountCandidate, int64, errors.Error) {
+	count, err := d.db.Count(dal.From(&models.UserAccountCandidate{}), dal.Where("status = ?", status))
+	if err != nil {
+		return nil, 0, err
+	}
+	var candidates []userAccountCandidate
+	err = d.db.All(&candidates,
+		dal.Select(`c.account_id, a.email AS account_email, a.full_name AS account_full_name, a.user_name AS account_user_name,
+			c.user_id, u.name AS user_name, u.email AS user_email, c.score, c.reasons, c.status`),
+		dal.From("_tool_org_user_account_candidates c"),
+		dal.Join("LEFT JOIN accounts a ON a.id = c.account_id"),
+		dal.Join("LEFT JOIN users u ON u.id = c.user_id"),
+		dal.Where("c.status = ?", status),
+		dal.Orderby("c.score DESC, c.account_id, c.user_id"),
+		dal.Limit(limit),
+		dal.Offset(offset),
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+	return candidates, count, nil
+}
+
+// reviewUserAccountCandidate records the decision of an admin, the account is mapped to the user once accepted
+// and other candidates of the account are rejected, rejecting a candidate accepted before removes the mapping
+func (d *dbStore) reviewUserAccountCandidate(review *userAccountCandidateReview) errors.Error {
+	var candidates []models.UserAccountCandidate
+	err := d.db.All(&candidates, dal.Where("account_id = ? AND user_id = ?", review.AccountId, review.UserId))
+	if err != nil {
+		return err
+	}
+	if len(candidates) == 0 {
+		return errors.NotFound.New(fmt.Sprintf("no candidate of user %s for account %s", review.UserId, review.AccountId))
+	}
+	candidate := &candidates[0]
+	candidate.Status = review.Status
+	err = d.db.Update(candidate)
+	if err != nil {
+		return err
+	}
+	if review.Status == models.CANDIDATE_REJECTED {
+		return d.db.Delete(
+			&crossdomain.UserAccount{},
+			dal.Where("account_id = ? AND user_id = ?", review.AccountId, review.UserId),
+		)
+	}
+	if review.Status != models.CANDIDATE_ACCEPTED {
+		return nil
+	}
+	err = d.db.CreateOrUpdate(&crossdomain.UserAccount{
+		UserId:    review.UserId,
+		AccountId: review.AccountId,
+	})
+	if err != nil {
+		return err
+	}
+	return d.db.UpdateColumn(
+		&models.UserAccountCandidate{},
+		"status",
+		models.CANDIDATE_REJECTED,
+		dal.Where("account_id = ? AND user_id != ? AND status = ?", review.AccountId, review.UserId, models.CANDIDATE_PENDING),
+	)
+}
+
 func (d *dbStore) deleteAll(i interface{}) errors.Error {
 	return d.db.Delete(i, dal.Where("1=1"))
 }
 
+// deleteAllUploaded keeps the records synced from directories
+func (d *dbStore) deleteAllUploaded(i interface{}) errors.Error {
+	return d.db.Delete(i, dal.Where("_raw_data_table = '' OR _raw_data_table IS NULL"))
+}
+
 func (d *dbStore) save(items []interface{}) errors.Error {
 	for _, item := range items {
 		batch, err := d.driver.ForType(reflect.TypeOf(item))
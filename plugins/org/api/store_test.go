@@ -0,0 +1,59 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/mocks"
+	"github.com/apache/incubator-devlake/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/plugins/core/dal"
+	"github.com/apache/incubator-devlake/plugins/org/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestReviewUserAccountCandidateRejectsAccepted(t *testing.T) {
+	mockDal := new(mocks.Dal)
+	mockDal.On("All", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		candidates := args.Get(0).(*[]models.UserAccountCandidate)
+		*candidates = []models.UserAccountCandidate{
+			{AccountId: "github:GithubAccount:1:101", UserId: "U1", Status: models.CANDIDATE_AUTO_ACCEPTED},
+		}
+	}).Return(nil).Once()
+	mockDal.On("Update", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		assert.Equal(t, models.CANDIDATE_REJECTED, args.Get(0).(*models.UserAccountCandidate).Status)
+	}).Return(nil).Once()
+	mockDal.On("Delete", mock.IsType(&crossdomain.UserAccount{}), mock.MatchedBy(func(clauses []dal.Clause) bool {
+		if len(clauses) != 1 || clauses[0].Type != dal.WhereClause {
+			return false
+		}
+		where := clauses[0].Data.(dal.DalClause)
+		return where.Expr == "account_id = ? AND user_id = ?" &&
+			assert.ObjectsAreEqual([]interface{}{"github:GithubAccount:1:101", "U1"}, where.Params)
+	})).Return(nil).Once()
+
+	store := &dbStore{db: mockDal}
+	err := store.reviewUserAccountCandidate(&userAccountCandidateReview{
+		AccountId: "github:GithubAccount:1:101",
+		UserId:    "U1",
+		Status:    models.CANDIDATE_REJECTED,
+	})
+	assert.Nil(t, err)
+	mockDal.AssertExpectations(t)
+}
@@ -63,7 +63,7 @@ func (h *Handlers) GetTeam(input *core.ApiResourceInput) (*core.ApiResourceOutpu
 	}, nil
 }
 
-// CreateTeam accepts a CSV file containing team information and saves it to the database
+// CreateTeam accepts a CSV file containing team information and saves it to the database, keeping the teams synced from directories
 // @Summary      Upload teams.csv file
 // @Description  upload teams.csv file
 // @Tags 		 plugins/org
@@ -85,7 +85,7 @@ func (h *Handlers) CreateTeam(input *core.ApiResourceInput) (*core.ApiResourceOu
 	for _, tm := range t.toDomainLayer(tt) {
 		items = append(items, tm)
 	}
-	err = h.store.deleteAll(&crossdomain.Team{})
+	err = h.store.deleteAllUploaded(&crossdomain.Team{})
 	if err != nil {
 		return nil, err
 	}
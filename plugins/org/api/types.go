@@ -275,3 +275,23 @@ func (*projectMapping) toDomainLayer(tt []projectMapping) []*crossdomain.Project
 // func (m *projectMapping) fakeData() []projectMapping {
 // 	return fakeProjectMapping
 // }
+
+// userAccountCandidate is a candidate mapping under review, with the account and the user shown side by side
+type userAccountCandidate struct {
+	AccountId       string  `json:"accountId"`
+	AccountEmail    string  `json:"accountEmail"`
+	AccountFullName string  `json:"accountFullName"`
+	AccountUserName string  `json:"accountUserName"`
+	UserId          string  `json:"userId"`
+	UserName        string  `json:"userName"`
+	UserEmail       string  `json:"userEmail"`
+	Score           float64 `json:"score"`
+	Reasons         string  `json:"reasons"`
+	Status          string  `json:"status"`
+}
+
+type userAccountCandidateReview struct {
+	AccountId string `json:"accountId" validate:"required"`
+	UserId    string `json:"userId" validate:"required"`
+	Status    string `json:"status" validate:"required,oneof=ACCEPTED REJECTED"`
+}
@@ -62,7 +62,7 @@ func (h *Handlers) GetUser(input *core.ApiResourceInput) (*core.ApiResourceOutpu
 	}, nil
 }
 
-// CreateUser accepts a CSV file containing user information mapping and saves it to the database
+// CreateUser accepts a CSV file containing user information mapping and saves it to the database, keeping the users synced from directories
 // @Summary      Upload users.csv file
 // @Description  upload users.csv file
 // @Tags 		 plugins/org
@@ -88,11 +88,11 @@ func (h *Handlers) CreateUser(input *core.ApiResourceInput) (*core.ApiResourceOu
 	for _, teamUser := range teamUsers {
 		items = append(items, teamUser)
 	}
-	err = h.store.deleteAll(&crossdomain.User{})
+	err = h.store.deleteAllUploaded(&crossdomain.User{})
 	if err != nil {
 		return nil, err
 	}
-	err = h.store.deleteAll(&crossdomain.TeamUser{})
+	err = h.store.deleteAllUploaded(&crossdomain.TeamUser{})
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,82 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"net/http"
+
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/plugins/core"
+	"github.com/apache/incubator-devlake/plugins/helper"
+	"github.com/apache/incubator-devlake/plugins/org/models"
+)
+
+// GetUserAccountCandidates returns candidate user/account mappings found by fuzzy matching
+// @Summary      Get user account candidates
+// @Description  get candidate user/account mappings found by fuzzy matching, pending ones by default
+// @Tags 		 plugins/org
+// @Produce      json
+// @Param        status   query     string  false  "PENDING, AUTO_ACCEPTED, ACCEPTED or REJECTED"
+// @Param        page     query     int     false  "page number, default 1"
+// @Param        pageSize query     int     false  "page size, default 50"
+// @Success      200
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router       /plugins/org/user_account_candidates [get]
+func (h *Handlers) GetUserAccountCandidates(input *core.ApiResourceInput) (*core.ApiResourceOutput, errors.Error) {
+	status := input.Query.Get("status")
+	if status == "" {
+		status = models.CANDIDATE_PENDING
+	}
+	limit, offset := helper.GetLimitOffset(input.Query, "pageSize", "page")
+	candidates, count, err := h.store.findUserAccountCandidates(status, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	return &core.ApiResourceOutput{
+		Body: map[string]interface{}{
+			"count":      count,
+			"candidates": candidates,
+		},
+		Status: http.StatusOK,
+	}, nil
+}
+
+// ReviewUserAccountCandidate accepts or rejects a candidate user/account mapping
+// @Summary      Review a user account candidate
+// @Description  accept a candidate to map the account to the user, or reject it so it will not be proposed again
+// @Tags 		 plugins/org
+// @Accept       json
+// @Param        body body userAccountCandidateReview true "json body"
+// @Success      200
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 404  {object} shared.ApiBody "Not Found"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router       /plugins/org/user_account_candidates [patch]
+func (h *Handlers) ReviewUserAccountCandidate(input *core.ApiResourceInput) (*core.ApiResourceOutput, errors.Error) {
+	review := &userAccountCandidateReview{}
+	err := helper.Decode(input.Body, review, h.validator)
+	if err != nil {
+		return nil, err
+	}
+	err = h.store.reviewUserAccountCandidate(review)
+	if err != nil {
+		return nil, err
+	}
+	return &core.ApiResourceOutput{Status: http.StatusOK}, nil
+}
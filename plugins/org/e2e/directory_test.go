@@ -0,0 +1,158 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/plugins/org/impl"
+	"github.com/apache/incubator-devlake/plugins/org/models"
+	"github.com/apache/incubator-devlake/plugins/org/tasks"
+)
+
+func TestDirectoryDataFlow(t *testing.T) {
+	var plugin impl.Org
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "org", plugin)
+
+	taskData := &tasks.TaskData{
+		Options: &tasks.Options{
+			ConnectionId: 1,
+		},
+		Connection: &models.DirectoryConnection{
+			Type: models.DIRECTORY_TYPE_SCIM,
+		},
+	}
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_org_directory_users.csv", "_raw_org_directory_users")
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_org_directory_groups.csv", "_raw_org_directory_groups")
+
+	// verify extraction
+	dataflowTester.FlushTabler(&models.DirectoryUser{})
+	dataflowTester.Subtask(tasks.ExtractDirectoryUsersMeta, taskData)
+	dataflowTester.VerifyTable(
+		models.DirectoryUser{},
+		"./snapshot_tables/_tool_org_directory_users.csv",
+		e2ehelper.ColumnWithRawData(
+			"connection_id",
+			"id",
+			"user_name",
+			"name",
+			"email",
+			"active",
+		),
+	)
+
+	dataflowTester.FlushTabler(&models.DirectoryGroup{})
+	dataflowTester.FlushTabler(&models.DirectoryGroupMember{})
+	dataflowTester.Subtask(tasks.ExtractDirectoryGroupsMeta, taskData)
+	dataflowTester.VerifyTable(
+		models.DirectoryGroup{},
+		"./snapshot_tables/_tool_org_directory_groups.csv",
+		e2ehelper.ColumnWithRawData(
+			"connection_id",
+			"id",
+			"name",
+		),
+	)
+	dataflowTester.VerifyTable(
+		models.DirectoryGroupMember{},
+		"./snapshot_tables/_tool_org_directory_group_members.csv",
+		e2ehelper.ColumnWithRawData(
+			"connection_id",
+			"group_id",
+			"member_id",
+		),
+	)
+
+	// verify conversion, the hand-curated user with the same email is reused
+	dataflowTester.FlushTabler(&crossdomain.User{})
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/curated_users.csv", &crossdomain.User{})
+	dataflowTester.Subtask(tasks.ConvertDirectoryUsersMeta, taskData)
+	dataflowTester.VerifyTable(
+		crossdomain.User{},
+		"./snapshot_tables/users.csv",
+		e2ehelper.ColumnWithRawData(
+			"id",
+			"email",
+			"name",
+		),
+	)
+
+	dataflowTester.FlushTabler(&crossdomain.Team{})
+	dataflowTester.FlushTabler(&crossdomain.TeamUser{})
+	dataflowTester.Subtask(tasks.ConvertDirectoryTeamsMeta, taskData)
+	dataflowTester.VerifyTable(
+		crossdomain.Team{},
+		"./snapshot_tables/teams.csv",
+		e2ehelper.ColumnWithRawData(
+			"id",
+			"name",
+			"alias",
+			"parent_id",
+			"sorting_index",
+		),
+	)
+	dataflowTester.VerifyTable(
+		crossdomain.TeamUser{},
+		"./snapshot_tables/team_users.csv",
+		e2ehelper.ColumnWithRawData(
+			"team_id",
+			"user_id",
+		),
+	)
+
+	dataflowTester.FlushTabler(&crossdomain.TeamUserHistory{})
+	dataflowTester.Subtask(tasks.UpdateTeamUserHistoriesMeta, taskData)
+	dataflowTester.VerifyTable(
+		crossdomain.TeamUserHistory{},
+		"./snapshot_tables/team_user_histories.csv",
+		e2ehelper.ColumnWithRawData(
+			"team_id",
+			"user_id",
+			"start_date",
+			"end_date",
+		),
+	)
+
+	// a week later bob left the platform team and alice joined it
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_org_directory_groups_changed.csv", "_raw_org_directory_groups")
+	dataflowTester.Subtask(tasks.ExtractDirectoryGroupsMeta, taskData)
+	dataflowTester.Subtask(tasks.ConvertDirectoryTeamsMeta, taskData)
+	dataflowTester.VerifyTable(
+		crossdomain.TeamUser{},
+		"./snapshot_tables/team_users_changed.csv",
+		e2ehelper.ColumnWithRawData(
+			"team_id",
+			"user_id",
+		),
+	)
+	dataflowTester.Subtask(tasks.UpdateTeamUserHistoriesMeta, taskData)
+	dataflowTester.VerifyTable(
+		crossdomain.TeamUserHistory{},
+		"./snapshot_tables/team_user_histories_changed.csv",
+		e2ehelper.ColumnWithRawData(
+			"team_id",
+			"user_id",
+			"start_date",
+			"end_date",
+		),
+	)
+}
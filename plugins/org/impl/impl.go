@@ -23,6 +23,8 @@ import (
 	"github.com/apache/incubator-devlake/plugins/core"
 	"github.com/apache/incubator-devlake/plugins/helper"
 	"github.com/apache/incubator-devlake/plugins/org/api"
+	"github.com/apache/incubator-devlake/plugins/org/models"
+	"github.com/apache/incubator-devlake/plugins/org/models/migrationscripts"
 	"github.com/apache/incubator-devlake/plugins/org/tasks"
 	"github.com/spf13/viper"
 	"gorm.io/gorm"
@@ -32,6 +34,8 @@ var _ core.PluginMeta = (*Org)(nil)
 var _ core.PluginInit = (*Org)(nil)
 var _ core.PluginTask = (*Org)(nil)
 var _ core.PluginModel = (*Org)(nil)
+var _ core.PluginMigration = (*Org)(nil)
+var _ core.PluginBlueprintV100 = (*Org)(nil)
 
 type Org struct {
 	handlers *api.Handlers
@@ -44,7 +48,13 @@ func (plugin *Org) Init(config *viper.Viper, logger core.Logger, db *gorm.DB) er
 }
 
 func (plugin Org) GetTablesInfo() []core.Tabler {
-	return []core.Tabler{}
+	return []core.Tabler{
+		&models.DirectoryConnection{},
+		&models.DirectoryUser{},
+		&models.DirectoryGroup{},
+		&models.DirectoryGroupMember{},
+		&models.UserAccountCandidate{},
+	}
 }
 
 func (plugin Org) Description() string {
@@ -53,7 +63,16 @@ func (plugin Org) Description() string {
 
 func (plugin Org) SubTaskMetas() []core.SubTaskMeta {
 	return []core.SubTaskMeta{
+		tasks.CollectScimUsersMeta,
+		tasks.CollectScimGroupsMeta,
+		tasks.CollectLdapEntriesMeta,
+		tasks.ExtractDirectoryUsersMeta,
+		tasks.ExtractDirectoryGroupsMeta,
+		tasks.ConvertDirectoryUsersMeta,
+		tasks.ConvertDirectoryTeamsMeta,
+		tasks.UpdateTeamUserHistoriesMeta,
 		tasks.ConnectUserAccountsExactMeta,
+		tasks.ConnectUserAccountsFuzzyMeta,
 	}
 }
 
@@ -66,13 +85,50 @@ func (plugin Org) PrepareTaskData(taskCtx core.TaskContext, options map[string]i
 	taskData := &tasks.TaskData{
 		Options: &op,
 	}
+	// users and teams are synced from the directory of the connection if given
+	if op.ConnectionId != 0 {
+		connectionHelper := helper.NewConnectionHelper(
+			taskCtx,
+			nil,
+		)
+		connection := &models.DirectoryConnection{}
+		err = connectionHelper.FirstById(connection, op.ConnectionId)
+		if err != nil {
+			return nil, errors.Default.Wrap(err, "unable to get directory connection by the given connection ID")
+		}
+		taskData.Connection = connection
+		if connection.Type == models.DIRECTORY_TYPE_SCIM {
+			taskData.ApiClient, err = tasks.NewScimApiClient(taskCtx, connection)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
 	return taskData, nil
 }
 
+// MakePipelinePlan leaves out connecting user accounts, which would run in the same stage as the collectors of other plugins
+func (plugin Org) MakePipelinePlan(connectionId uint64, scope []*core.BlueprintScopeV100) (core.PipelinePlan, errors.Error) {
+	return api.MakePipelinePlan([]core.SubTaskMeta{
+		tasks.CollectScimUsersMeta,
+		tasks.CollectScimGroupsMeta,
+		tasks.CollectLdapEntriesMeta,
+		tasks.ExtractDirectoryUsersMeta,
+		tasks.ExtractDirectoryGroupsMeta,
+		tasks.ConvertDirectoryUsersMeta,
+		tasks.ConvertDirectoryTeamsMeta,
+		tasks.UpdateTeamUserHistoriesMeta,
+	}, connectionId, scope)
+}
+
 func (plugin Org) RootPkgPath() string {
 	return "github.com/apache/incubator-devlake/plugins/org"
 }
 
+func (plugin Org) MigrationScripts() []core.MigrationScript {
+	return migrationscripts.All()
+}
+
 func (plugin Org) ApiResources() map[string]map[string]core.ApiResourceHandler {
 	return map[string]map[string]core.ApiResourceHandler{
 		"teams.csv": {
@@ -92,5 +148,21 @@ func (plugin Org) ApiResources() map[string]map[string]core.ApiResourceHandler {
 			"GET": plugin.handlers.GetProjectMapping,
 			"PUT": plugin.handlers.CreateProjectMapping,
 		},
+		"user_account_candidates": {
+			"GET":   plugin.handlers.GetUserAccountCandidates,
+			"PATCH": plugin.handlers.ReviewUserAccountCandidate,
+		},
+		"test": {
+			"POST": plugin.handlers.TestConnection,
+		},
+		"connections": {
+			"POST": plugin.handlers.PostConnections,
+			"GET":  plugin.handlers.ListConnections,
+		},
+		"connections/:connectionId": {
+			"GET":    plugin.handlers.GetConnection,
+			"PATCH":  plugin.handlers.PatchConnection,
+			"DELETE": plugin.handlers.DeleteConnection,
+		},
 	}
 }
@@ -0,0 +1,53 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import "github.com/apache/incubator-devlake/plugins/helper"
+
+const (
+	DIRECTORY_TYPE_LDAP = "ldap"
+	DIRECTORY_TYPE_SCIM = "scim"
+)
+
+// DirectoryConnection holds the access to a directory service users and teams are synced from, the endpoint is
+// an LDAP url like ldap://ldap.example.com:389 for `ldap`, or the base url of a SCIM 2.0 service for `scim`
+type DirectoryConnection struct {
+	helper.RestConnection `mapstructure:",squash"`
+	Type                  string `mapstructure:"type" validate:"required,oneof=ldap scim" json:"type" gorm:"type:varchar(20)"`
+	// bearer token of the SCIM service
+	Token string `mapstructure:"token" json:"token" encrypt:"yes"`
+	// bind account and search settings of the LDAP server
+	BindDn       string `mapstructure:"bindDn" json:"bindDn" gorm:"type:varchar(255)"`
+	BindPassword string `mapstructure:"bindPassword" json:"bindPassword" encrypt:"yes"`
+	BaseDn       string `mapstructure:"baseDn" json:"baseDn" gorm:"type:varchar(255)"`
+	UserFilter   string `mapstructure:"userFilter" json:"userFilter" gorm:"type:varchar(255)"`
+	GroupFilter  string `mapstructure:"groupFilter" json:"groupFilter" gorm:"type:varchar(255)"`
+}
+
+type TestConnectionRequest struct {
+	Type         string `json:"type" validate:"required,oneof=ldap scim"`
+	Endpoint     string `json:"endpoint" validate:"required,url"`
+	Proxy        string `json:"proxy"`
+	Token        string `json:"token"`
+	BindDn       string `json:"bindDn"`
+	BindPassword string `json:"bindPassword"`
+}
+
+func (DirectoryConnection) TableName() string {
+	return "_tool_org_directory_connections"
+}
@@ -0,0 +1,59 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import "github.com/apache/incubator-devlake/models/common"
+
+// DirectoryUser is a user entry of the directory, Id is the SCIM id or the LDAP dn
+type DirectoryUser struct {
+	ConnectionId uint64 `gorm:"primaryKey"`
+	Id           string `gorm:"primaryKey;type:varchar(255)"`
+	UserName     string `gorm:"type:varchar(255)"`
+	Name         string `gorm:"type:varchar(255)"`
+	Email        string `gorm:"type:varchar(255)"`
+	Active       bool
+	common.NoPKModel
+}
+
+func (DirectoryUser) TableName() string {
+	return "_tool_org_directory_users"
+}
+
+// DirectoryGroup is a group entry of the directory, Id is the SCIM id or the LDAP dn
+type DirectoryGroup struct {
+	ConnectionId uint64 `gorm:"primaryKey"`
+	Id           string `gorm:"primaryKey;type:varchar(255)"`
+	Name         string `gorm:"type:varchar(255)"`
+	common.NoPKModel
+}
+
+func (DirectoryGroup) TableName() string {
+	return "_tool_org_directory_groups"
+}
+
+// DirectoryGroupMember is a member of a group, which could be either a user or a nested group
+type DirectoryGroupMember struct {
+	ConnectionId uint64 `gorm:"primaryKey"`
+	GroupId      string `gorm:"primaryKey;type:varchar(255)"`
+	MemberId     string `gorm:"primaryKey;type:varchar(255)"`
+	common.NoPKModel
+}
+
+func (DirectoryGroupMember) TableName() string {
+	return "_tool_org_directory_group_members"
+}
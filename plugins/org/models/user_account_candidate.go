@@ -0,0 +1,43 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import "github.com/apache/incubator-devlake/models/common"
+
+// status of a candidate user/account mapping found by fuzzy resolution
+const (
+	CANDIDATE_PENDING       = "PENDING"
+	CANDIDATE_AUTO_ACCEPTED = "AUTO_ACCEPTED"
+	CANDIDATE_ACCEPTED      = "ACCEPTED"
+	CANDIDATE_REJECTED      = "REJECTED"
+)
+
+// UserAccountCandidate is a scored guess that an account belongs to a user, it is mapped into user_accounts once
+// the score reaches the auto-accept threshold, or once an admin accepts it
+type UserAccountCandidate struct {
+	AccountId string  `gorm:"primaryKey;type:varchar(255)" json:"accountId"`
+	UserId    string  `gorm:"primaryKey;type:varchar(255)" json:"userId"`
+	Score     float64 `json:"score"`
+	Reasons   string  `gorm:"type:varchar(255)" json:"reasons"` // comma separated signals the score was based on
+	Status    string  `gorm:"type:varchar(20);index" json:"status"`
+	common.NoPKModel
+}
+
+func (UserAccountCandidate) TableName() string {
+	return "_tool_org_user_account_candidates"
+}
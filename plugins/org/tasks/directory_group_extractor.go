@@ -0,0 +1,76 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/plugins/core"
+	"github.com/apache/incubator-devlake/plugins/helper"
+	"github.com/apache/incubator-devlake/plugins/org/models"
+)
+
+var ExtractDirectoryGroupsMeta = core.SubTaskMeta{
+	Name:             "extractDirectoryGroups",
+	EntryPoint:       ExtractDirectoryGroups,
+	EnabledByDefault: true,
+	Description:      "Extract raw directory groups into tool layer table _tool_org_directory_groups and their members",
+	DomainTypes:      []string{core.DOMAIN_TYPE_CROSS},
+}
+
+func ExtractDirectoryGroups(taskCtx core.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*TaskData)
+	if !isDirectorySynced(data) {
+		return nil
+	}
+	extractor, err := helper.NewApiExtractor(helper.ApiExtractorArgs{
+		RawDataSubTaskArgs: helper.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: Params{
+				ConnectionId: data.Options.ConnectionId,
+			},
+			Table: RAW_DIRECTORY_GROUP_TABLE,
+		},
+		Extract: func(row *helper.RawData) ([]interface{}, errors.Error) {
+			group := &DirectoryGroup{}
+			err := errors.Convert(json.Unmarshal(row.Data, group))
+			if err != nil {
+				return nil, err
+			}
+			results := make([]interface{}, 0, len(group.Members)+1)
+			results = append(results, &models.DirectoryGroup{
+				ConnectionId: data.Options.ConnectionId,
+				Id:           group.Id,
+				Name:         group.DisplayName,
+			})
+			for _, member := range group.Members {
+				results = append(results, &models.DirectoryGroupMember{
+					ConnectionId: data.Options.ConnectionId,
+					GroupId:      group.Id,
+					MemberId:     member.Value,
+				})
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
@@ -0,0 +1,146 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/models/domainlayer"
+	"github.com/apache/incubator-devlake/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/plugins/core"
+	"github.com/apache/incubator-devlake/plugins/core/dal"
+	"github.com/apache/incubator-devlake/plugins/helper"
+	"github.com/apache/incubator-devlake/plugins/org/models"
+)
+
+var ConvertDirectoryTeamsMeta = core.SubTaskMeta{
+	Name:             "convertDirectoryTeams",
+	EntryPoint:       ConvertDirectoryTeams,
+	EnabledByDefault: true,
+	Description:      "Convert directory groups into domain layer table teams and team_users, nested groups become sub-teams",
+	DomainTypes:      []string{core.DOMAIN_TYPE_CROSS},
+}
+
+func ConvertDirectoryTeams(taskCtx core.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*TaskData)
+	if !isDirectorySynced(data) {
+		return nil
+	}
+	rawDataSubTaskArgs := helper.RawDataSubTaskArgs{
+		Ctx: taskCtx,
+		Params: Params{
+			ConnectionId: data.Options.ConnectionId,
+		},
+		Table: RAW_DIRECTORY_GROUP_TABLE,
+	}
+	userRawDataSubTask, err := helper.NewRawDataSubTask(helper.RawDataSubTaskArgs{
+		Ctx:    taskCtx,
+		Params: rawDataSubTaskArgs.Params,
+		Table:  RAW_DIRECTORY_USER_TABLE,
+	})
+	if err != nil {
+		return err
+	}
+	userIds, err := getDomainUserIds(db, data.Options.ConnectionId, userRawDataSubTask)
+	if err != nil {
+		return err
+	}
+	var inactiveUserIds []string
+	err = db.Pluck("id", &inactiveUserIds,
+		dal.From(&models.DirectoryUser{}),
+		dal.Where("connection_id = ? AND active = ?", data.Options.ConnectionId, false),
+	)
+	if err != nil {
+		return err
+	}
+	for _, id := range inactiveUserIds {
+		delete(userIds, id)
+	}
+
+	var groups []models.DirectoryGroup
+	err = db.All(&groups, dal.Where("connection_id = ?", data.Options.ConnectionId))
+	if err != nil {
+		return err
+	}
+	isGroup := make(map[string]bool, len(groups))
+	for _, group := range groups {
+		isGroup[group.Id] = true
+	}
+	var members []models.DirectoryGroupMember
+	err = db.All(&members,
+		dal.Where("connection_id = ?", data.Options.ConnectionId),
+		dal.Orderby("group_id, member_id"),
+	)
+	if err != nil {
+		return err
+	}
+	teamIdGen := didgen.NewDomainIdGenerator(&models.DirectoryGroup{})
+	parentIds := make(map[string]string)
+	memberUserIds := make(map[string][]string)
+	for _, member := range members {
+		if isGroup[member.MemberId] {
+			// a group nested in more than one group is placed under the first of them
+			if _, ok := parentIds[member.MemberId]; !ok {
+				parentIds[member.MemberId] = teamIdGen.Generate(member.ConnectionId, member.GroupId)
+			}
+		} else if userId, ok := userIds[member.MemberId]; ok {
+			memberUserIds[member.GroupId] = append(memberUserIds[member.GroupId], userId)
+		}
+	}
+
+	cursor, err := db.Cursor(
+		dal.From(&models.DirectoryGroup{}),
+		dal.Where("connection_id = ?", data.Options.ConnectionId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	converter, err := helper.NewDataConverter(helper.DataConverterArgs{
+		RawDataSubTaskArgs: rawDataSubTaskArgs,
+		InputRowType:       reflect.TypeOf(models.DirectoryGroup{}),
+		Input:              cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			group := inputRow.(*models.DirectoryGroup)
+			teamId := teamIdGen.Generate(group.ConnectionId, group.Id)
+			results := make([]interface{}, 0, len(memberUserIds[group.Id])+1)
+			results = append(results, &crossdomain.Team{
+				DomainEntity: domainlayer.DomainEntity{
+					Id: teamId,
+				},
+				Name:     group.Name,
+				ParentId: parentIds[group.Id],
+			})
+			for _, userId := range memberUserIds[group.Id] {
+				results = append(results, &crossdomain.TeamUser{
+					TeamId: teamId,
+					UserId: userId,
+				})
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return converter.Execute()
+}
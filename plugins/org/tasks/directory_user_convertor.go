@@ -0,0 +1,136 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/models/domainlayer"
+	"github.com/apache/incubator-devlake/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/plugins/core"
+	"github.com/apache/incubator-devlake/plugins/core/dal"
+	"github.com/apache/incubator-devlake/plugins/helper"
+	"github.com/apache/incubator-devlake/plugins/org/models"
+)
+
+var ConvertDirectoryUsersMeta = core.SubTaskMeta{
+	Name:             "convertDirectoryUsers",
+	EntryPoint:       ConvertDirectoryUsers,
+	EnabledByDefault: true,
+	Description:      "Convert directory users into domain layer table users, users are kept after leaving the directory",
+	DomainTypes:      []string{core.DOMAIN_TYPE_CROSS},
+}
+
+func ConvertDirectoryUsers(taskCtx core.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*TaskData)
+	if !isDirectorySynced(data) {
+		return nil
+	}
+	rawDataSubTask, err := helper.NewRawDataSubTask(helper.RawDataSubTaskArgs{
+		Ctx: taskCtx,
+		Params: Params{
+			ConnectionId: data.Options.ConnectionId,
+		},
+		Table: RAW_DIRECTORY_USER_TABLE,
+	})
+	if err != nil {
+		return err
+	}
+	userIds, err := getDomainUserIds(db, data.Options.ConnectionId, rawDataSubTask)
+	if err != nil {
+		return err
+	}
+	cursor, err := db.Cursor(
+		dal.From(&models.DirectoryUser{}),
+		dal.Where("connection_id = ?", data.Options.ConnectionId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	// users who left the directory are not deleted, so accounts mapped to them are still attributed
+	divider := helper.NewBatchSaveDivider(taskCtx, 500, rawDataSubTask.GetTable(), rawDataSubTask.GetParams())
+	divider.SetIncrementalMode(true)
+	batch, err := divider.ForType(reflect.TypeOf(&crossdomain.User{}))
+	if err != nil {
+		return err
+	}
+	userIdGen := didgen.NewDomainIdGenerator(&models.DirectoryUser{})
+	for cursor.Next() {
+		directoryUser := &models.DirectoryUser{}
+		err = db.Fetch(cursor, directoryUser)
+		if err != nil {
+			return err
+		}
+		userId := userIdGen.Generate(directoryUser.ConnectionId, directoryUser.Id)
+		if userIds[directoryUser.Id] != userId {
+			// the user was curated by hand, which is left as it is
+			continue
+		}
+		user := &crossdomain.User{
+			DomainEntity: domainlayer.DomainEntity{
+				Id: userId,
+			},
+			Name:  directoryUser.Name,
+			Email: directoryUser.Email,
+		}
+		user.RawDataOrigin = directoryUser.RawDataOrigin
+		err = batch.Add(user)
+		if err != nil {
+			return err
+		}
+	}
+	return divider.Close()
+}
+
+// getDomainUserIds maps ids of directory users to ids of domain users, a directory user is mapped to
+// the user not synced from the directory with the same email if any, so hand-curated users are never duplicated
+func getDomainUserIds(db dal.Dal, connectionId uint64, rawDataSubTask *helper.RawDataSubTask) (map[string]string, errors.Error) {
+	var users []crossdomain.User
+	err := db.All(&users, dal.Where(
+		"email != '' AND (_raw_data_table IS NULL OR _raw_data_table != ? OR _raw_data_params != ?)",
+		rawDataSubTask.GetTable(), rawDataSubTask.GetParams(),
+	))
+	if err != nil {
+		return nil, err
+	}
+	curatedUserIds := make(map[string]string, len(users))
+	for _, user := range users {
+		curatedUserIds[strings.ToLower(user.Email)] = user.Id
+	}
+	var directoryUsers []models.DirectoryUser
+	err = db.All(&directoryUsers, dal.Where("connection_id = ?", connectionId))
+	if err != nil {
+		return nil, err
+	}
+	userIdGen := didgen.NewDomainIdGenerator(&models.DirectoryUser{})
+	userIds := make(map[string]string, len(directoryUsers))
+	for _, directoryUser := range directoryUsers {
+		if userId, ok := curatedUserIds[strings.ToLower(directoryUser.Email)]; directoryUser.Email != "" && ok {
+			userIds[directoryUser.Id] = userId
+		} else {
+			userIds[directoryUser.Id] = userIdGen.Generate(directoryUser.ConnectionId, directoryUser.Id)
+		}
+	}
+	return userIds, nil
+}
@@ -0,0 +1,72 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/plugins/core"
+	"github.com/apache/incubator-devlake/plugins/helper"
+	"github.com/apache/incubator-devlake/plugins/org/models"
+)
+
+var ExtractDirectoryUsersMeta = core.SubTaskMeta{
+	Name:             "extractDirectoryUsers",
+	EntryPoint:       ExtractDirectoryUsers,
+	EnabledByDefault: true,
+	Description:      "Extract raw directory users into tool layer table _tool_org_directory_users",
+	DomainTypes:      []string{core.DOMAIN_TYPE_CROSS},
+}
+
+func ExtractDirectoryUsers(taskCtx core.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*TaskData)
+	if !isDirectorySynced(data) {
+		return nil
+	}
+	extractor, err := helper.NewApiExtractor(helper.ApiExtractorArgs{
+		RawDataSubTaskArgs: helper.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: Params{
+				ConnectionId: data.Options.ConnectionId,
+			},
+			Table: RAW_DIRECTORY_USER_TABLE,
+		},
+		Extract: func(row *helper.RawData) ([]interface{}, errors.Error) {
+			user := &DirectoryUser{}
+			err := errors.Convert(json.Unmarshal(row.Data, user))
+			if err != nil {
+				return nil, err
+			}
+			return []interface{}{
+				&models.DirectoryUser{
+					ConnectionId: data.Options.ConnectionId,
+					Id:           user.Id,
+					UserName:     user.UserName,
+					Name:         user.getName(),
+					Email:        user.getEmail(),
+					Active:       user.isActive(),
+				},
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
@@ -0,0 +1,215 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/plugins/core"
+	"github.com/apache/incubator-devlake/plugins/core/dal"
+	"github.com/apache/incubator-devlake/plugins/helper"
+	"github.com/apache/incubator-devlake/plugins/org/models"
+	"github.com/go-ldap/ldap/v3"
+)
+
+const DEFAULT_LDAP_USER_FILTER = "(|(objectClass=inetOrgPerson)(objectClass=user))"
+const DEFAULT_LDAP_GROUP_FILTER = "(|(objectClass=groupOfNames)(objectClass=groupOfUniqueNames)(objectClass=group))"
+
+const ldapPageSize = 500
+
+// the ACCOUNTDISABLE flag of userAccountControl in Active Directory
+const adAccountDisabled = 0x2
+
+var CollectLdapEntriesMeta = core.SubTaskMeta{
+	Name:             "collectLdapEntries",
+	EntryPoint:       CollectLdapEntries,
+	EnabledByDefault: true,
+	Description:      "Collect users and groups from the LDAP server of the directory connection",
+	DomainTypes:      []string{core.DOMAIN_TYPE_CROSS},
+}
+
+// DialLdap connects to the LDAP server and binds with the given account, anonymously if bindDn is empty
+func DialLdap(endpoint, bindDn, bindPassword string) (*ldap.Conn, errors.Error) {
+	conn, err := ldap.DialURL(endpoint, ldap.DialWithDialer(&net.Dialer{Timeout: 10 * time.Second}))
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "failed to connect to the LDAP server")
+	}
+	if bindDn == "" {
+		err = conn.UnauthenticatedBind("")
+	} else {
+		err = conn.Bind(bindDn, bindPassword)
+	}
+	if err != nil {
+		conn.Close()
+		return nil, errors.Unauthorized.Wrap(err, "failed to bind to the LDAP server")
+	}
+	return conn, nil
+}
+
+func CollectLdapEntries(taskCtx core.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*TaskData)
+	if !isDirectoryType(data, models.DIRECTORY_TYPE_LDAP) {
+		return nil
+	}
+	connection := data.Connection
+	conn, err := DialLdap(connection.Endpoint, connection.BindDn, connection.BindPassword)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	userFilter := connection.UserFilter
+	if userFilter == "" {
+		userFilter = DEFAULT_LDAP_USER_FILTER
+	}
+	userEntries, err := searchLdap(conn, connection.BaseDn, userFilter, []string{"uid", "sAMAccountName", "cn", "displayName", "mail", "userAccountControl"})
+	if err != nil {
+		return err
+	}
+	users := make([]interface{}, len(userEntries))
+	for i, entry := range userEntries {
+		users[i] = normalizeLdapUser(entry)
+	}
+	err = saveLdapRawData(taskCtx, RAW_DIRECTORY_USER_TABLE, connection.Endpoint, users)
+	if err != nil {
+		return err
+	}
+
+	groupFilter := connection.GroupFilter
+	if groupFilter == "" {
+		groupFilter = DEFAULT_LDAP_GROUP_FILTER
+	}
+	groupEntries, err := searchLdap(conn, connection.BaseDn, groupFilter, []string{"cn", "member", "uniqueMember"})
+	if err != nil {
+		return err
+	}
+	groups := make([]interface{}, len(groupEntries))
+	for i, entry := range groupEntries {
+		groups[i] = normalizeLdapGroup(entry)
+	}
+	return saveLdapRawData(taskCtx, RAW_DIRECTORY_GROUP_TABLE, connection.Endpoint, groups)
+}
+
+func searchLdap(conn *ldap.Conn, baseDn, filter string, attributes []string) ([]*ldap.Entry, errors.Error) {
+	request := ldap.NewSearchRequest(
+		baseDn,
+		ldap.ScopeWholeSubtree,
+		ldap.NeverDerefAliases,
+		0,
+		0,
+		false,
+		filter,
+		attributes,
+		nil,
+	)
+	result, err := conn.SearchWithPaging(request, ldapPageSize)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "failed to search the LDAP server with filter "+filter)
+	}
+	return result.Entries, nil
+}
+
+// saveLdapRawData replaces the records of previous collection in the raw table, the same way ApiCollector does
+func saveLdapRawData(taskCtx core.SubTaskContext, table string, endpoint string, records []interface{}) errors.Error {
+	data := taskCtx.GetData().(*TaskData)
+	rawDataSubTask, err := helper.NewRawDataSubTask(helper.RawDataSubTaskArgs{
+		Ctx: taskCtx,
+		Params: Params{
+			ConnectionId: data.Options.ConnectionId,
+		},
+		Table: table,
+	})
+	if err != nil {
+		return err
+	}
+	db := taskCtx.GetDal()
+	rawTable := rawDataSubTask.GetTable()
+	params := rawDataSubTask.GetParams()
+	err = db.AutoMigrate(&helper.RawData{}, dal.From(rawTable))
+	if err != nil {
+		return err
+	}
+	err = db.Delete(&helper.RawData{}, dal.From(rawTable), dal.Where("params = ?", params))
+	if err != nil {
+		return err
+	}
+	if len(records) == 0 {
+		return nil
+	}
+	rows := make([]*helper.RawData, len(records))
+	for i, record := range records {
+		blob, err := errors.Convert01(json.Marshal(record))
+		if err != nil {
+			return err
+		}
+		rows[i] = &helper.RawData{
+			Params: params,
+			Data:   blob,
+			Url:    endpoint,
+		}
+	}
+	return db.Create(rows, dal.From(rawTable))
+}
+
+// normalizeDn makes dn comparable, member attributes may not refer to an entry with the same case and spacing
+func normalizeDn(dn string) string {
+	parsed, err := ldap.ParseDN(dn)
+	if err != nil {
+		return strings.ToLower(strings.TrimSpace(dn))
+	}
+	return strings.ToLower(parsed.String())
+}
+
+func normalizeLdapUser(entry *ldap.Entry) *DirectoryUser {
+	user := &DirectoryUser{
+		Id:          normalizeDn(entry.DN),
+		UserName:    entry.GetAttributeValue("uid"),
+		DisplayName: entry.GetAttributeValue("displayName"),
+	}
+	if user.UserName == "" {
+		user.UserName = entry.GetAttributeValue("sAMAccountName")
+	}
+	if user.DisplayName == "" {
+		user.DisplayName = entry.GetAttributeValue("cn")
+	}
+	for i, mail := range entry.GetAttributeValues("mail") {
+		user.Emails = append(user.Emails, DirectoryEmail{Value: mail, Primary: i == 0})
+	}
+	if flags, err := strconv.Atoi(entry.GetAttributeValue("userAccountControl")); err == nil {
+		active := flags&adAccountDisabled == 0
+		user.Active = &active
+	}
+	return user
+}
+
+func normalizeLdapGroup(entry *ldap.Entry) *DirectoryGroup {
+	group := &DirectoryGroup{
+		Id:          normalizeDn(entry.DN),
+		DisplayName: entry.GetAttributeValue("cn"),
+	}
+	members := append(entry.GetAttributeValues("member"), entry.GetAttributeValues("uniqueMember")...)
+	for _, member := range members {
+		group.Members = append(group.Members, DirectoryMember{Value: normalizeDn(member)})
+	}
+	return group
+}
@@ -0,0 +1,69 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"testing"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeDn(t *testing.T) {
+	assert.Equal(t, "cn=alice chen,ou=people,dc=example,dc=org", normalizeDn("CN=Alice Chen, OU=People,DC=example,DC=org"))
+	assert.Equal(t, "not a dn", normalizeDn(" Not a DN "))
+}
+
+func TestNormalizeLdapUser(t *testing.T) {
+	user := normalizeLdapUser(ldap.NewEntry("uid=alice,ou=people,dc=example,dc=org", map[string][]string{
+		"uid":  {"alice"},
+		"cn":   {"Alice Chen"},
+		"mail": {"alice@example.com", "achen@example.com"},
+	}))
+	assert.Equal(t, "uid=alice,ou=people,dc=example,dc=org", user.Id)
+	assert.Equal(t, "alice", user.UserName)
+	assert.Equal(t, "Alice Chen", user.getName())
+	assert.Equal(t, "alice@example.com", user.getEmail())
+	assert.True(t, user.isActive())
+
+	// Active Directory account disabled by userAccountControl
+	user = normalizeLdapUser(ldap.NewEntry("CN=Bob Li,OU=Staff,DC=corp,DC=example,DC=com", map[string][]string{
+		"sAMAccountName":     {"bob"},
+		"displayName":        {"Bob Li"},
+		"userAccountControl": {"514"},
+	}))
+	assert.Equal(t, "cn=bob li,ou=staff,dc=corp,dc=example,dc=com", user.Id)
+	assert.Equal(t, "bob", user.UserName)
+	assert.Equal(t, "", user.getEmail())
+	assert.False(t, user.isActive())
+}
+
+func TestNormalizeLdapGroup(t *testing.T) {
+	group := normalizeLdapGroup(ldap.NewEntry("cn=platform,ou=groups,dc=example,dc=org", map[string][]string{
+		"cn":           {"platform"},
+		"member":       {"UID=alice,OU=people,DC=example,DC=org", "cn=sre,ou=groups,dc=example,dc=org"},
+		"uniqueMember": {"uid=bob,ou=people,dc=example,dc=org"},
+	}))
+	assert.Equal(t, "cn=platform,ou=groups,dc=example,dc=org", group.Id)
+	assert.Equal(t, "platform", group.DisplayName)
+	assert.Equal(t, []DirectoryMember{
+		{Value: "uid=alice,ou=people,dc=example,dc=org"},
+		{Value: "cn=sre,ou=groups,dc=example,dc=org"},
+		{Value: "uid=bob,ou=people,dc=example,dc=org"},
+	}, group.Members)
+}
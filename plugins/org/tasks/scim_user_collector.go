@@ -0,0 +1,77 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/plugins/core"
+	"github.com/apache/incubator-devlake/plugins/helper"
+	"github.com/apache/incubator-devlake/plugins/org/models"
+)
+
+var CollectScimUsersMeta = core.SubTaskMeta{
+	Name:             "collectScimUsers",
+	EntryPoint:       CollectScimUsers,
+	EnabledByDefault: true,
+	Description:      "Collect users from the SCIM 2.0 endpoint of the directory connection",
+	DomainTypes:      []string{core.DOMAIN_TYPE_CROSS},
+}
+
+func CollectScimUsers(taskCtx core.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*TaskData)
+	if !isDirectoryType(data, models.DIRECTORY_TYPE_SCIM) {
+		return nil
+	}
+	collector, err := helper.NewApiCollector(helper.ApiCollectorArgs{
+		RawDataSubTaskArgs: helper.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: Params{
+				ConnectionId: data.Options.ConnectionId,
+			},
+			Table: RAW_DIRECTORY_USER_TABLE,
+		},
+		ApiClient:   data.ApiClient,
+		PageSize:    100,
+		UrlTemplate: "Users",
+		Query: func(reqData *helper.RequestData) (url.Values, errors.Error) {
+			query := url.Values{}
+			// startIndex of SCIM is the 1-based index of the first result
+			query.Set("startIndex", fmt.Sprintf("%v", reqData.Pager.Skip+1))
+			query.Set("count", fmt.Sprintf("%v", reqData.Pager.Size))
+			return query, nil
+		},
+		GetTotalPages: GetTotalPagesFromResponse,
+		ResponseParser: func(res *http.Response) ([]json.RawMessage, errors.Error) {
+			body := &ScimListResponse{}
+			err := helper.UnmarshalResponse(res, body)
+			if err != nil {
+				return nil, err
+			}
+			return body.Resources, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return collector.Execute()
+}
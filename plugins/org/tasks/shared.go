@@ -0,0 +1,113 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/plugins/helper"
+)
+
+const RAW_DIRECTORY_USER_TABLE = "org_directory_users"
+const RAW_DIRECTORY_GROUP_TABLE = "org_directory_groups"
+
+// DirectoryUser is a user in the format of SCIM 2.0 resource, LDAP entries are normalized into it as well
+type DirectoryUser struct {
+	Id          string `json:"id"`
+	UserName    string `json:"userName"`
+	DisplayName string `json:"displayName"`
+	Name        struct {
+		Formatted string `json:"formatted"`
+	} `json:"name"`
+	Emails []DirectoryEmail `json:"emails"`
+	// Active is optional in SCIM, users are active unless told otherwise
+	Active *bool `json:"active"`
+}
+
+type DirectoryEmail struct {
+	Value   string `json:"value"`
+	Primary bool   `json:"primary"`
+}
+
+// DirectoryGroup is a group in the format of SCIM 2.0 resource, LDAP entries are normalized into it as well
+type DirectoryGroup struct {
+	Id          string            `json:"id"`
+	DisplayName string            `json:"displayName"`
+	Members     []DirectoryMember `json:"members"`
+}
+
+// DirectoryMember refers to either a user or a nested group by id
+type DirectoryMember struct {
+	Value string `json:"value"`
+}
+
+// ScimListResponse is the envelope of SCIM 2.0 list responses
+type ScimListResponse struct {
+	TotalResults int               `json:"totalResults"`
+	Resources    []json.RawMessage `json:"Resources"`
+}
+
+func isDirectoryType(data *TaskData, directoryType string) bool {
+	return data.Connection != nil && data.Connection.Type == directoryType
+}
+
+func isDirectorySynced(data *TaskData) bool {
+	return data.Connection != nil
+}
+
+func GetTotalPagesFromResponse(res *http.Response, args *helper.ApiCollectorArgs) (int, errors.Error) {
+	body := &ScimListResponse{}
+	err := helper.UnmarshalResponse(res, body)
+	if err != nil {
+		return 0, err
+	}
+	pages := body.TotalResults / args.PageSize
+	if body.TotalResults%args.PageSize > 0 {
+		pages++
+	}
+	return pages, nil
+}
+
+// getEmail returns the primary email of the user, or the first one if none is marked primary
+func (user *DirectoryUser) getEmail() string {
+	for _, email := range user.Emails {
+		if email.Primary {
+			return email.Value
+		}
+	}
+	if len(user.Emails) > 0 {
+		return user.Emails[0].Value
+	}
+	return ""
+}
+
+func (user *DirectoryUser) getName() string {
+	if user.DisplayName != "" {
+		return user.DisplayName
+	}
+	if user.Name.Formatted != "" {
+		return user.Name.Formatted
+	}
+	return user.UserName
+}
+
+func (user *DirectoryUser) isActive() bool {
+	return user.Active == nil || *user.Active
+}
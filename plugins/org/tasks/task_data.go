@@ -17,12 +17,22 @@ limitations under the License.
 
 package tasks
 
+import (
+	"github.com/apache/incubator-devlake/plugins/helper"
+	"github.com/apache/incubator-devlake/plugins/org/models"
+)
+
 type Options struct {
 	ConnectionId uint64 `json:"connectionId"`
+	// accounts are mapped to users by fuzzy matching once the score reaches it, DEFAULT_AUTO_ACCEPT_SCORE if not set
+	AutoAcceptScore float64 `json:"autoAcceptScore"`
 }
 
 type TaskData struct {
 	Options *Options
+	// Connection is nil unless users and teams are synced from a directory
+	Connection *models.DirectoryConnection
+	ApiClient  *helper.ApiAsyncClient
 }
 type Params struct {
 	ConnectionId uint64
@@ -0,0 +1,115 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/models/common"
+	"github.com/apache/incubator-devlake/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/plugins/core"
+	"github.com/apache/incubator-devlake/plugins/core/dal"
+	"github.com/apache/incubator-devlake/plugins/helper"
+)
+
+var UpdateTeamUserHistoriesMeta = core.SubTaskMeta{
+	Name:             "updateTeamUserHistories",
+	EntryPoint:       UpdateTeamUserHistories,
+	EnabledByDefault: true,
+	Description:      "Record team membership changes of the directory in team_user_histories, as of the time groups were collected",
+	DomainTypes:      []string{core.DOMAIN_TYPE_CROSS},
+}
+
+func UpdateTeamUserHistories(taskCtx core.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*TaskData)
+	if !isDirectorySynced(data) {
+		return nil
+	}
+	rawDataSubTask, err := helper.NewRawDataSubTask(helper.RawDataSubTaskArgs{
+		Ctx: taskCtx,
+		Params: Params{
+			ConnectionId: data.Options.ConnectionId,
+		},
+		Table: RAW_DIRECTORY_GROUP_TABLE,
+	})
+	if err != nil {
+		return err
+	}
+	table, params := rawDataSubTask.GetTable(), rawDataSubTask.GetParams()
+
+	// memberships are observed when the groups were collected
+	var rawData []helper.RawData
+	err = db.All(&rawData, dal.From(table), dal.Where("params = ?", params), dal.Orderby("created_at DESC"), dal.Limit(1))
+	if err != nil {
+		return err
+	}
+	if len(rawData) == 0 {
+		return nil
+	}
+	syncedAt := rawData[0].CreatedAt
+
+	var teamUsers []crossdomain.TeamUser
+	err = db.All(&teamUsers, dal.Where("_raw_data_table = ? AND _raw_data_params = ?", table, params))
+	if err != nil {
+		return err
+	}
+	var openHistories []crossdomain.TeamUserHistory
+	err = db.All(&openHistories, dal.Where("_raw_data_table = ? AND _raw_data_params = ? AND end_date IS NULL", table, params))
+	if err != nil {
+		return err
+	}
+
+	isMember := make(map[crossdomain.TeamUser]bool, len(teamUsers))
+	for _, teamUser := range teamUsers {
+		isMember[crossdomain.TeamUser{TeamId: teamUser.TeamId, UserId: teamUser.UserId}] = true
+	}
+	for _, history := range openHistories {
+		key := crossdomain.TeamUser{TeamId: history.TeamId, UserId: history.UserId}
+		if isMember[key] {
+			delete(isMember, key)
+			continue
+		}
+		// the user left the team
+		history.EndDate = &syncedAt
+		err = db.Update(&history)
+		if err != nil {
+			return err
+		}
+	}
+	for _, teamUser := range teamUsers {
+		if !isMember[crossdomain.TeamUser{TeamId: teamUser.TeamId, UserId: teamUser.UserId}] {
+			continue
+		}
+		// the user joined the team
+		err = db.CreateOrUpdate(&crossdomain.TeamUserHistory{
+			TeamId:    teamUser.TeamId,
+			UserId:    teamUser.UserId,
+			StartDate: syncedAt,
+			NoPKModel: common.NoPKModel{
+				RawDataOrigin: common.RawDataOrigin{
+					RawDataTable:  table,
+					RawDataParams: params,
+				},
+			},
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
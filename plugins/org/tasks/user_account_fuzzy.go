@@ -0,0 +1,316 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/plugins/core"
+	"github.com/apache/incubator-devlake/plugins/core/dal"
+	"github.com/apache/incubator-devlake/plugins/org/models"
+)
+
+var ConnectUserAccountsFuzzyMeta = core.SubTaskMeta{
+	Name:             "connectUserAccountsFuzzy",
+	EntryPoint:       ConnectUserAccountsFuzzy,
+	EnabledByDefault: true,
+	Description:      "score users of accounts not matched exactly, accept confident matches and queue the rest for review",
+	DomainTypes:      []string{core.DOMAIN_TYPE_CROSS},
+}
+
+const DEFAULT_AUTO_ACCEPT_SCORE = 0.9
+
+// candidates scored lower are not worth a review
+const MIN_CANDIDATE_SCORE = 0.5
+
+// signals a candidate is scored by
+const (
+	SIGNAL_EMAIL          = "email"
+	SIGNAL_NAME           = "name"
+	SIGNAL_USER_NAME      = "user_name"
+	SIGNAL_EMAIL_ALIAS    = "email_alias"
+	SIGNAL_GITHUB_NOREPLY = "github_noreply"
+	SIGNAL_COMMIT_ALIAS   = "commit_alias"
+)
+
+// signalWeights are the probabilities of a signal alone being right, signals are combined as independent evidences
+var signalWeights = map[string]float64{
+	SIGNAL_EMAIL:          0.9,
+	SIGNAL_NAME:           0.8,
+	SIGNAL_USER_NAME:      0.6,
+	SIGNAL_EMAIL_ALIAS:    0.6,
+	SIGNAL_GITHUB_NOREPLY: 0.7,
+	SIGNAL_COMMIT_ALIAS:   0.6,
+}
+
+// 12345+octocat@users.noreply.github.com or octocat@users.noreply.github.com
+var githubNoreplyPattern = regexp.MustCompile(`^(?:\d+\+)?([^@]+)@users\.noreply\.github\.com$`)
+var coAuthoredByPattern = regexp.MustCompile(`(?mi)^\s*co-authored-by:\s*(.+?)\s*<([^>]+)>\s*$`)
+
+func ConnectUserAccountsFuzzy(taskCtx core.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*TaskData)
+	autoAcceptScore := data.Options.AutoAcceptScore
+	if autoAcceptScore <= 0 {
+		autoAcceptScore = DEFAULT_AUTO_ACCEPT_SCORE
+	}
+
+	var users []crossdomain.User
+	err := db.All(&users)
+	if err != nil {
+		return err
+	}
+	index := newUserIndex(users)
+	commitAliases, err := getCommitAliases(db)
+	if err != nil {
+		return err
+	}
+	var rejected []models.UserAccountCandidate
+	err = db.All(&rejected, dal.Where("status = ?", models.CANDIDATE_REJECTED))
+	if err != nil {
+		return err
+	}
+	isRejected := make(map[[2]string]bool, len(rejected))
+	for _, candidate := range rejected {
+		isRejected[[2]string{candidate.AccountId, candidate.UserId}] = true
+	}
+	// pending candidates are scored again with the latest users and accounts
+	err = db.Delete(&models.UserAccountCandidate{}, dal.Where("status = ?", models.CANDIDATE_PENDING))
+	if err != nil {
+		return err
+	}
+
+	cursor, err := db.Cursor(
+		dal.From(&crossdomain.Account{}),
+		dal.Where("id NOT IN (SELECT account_id FROM user_accounts)"),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+	for cursor.Next() {
+		account := &crossdomain.Account{}
+		err = db.Fetch(cursor, account)
+		if err != nil {
+			return err
+		}
+		candidates := make([]*models.UserAccountCandidate, 0)
+		for userId, signals := range index.match(account, commitAliases[strings.ToLower(account.Email)]) {
+			score := scoreSignals(signals)
+			if score < MIN_CANDIDATE_SCORE || isRejected[[2]string{account.Id, userId}] {
+				continue
+			}
+			candidates = append(candidates, &models.UserAccountCandidate{
+				AccountId: account.Id,
+				UserId:    userId,
+				Score:     score,
+				Reasons:   strings.Join(signals, ","),
+				Status:    models.CANDIDATE_PENDING,
+			})
+		}
+		sort.Slice(candidates, func(i, j int) bool {
+			if candidates[i].Score != candidates[j].Score {
+				return candidates[i].Score > candidates[j].Score
+			}
+			return candidates[i].UserId < candidates[j].UserId
+		})
+		// accepted only if no other user is as likely
+		if len(candidates) > 0 && candidates[0].Score >= autoAcceptScore &&
+			(len(candidates) == 1 || candidates[1].Score < autoAcceptScore) {
+			candidates[0].Status = models.CANDIDATE_AUTO_ACCEPTED
+			err = db.CreateOrUpdate(&crossdomain.UserAccount{
+				UserId:    candidates[0].UserId,
+				AccountId: account.Id,
+			})
+			if err != nil {
+				return err
+			}
+			candidates = candidates[:1]
+		}
+		for _, candidate := range candidates {
+			err = db.CreateOrUpdate(candidate)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+type userIndex struct {
+	byName      map[string][]string
+	byLocalPart map[string][]string
+	emails      map[string]string
+}
+
+func newUserIndex(users []crossdomain.User) *userIndex {
+	index := &userIndex{
+		byName:      make(map[string][]string),
+		byLocalPart: make(map[string][]string),
+		emails:      make(map[string]string),
+	}
+	for _, user := range users {
+		if name := normalizeName(user.Name); name != "" {
+			index.byName[name] = append(index.byName[name], user.Id)
+		}
+		if localPart := normalizeLocalPart(user.Email); localPart != "" {
+			index.byLocalPart[localPart] = append(index.byLocalPart[localPart], user.Id)
+			index.emails[user.Id] = strings.ToLower(user.Email)
+		}
+	}
+	return index
+}
+
+// match returns signals of users matching the account, commitNames are names committed with the email of the account
+func (index *userIndex) match(account *crossdomain.Account, commitNames []string) map[string][]string {
+	matches := make(map[string][]string)
+	add := func(userIds []string, signal string) {
+		for _, userId := range userIds {
+			signals := matches[userId]
+			if len(signals) == 0 || signals[len(signals)-1] != signal {
+				matches[userId] = append(signals, signal)
+			}
+		}
+	}
+	// the same name given as full name, user name or alias is a single evidence
+	matchedNames := make(map[string]bool)
+	addName := func(name string, signal string) {
+		name = normalizeName(name)
+		if name == "" || matchedNames[name] {
+			return
+		}
+		matchedNames[name] = true
+		add(index.byName[name], signal)
+	}
+	addName(account.FullName, SIGNAL_NAME)
+	addName(account.UserName, SIGNAL_USER_NAME)
+	if login := getGithubNoreplyLogin(account.Email); login != "" {
+		add(index.byLocalPart[normalizeAlias(login)], SIGNAL_GITHUB_NOREPLY)
+		addName(login, SIGNAL_GITHUB_NOREPLY)
+	} else if localPart := normalizeLocalPart(account.Email); localPart != "" {
+		for _, userId := range index.byLocalPart[localPart] {
+			// the same address in different cases, which is missed by exact matching
+			if index.emails[userId] == strings.ToLower(account.Email) {
+				add([]string{userId}, SIGNAL_EMAIL)
+			} else {
+				add([]string{userId}, SIGNAL_EMAIL_ALIAS)
+			}
+		}
+	}
+	for _, name := range commitNames {
+		addName(name, SIGNAL_COMMIT_ALIAS)
+	}
+	return matches
+}
+
+// scoreSignals combines weights of signals as independent evidences: 1 - (1 - w1) * (1 - w2) ..., rounded to 2 decimals
+func scoreSignals(signals []string) float64 {
+	doubt := 1.0
+	for _, signal := range signals {
+		doubt *= 1 - signalWeights[signal]
+	}
+	return math.Round((1-doubt)*100) / 100
+}
+
+// getCommitAliases collects names committed with each email, by commit authors, committers and co-authors
+func getCommitAliases(db dal.Dal) (map[string][]string, errors.Error) {
+	type alias struct {
+		Name  string
+		Email string
+	}
+	aliases := make(map[string][]string)
+	seen := make(map[alias]bool)
+	addAlias := func(name, email string) {
+		key := alias{Name: strings.TrimSpace(name), Email: strings.ToLower(strings.TrimSpace(email))}
+		if key.Name == "" || key.Email == "" || seen[key] {
+			return
+		}
+		seen[key] = true
+		aliases[key.Email] = append(aliases[key.Email], key.Name)
+	}
+	for _, columns := range []string{"author_name AS name, author_email AS email", "committer_name AS name, committer_email AS email"} {
+		var committed []alias
+		err := db.All(&committed, dal.Select("DISTINCT "+columns), dal.From("commits"))
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range committed {
+			addAlias(a.Name, a.Email)
+		}
+	}
+	var messages []string
+	err := db.Pluck("message", &messages, dal.From("commits"), dal.Where("message LIKE ?", "%Co-authored-by:%"))
+	if err != nil {
+		return nil, err
+	}
+	for _, message := range messages {
+		for _, coAuthor := range parseCoAuthors(message) {
+			addAlias(coAuthor[0], coAuthor[1])
+		}
+	}
+	return aliases, nil
+}
+
+// parseCoAuthors returns name and email of each `Co-authored-by: name <email>` trailer in the commit message
+func parseCoAuthors(message string) [][2]string {
+	var coAuthors [][2]string
+	for _, match := range coAuthoredByPattern.FindAllStringSubmatch(message, -1) {
+		coAuthors = append(coAuthors, [2]string{match[1], match[2]})
+	}
+	return coAuthors
+}
+
+// normalizeName makes "Chen, Alice", "alice.chen" and "Alice  Chen" comparable as "alice chen"
+func normalizeName(name string) string {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+	sort.Strings(words)
+	return strings.Join(words, " ")
+}
+
+// normalizeLocalPart makes aliases of an email comparable, Alice.Chen+ci@example.com is alicechen
+func normalizeLocalPart(email string) string {
+	at := strings.LastIndex(email, "@")
+	if at <= 0 {
+		return ""
+	}
+	return normalizeAlias(email[:at])
+}
+
+func normalizeAlias(alias string) string {
+	alias = strings.ToLower(alias)
+	if plus := strings.Index(alias, "+"); plus > 0 {
+		alias = alias[:plus]
+	}
+	return strings.ReplaceAll(alias, ".", "")
+}
+
+func getGithubNoreplyLogin(email string) string {
+	match := githubNoreplyPattern.FindStringSubmatch(strings.ToLower(email))
+	if match == nil {
+		return ""
+	}
+	return match[1]
+}
@@ -0,0 +1,82 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/models/domainlayer"
+	"github.com/apache/incubator-devlake/models/domainlayer/crossdomain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeName(t *testing.T) {
+	assert.Equal(t, "alice chen", normalizeName("Alice Chen"))
+	assert.Equal(t, "alice chen", normalizeName("Chen, Alice"))
+	assert.Equal(t, "alice chen", normalizeName("alice.chen"))
+	assert.Equal(t, "alice chen", normalizeName(" alice_chen "))
+	assert.Equal(t, "", normalizeName(" - "))
+}
+
+func TestNormalizeLocalPart(t *testing.T) {
+	assert.Equal(t, "alicechen", normalizeLocalPart("Alice.Chen+ci@example.com"))
+	assert.Equal(t, "bob", normalizeLocalPart("bob@example.com"))
+	assert.Equal(t, "", normalizeLocalPart("not an email"))
+	assert.Equal(t, "", normalizeLocalPart("@example.com"))
+}
+
+func TestGetGithubNoreplyLogin(t *testing.T) {
+	assert.Equal(t, "octocat", getGithubNoreplyLogin("1234567+octocat@users.noreply.github.com"))
+	assert.Equal(t, "octocat", getGithubNoreplyLogin("Octocat@users.noreply.github.com"))
+	assert.Equal(t, "", getGithubNoreplyLogin("octocat@github.com"))
+}
+
+func TestParseCoAuthors(t *testing.T) {
+	message := "fix: retry on timeout\n\nCo-authored-by: Alice Chen <alice@example.com>\nco-authored-by: bob <bob@example.com>\n"
+	assert.Equal(t, [][2]string{
+		{"Alice Chen", "alice@example.com"},
+		{"bob", "bob@example.com"},
+	}, parseCoAuthors(message))
+	assert.Nil(t, parseCoAuthors("fix: retry on timeout"))
+}
+
+func TestScoreSignals(t *testing.T) {
+	assert.Equal(t, 0.8, scoreSignals([]string{SIGNAL_NAME}))
+	assert.Equal(t, 0.92, scoreSignals([]string{SIGNAL_NAME, SIGNAL_EMAIL_ALIAS}))
+	assert.Equal(t, 0.0, scoreSignals(nil))
+}
+
+func TestUserIndexMatch(t *testing.T) {
+	index := newUserIndex([]crossdomain.User{
+		{DomainEntity: domainlayer.DomainEntity{Id: "u1"}, Name: "Alice Chen", Email: "alice.chen@example.com"},
+		{DomainEntity: domainlayer.DomainEntity{Id: "u2"}, Name: "Bob Li", Email: "bob@example.com"},
+	})
+	assert.Equal(t, map[string][]string{
+		"u1": {SIGNAL_NAME, SIGNAL_EMAIL_ALIAS},
+	}, index.match(&crossdomain.Account{FullName: "Chen, Alice", Email: "alicechen@corp.example.com"}, nil))
+	assert.Equal(t, map[string][]string{
+		"u2": {SIGNAL_GITHUB_NOREPLY, SIGNAL_COMMIT_ALIAS},
+	}, index.match(&crossdomain.Account{Email: "42+bob@users.noreply.github.com"}, []string{"Bob Li", "bob li"}))
+	assert.Equal(t, map[string][]string{
+		"u2": {SIGNAL_EMAIL},
+	}, index.match(&crossdomain.Account{Email: "Bob@Example.com"}, nil))
+	assert.Equal(t, map[string][]string{
+		"u1": {SIGNAL_NAME},
+	}, index.match(&crossdomain.Account{FullName: "Alice Chen", UserName: "alice.chen"}, []string{"Chen, Alice"}))
+	assert.Empty(t, index.match(&crossdomain.Account{FullName: "Carol"}, nil))
+}
@@ -0,0 +1,116 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/models/domainlayer"
+	"github.com/apache/incubator-devlake/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/plugins/core"
+	"github.com/apache/incubator-devlake/plugins/core/dal"
+	"github.com/apache/incubator-devlake/plugins/helper"
+	"github.com/apache/incubator-devlake/plugins/pagerduty/models"
+	"github.com/apache/incubator-devlake/plugins/pagerduty/tasks"
+	"github.com/apache/incubator-devlake/utils"
+)
+
+func MakeDataSourcePipelinePlanV200(subtaskMetas []core.SubTaskMeta, connectionId uint64, bpScopes []*core.BlueprintScopeV200) (core.PipelinePlan, []core.Scope, errors.Error) {
+	connection := &models.PagerDutyConnection{}
+	err := connectionHelper.FirstById(connection, connectionId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	plan, err := makeDataSourcePipelinePlanV200(subtaskMetas, bpScopes, connection)
+	if err != nil {
+		return nil, nil, err
+	}
+	scopes, err := makeScopesV200(bpScopes, connection)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return plan, scopes, nil
+}
+
+// makeDataSourcePipelinePlanV200 makes a single task for all services, since the incidents of a connection are
+// collected by the tap all at once
+func makeDataSourcePipelinePlanV200(
+	subtaskMetas []core.SubTaskMeta,
+	bpScopes []*core.BlueprintScopeV200,
+	connection *models.PagerDutyConnection,
+) (core.PipelinePlan, errors.Error) {
+	if len(bpScopes) == 0 {
+		return core.PipelinePlan{}, nil
+	}
+	var entities []string
+	for _, bpScope := range bpScopes {
+		for _, entity := range bpScope.Entities {
+			if !utils.StringsContains(entities, entity) {
+				entities = append(entities, entity)
+			}
+		}
+	}
+	options := map[string]interface{}{
+		"connectionId": connection.ID,
+	}
+	// make sure task options is valid
+	_, err := tasks.DecodeAndValidateTaskOptions(options)
+	if err != nil {
+		return nil, err
+	}
+	subtasks, err := helper.MakePipelinePlanSubtasks(subtaskMetas, entities)
+	if err != nil {
+		return nil, err
+	}
+	return core.PipelinePlan{
+		{
+			{
+				Plugin:   "pagerduty",
+				Subtasks: subtasks,
+				Options:  options,
+			},
+		},
+	}, nil
+}
+
+func makeScopesV200(bpScopes []*core.BlueprintScopeV200, connection *models.PagerDutyConnection) ([]core.Scope, errors.Error) {
+	scopes := make([]core.Scope, 0)
+	serviceIdGen := didgen.NewDomainIdGenerator(&models.Service{})
+	for _, bpScope := range bpScopes {
+		service := &models.Service{}
+		err := basicRes.GetDal().First(service, dal.Where(`connection_id = ? AND id = ?`, connection.ID, bpScope.Id))
+		if err != nil {
+			return nil, errors.Default.Wrap(err, fmt.Sprintf("fail to find service %s", bpScope.Id))
+		}
+		// services are converted into boards, see ConvertServices
+		if utils.StringsContains(bpScope.Entities, core.DOMAIN_TYPE_TICKET) {
+			scopes = append(scopes, &ticket.Board{
+				DomainEntity: domainlayer.DomainEntity{
+					Id: serviceIdGen.Generate(connection.ID, service.Id),
+				},
+				Name: service.Name,
+				Url:  service.Url,
+			})
+		}
+	}
+	return scopes, nil
+}
@@ -0,0 +1,125 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/mocks"
+	"github.com/apache/incubator-devlake/models/common"
+	"github.com/apache/incubator-devlake/models/domainlayer"
+	"github.com/apache/incubator-devlake/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/plugins/core"
+	"github.com/apache/incubator-devlake/plugins/helper"
+	"github.com/apache/incubator-devlake/plugins/pagerduty/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestMakeDataSourcePipelinePlanV200(t *testing.T) {
+	mockMeta := mocks.NewPluginMeta(t)
+	mockMeta.On("RootPkgPath").Return("github.com/apache/incubator-devlake/plugins/pagerduty")
+	err := core.RegisterPlugin("pagerduty", mockMeta)
+	assert.Nil(t, err)
+	bpScopes := []*core.BlueprintScopeV200{
+		{
+			Entities: []string{core.DOMAIN_TYPE_TICKET},
+			Id:       "PIKL83L",
+		},
+		{
+			Entities: []string{core.DOMAIN_TYPE_TICKET},
+			Id:       "PXXXXXX",
+		},
+	}
+	connection := &models.PagerDutyConnection{
+		BaseConnection: helper.BaseConnection{
+			Name: "pagerduty",
+			Model: common.Model{
+				ID: 1,
+			},
+		},
+	}
+
+	plan, err := makeDataSourcePipelinePlanV200(nil, bpScopes, connection)
+	assert.Nil(t, err)
+	basicRes = NewMockBasicRes()
+	scopes, err := makeScopesV200(bpScopes, connection)
+	assert.Nil(t, err)
+
+	// incidents of all services are collected by a single task
+	expectPlan := core.PipelinePlan{
+		core.PipelineStage{
+			{
+				Plugin:   "pagerduty",
+				Subtasks: []string{},
+				Options: map[string]interface{}{
+					"connectionId": uint64(1),
+				},
+			},
+		},
+	}
+	assert.Equal(t, expectPlan, plan)
+
+	expectScopes := []core.Scope{
+		&ticket.Board{
+			DomainEntity: domainlayer.DomainEntity{
+				Id: "pagerduty:Service:1:PIKL83L",
+			},
+			Name: "DevService",
+			Url:  "https://keon-test.pagerduty.com/service-directory/PIKL83L",
+		},
+		&ticket.Board{
+			DomainEntity: domainlayer.DomainEntity{
+				Id: "pagerduty:Service:1:PXXXXXX",
+			},
+			Name: "OpsService",
+			Url:  "https://keon-test.pagerduty.com/service-directory/PXXXXXX",
+		},
+	}
+	assert.Equal(t, expectScopes, scopes)
+}
+
+// NewMockBasicRes returns the services of the test scopes in order
+func NewMockBasicRes() *mocks.BasicRes {
+	services := []*models.Service{
+		{
+			ConnectionId: 1,
+			Id:           "PIKL83L",
+			Name:         "DevService",
+			Url:          "https://keon-test.pagerduty.com/service-directory/PIKL83L",
+		},
+		{
+			ConnectionId: 1,
+			Id:           "PXXXXXX",
+			Name:         "OpsService",
+			Url:          "https://keon-test.pagerduty.com/service-directory/PXXXXXX",
+		},
+	}
+	mockRes := new(mocks.BasicRes)
+	mockDal := new(mocks.Dal)
+	for _, service := range services {
+		service := service
+		mockDal.On("First", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			dst := args.Get(0).(*models.Service)
+			*dst = *service
+		}).Return(nil).Once()
+	}
+	mockRes.On("GetDal").Return(mockDal)
+	mockRes.On("GetConfig", mock.Anything).Return("")
+	return mockRes
+}
@@ -0,0 +1,130 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/plugins/core"
+	"github.com/apache/incubator-devlake/plugins/core/dal"
+	"github.com/apache/incubator-devlake/plugins/helper"
+	"github.com/apache/incubator-devlake/plugins/pagerduty/models"
+	"github.com/mitchellh/mapstructure"
+	"gorm.io/gorm"
+)
+
+type req struct {
+	Data []*models.Service `json:"data"`
+}
+
+// PutScope create or update pagerduty service
+// @Summary create or update pagerduty service
+// @Description Create or update pagerduty service
+// @Tags plugins/pagerduty
+// @Accept application/json
+// @Param connectionId path int true "connection ID"
+// @Param scope body req true "json"
+// @Success 200  {object} []models.Service
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/pagerduty/connections/{connectionId}/scopes [PUT]
+func PutScope(input *core.ApiResourceInput) (*core.ApiResourceOutput, errors.Error) {
+	connectionId, _ := strconv.ParseUint(input.Params["connectionId"], 10, 64)
+	if connectionId == 0 {
+		return nil, errors.BadInput.New("invalid connectionId")
+	}
+	var services req
+	err := errors.Convert(mapstructure.Decode(input.Body, &services))
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "decoding PagerDuty service error")
+	}
+	keeper := make(map[string]struct{})
+	for _, service := range services.Data {
+		if _, ok := keeper[service.Id]; ok {
+			return nil, errors.BadInput.New("duplicated item")
+		} else {
+			keeper[service.Id] = struct{}{}
+		}
+		if service.Id == "" {
+			return nil, errors.BadInput.New("id is required for PagerDuty services")
+		}
+		service.ConnectionId = connectionId
+	}
+	err = basicRes.GetDal().CreateOrUpdate(services.Data)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error on saving Service")
+	}
+	return &core.ApiResourceOutput{Body: services.Data, Status: http.StatusOK}, nil
+}
+
+// GetScopeList get PagerDuty services
+// @Summary get PagerDuty services
+// @Description get PagerDuty services
+// @Tags plugins/pagerduty
+// @Param connectionId path int true "connection ID"
+// @Param pageSize query int false "page size, default 50"
+// @Param page query int false "page size, default 1"
+// @Success 200  {object} []models.Service
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/pagerduty/connections/{connectionId}/scopes/ [GET]
+func GetScopeList(input *core.ApiResourceInput) (*core.ApiResourceOutput, errors.Error) {
+	var services []models.Service
+	connectionId, _ := strconv.ParseUint(input.Params["connectionId"], 10, 64)
+	if connectionId == 0 {
+		return nil, errors.BadInput.New("invalid path params")
+	}
+	limit, offset := helper.GetLimitOffset(input.Query, "pageSize", "page")
+	err := basicRes.GetDal().All(&services, dal.Where("connection_id = ?", connectionId), dal.Limit(limit), dal.Offset(offset))
+	if err != nil {
+		return nil, err
+	}
+	return &core.ApiResourceOutput{Body: services, Status: http.StatusOK}, nil
+}
+
+// GetScope get one PagerDuty service
+// @Summary get one PagerDuty service
+// @Description get one PagerDuty service
+// @Tags plugins/pagerduty
+// @Param connectionId path int true "connection ID"
+// @Param serviceId path string true "service ID"
+// @Success 200  {object} models.Service
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/pagerduty/connections/{connectionId}/scopes/{serviceId} [GET]
+func GetScope(input *core.ApiResourceInput) (*core.ApiResourceOutput, errors.Error) {
+	var service models.Service
+	connectionId, _ := strconv.ParseUint(input.Params["connectionId"], 10, 64)
+	if connectionId == 0 {
+		return nil, errors.BadInput.New("invalid connectionId")
+	}
+	serviceId := input.Params["serviceId"]
+	if serviceId == "" {
+		return nil, errors.BadInput.New("invalid serviceId")
+	}
+	err := basicRes.GetDal().First(&service, dal.Where("connection_id = ? AND id = ?", connectionId, serviceId))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.NotFound.New("record not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &core.ApiResourceOutput{Body: service, Status: http.StatusOK}, nil
+}
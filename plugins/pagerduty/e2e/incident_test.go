@@ -20,6 +20,7 @@ package e2e
 import (
 	"github.com/apache/incubator-devlake/helpers/e2ehelper"
 	"github.com/apache/incubator-devlake/models/common"
+	"github.com/apache/incubator-devlake/models/domainlayer/crossdomain"
 	"github.com/apache/incubator-devlake/models/domainlayer/ticket"
 	"github.com/apache/incubator-devlake/plugins/pagerduty/impl"
 	"github.com/apache/incubator-devlake/plugins/pagerduty/models"
@@ -34,6 +35,11 @@ func TestIncidentDataFlow(t *testing.T) {
 	taskData := &tasks.PagerDutyTaskData{
 		Options: &tasks.PagerDutyOptions{
 			ConnectionId: 1,
+			Transformations: tasks.TransformationRules{
+				ServiceRepos: map[string][]string{
+					"PIKL83L": {"github:GithubRepo:1:384111310"},
+				},
+			},
 		},
 	}
 
@@ -45,6 +51,7 @@ func TestIncidentDataFlow(t *testing.T) {
 	dataflowTester.FlushTabler(&models.User{})
 	dataflowTester.FlushTabler(&models.Service{})
 	dataflowTester.FlushTabler(&models.Assignment{})
+	dataflowTester.FlushTabler(&models.LogEntry{})
 	dataflowTester.Subtask(tasks.ExtractIncidentsMeta, taskData)
 	dataflowTester.VerifyTableWithOptions(
 		models.Incident{},
@@ -74,7 +81,32 @@ func TestIncidentDataFlow(t *testing.T) {
 			IgnoreTypes: []any{common.Model{}},
 		},
 	)
+	dataflowTester.VerifyTableWithOptions(
+		models.LogEntry{},
+		e2ehelper.TableOptions{
+			CSVRelPath:   "./snapshot_tables/_tool_pagerduty_log_entries.csv",
+			IgnoreFields: []string{"created_at", "updated_at"},
+		},
+	)
+	dataflowTester.FlushTabler(&ticket.Board{})
+	dataflowTester.FlushTabler(&crossdomain.BoardRepo{})
+	dataflowTester.Subtask(tasks.ConvertServicesMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(
+		ticket.Board{},
+		e2ehelper.TableOptions{
+			CSVRelPath:  "./snapshot_tables/boards.csv",
+			IgnoreTypes: []any{common.NoPKModel{}},
+		},
+	)
+	dataflowTester.VerifyTableWithOptions(
+		crossdomain.BoardRepo{},
+		e2ehelper.TableOptions{
+			CSVRelPath:  "./snapshot_tables/board_repos.csv",
+			IgnoreTypes: []any{common.NoPKModel{}},
+		},
+	)
 	dataflowTester.FlushTabler(&ticket.Issue{})
+	dataflowTester.FlushTabler(&ticket.BoardIssue{})
 	dataflowTester.Subtask(tasks.ConvertIncidentsMeta, taskData)
 	dataflowTester.VerifyTableWithOptions(
 		ticket.Issue{},
@@ -83,4 +115,20 @@ func TestIncidentDataFlow(t *testing.T) {
 			IgnoreTypes: []any{common.NoPKModel{}},
 		},
 	)
+	dataflowTester.VerifyTableWithOptions(
+		ticket.BoardIssue{},
+		e2ehelper.TableOptions{
+			CSVRelPath:  "./snapshot_tables/board_issues.csv",
+			IgnoreTypes: []any{common.NoPKModel{}},
+		},
+	)
+	dataflowTester.FlushTabler(&ticket.IssueChangelogs{})
+	dataflowTester.Subtask(tasks.ConvertLogEntriesMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(
+		ticket.IssueChangelogs{},
+		e2ehelper.TableOptions{
+			CSVRelPath:  "./snapshot_tables/issue_changelogs.csv",
+			IgnoreTypes: []any{common.NoPKModel{}},
+		},
+	)
 }
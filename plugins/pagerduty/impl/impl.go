@@ -38,8 +38,12 @@ var _ core.PluginInit = (*PagerDuty)(nil)
 var _ core.PluginTask = (*PagerDuty)(nil)
 var _ core.PluginApi = (*PagerDuty)(nil)
 var _ core.PluginBlueprintV100 = (*PagerDuty)(nil)
+var _ core.DataSourcePluginBlueprintV200 = (*PagerDuty)(nil)
 var _ core.CloseablePluginTask = (*PagerDuty)(nil)
 
+// defaultCollectionMonths is how far back incidents are collected when no start_date is given
+const defaultCollectionMonths = 6
+
 type PagerDuty struct{}
 
 func (plugin PagerDuty) Description() string {
@@ -55,7 +59,9 @@ func (plugin PagerDuty) SubTaskMetas() []core.SubTaskMeta {
 	return []core.SubTaskMeta{
 		tasks.CollectIncidentsMeta,
 		tasks.ExtractIncidentsMeta,
+		tasks.ConvertServicesMeta,
 		tasks.ConvertIncidentsMeta,
+		tasks.ConvertLogEntriesMeta,
 	}
 }
 
@@ -73,9 +79,13 @@ func (plugin PagerDuty) PrepareTaskData(taskCtx core.TaskContext, options map[st
 	if err != nil {
 		return nil, errors.Default.Wrap(err, "unable to get Pagerduty connection by the given connection ID")
 	}
-	startDate, err := parseTime("start_date", options)
-	if err != nil {
-		return nil, err
+	// v200 plans carry no start_date, the tap resumes from its own state after the first collection anyway
+	startDate := time.Now().AddDate(0, -defaultCollectionMonths, 0).UTC().Truncate(time.Second)
+	if _, ok := options["start_date"]; ok {
+		startDate, err = parseTime("start_date", options)
+		if err != nil {
+			return nil, err
+		}
 	}
 	config := &models.PagerDutyConfig{
 		Token:     connection.Token,
@@ -119,6 +129,13 @@ func (plugin PagerDuty) ApiResources() map[string]map[string]core.ApiResourceHan
 			"PATCH":  api.PatchConnection,
 			"DELETE": api.DeleteConnection,
 		},
+		"connections/:connectionId/scopes/:serviceId": {
+			"GET": api.GetScope,
+		},
+		"connections/:connectionId/scopes": {
+			"GET": api.GetScopeList,
+			"PUT": api.PutScope,
+		},
 	}
 }
 
@@ -126,6 +143,10 @@ func (plugin PagerDuty) MakePipelinePlan(connectionId uint64, scope []*core.Blue
 	return api.MakePipelinePlan(plugin.SubTaskMetas(), connectionId, scope)
 }
 
+func (plugin PagerDuty) MakeDataSourcePipelinePlanV200(connectionId uint64, scopes []*core.BlueprintScopeV200) (pp core.PipelinePlan, sc []core.Scope, err errors.Error) {
+	return api.MakeDataSourcePipelinePlanV200(plugin.SubTaskMetas(), connectionId, scopes)
+}
+
 func (plugin PagerDuty) Close(taskCtx core.TaskContext) errors.Error {
 	_, ok := taskCtx.GetData().(*tasks.PagerDutyTaskData)
 	if !ok {
@@ -0,0 +1,52 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"github.com/apache/incubator-devlake/models/common"
+	"time"
+)
+
+const (
+	LogEntryTypeTrigger       LogEntryType = "trigger_log_entry"
+	LogEntryTypeAcknowledge   LogEntryType = "acknowledge_log_entry"
+	LogEntryTypeUnacknowledge LogEntryType = "unacknowledge_log_entry"
+	LogEntryTypeEscalate      LogEntryType = "escalate_log_entry"
+	LogEntryTypeResolve       LogEntryType = "resolve_log_entry"
+)
+
+type (
+	LogEntryType string
+
+	LogEntry struct {
+		common.NoPKModel
+		ConnectionId   uint64 `gorm:"primaryKey"`
+		Id             string `gorm:"primaryKey"`
+		IncidentNumber int    `gorm:"index"`
+		Type           LogEntryType
+		Summary        string
+		AgentId        string
+		AgentType      string //user_reference, service_reference, etc.
+		AgentName      string
+		CreatedDate    time.Time
+	}
+)
+
+func (LogEntry) TableName() string {
+	return "_tool_pagerduty_log_entries"
+}
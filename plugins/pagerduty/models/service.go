@@ -20,11 +20,11 @@ package models
 import "github.com/apache/incubator-devlake/models/common"
 
 type Service struct {
-	common.NoPKModel
-	ConnectionId uint64 `gorm:"primaryKey"`
-	Url          string
-	Id           string `gorm:"primaryKey"`
-	Name         string
+	common.NoPKModel `json:"-" mapstructure:"-"`
+	ConnectionId     uint64 `gorm:"primaryKey" mapstructure:"connectionId,omitempty" json:"connectionId"`
+	Url              string `mapstructure:"url,omitempty" json:"url"`
+	Id               string `gorm:"primaryKey" mapstructure:"id" json:"id"`
+	Name             string `mapstructure:"name" json:"name"`
 }
 
 func (Service) TableName() string {
@@ -36,7 +36,7 @@ var ConvertIncidentsMeta = core.SubTaskMeta{
 	Name:             "convertIncidents",
 	EntryPoint:       ConvertIncidents,
 	EnabledByDefault: true,
-	Description:      "Convert incidents into domain layer table issues",
+	Description:      "Convert incidents into domain layer table issues and board_issues",
 	DomainTypes:      []string{core.DOMAIN_TYPE_TICKET},
 }
 
@@ -66,6 +66,7 @@ func ConvertIncidents(taskCtx core.SubTaskContext) errors.Error {
 	defer cursor.Close()
 	seenIncidents := map[int]*IncidentWithUser{}
 	idGen := didgen.NewDomainIdGenerator(&models.Incident{})
+	serviceIdGen := didgen.NewDomainIdGenerator(&models.Service{})
 	converter, err := helper.NewDataConverter(helper.DataConverterArgs{
 		RawDataSubTaskArgs: helper.RawDataSubTaskArgs{
 			Ctx: taskCtx,
@@ -87,7 +88,7 @@ func ConvertIncidents(taskCtx core.SubTaskContext) errors.Error {
 					return nil, nil
 				}
 			}
-			status := getStatus(incident)
+			status := getStatus(incident.Status)
 			leadTime, resolutionDate := getTimes(incident)
 			domainIssue := &ticket.Issue{
 				DomainEntity: domainlayer.DomainEntity{
@@ -108,9 +109,16 @@ func ConvertIncidents(taskCtx core.SubTaskContext) errors.Error {
 				AssigneeName:    user.Name,
 			}
 			seenIncidents[incident.Number] = combined
-			return []interface{}{
+			results := []interface{}{
 				domainIssue,
-			}, nil
+			}
+			if incident.ServiceId != "" {
+				results = append(results, &ticket.BoardIssue{
+					BoardId: serviceIdGen.Generate(data.Options.ConnectionId, incident.ServiceId),
+					IssueId: domainIssue.Id,
+				})
+			}
+			return results, nil
 		},
 	})
 	if err != nil {
@@ -119,14 +127,14 @@ func ConvertIncidents(taskCtx core.SubTaskContext) errors.Error {
 	return converter.Execute()
 }
 
-func getStatus(incident *models.Incident) string {
-	if incident.Status == models.IncidentStatusTriggered {
+func getStatus(status models.IncidentStatus) string {
+	if status == models.IncidentStatusTriggered {
 		return ticket.TODO
 	}
-	if incident.Status == models.IncidentStatusAcknowledged {
+	if status == models.IncidentStatusAcknowledged {
 		return ticket.IN_PROGRESS
 	}
-	if incident.Status == models.IncidentStatusResolved {
+	if status == models.IncidentStatusResolved {
 		return ticket.DONE
 	}
 	panic("unknown incident status encountered")
@@ -82,6 +82,22 @@ func ExtractIncidents(taskCtx core.SubTaskContext) errors.Error {
 					Name:         *userRaw.Summary,
 				})
 			}
+			for _, logEntryRaw := range incidentRaw.LogEntries {
+				logEntry := &models.LogEntry{
+					ConnectionId:   data.Options.ConnectionId,
+					Id:             *logEntryRaw.Id,
+					IncidentNumber: *incidentRaw.IncidentNumber,
+					Type:           models.LogEntryType(resolve(logEntryRaw.Type)),
+					Summary:        resolve(logEntryRaw.Summary),
+					CreatedDate:    *logEntryRaw.CreatedAt,
+				}
+				if logEntryRaw.Agent != nil {
+					logEntry.AgentId = resolve(logEntryRaw.Agent.Id)
+					logEntry.AgentType = resolve(logEntryRaw.Agent.Type)
+					logEntry.AgentName = resolve(logEntryRaw.Agent.Summary)
+				}
+				results = append(results, logEntry)
+			}
 			return results, nil
 		},
 	})
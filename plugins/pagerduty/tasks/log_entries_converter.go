@@ -0,0 +1,120 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/models/domainlayer"
+	"github.com/apache/incubator-devlake/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/plugins/core"
+	"github.com/apache/incubator-devlake/plugins/core/dal"
+	"github.com/apache/incubator-devlake/plugins/helper"
+	"github.com/apache/incubator-devlake/plugins/pagerduty/models"
+	"reflect"
+)
+
+var ConvertLogEntriesMeta = core.SubTaskMeta{
+	Name:             "convertLogEntries",
+	EntryPoint:       ConvertLogEntries,
+	EnabledByDefault: true,
+	Description:      "Convert incident log entries into domain layer table issue_changelogs",
+	DomainTypes:      []string{core.DOMAIN_TYPE_TICKET},
+}
+
+// the status an incident ends up in after each type of log entry, other types don't change the status
+var logEntryStatuses = map[models.LogEntryType]models.IncidentStatus{
+	models.LogEntryTypeTrigger:       models.IncidentStatusTriggered,
+	models.LogEntryTypeAcknowledge:   models.IncidentStatusAcknowledged,
+	models.LogEntryTypeUnacknowledge: models.IncidentStatusTriggered,
+	models.LogEntryTypeEscalate:      models.IncidentStatusTriggered,
+	models.LogEntryTypeResolve:       models.IncidentStatusResolved,
+}
+
+func ConvertLogEntries(taskCtx core.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*PagerDutyTaskData)
+	logEntryTypes := make([]models.LogEntryType, 0, len(logEntryStatuses))
+	for logEntryType := range logEntryStatuses {
+		logEntryTypes = append(logEntryTypes, logEntryType)
+	}
+	cursor, err := db.Cursor(
+		dal.From(&models.LogEntry{}),
+		dal.Where("connection_id = ? AND type IN ?", data.Options.ConnectionId, logEntryTypes),
+		dal.Orderby("incident_number, created_date, id"),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+	// log entries are ordered by time, so the status before each entry is the one the previous entry left
+	lastStatuses := map[int]models.IncidentStatus{}
+	idGen := didgen.NewDomainIdGenerator(&models.LogEntry{})
+	incidentIdGen := didgen.NewDomainIdGenerator(&models.Incident{})
+	userIdGen := didgen.NewDomainIdGenerator(&models.User{})
+	converter, err := helper.NewDataConverter(helper.DataConverterArgs{
+		RawDataSubTaskArgs: helper.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: models.PagerDutyParams{
+				ConnectionId: data.Options.ConnectionId,
+				Stream:       models.IncidentStream,
+			},
+			Table: RAW_INCIDENTS_TABLE,
+		},
+		InputRowType: reflect.TypeOf(models.LogEntry{}),
+		Input:        cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			logEntry := inputRow.(*models.LogEntry)
+			toStatus := logEntryStatuses[logEntry.Type]
+			fromStatus, ok := lastStatuses[logEntry.IncidentNumber]
+			lastStatuses[logEntry.IncidentNumber] = toStatus
+			if logEntry.Type == models.LogEntryTypeTrigger {
+				// the incident is created by this entry, which is already recorded as the issue created_date
+				return nil, nil
+			}
+			if !ok {
+				fromStatus = models.IncidentStatusTriggered
+			}
+			changelog := &ticket.IssueChangelogs{
+				DomainEntity: domainlayer.DomainEntity{
+					Id: idGen.Generate(data.Options.ConnectionId, logEntry.Id),
+				},
+				IssueId:           incidentIdGen.Generate(data.Options.ConnectionId, logEntry.IncidentNumber),
+				AuthorName:        logEntry.AgentName,
+				FieldId:           string(logEntry.Type),
+				FieldName:         "status",
+				OriginalFromValue: string(fromStatus),
+				OriginalToValue:   string(toStatus),
+				FromValue:         getStatus(fromStatus),
+				ToValue:           getStatus(toStatus),
+				CreatedDate:       logEntry.CreatedDate,
+			}
+			// the agent may also be a service or an integration, only users are authors
+			if logEntry.AgentType == "user_reference" {
+				changelog.AuthorId = userIdGen.Generate(data.Options.ConnectionId, logEntry.AgentId)
+			}
+			return []interface{}{
+				changelog,
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return converter.Execute()
+}
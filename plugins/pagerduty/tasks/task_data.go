@@ -25,9 +25,9 @@ import (
 )
 
 type PagerDutyOptions struct {
-	ConnectionId    uint64   `json:"connectionId"`
-	Tasks           []string `json:"tasks,omitempty"`
-	Transformations TransformationRules
+	ConnectionId    uint64              `json:"connectionId"`
+	Tasks           []string            `json:"tasks,omitempty"`
+	Transformations TransformationRules `mapstructure:"transformationRules" json:"transformationRules"`
 }
 
 type PagerDutyTaskData struct {
@@ -37,7 +37,9 @@ type PagerDutyTaskData struct {
 }
 
 type TransformationRules struct {
-	//Placeholder struct for later if needed
+	// ServiceRepos maps a PagerDuty service id to the domain ids of the repos it is deployed from,
+	// e.g. {"PIKL83L": ["github:GithubRepo:1:384111310"]}
+	ServiceRepos map[string][]string `mapstructure:"serviceRepos" json:"serviceRepos"`
 }
 
 func DecodeAndValidateTaskOptions(options map[string]interface{}) (*PagerDutyOptions, errors.Error) {
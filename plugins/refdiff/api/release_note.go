@@ -0,0 +1,124 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/plugins/core"
+	"github.com/apache/incubator-devlake/plugins/core/dal"
+	"github.com/apache/incubator-devlake/plugins/helper"
+	"github.com/apache/incubator-devlake/plugins/refdiff/tasks"
+)
+
+// GetReleaseNotes returns the release notes generated for a repo
+// @Summary      Get release notes
+// @Description  get the release notes generated by refdiff for tag pairs or ref pairs of a repo
+// @Tags 		 plugins/refdiff
+// @Produce      json
+// @Param        repoId   query     string  true   "repo id"
+// @Param        page     query     int     false  "page number, default 1"
+// @Param        pageSize query     int     false  "page size, default 50"
+// @Success      200
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router       /plugins/refdiff/release-notes [get]
+func GetReleaseNotes(input *core.ApiResourceInput) (*core.ApiResourceOutput, errors.Error) {
+	repoId := input.Query.Get("repoId")
+	if repoId == "" {
+		return nil, errors.BadInput.New("repoId is required")
+	}
+	db := basicRes.GetDal()
+	clauses := []dal.Clause{
+		dal.From(&code.RefsReleaseNote{}),
+		dal.Where("repo_id = ?", repoId),
+	}
+	count, err := db.Count(clauses...)
+	if err != nil {
+		return nil, err
+	}
+	limit, offset := helper.GetLimitOffset(input.Query, "pageSize", "page")
+	releaseNotes := make([]code.RefsReleaseNote, 0)
+	err = db.All(
+		&releaseNotes,
+		append(clauses, dal.Orderby("created_at DESC, new_ref_id DESC"), dal.Limit(limit), dal.Offset(offset))...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &core.ApiResourceOutput{
+		Body: map[string]interface{}{
+			"count":        count,
+			"releaseNotes": releaseNotes,
+		},
+		Status: http.StatusOK,
+	}, nil
+}
+
+// GetReleaseNote builds the release note between two refs
+// @Summary      Get a release note
+// @Description  build the release note between two refs from commits_diffs, refs_issues_diffs and refs_pr_cherrypicks, which must have been calculated by refdiff
+// @Tags 		 plugins/refdiff
+// @Produce      json
+// @Param        repoId query     string  true   "repo id"
+// @Param        newRef query     string  true   "new ref name, e.g. v1.1.0"
+// @Param        oldRef query     string  true   "old ref name, e.g. v1.0.0"
+// @Param        format query     string  false  "json by default, or markdown"
+// @Success      200  {object} tasks.ReleaseNote
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 404  {object} shared.ApiBody "Not Found"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router       /plugins/refdiff/release-notes/detail [get]
+func GetReleaseNote(input *core.ApiResourceInput) (*core.ApiResourceOutput, errors.Error) {
+	repoId := input.Query.Get("repoId")
+	newRef := input.Query.Get("newRef")
+	oldRef := input.Query.Get("oldRef")
+	if repoId == "" || newRef == "" || oldRef == "" {
+		return nil, errors.BadInput.New("repoId, newRef and oldRef are required")
+	}
+	db := basicRes.GetDal()
+	refs := make([]*code.Ref, 0, 2)
+	for _, refName := range []string{newRef, oldRef} {
+		var found []code.Ref
+		err := db.All(&found, dal.Where("id = ?", fmt.Sprintf("%s:%s", repoId, refName)), dal.Limit(1))
+		if err != nil {
+			return nil, err
+		}
+		if len(found) == 0 {
+			return nil, errors.NotFound.New(fmt.Sprintf("ref %s not found in repo %s", refName, repoId))
+		}
+		refs = append(refs, &found[0])
+	}
+	note, err := tasks.BuildReleaseNote(
+		db, repoId, tasks.RefCommitPair{refs[0].CommitSha, refs[1].CommitSha, newRef, oldRef},
+	)
+	if err != nil {
+		return nil, err
+	}
+	if input.Query.Get("format") == "markdown" {
+		return &core.ApiResourceOutput{
+			Body:        []byte(note.Markdown()),
+			ContentType: "text/markdown; charset=utf-8",
+			Status:      http.StatusOK,
+		}, nil
+	}
+	return &core.ApiResourceOutput{Body: note, Status: http.StatusOK}, nil
+}
@@ -0,0 +1,62 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/plugins/refdiff/impl"
+	"github.com/apache/incubator-devlake/plugins/refdiff/tasks"
+)
+
+func TestIncrementalCommitDiffDataFlow(t *testing.T) {
+	var plugin impl.RefDiff
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "refdiff", plugin)
+
+	taskData := &tasks.RefdiffTaskData{
+		Options: &tasks.RefdiffOptions{
+			RepoId: "github:GithubRepo:1:2",
+			AllPairs: tasks.RefCommitPairs{
+				// v1.1.0 moved from commit_sha_r3 to commit_sha_r5, the finished pair is reused
+				{"commit_sha_r5", "commit_sha_r1", "v1.1.0", "v1.0.0"},
+				// points to the finished pair, nothing to calculate
+				{"commit_sha_r3", "commit_sha_r1", "v1.0.2", "v1.0.0"},
+			},
+		},
+	}
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/incremental_repo_commits.csv", &code.RepoCommit{})
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/incremental_commit_parents.csv", &code.CommitParent{})
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/incremental_commits_diffs.csv", &code.CommitsDiff{})
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/incremental_finished_commits_diffs.csv", &code.FinishedCommitsDiff{})
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/incremental_ref_commits.csv", &code.RefCommit{})
+
+	dataflowTester.Subtask(tasks.CalculateCommitsDiffMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&code.CommitsDiff{}, e2ehelper.TableOptions{
+		CSVRelPath: "./snapshot_tables/incremental_commits_diffs.csv",
+	})
+	dataflowTester.VerifyTableWithOptions(&code.FinishedCommitsDiff{}, e2ehelper.TableOptions{
+		CSVRelPath: "./snapshot_tables/incremental_finished_commits_diffs.csv",
+	})
+	dataflowTester.VerifyTableWithOptions(&code.RefCommit{}, e2ehelper.TableOptions{
+		CSVRelPath: "./snapshot_tables/incremental_ref_commits.csv",
+	})
+}
@@ -0,0 +1,75 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/plugins/refdiff/impl"
+	"github.com/apache/incubator-devlake/plugins/refdiff/tasks"
+)
+
+func TestReleaseNoteDataFlow(t *testing.T) {
+	var plugin impl.RefDiff
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "refdiff", plugin)
+
+	taskData := &tasks.RefdiffTaskData{
+		Options: &tasks.RefdiffOptions{
+			RepoId: "github:GithubRepo:1:484251804",
+			AllPairs: tasks.RefCommitPairs{
+				{"commit_sha_v110", "commit_sha_v100", "v1.1.0", "v1.0.0"},
+			},
+		},
+	}
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/release_note_commits_diffs.csv", &code.CommitsDiff{})
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/release_note_commits.csv", &code.Commit{})
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/release_note_pull_requests.csv", &code.PullRequest{})
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/release_note_pull_request_commits.csv", &code.PullRequestCommit{})
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/release_note_issues.csv", &ticket.Issue{})
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/release_note_refs_issues_diffs.csv", &crossdomain.RefsIssuesDiffs{})
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/release_note_refs_pr_cherrypicks.csv", &code.RefsPrCherrypick{})
+
+	// verify generation
+	dataflowTester.FlushTabler(&code.RefsReleaseNote{})
+	dataflowTester.Subtask(tasks.GenerateReleaseNotesMeta, taskData)
+	dataflowTester.VerifyTable(
+		code.RefsReleaseNote{},
+		"./snapshot_tables/refs_release_notes.csv",
+		[]string{
+			"new_ref_id",
+			"old_ref_id",
+			"repo_id",
+			"new_ref_name",
+			"old_ref_name",
+			"new_ref_commit_sha",
+			"old_ref_commit_sha",
+			"commit_count",
+			"pr_count",
+			"issue_count",
+			"contributor_count",
+			"cherrypick_count",
+			"content",
+		},
+	)
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/apache/incubator-devlake/errors"
 	"github.com/apache/incubator-devlake/plugins/core"
 	"github.com/apache/incubator-devlake/plugins/helper"
+	"github.com/apache/incubator-devlake/plugins/refdiff/api"
 	"github.com/apache/incubator-devlake/plugins/refdiff/tasks"
 	"github.com/spf13/viper"
 	"gorm.io/gorm"
@@ -64,6 +65,7 @@ func (plugin RefDiff) Settings() interface{} {
 }
 
 func (plugin RefDiff) Init(config *viper.Viper, logger core.Logger, db *gorm.DB) errors.Error {
+	api.Init(config, logger, db)
 	return nil
 }
 
@@ -72,6 +74,7 @@ func (plugin RefDiff) SubTaskMetas() []core.SubTaskMeta {
 		tasks.CalculateCommitsDiffMeta,
 		tasks.CalculateIssuesDiffMeta,
 		tasks.CalculatePrCherryPickMeta,
+		tasks.GenerateReleaseNotesMeta,
 		tasks.CalculateProjectDeploymentCommitsDiffMeta,
 	}
 }
@@ -108,5 +111,12 @@ func (plugin RefDiff) RootPkgPath() string {
 }
 
 func (plugin RefDiff) ApiResources() map[string]map[string]core.ApiResourceHandler {
-	return nil
+	return map[string]map[string]core.ApiResourceHandler{
+		"release-notes": {
+			"GET": api.GetReleaseNotes,
+		},
+		"release-notes/detail": {
+			"GET": api.GetReleaseNote,
+		},
+	}
 }
@@ -19,14 +19,11 @@ package tasks
 
 import (
 	"fmt"
-	"reflect"
 
 	"github.com/apache/incubator-devlake/errors"
 
 	"github.com/apache/incubator-devlake/models/domainlayer/code"
 	"github.com/apache/incubator-devlake/plugins/core"
-	"github.com/apache/incubator-devlake/plugins/core/dal"
-	"github.com/apache/incubator-devlake/plugins/refdiff/utils"
 )
 
 func CalculateCommitsDiff(taskCtx core.SubTaskContext) errors.Error {
@@ -40,30 +37,26 @@ func CalculateCommitsDiff(taskCtx core.SubTaskContext) errors.Error {
 		return nil
 	}
 
-	// get all data from finish_commits_diffs
-	commitPairsSrc := data.Options.AllPairs
-	var commitPairs RefCommitPairs
-	refCommit := &code.RefCommit{}
-	for _, pair := range commitPairsSrc {
-		newRefId := fmt.Sprintf("%s:%s", repoId, pair[2])
-		oldRefId := fmt.Sprintf("%s:%s", repoId, pair[3])
+	calculator, err := newCommitsDiffCalculator(db, logger)
+	if err != nil {
+		return err
+	}
 
-		count, err := db.Count(
-			dal.Select("*"),
-			dal.From("finished_commits_diffs"),
-			dal.Where("new_commit_sha = ? and old_commit_sha = ?", pair[0], pair[1]))
+	// a pair is calculated only if it is new or its refs moved since last time
+	var commitPairs RefCommitPairs
+	for _, pair := range data.Options.AllPairs {
+		err = db.CreateOrUpdate(&code.RefCommit{
+			NewRefId:     fmt.Sprintf("%s:%s", repoId, pair[2]),
+			OldRefId:     fmt.Sprintf("%s:%s", repoId, pair[3]),
+			NewCommitSha: pair[0],
+			OldCommitSha: pair[1],
+		})
 		if err != nil {
 			return err
 		}
-		if count == 0 {
+		if !calculator.isFinished(pair[0], pair[1]) {
 			commitPairs = append(commitPairs, pair)
 		}
-		if pair[2] != newRefId || pair[3] != oldRefId {
-			refCommit.NewCommitSha = pair[0]
-			refCommit.OldCommitSha = pair[1]
-			refCommit.NewRefId = newRefId
-			refCommit.OldRefId = oldRefId
-		}
 	}
 
 	if len(commitPairs) == 0 {
@@ -71,121 +64,24 @@ func CalculateCommitsDiff(taskCtx core.SubTaskContext) errors.Error {
 		return nil
 	}
 
-	commitNodeGraph := utils.NewCommitNodeGraph()
-	// mysql limit
-	insertCountLimitOfCommitsDiff := int(65535 / reflect.ValueOf(code.CommitsDiff{}).NumField())
-
-	// load commits from db
-	commitParent := &code.CommitParent{}
-	cursor, err := db.Cursor(
-		dal.Select("cp.*"),
-		dal.Join("LEFT JOIN repo_commits rc ON (rc.commit_sha = cp.commit_sha)"),
-		dal.From("commit_parents cp"),
-		dal.Where("rc.repo_id = ?", repoId),
-	)
+	commitNodeGraph, err := loadCommitNodeGraph(ctx, db, repoId)
 	if err != nil {
 		return err
 	}
-	defer cursor.Close()
-
-	for cursor.Next() {
-		select {
-		case <-ctx.Done():
-			return errors.Convert(ctx.Err())
-		default:
-		}
-		err = db.Fetch(cursor, commitParent)
-		if err != nil {
-			return errors.Default.Wrap(err, "failed to read commit from database")
-		}
-		commitNodeGraph.AddParent(commitParent.CommitSha, commitParent.ParentCommitSha)
-	}
-
 	logger.Info("Create a commit node graph with node count[%d]", commitNodeGraph.Size())
 
 	// calculate diffs for commits pairs and store them into database
-	commitsDiff := &code.CommitsDiff{}
-	finishedCommitDiff := &code.FinishedCommitsDiff{}
-	lenCommitPairs := len(commitPairs)
-	taskCtx.SetProgress(0, lenCommitPairs)
-
+	taskCtx.SetProgress(0, len(commitPairs))
 	for _, pair := range commitPairs {
 		select {
 		case <-ctx.Done():
 			return errors.Convert(ctx.Err())
 		default:
 		}
-		// ref might advance, keep commit sha for debugging
-		commitsDiff.NewCommitSha = pair[0]
-		commitsDiff.OldCommitSha = pair[1]
-
-		finishedCommitDiff.NewCommitSha = pair[0]
-		finishedCommitDiff.OldCommitSha = pair[1]
-
-		if commitsDiff.NewCommitSha == commitsDiff.OldCommitSha {
-			// different refs might point to a same commit, it is ok
-			logger.Info(
-				"skipping ref pair due to they are the same %s",
-				commitsDiff.NewCommitSha,
-			)
-			continue
-		}
-
-		lostSha, oldCount, newCount := commitNodeGraph.CalculateLostSha(pair[1], pair[0])
-
-		commitsDiffs := []code.CommitsDiff{}
-		refCommits := []code.RefCommit{}
-		finishedCommitDiffs := []code.FinishedCommitsDiff{}
-
-		commitsDiff.SortingIndex = 1
-		for _, sha := range lostSha {
-			commitsDiff.CommitSha = sha
-			commitsDiffs = append(commitsDiffs, *commitsDiff)
-
-			// sql limit placeholders count only 65535
-			if commitsDiff.SortingIndex%insertCountLimitOfCommitsDiff == 0 {
-				logger.Info("commitsDiffs count in limited[%d] index[%d]--exec and clean", len(commitsDiffs), commitsDiff.SortingIndex)
-				err = db.CreateIfNotExist(commitsDiffs)
-				if err != nil {
-					return err
-				}
-				commitsDiffs = []code.CommitsDiff{}
-			}
-
-			commitsDiff.SortingIndex++
-		}
-
-		if len(commitsDiffs) > 0 {
-			logger.Info("insert data count [%d]", len(commitsDiffs))
-			err = db.CreateIfNotExist(commitsDiffs)
-			if err != nil {
-				return err
-			}
-		}
-
-		refCommits = append(refCommits, *refCommit)
-		if len(refCommits) > 0 {
-			err = db.CreateIfNotExist(refCommits)
-			if err != nil {
-				return err
-			}
-		}
-
-		finishedCommitDiffs = append(finishedCommitDiffs, *finishedCommitDiff)
-		if len(finishedCommitDiffs) > 0 {
-			err = db.CreateIfNotExist(finishedCommitDiffs)
-			if err != nil {
-				return err
-			}
+		err = calculator.calculate(commitNodeGraph, pair[0], pair[1])
+		if err != nil {
+			return err
 		}
-
-		logger.Info(
-			"total %d commits of difference found between [new][%s] and [old][%s(total:%d)]",
-			newCount,
-			commitsDiff.NewCommitSha,
-			commitsDiff.OldCommitSha,
-			oldCount,
-		)
 		taskCtx.IncProgress(1)
 	}
 	return nil
@@ -0,0 +1,210 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/plugins/core"
+	"github.com/apache/incubator-devlake/plugins/core/dal"
+	"github.com/apache/incubator-devlake/plugins/refdiff/utils"
+)
+
+// commitsDiffCalculator calculates commits diffs of commit pairs and saves them along with finished_commits_diffs.
+// Finished pairs are never calculated again, and a new pair is calculated incrementally from the nearest
+// finished pair which shares the old commit or the new commit with it.
+type commitsDiffCalculator struct {
+	db            dal.Dal
+	logger        core.Logger
+	finished      map[[2]string]bool
+	finishedByOld map[string][]string
+	finishedByNew map[string][]string
+	// mysql limit
+	insertCountLimit int
+}
+
+func newCommitsDiffCalculator(db dal.Dal, logger core.Logger) (*commitsDiffCalculator, errors.Error) {
+	var finishedCommitsDiffs []code.FinishedCommitsDiff
+	err := db.All(&finishedCommitsDiffs)
+	if err != nil {
+		return nil, err
+	}
+	calculator := &commitsDiffCalculator{
+		db:               db,
+		logger:           logger,
+		finished:         make(map[[2]string]bool, len(finishedCommitsDiffs)),
+		finishedByOld:    make(map[string][]string),
+		finishedByNew:    make(map[string][]string),
+		insertCountLimit: int(65535 / reflect.ValueOf(code.CommitsDiff{}).NumField()),
+	}
+	for _, finished := range finishedCommitsDiffs {
+		calculator.markFinished(finished.NewCommitSha, finished.OldCommitSha)
+	}
+	return calculator, nil
+}
+
+func (c *commitsDiffCalculator) isFinished(newSha string, oldSha string) bool {
+	return c.finished[[2]string{newSha, oldSha}]
+}
+
+func (c *commitsDiffCalculator) markFinished(newSha string, oldSha string) {
+	c.finished[[2]string{newSha, oldSha}] = true
+	c.finishedByOld[oldSha] = append(c.finishedByOld[oldSha], newSha)
+	c.finishedByNew[newSha] = append(c.finishedByNew[newSha], oldSha)
+}
+
+// calculate saves commits which newSha has but oldSha does not have into commits_diffs
+func (c *commitsDiffCalculator) calculate(graph *utils.CommitNodeGraph, newSha string, oldSha string) errors.Error {
+	if newSha == oldSha {
+		// different refs might point to a same commit, it is ok
+		c.logger.Info("skipping ref pair due to they are the same %s", newSha)
+		return nil
+	}
+	if c.isFinished(newSha, oldSha) {
+		return nil
+	}
+	lostSha, err := c.calculateLostSha(graph, newSha, oldSha)
+	if err != nil {
+		return err
+	}
+
+	commitsDiffs := make([]code.CommitsDiff, 0, len(lostSha))
+	for i, sha := range lostSha {
+		commitsDiffs = append(commitsDiffs, code.CommitsDiff{
+			NewCommitSha: newSha,
+			OldCommitSha: oldSha,
+			CommitSha:    sha,
+			SortingIndex: i + 1,
+		})
+		// sql limit placeholders count only 65535
+		if len(commitsDiffs) == c.insertCountLimit {
+			c.logger.Info("commitsDiffs count in limited[%d] index[%d]--exec and clean", len(commitsDiffs), i+1)
+			err = c.db.CreateIfNotExist(commitsDiffs)
+			if err != nil {
+				return err
+			}
+			commitsDiffs = commitsDiffs[:0]
+		}
+	}
+	if len(commitsDiffs) > 0 {
+		c.logger.Info("insert data count [%d]", len(commitsDiffs))
+		err = c.db.CreateIfNotExist(commitsDiffs)
+		if err != nil {
+			return err
+		}
+	}
+	err = c.db.CreateIfNotExist(&code.FinishedCommitsDiff{NewCommitSha: newSha, OldCommitSha: oldSha})
+	if err != nil {
+		return err
+	}
+	c.markFinished(newSha, oldSha)
+	c.logger.Info("total %d commits of difference found between [new][%s] and [old][%s]", len(lostSha), newSha, oldSha)
+	return nil
+}
+
+// calculateLostSha reuses the commits of a finished pair (base, old) when base is between old and new,
+// or of a finished pair (new, base) when base is between old and new, and only walks the rest of the history
+func (c *commitsDiffCalculator) calculateLostSha(graph *utils.CommitNodeGraph, newSha string, oldSha string) ([]string, errors.Error) {
+	baseSha, distance, isNewBase := "", -1, false
+	for _, sha := range c.finishedByOld[oldSha] {
+		if sha == newSha || !graph.IsAncestor(sha, newSha) || !graph.IsAncestor(oldSha, sha) {
+			continue
+		}
+		if d := graph.Generation(newSha) - graph.Generation(sha); distance < 0 || d < distance {
+			baseSha, distance, isNewBase = sha, d, true
+		}
+	}
+	for _, sha := range c.finishedByNew[newSha] {
+		if sha == oldSha || !graph.IsAncestor(sha, newSha) || !graph.IsAncestor(oldSha, sha) {
+			continue
+		}
+		if d := graph.Generation(sha) - graph.Generation(oldSha); distance < 0 || d < distance {
+			baseSha, distance, isNewBase = sha, d, false
+		}
+	}
+
+	if baseSha == "" {
+		lostSha, oldCount, _ := graph.CalculateLostSha(oldSha, newSha)
+		c.logger.Debug("walked %d commits of [old][%s]", oldCount, oldSha)
+		return lostSha, nil
+	}
+
+	if isNewBase {
+		// new moved forward from base: (new, base) + (base, old)
+		lostSha, _, _ := graph.CalculateLostSha(baseSha, newSha)
+		finishedSha, err := c.loadCommitsDiff(baseSha, oldSha)
+		if err != nil {
+			return nil, err
+		}
+		c.logger.Info("reuse %d commits of finished pair [new][%s] and [old][%s]", len(finishedSha), baseSha, oldSha)
+		return append(lostSha, finishedSha...), nil
+	}
+	// old moved forward to base: (new, base) + (base, old)
+	finishedSha, err := c.loadCommitsDiff(newSha, baseSha)
+	if err != nil {
+		return nil, err
+	}
+	lostSha, _, _ := graph.CalculateLostSha(oldSha, baseSha)
+	c.logger.Info("reuse %d commits of finished pair [new][%s] and [old][%s]", len(finishedSha), newSha, baseSha)
+	return append(finishedSha, lostSha...), nil
+}
+
+func (c *commitsDiffCalculator) loadCommitsDiff(newSha string, oldSha string) ([]string, errors.Error) {
+	var shas []string
+	err := c.db.Pluck(
+		"commit_sha",
+		&shas,
+		dal.From(&code.CommitsDiff{}),
+		dal.Where("new_commit_sha = ? AND old_commit_sha = ?", newSha, oldSha),
+		dal.Orderby("sorting_index"),
+	)
+	return shas, err
+}
+
+// loadCommitNodeGraph loads the commit parents of the repo into a graph
+func loadCommitNodeGraph(ctx context.Context, db dal.Dal, repoId string) (*utils.CommitNodeGraph, errors.Error) {
+	commitNodeGraph := utils.NewCommitNodeGraph()
+	cursor, err := db.Cursor(
+		dal.Select("cp.*"),
+		dal.Join("LEFT JOIN repo_commits rc ON (rc.commit_sha = cp.commit_sha)"),
+		dal.From("commit_parents cp"),
+		dal.Where("rc.repo_id = ?", repoId),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close()
+
+	commitParent := &code.CommitParent{}
+	for cursor.Next() {
+		select {
+		case <-ctx.Done():
+			return nil, errors.Convert(ctx.Err())
+		default:
+		}
+		err = db.Fetch(cursor, commitParent)
+		if err != nil {
+			return nil, errors.Default.Wrap(err, "failed to read commit from database")
+		}
+		commitNodeGraph.AddParent(commitParent.CommitSha, commitParent.ParentCommitSha)
+	}
+	return commitNodeGraph, nil
+}
@@ -22,7 +22,6 @@ import (
 	"fmt"
 	"regexp"
 	"sort"
-	"strings"
 	"time"
 
 	"github.com/apache/incubator-devlake/errors"
@@ -30,6 +29,7 @@ import (
 
 	"github.com/apache/incubator-devlake/models/domainlayer/code"
 	"github.com/apache/incubator-devlake/plugins/core/dal"
+	"github.com/apache/incubator-devlake/plugins/refdiff/utils"
 )
 
 type RefdiffOptions struct {
@@ -39,7 +39,7 @@ type RefdiffOptions struct {
 
 	TagsPattern string // The Pattern to match from all tags
 	TagsLimit   int    // How many tags be matched should be used.
-	TagsOrder   string // The Rule to Order the tag list
+	TagsOrder   string // The Rule to Order the tag list, one of (reverse) alphabetically, (reverse) semver and (reverse) calver
 
 	AllPairs    RefCommitPairs // Pairs and TagsPattern Pairs
 	ProjectName string
@@ -64,6 +64,8 @@ type RefsAlphabetically Refs
 type RefsReverseAlphabetically Refs
 type RefsSemver Refs
 type RefsReverseSemver Refs
+type RefsCalver Refs
+type RefsReverseCalver Refs
 
 func (rs Refs) Len() int {
 	return len(rs)
@@ -98,29 +100,7 @@ func (rs RefsSemver) Len() int {
 }
 
 func (rs RefsSemver) Less(i, j int) bool {
-	parti := strings.Split(rs[i].Name, ".")
-	partj := strings.Split(rs[j].Name, ".")
-
-	for k := 0; k < len(partj); k++ {
-		if k >= len(parti) {
-			return true
-		}
-
-		if len(parti[k]) < len(partj[k]) {
-			return true
-		}
-		if len(parti[k]) > len(partj[k]) {
-			return false
-		}
-
-		if parti[k] < partj[k] {
-			return true
-		}
-		if parti[k] > partj[k] {
-			return false
-		}
-	}
-	return false
+	return lessVersion(rs[i].Name, rs[j].Name, utils.ParseSemver, false)
 }
 
 func (rs RefsSemver) Swap(i, j int) {
@@ -132,13 +112,58 @@ func (rs RefsReverseSemver) Len() int {
 }
 
 func (rs RefsReverseSemver) Less(i, j int) bool {
-	return RefsSemver(rs).Less(j, i)
+	return lessVersion(rs[i].Name, rs[j].Name, utils.ParseSemver, true)
 }
 
 func (rs RefsReverseSemver) Swap(i, j int) {
 	rs[i], rs[j] = rs[j], rs[i]
 }
 
+func (rs RefsCalver) Len() int {
+	return len(rs)
+}
+
+func (rs RefsCalver) Less(i, j int) bool {
+	return lessVersion(rs[i].Name, rs[j].Name, utils.ParseCalver, false)
+}
+
+func (rs RefsCalver) Swap(i, j int) {
+	rs[i], rs[j] = rs[j], rs[i]
+}
+
+func (rs RefsReverseCalver) Len() int {
+	return len(rs)
+}
+
+func (rs RefsReverseCalver) Less(i, j int) bool {
+	return lessVersion(rs[i].Name, rs[j].Name, utils.ParseCalver, true)
+}
+
+func (rs RefsReverseCalver) Swap(i, j int) {
+	rs[i], rs[j] = rs[j], rs[i]
+}
+
+// lessVersion compares two tag names by the version parsed from them, tags which can not be parsed
+// are always put at the end so that they would be cut off first by TagsLimit
+func lessVersion(namei, namej string, parse func(string) *utils.Version, reverse bool) bool {
+	vi, vj := parse(namei), parse(namej)
+	if vi == nil || vj == nil {
+		if vi != nil || vj != nil {
+			return vi != nil
+		}
+		return namei < namej
+	}
+	c := vi.Compare(vj)
+	if c == 0 {
+		// v1.0.0 and 1.0.0 point to the same version, keep the order stable
+		return namei < namej
+	}
+	if reverse {
+		return c > 0
+	}
+	return c < 0
+}
+
 // CalculateTagPattern Calculate the TagPattern order by tagsOrder and return the Refs
 func CalculateTagPattern(db dal.Dal, tagsPattern string, tagsLimit int, tagsOrder string) (Refs, errors.Error) {
 	rs := Refs{}
@@ -156,7 +181,7 @@ func CalculateTagPattern(db dal.Dal, tagsPattern string, tagsLimit int, tagsOrde
 	if err != nil {
 		return rs, err
 	}
-	defer rows.Next()
+	defer rows.Close()
 	r, err := errors.Convert01(regexp.Compile(tagsPattern))
 	if err != nil {
 		return rs, errors.Default.Wrap(err, fmt.Sprintf("unable to parse: %s", tagsPattern))
@@ -181,6 +206,10 @@ func CalculateTagPattern(db dal.Dal, tagsPattern string, tagsLimit int, tagsOrde
 		sort.Sort(RefsSemver(rs))
 	case "reverse semver":
 		sort.Sort(RefsReverseSemver(rs))
+	case "calver":
+		sort.Sort(RefsCalver(rs))
+	case "reverse calver":
+		sort.Sort(RefsReverseCalver(rs))
 	default:
 	}
 
@@ -0,0 +1,110 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/apache/incubator-devlake/models/domainlayer/code"
+	"github.com/stretchr/testify/assert"
+)
+
+func refNames(rs Refs) []string {
+	names := make([]string, 0, len(rs))
+	for _, r := range rs {
+		names = append(names, r.Name)
+	}
+	return names
+}
+
+func newRefs(names ...string) Refs {
+	rs := make(Refs, 0, len(names))
+	for _, name := range names {
+		rs = append(rs, code.Ref{Name: name})
+	}
+	return rs
+}
+
+func TestRefsSemver(t *testing.T) {
+	rs := newRefs("v1.10.0", "latest", "v1.2.0", "v1.2.0-rc.2", "v1.2.0-rc.10", "v1.9.3")
+	sort.Sort(RefsSemver(rs))
+	assert.Equal(t, []string{"v1.2.0-rc.2", "v1.2.0-rc.10", "v1.2.0", "v1.9.3", "v1.10.0", "latest"}, refNames(rs))
+
+	sort.Sort(RefsReverseSemver(rs))
+	assert.Equal(t, []string{"v1.10.0", "v1.9.3", "v1.2.0", "v1.2.0-rc.10", "v1.2.0-rc.2", "latest"}, refNames(rs))
+}
+
+func TestRefsCalver(t *testing.T) {
+	rs := newRefs("2022.10.1", "nightly", "2022.9.30", "2022.10.1-rc1", "2021.12.31")
+	sort.Sort(RefsCalver(rs))
+	assert.Equal(t, []string{"2021.12.31", "2022.9.30", "2022.10.1-rc1", "2022.10.1", "nightly"}, refNames(rs))
+
+	sort.Sort(RefsReverseCalver(rs))
+	assert.Equal(t, []string{"2022.10.1", "2022.10.1-rc1", "2022.9.30", "2021.12.31", "nightly"}, refNames(rs))
+}
+
+func TestReleaseNoteMarkdown(t *testing.T) {
+	note := &ReleaseNote{
+		NewRefName:  "v1.1.0",
+		OldRefName:  "v1.0.0",
+		CommitCount: 3,
+		PullRequests: []ReleaseNotePullRequest{
+			{PullRequestKey: 12, Title: "Add retry", Url: "https://example.com/pull/12", AuthorName: "alice"},
+		},
+		IssueGroups: groupIssuesByType([]ReleaseNoteIssue{
+			{IssueKey: "DL-2", Title: "Crash on start", Type: "BUG"},
+			{IssueKey: "DL-3", Title: "Cleanup"},
+			{IssueKey: "DL-1", Title: "Retry collectors", Type: "REQUIREMENT", Url: "https://example.com/DL-1"},
+		}),
+		Contributors: []ReleaseNoteContributor{{Name: "alice", CommitCount: 2}, {Name: "bob", CommitCount: 1}},
+		Cherrypicks: []ReleaseNoteCherrypick{
+			{ParentPrKey: 12, ParentPrUrl: "https://example.com/pull/12", CherrypickBaseBranches: "release-1.0,release-0.9", CherrypickPrKeys: "15,16"},
+		},
+	}
+	assert.Equal(t, 3, note.IssueCount())
+	assert.Equal(t, `# v1.1.0
+
+Changes since v1.0.0: 3 commits, 1 pull requests, 3 issues, 2 contributors
+
+## Pull Requests
+
+- [#12](https://example.com/pull/12) Add retry by alice
+
+## Features
+
+- [DL-1](https://example.com/DL-1) Retry collectors
+
+## Bug Fixes
+
+- DL-2 Crash on start
+
+## Others
+
+- DL-3 Cleanup
+
+## Cherry-picks
+
+- [#12](https://example.com/pull/12) cherry-picked to release-1.0, release-0.9 as #15, #16
+
+## Contributors
+
+- alice (2 commits)
+- bob (1 commits)
+`, note.Markdown())
+}
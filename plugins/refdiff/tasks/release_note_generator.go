@@ -0,0 +1,337 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/plugins/core"
+	"github.com/apache/incubator-devlake/plugins/core/dal"
+)
+
+// ReleaseNote is built from commits_diffs, refs_issues_diffs and refs_pr_cherrypicks for a pair of refs
+type ReleaseNote struct {
+	RepoId          string                   `json:"repoId"`
+	NewRefName      string                   `json:"newRefName"`
+	OldRefName      string                   `json:"oldRefName"`
+	NewRefCommitSha string                   `json:"newRefCommitSha"`
+	OldRefCommitSha string                   `json:"oldRefCommitSha"`
+	CommitCount     int                      `json:"commitCount"`
+	PullRequests    []ReleaseNotePullRequest `json:"pullRequests"`
+	IssueGroups     []ReleaseNoteIssueGroup  `json:"issueGroups"`
+	Contributors    []ReleaseNoteContributor `json:"contributors"`
+	Cherrypicks     []ReleaseNoteCherrypick  `json:"cherrypicks"`
+}
+
+type ReleaseNotePullRequest struct {
+	Id             string `json:"id"`
+	PullRequestKey int    `json:"pullRequestKey"`
+	Title          string `json:"title"`
+	Url            string `json:"url"`
+	AuthorName     string `json:"authorName"`
+}
+
+type ReleaseNoteIssue struct {
+	Id       string `json:"id"`
+	IssueKey string `json:"issueKey"`
+	Title    string `json:"title"`
+	Url      string `json:"url"`
+	Type     string `json:"type"`
+}
+
+type ReleaseNoteIssueGroup struct {
+	Type   string             `json:"type"`
+	Issues []ReleaseNoteIssue `json:"issues"`
+}
+
+type ReleaseNoteContributor struct {
+	Name        string `json:"name"`
+	Email       string `json:"email"`
+	CommitCount int    `json:"commitCount"`
+}
+
+type ReleaseNoteCherrypick struct {
+	ParentPrKey            int    `json:"parentPrKey"`
+	ParentPrUrl            string `json:"parentPrUrl"`
+	CherrypickBaseBranches string `json:"cherrypickBaseBranches"`
+	CherrypickPrKeys       string `json:"cherrypickPrKeys"`
+}
+
+const ISSUE_TYPE_OTHER = "OTHER"
+
+// issue groups are listed in this order, the other types follow alphabetically
+var issueTypeOrder = map[string]int{
+	ticket.REQUIREMENT: 1,
+	ticket.BUG:         2,
+	ticket.INCIDENT:    3,
+}
+
+var issueTypeTitles = map[string]string{
+	ticket.REQUIREMENT: "Features",
+	ticket.BUG:         "Bug Fixes",
+	ticket.INCIDENT:    "Incidents",
+	ISSUE_TYPE_OTHER:   "Others",
+}
+
+func GenerateReleaseNotes(taskCtx core.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*RefdiffTaskData)
+	repoId := data.Options.RepoId
+	db := taskCtx.GetDal()
+
+	if data.Options.ProjectName != "" {
+		return nil
+	}
+	taskCtx.SetProgress(0, len(data.Options.AllPairs))
+	for _, pair := range data.Options.AllPairs {
+		note, err := BuildReleaseNote(db, repoId, pair)
+		if err != nil {
+			return err
+		}
+		err = db.CreateOrUpdate(&code.RefsReleaseNote{
+			NewRefId:         fmt.Sprintf("%s:%s", repoId, pair[2]),
+			OldRefId:         fmt.Sprintf("%s:%s", repoId, pair[3]),
+			RepoId:           repoId,
+			NewRefName:       note.NewRefName,
+			OldRefName:       note.OldRefName,
+			NewRefCommitSha:  note.NewRefCommitSha,
+			OldRefCommitSha:  note.OldRefCommitSha,
+			CommitCount:      note.CommitCount,
+			PrCount:          len(note.PullRequests),
+			IssueCount:       note.IssueCount(),
+			ContributorCount: len(note.Contributors),
+			CherrypickCount:  len(note.Cherrypicks),
+			Content:          note.Markdown(),
+		})
+		if err != nil {
+			return err
+		}
+		taskCtx.IncProgress(1)
+	}
+	return nil
+}
+
+// BuildReleaseNote collects pull requests, issues, contributors and cherry-picks between the refs of the pair,
+// commits_diffs, refs_issues_diffs and refs_pr_cherrypicks of the pair must have been calculated
+func BuildReleaseNote(db dal.Dal, repoId string, pair RefCommitPair) (*ReleaseNote, errors.Error) {
+	note := &ReleaseNote{
+		RepoId:          repoId,
+		NewRefCommitSha: pair[0],
+		OldRefCommitSha: pair[1],
+		NewRefName:      pair[2],
+		OldRefName:      pair[3],
+	}
+	diffCommits := `SELECT commit_sha FROM commits_diffs WHERE new_commit_sha = ? AND old_commit_sha = ?`
+
+	// contributors are grouped by email, or by name if the email is missing
+	var authors []struct {
+		AuthorName  string
+		AuthorEmail string
+	}
+	err := db.All(
+		&authors,
+		dal.Select("commits.author_name, commits.author_email"),
+		dal.From("commits_diffs"),
+		dal.Join("LEFT JOIN commits ON commits.sha = commits_diffs.commit_sha"),
+		dal.Where("commits_diffs.new_commit_sha = ? AND commits_diffs.old_commit_sha = ?", pair[0], pair[1]),
+		dal.Orderby("commits_diffs.sorting_index"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	note.CommitCount = len(authors)
+	contributors := make(map[string]*ReleaseNoteContributor)
+	for _, author := range authors {
+		key := strings.ToLower(author.AuthorEmail)
+		if key == "" {
+			key = author.AuthorName
+		}
+		if key == "" {
+			continue
+		}
+		if contributors[key] == nil {
+			contributors[key] = &ReleaseNoteContributor{Name: author.AuthorName, Email: author.AuthorEmail}
+		}
+		contributors[key].CommitCount++
+	}
+	for _, contributor := range contributors {
+		note.Contributors = append(note.Contributors, *contributor)
+	}
+	sort.Slice(note.Contributors, func(i, j int) bool {
+		if note.Contributors[i].CommitCount != note.Contributors[j].CommitCount {
+			return note.Contributors[i].CommitCount > note.Contributors[j].CommitCount
+		}
+		return note.Contributors[i].Name < note.Contributors[j].Name
+	})
+
+	// a pull request is included if its merge commit or any of its commits is in the diff
+	err = db.All(
+		&note.PullRequests,
+		dal.Select("id, pull_request_key, title, url, author_name"),
+		dal.From(&code.PullRequest{}),
+		dal.Where(
+			`base_repo_id = ? AND (merge_commit_sha IN (`+diffCommits+`)
+			OR id IN (SELECT pull_request_id FROM pull_request_commits WHERE commit_sha IN (`+diffCommits+`)))`,
+			repoId, pair[0], pair[1], pair[0], pair[1],
+		),
+		dal.Orderby("pull_request_key"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var issues []ReleaseNoteIssue
+	err = db.All(
+		&issues,
+		dal.Select("issues.id, issues.issue_key, issues.title, issues.url, issues.type"),
+		dal.From("refs_issues_diffs"),
+		dal.Join("LEFT JOIN issues ON issues.id = refs_issues_diffs.issue_id"),
+		dal.Where(
+			"refs_issues_diffs.new_ref_id = ? AND refs_issues_diffs.old_ref_id = ?",
+			fmt.Sprintf("%s:%s", repoId, pair[2]), fmt.Sprintf("%s:%s", repoId, pair[3]),
+		),
+		dal.Orderby("issues.issue_key"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	note.IssueGroups = groupIssuesByType(issues)
+
+	if len(note.PullRequests) > 0 {
+		prIds := make([]string, 0, len(note.PullRequests))
+		for _, pr := range note.PullRequests {
+			prIds = append(prIds, pr.Id)
+		}
+		err = db.All(
+			&note.Cherrypicks,
+			dal.Select("parent_pr_key, parent_pr_url, cherrypick_base_branches, cherrypick_pr_keys"),
+			dal.From(&code.RefsPrCherrypick{}),
+			dal.Where("parent_pr_id IN ?", prIds),
+			dal.Orderby("parent_pr_key"),
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return note, nil
+}
+
+func groupIssuesByType(issues []ReleaseNoteIssue) []ReleaseNoteIssueGroup {
+	groups := make([]ReleaseNoteIssueGroup, 0)
+	index := make(map[string]int)
+	for _, issue := range issues {
+		issueType := issue.Type
+		if issueType == "" {
+			issueType = ISSUE_TYPE_OTHER
+		}
+		i, ok := index[issueType]
+		if !ok {
+			i = len(groups)
+			index[issueType] = i
+			groups = append(groups, ReleaseNoteIssueGroup{Type: issueType})
+		}
+		groups[i].Issues = append(groups[i].Issues, issue)
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		oi, oj := issueTypeOrder[groups[i].Type], issueTypeOrder[groups[j].Type]
+		if oi == 0 || oj == 0 {
+			if oi != 0 || oj != 0 {
+				return oi != 0
+			}
+			return groups[i].Type < groups[j].Type
+		}
+		return oi < oj
+	})
+	return groups
+}
+
+// IssueCount returns the number of issues in all groups
+func (note *ReleaseNote) IssueCount() int {
+	count := 0
+	for _, group := range note.IssueGroups {
+		count += len(group.Issues)
+	}
+	return count
+}
+
+// Markdown renders the release note, empty sections are omitted
+func (note *ReleaseNote) Markdown() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# %s\n\n", note.NewRefName)
+	fmt.Fprintf(
+		&sb, "Changes since %s: %d commits, %d pull requests, %d issues, %d contributors\n",
+		note.OldRefName, note.CommitCount, len(note.PullRequests), note.IssueCount(), len(note.Contributors),
+	)
+	if len(note.PullRequests) > 0 {
+		sb.WriteString("\n## Pull Requests\n\n")
+		for _, pr := range note.PullRequests {
+			fmt.Fprintf(&sb, "- %s %s", markdownLink(fmt.Sprintf("#%d", pr.PullRequestKey), pr.Url), pr.Title)
+			if pr.AuthorName != "" {
+				fmt.Fprintf(&sb, " by %s", pr.AuthorName)
+			}
+			sb.WriteString("\n")
+		}
+	}
+	for _, group := range note.IssueGroups {
+		title := issueTypeTitles[group.Type]
+		if title == "" {
+			title = group.Type
+		}
+		fmt.Fprintf(&sb, "\n## %s\n\n", title)
+		for _, issue := range group.Issues {
+			fmt.Fprintf(&sb, "- %s %s\n", markdownLink(issue.IssueKey, issue.Url), issue.Title)
+		}
+	}
+	if len(note.Cherrypicks) > 0 {
+		sb.WriteString("\n## Cherry-picks\n\n")
+		for _, cherrypick := range note.Cherrypicks {
+			fmt.Fprintf(
+				&sb, "- %s cherry-picked to %s as #%s\n",
+				markdownLink(fmt.Sprintf("#%d", cherrypick.ParentPrKey), cherrypick.ParentPrUrl),
+				strings.ReplaceAll(cherrypick.CherrypickBaseBranches, ",", ", "),
+				strings.ReplaceAll(cherrypick.CherrypickPrKeys, ",", ", #"),
+			)
+		}
+	}
+	if len(note.Contributors) > 0 {
+		sb.WriteString("\n## Contributors\n\n")
+		for _, contributor := range note.Contributors {
+			fmt.Fprintf(&sb, "- %s (%d commits)\n", contributor.Name, contributor.CommitCount)
+		}
+	}
+	return sb.String()
+}
+
+func markdownLink(text, url string) string {
+	if url == "" {
+		return text
+	}
+	return fmt.Sprintf("[%s](%s)", text, url)
+}
+
+var GenerateReleaseNotesMeta = core.SubTaskMeta{
+	Name:             "generateReleaseNotes",
+	EntryPoint:       GenerateReleaseNotes,
+	EnabledByDefault: true,
+	Description:      "Generate release notes between refs from commits diffs, issues diffs and pr cherry picks",
+	DomainTypes:      []string{core.DOMAIN_TYPE_CODE},
+}
@@ -17,9 +17,14 @@ limitations under the License.
 
 package utils
 
+import "container/heap"
+
 type CommitNode struct {
 	Sha    string
 	Parent []*CommitNode
+	// generation is 1 for root commits and 1 + the max generation of parents for others,
+	// it is calculated lazily after the graph is built
+	generation int
 }
 
 type CommitNodeGraph struct {
@@ -55,54 +60,197 @@ func (cng *CommitNodeGraph) AddParent(commit_sha string, commit_parent_sha strin
 
 // CalculateLostSha calculates commit sha which newCommitNode has but oldCommitNode does not have
 func (cng *CommitNodeGraph) CalculateLostSha(source_sha string, target_sha string) ([]string, int, int) {
-	var oldCommitNode *CommitNode
-	var newCommitNode *CommitNode
-	var lostSha []string
-	var ok bool
-	if oldCommitNode, ok = cng.node[source_sha]; !ok {
-		oldCommitNode = &CommitNode{
-			Sha: source_sha,
-		}
-	}
-	if newCommitNode, ok = cng.node[target_sha]; !ok {
-		newCommitNode = &CommitNode{
-			Sha: target_sha,
-		}
-	}
-
-	oldGroup := make(map[string]*CommitNode)
+	oldCommitNode := cng.getNode(source_sha)
+	newCommitNode := cng.getNode(target_sha)
+	painted := cng.paintDown(newCommitNode, oldCommitNode)
 
+	var lostSha []string
+	var newGroup = make(map[string]*CommitNode)
 	var dfs func(*CommitNode)
-	// put all commit sha which can be depth-first-searched by old commit
+	// put all commit sha which can be depth-first-searched by new commit, will stop when find any painted by old commit
 	dfs = func(now *CommitNode) {
-		if _, ok = oldGroup[now.Sha]; ok {
+		if painted[now.Sha] != paintedByNew {
+			return
+		}
+		if _, ok := newGroup[now.Sha]; ok {
 			return
 		}
-		oldGroup[now.Sha] = now
+		newGroup[now.Sha] = now
+		lostSha = append(lostSha, now.Sha)
 		for _, node := range now.Parent {
 			dfs(node)
 		}
 	}
-	dfs(oldCommitNode)
+	dfs(newCommitNode)
 
-	var newGroup = make(map[string]*CommitNode)
-	// put all commit sha which can be depth-first-searched by new commit, will stop when find any in either group
-	dfs = func(now *CommitNode) {
-		if _, ok = oldGroup[now.Sha]; ok {
+	oldCount := 0
+	for _, flag := range painted {
+		if flag&paintedByOld != 0 {
+			oldCount++
+		}
+	}
+	return lostSha, oldCount, len(newGroup)
+}
+
+// IsAncestor returns true if ancestor_sha can be reached from descendant_sha, a commit is an ancestor of itself
+func (cng *CommitNodeGraph) IsAncestor(ancestor_sha string, descendant_sha string) bool {
+	if ancestor_sha == descendant_sha {
+		return true
+	}
+	ancestor, ok := cng.node[ancestor_sha]
+	if !ok {
+		return false
+	}
+	descendant, ok := cng.node[descendant_sha]
+	if !ok {
+		return false
+	}
+	generation := cng.generationOf(ancestor)
+	visited := make(map[string]bool)
+	stack := []*CommitNode{descendant}
+	for len(stack) > 0 {
+		now := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if now == ancestor {
+			return true
+		}
+		if visited[now.Sha] {
+			continue
+		}
+		visited[now.Sha] = true
+		for _, parent := range now.Parent {
+			// commits older than the ancestor can not lead to it
+			if cng.generationOf(parent) >= generation {
+				stack = append(stack, parent)
+			}
+		}
+	}
+	return false
+}
+
+// Generation returns the generation of the commit, or 0 if the commit is not in the graph
+func (cng *CommitNodeGraph) Generation(sha string) int {
+	node, ok := cng.node[sha]
+	if !ok {
+		return 0
+	}
+	return cng.generationOf(node)
+}
+
+const (
+	paintedByNew = 1 << iota
+	paintedByOld
+)
+
+// paintDown walks from both commits at the same time in the order of generation, from high to low,
+// and paints the commits with the sides they can be reached from. The walk stops once all the
+// commits in the queue are reachable from the old commit, so only the part of history between
+// the two commits and their merge bases is visited instead of the whole ancestry of the old commit.
+func (cng *CommitNodeGraph) paintDown(newCommitNode *CommitNode, oldCommitNode *CommitNode) map[string]int {
+	painted := make(map[string]int)
+	queued := make(map[string]bool)
+	queue := &commitNodeQueue{graph: cng}
+	// number of queued commits which are not painted by old commit
+	pending := 0
+	paint := func(node *CommitNode, flag int) {
+		before := painted[node.Sha]
+		after := before | flag
+		if after == before {
 			return
 		}
-		if _, ok = newGroup[now.Sha]; ok {
+		painted[node.Sha] = after
+		if queued[node.Sha] {
+			if before&paintedByOld == 0 && after&paintedByOld != 0 {
+				pending--
+			}
 			return
 		}
-		newGroup[now.Sha] = now
-		lostSha = append(lostSha, now.Sha)
-		for _, node := range now.Parent {
-			dfs(node)
+		queued[node.Sha] = true
+		heap.Push(queue, node)
+		if after&paintedByOld == 0 {
+			pending++
 		}
 	}
-	dfs(newCommitNode)
+	paint(oldCommitNode, paintedByOld)
+	paint(newCommitNode, paintedByNew)
+	for pending > 0 {
+		// children always have higher generation than their parents, so a commit would not be painted again once popped
+		now := heap.Pop(queue).(*CommitNode)
+		delete(queued, now.Sha)
+		flag := painted[now.Sha]
+		if flag&paintedByOld == 0 {
+			pending--
+		}
+		for _, parent := range now.Parent {
+			paint(parent, flag)
+		}
+	}
+	return painted
+}
+
+// generationOf calculates generation without recursion since history might be very deep
+func (cng *CommitNodeGraph) generationOf(node *CommitNode) int {
+	stack := []*CommitNode{node}
+	for len(stack) > 0 {
+		now := stack[len(stack)-1]
+		if now.generation > 0 {
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		generation := 1
+		ready := true
+		for _, parent := range now.Parent {
+			if parent.generation == 0 {
+				ready = false
+				stack = append(stack, parent)
+			} else if parent.generation+1 > generation {
+				generation = parent.generation + 1
+			}
+		}
+		if ready {
+			now.generation = generation
+			stack = stack[:len(stack)-1]
+		}
+	}
+	return node.generation
+}
+
+// getNode returns the node of sha, commits which are not in the graph are treated as commits without parent
+func (cng *CommitNodeGraph) getNode(sha string) *CommitNode {
+	if node, ok := cng.node[sha]; ok {
+		return node
+	}
+	return &CommitNode{
+		Sha: sha,
+	}
+}
+
+// commitNodeQueue is a max heap of commits by generation
+type commitNodeQueue struct {
+	graph *CommitNodeGraph
+	nodes []*CommitNode
+}
+
+func (q *commitNodeQueue) Len() int {
+	return len(q.nodes)
+}
+
+func (q *commitNodeQueue) Less(i, j int) bool {
+	return q.graph.generationOf(q.nodes[i]) > q.graph.generationOf(q.nodes[j])
+}
+
+func (q *commitNodeQueue) Swap(i, j int) {
+	q.nodes[i], q.nodes[j] = q.nodes[j], q.nodes[i]
+}
+
+func (q *commitNodeQueue) Push(x any) {
+	q.nodes = append(q.nodes, x.(*CommitNode))
+}
 
-	return lostSha, len(oldGroup), len(newGroup)
+func (q *commitNodeQueue) Pop() any {
+	node := q.nodes[len(q.nodes)-1]
+	q.nodes = q.nodes[:len(q.nodes)-1]
+	return node
 }
 
 func (cng *CommitNodeGraph) Size() int {
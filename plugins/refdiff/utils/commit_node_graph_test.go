@@ -0,0 +1,112 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// naiveLostSha walks the whole ancestry of the old commit, which is what paintDown avoids
+func naiveLostSha(cng *CommitNodeGraph, oldSha string, newSha string) []string {
+	oldGroup := make(map[string]bool)
+	var walkOld func(*CommitNode)
+	walkOld = func(now *CommitNode) {
+		if oldGroup[now.Sha] {
+			return
+		}
+		oldGroup[now.Sha] = true
+		for _, node := range now.Parent {
+			walkOld(node)
+		}
+	}
+	walkOld(cng.getNode(oldSha))
+	var lostSha []string
+	newGroup := make(map[string]bool)
+	var walkNew func(*CommitNode)
+	walkNew = func(now *CommitNode) {
+		if oldGroup[now.Sha] || newGroup[now.Sha] {
+			return
+		}
+		newGroup[now.Sha] = true
+		lostSha = append(lostSha, now.Sha)
+		for _, node := range now.Parent {
+			walkNew(node)
+		}
+	}
+	walkNew(cng.getNode(newSha))
+	return lostSha
+}
+
+func TestCalculateLostSha(t *testing.T) {
+	//   c1 - c2 - c3 ------ c6 - c7
+	//          \          /
+	//           c4 - c5 -
+	cng := NewCommitNodeGraph()
+	cng.AddParent("c2", "c1")
+	cng.AddParent("c3", "c2")
+	cng.AddParent("c4", "c2")
+	cng.AddParent("c5", "c4")
+	cng.AddParent("c6", "c3")
+	cng.AddParent("c6", "c5")
+	cng.AddParent("c7", "c6")
+
+	lostSha, _, newCount := cng.CalculateLostSha("c3", "c7")
+	assert.Equal(t, []string{"c7", "c6", "c5", "c4"}, lostSha)
+	assert.Equal(t, 4, newCount)
+
+	lostSha, _, _ = cng.CalculateLostSha("c5", "c3")
+	assert.Equal(t, []string{"c3"}, lostSha)
+
+	lostSha, _, _ = cng.CalculateLostSha("c7", "c3")
+	assert.Empty(t, lostSha)
+
+	lostSha, _, _ = cng.CalculateLostSha("unknown", "c2")
+	assert.Equal(t, []string{"c2", "c1"}, lostSha)
+
+	assert.True(t, cng.IsAncestor("c4", "c7"))
+	assert.True(t, cng.IsAncestor("c7", "c7"))
+	assert.False(t, cng.IsAncestor("c5", "c3"))
+	assert.False(t, cng.IsAncestor("c7", "c1"))
+	assert.Equal(t, 5, cng.Generation("c6"))
+	assert.Equal(t, 0, cng.Generation("unknown"))
+}
+
+func TestCalculateLostShaRandomGraph(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+	cng := NewCommitNodeGraph()
+	count := 300
+	for i := 1; i < count; i++ {
+		sha := fmt.Sprintf("c%d", i)
+		cng.AddParent(sha, fmt.Sprintf("c%d", r.Intn(i)))
+		// merge commits
+		if r.Intn(5) == 0 {
+			cng.AddParent(sha, fmt.Sprintf("c%d", r.Intn(i)))
+		}
+	}
+	for k := 0; k < 200; k++ {
+		oldSha := fmt.Sprintf("c%d", r.Intn(count))
+		newSha := fmt.Sprintf("c%d", r.Intn(count))
+		lostSha, _, _ := cng.CalculateLostSha(oldSha, newSha)
+		assert.Equal(t, naiveLostSha(cng, oldSha, newSha), lostSha, "%s..%s", oldSha, newSha)
+		assert.Equal(t, len(lostSha) == 0, cng.IsAncestor(newSha, oldSha), "%s..%s", newSha, oldSha)
+	}
+}
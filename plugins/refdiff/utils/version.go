@@ -0,0 +1,168 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Version is a parsed tag name, it is used to order tags by semantic version or calendar version
+type Version struct {
+	Core       []int
+	PreRelease []string
+}
+
+// ParseSemver parses tag names like `v1.2.3`, `release-1.2.3-rc.1` or `1.2.3+build.5`,
+// the text before the first digit is ignored and so is the build metadata.
+// It returns nil if the tag is not a semantic version.
+func ParseSemver(name string) *Version {
+	name = trimVersionAffixes(name)
+	if name == "" {
+		return nil
+	}
+	core, preRelease, _ := strings.Cut(name, "-")
+	parts := strings.Split(core, ".")
+	if len(parts) > 3 {
+		return nil
+	}
+	version := &Version{}
+	for _, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return nil
+		}
+		version.Core = append(version.Core, n)
+	}
+	if preRelease != "" {
+		version.PreRelease = strings.Split(preRelease, ".")
+		for _, identifier := range version.PreRelease {
+			if identifier == "" {
+				return nil
+			}
+		}
+	}
+	return version
+}
+
+// ParseCalver parses tag names like `2022.12.01`, `v22.04.1`, `2022-12-01` or `2022.12-rc1`,
+// leading numeric segments separated by `.`, `-` or `_` make the core, and the rest is
+// handled as pre-release identifiers, `rc1` is split into `rc` and `1` so that `rc10` is after `rc9`.
+// It returns nil if the tag is not a calendar version.
+func ParseCalver(name string) *Version {
+	name = trimVersionAffixes(name)
+	if name == "" {
+		return nil
+	}
+	segments := strings.FieldsFunc(name, func(r rune) bool {
+		return r == '.' || r == '-' || r == '_'
+	})
+	version := &Version{}
+	for i, segment := range segments {
+		n, err := strconv.Atoi(segment)
+		if err != nil {
+			for _, rest := range segments[i:] {
+				version.PreRelease = append(version.PreRelease, splitAlphaNumeric(rest)...)
+			}
+			break
+		}
+		version.Core = append(version.Core, n)
+	}
+	// the first segment is the year, either in YY or YYYY format
+	if len(version.Core) < 2 || version.Core[0] > 9999 {
+		return nil
+	}
+	return version
+}
+
+// Compare returns -1, 0 or 1 when v is lower, equal to or higher than other,
+// missing core numbers are considered as zero and a pre-release has a lower precedence
+// than the normal version, just like semver 2.0.0 defines
+func (v *Version) Compare(other *Version) int {
+	for i := 0; i < len(v.Core) || i < len(other.Core); i++ {
+		a, b := 0, 0
+		if i < len(v.Core) {
+			a = v.Core[i]
+		}
+		if i < len(other.Core) {
+			b = other.Core[i]
+		}
+		if a != b {
+			return compareInt(a, b)
+		}
+	}
+	if len(v.PreRelease) == 0 || len(other.PreRelease) == 0 {
+		// 1.0.0 > 1.0.0-rc.1
+		return compareInt(len(other.PreRelease), len(v.PreRelease))
+	}
+	for i := 0; i < len(v.PreRelease) && i < len(other.PreRelease); i++ {
+		if c := compareIdentifier(v.PreRelease[i], other.PreRelease[i]); c != 0 {
+			return c
+		}
+	}
+	return compareInt(len(v.PreRelease), len(other.PreRelease))
+}
+
+// numeric identifiers are compared numerically and always have lower precedence than alphanumeric ones
+func compareIdentifier(a, b string) int {
+	na, errA := strconv.Atoi(a)
+	nb, errB := strconv.Atoi(b)
+	switch {
+	case errA == nil && errB == nil:
+		return compareInt(na, nb)
+	case errA == nil:
+		return -1
+	case errB == nil:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+func compareInt(a, b int) int {
+	if a < b {
+		return -1
+	}
+	if a > b {
+		return 1
+	}
+	return 0
+}
+
+// trimVersionAffixes removes the prefix before the first digit, like `v` or `release-`, and the build metadata
+func trimVersionAffixes(name string) string {
+	i := strings.IndexFunc(name, unicode.IsDigit)
+	if i < 0 {
+		return ""
+	}
+	name, _, _ = strings.Cut(name[i:], "+")
+	return name
+}
+
+// splitAlphaNumeric splits `rc10` into `rc` and `10`
+func splitAlphaNumeric(s string) []string {
+	var parts []string
+	start := 0
+	for i := 1; i < len(s); i++ {
+		if unicode.IsDigit(rune(s[i])) != unicode.IsDigit(rune(s[i-1])) {
+			parts = append(parts, s[start:i])
+			start = i
+		}
+	}
+	return append(parts, s[start:])
+}
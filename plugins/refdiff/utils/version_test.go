@@ -0,0 +1,77 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSemver(t *testing.T) {
+	assert.Equal(t, &Version{Core: []int{1, 2, 3}}, ParseSemver("v1.2.3"))
+	assert.Equal(t, &Version{Core: []int{1, 2, 3}}, ParseSemver("release-1.2.3+build.5"))
+	assert.Equal(t, &Version{Core: []int{1, 0, 0}, PreRelease: []string{"rc", "1"}}, ParseSemver("1.0.0-rc.1"))
+	assert.Equal(t, &Version{Core: []int{2}}, ParseSemver("v2"))
+	assert.Nil(t, ParseSemver("main"))
+	assert.Nil(t, ParseSemver("v1.2.3.4"))
+	assert.Nil(t, ParseSemver("v1.x"))
+	assert.Nil(t, ParseSemver("v1.0.0-rc..1"))
+}
+
+func TestParseCalver(t *testing.T) {
+	assert.Equal(t, &Version{Core: []int{2022, 12, 1}}, ParseCalver("2022.12.01"))
+	assert.Equal(t, &Version{Core: []int{2022, 12, 1}}, ParseCalver("release-2022-12-01"))
+	assert.Equal(t, &Version{Core: []int{22, 4, 1}}, ParseCalver("v22.04.1"))
+	assert.Equal(t, &Version{Core: []int{2022, 12}, PreRelease: []string{"rc", "10"}}, ParseCalver("2022.12-rc10"))
+	assert.Nil(t, ParseCalver("v2"))
+	assert.Nil(t, ParseCalver("main"))
+}
+
+func TestVersionCompare(t *testing.T) {
+	// ordered by semver 2.0.0 precedence
+	ordered := []string{
+		"v1.0.0-alpha",
+		"v1.0.0-alpha.1",
+		"v1.0.0-alpha.beta",
+		"v1.0.0-beta",
+		"v1.0.0-beta.2",
+		"v1.0.0-beta.11",
+		"v1.0.0-rc.1",
+		"v1.0.0",
+		"v1.2.0",
+		"v1.10.0",
+		"v2.0.0",
+	}
+	for i := 1; i < len(ordered); i++ {
+		assert.Equal(t, -1, ParseSemver(ordered[i-1]).Compare(ParseSemver(ordered[i])), ordered[i])
+		assert.Equal(t, 1, ParseSemver(ordered[i]).Compare(ParseSemver(ordered[i-1])), ordered[i])
+	}
+	assert.Equal(t, 0, ParseSemver("v1.2").Compare(ParseSemver("1.2.0+build.1")))
+
+	calver := []string{
+		"2021.12.31",
+		"2022.1.5-rc9",
+		"2022.1.5-rc10",
+		"2022.01.05",
+		"2022.10.1",
+	}
+	for i := 1; i < len(calver); i++ {
+		assert.Equal(t, -1, ParseCalver(calver[i-1]).Compare(ParseCalver(calver[i])), calver[i])
+	}
+}
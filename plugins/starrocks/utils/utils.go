@@ -37,6 +37,7 @@ func GetTablesByDomainLayer(domainLayer string) []string {
 			"pull_request_commits",
 			"repo_commits",
 			"pull_request_labels",
+			"pull_request_reviewers",
 			"commit_parents",
 			"notes",
 			"pull_request_comments",
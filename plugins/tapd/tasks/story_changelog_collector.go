@@ -18,16 +18,13 @@ limitations under the License.
 package tasks
 
 import (
-	goerror "errors"
 	"fmt"
+	"net/url"
+	"time"
+
 	"github.com/apache/incubator-devlake/errors"
 	"github.com/apache/incubator-devlake/plugins/core"
-	"github.com/apache/incubator-devlake/plugins/core/dal"
 	"github.com/apache/incubator-devlake/plugins/helper"
-	"github.com/apache/incubator-devlake/plugins/tapd/models"
-	"gorm.io/gorm"
-	"net/url"
-	"time"
 )
 
 const RAW_STORY_CHANGELOG_TABLE = "tapd_api_story_changelogs"
@@ -36,42 +33,30 @@ var _ core.SubTaskEntryPoint = CollectStoryChangelogs
 
 func CollectStoryChangelogs(taskCtx core.SubTaskContext) errors.Error {
 	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_STORY_CHANGELOG_TABLE, false)
-	db := taskCtx.GetDal()
 	logger := taskCtx.GetLogger()
 	logger.Info("collect storyChangelogs")
-	since := data.Since
-	incremental := false
-	if since == nil {
-		// user didn't specify a time range to sync, try load from database
-		var latestUpdated models.TapdStoryChangelog
-		clauses := []dal.Clause{
-			dal.Where("connection_id = ? and workspace_id = ?", data.Options.ConnectionId, data.Options.WorkspaceId),
-			dal.Orderby("created DESC"),
-		}
-		err := db.First(&latestUpdated, clauses...)
-		if err != nil && !goerror.Is(err, gorm.ErrRecordNotFound) {
-			return errors.NotFound.Wrap(err, "failed to get latest tapd changelog record")
-		}
-		if latestUpdated.Id > 0 {
-			since = (*time.Time)(latestUpdated.Created)
-			incremental = true
-		}
+
+	collectorWithState, err := helper.NewApiCollectorWithState(*rawDataSubTaskArgs, data.Since)
+	if err != nil {
+		return err
 	}
 
-	collector, err := helper.NewApiCollector(helper.ApiCollectorArgs{
-		RawDataSubTaskArgs: *rawDataSubTaskArgs,
-		Incremental:        incremental,
-		ApiClient:          data.ApiClient,
-		PageSize:           100,
-		UrlTemplate:        "story_changes",
+	err = collectorWithState.InitCollector(helper.ApiCollectorArgs{
+		ApiClient: data.ApiClient,
+		PageSize:  100,
+		// changelogs are never updated, the window is only extended by a day since tapd filters by date
+		TimeWindow: &helper.IncrementalTimeWindow{
+			Overlap: 24 * time.Hour,
+		},
+		UrlTemplate: "story_changes",
 		Query: func(reqData *helper.RequestData) (url.Values, errors.Error) {
 			query := url.Values{}
 			query.Set("workspace_id", fmt.Sprintf("%v", data.Options.WorkspaceId))
 			query.Set("page", fmt.Sprintf("%v", reqData.Pager.Page))
 			query.Set("limit", fmt.Sprintf("%v", reqData.Pager.Size))
 			query.Set("order", "created asc")
-			if since != nil {
-				query.Set("created", fmt.Sprintf(">%s", since.In(data.Options.CstZone).Format("2006-01-02")))
+			if reqData.Since != nil {
+				query.Set("created", fmt.Sprintf(">%s", reqData.Since.In(data.Options.CstZone).Format("2006-01-02")))
 			}
 			return query, nil
 		},
@@ -81,7 +66,7 @@ func CollectStoryChangelogs(taskCtx core.SubTaskContext) errors.Error {
 		logger.Error(err, "collect story changelog error")
 		return err
 	}
-	return collector.Execute()
+	return collectorWithState.Execute()
 }
 
 var CollectStoryChangelogMeta = core.SubTaskMeta{
@@ -126,17 +126,46 @@ func RunTask(
 		return err
 	}
 
-	err = RunPluginTask(
-		ctx,
-		config.GetConfig(),
-		log.Nested(task.Plugin),
-		db,
-		task.ID,
-		task.Plugin,
-		subtasks,
-		options,
-		progress,
-	)
+	var retryPolicy *core.RetryPolicy
+	if len(task.RetryPolicy) > 0 {
+		retryPolicy = &core.RetryPolicy{}
+		err = errors.Convert(json.Unmarshal(task.RetryPolicy, retryPolicy))
+		if err != nil {
+			return err
+		}
+	}
+
+	// failed runs are retried in place, subtasks finished by previous attempts would be skipped
+	for {
+		task.Attempts++
+		if err := db.Model(task).Update("attempts", task.Attempts).Error; err != nil {
+			return errors.Convert(err)
+		}
+		err = RunPluginTask(
+			ctx,
+			config.GetConfig(),
+			log.Nested(task.Plugin),
+			db,
+			task.ID,
+			task.Plugin,
+			subtasks,
+			options,
+			progress,
+		)
+		if ctx.Err() != nil || !retryPolicy.ShouldRetry(task.Attempts, err) {
+			break
+		}
+		backoff := retryPolicy.Backoff(task.Attempts)
+		log.Warn(err, "attempt %d of task %d failed, retrying in %s", task.Attempts, task.ID, backoff)
+		if err := db.Model(task).Update("message", err.Messages().Format()).Error; err != nil {
+			log.Error(err, "failed to record the failure of attempt %d", task.Attempts)
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+	}
 	if err != nil && task.SkipOnFail {
 		return nil
 	}
@@ -193,47 +222,9 @@ func RunPluginSubTasks(
 	log.Info("start plugin")
 	// find out all possible subtasks this plugin can offer
 	subtaskMetas := pluginTask.SubTaskMetas()
-	subtasksFlag := make(map[string]bool)
-	for _, subtaskMeta := range subtaskMetas {
-		subtasksFlag[subtaskMeta.Name] = subtaskMeta.EnabledByDefault
-	}
-	/* subtasksFlag example
-	subtasksFlag := map[string]bool{
-		"collectProject": true,
-		"convertCommits": true,
-		...
-	}
-	*/
-
-	// user specifies what subtasks to run
-	if len(subtaskNames) != 0 {
-		// decode user specified subtasks
-		var specifiedTasks []string
-		err := helper.Decode(subtaskNames, &specifiedTasks, nil)
-		if err != nil {
-			return errors.Default.Wrap(err, "subtasks could not be decoded")
-		}
-		if len(specifiedTasks) > 0 {
-			// first, disable all subtasks
-			for task := range subtasksFlag {
-				subtasksFlag[task] = false
-			}
-			// second, check specified subtasks is valid and enable them if so
-			for _, task := range specifiedTasks {
-				if _, ok := subtasksFlag[task]; ok {
-					subtasksFlag[task] = true
-				} else {
-					return errors.Default.New(fmt.Sprintf("subtask %s does not exist", task))
-				}
-			}
-		}
-	}
-
-	// make sure `Required` subtasks are always enabled
-	for _, subtaskMeta := range subtaskMetas {
-		if subtaskMeta.Required {
-			subtasksFlag[subtaskMeta.Name] = true
-		}
+	subtasksFlag, err := GetSubtasksFlag(subtaskMetas, subtaskNames)
+	if err != nil {
+		return err
 	}
 
 	// calculate total step(number of task to run)
@@ -245,6 +236,9 @@ func RunPluginSubTasks(
 	}
 
 	taskCtx := helper.NewDefaultTaskContext(ctx, cfg, log, db, name, subtasksFlag, progress)
+	if connectionCtx, ok := taskCtx.(*helper.DefaultTaskContext); ok {
+		connectionCtx.SetConnectionKey(helper.GetConnectionKey(name, options))
+	}
 	if closeablePlugin, ok := pluginTask.(core.CloseablePluginTask); ok {
 		defer closeablePlugin.Close(taskCtx)
 	}
@@ -254,6 +248,12 @@ func RunPluginSubTasks(
 	}
 	taskCtx.SetData(taskData)
 
+	// figure out which subtasks were finished by the failed task this one was spawned from
+	resume, err := loadResumeState(db, taskID)
+	if err != nil {
+		return errors.Default.Wrap(err, fmt.Sprintf("error loading resume state for task %d", taskID))
+	}
+
 	// execute subtasks in order
 	taskCtx.SetProgress(0, steps)
 	subtaskNumber := 0
@@ -268,6 +268,18 @@ func RunPluginSubTasks(
 			continue
 		}
 
+		// skip subtasks finished by previous attempts
+		skip, since := resume.next(&subtaskMeta)
+		if skip {
+			log.Info("skipping subtask %s which was finished by previous attempt", subtaskMeta.Name)
+			subtaskNumber++
+			taskCtx.IncProgress(1)
+			continue
+		}
+		if resumable, ok := subtaskCtx.(*helper.DefaultSubTaskContext); ok && since != nil {
+			resumable.SetResumeSince(since)
+		}
+
 		// run subtask
 		log.Info("executing subtask %s", subtaskMeta.Name)
 		subtaskNumber++
@@ -287,9 +299,65 @@ func RunPluginSubTasks(
 		taskCtx.IncProgress(1)
 	}
 
+	// apply raw data retention policies once extractors are done with the raw data collected by the task
+	if defaultTaskCtx, ok := taskCtx.(*helper.DefaultTaskContext); ok {
+		err = helper.CompactRawData(defaultTaskCtx)
+		if err != nil {
+			log.Warn(err, "failed to compact raw data")
+		}
+	}
+
 	return nil
 }
 
+// GetSubtasksFlag figures out which subtasks are going to be executed, subtasks are enabled by default
+// according to their `EnabledByDefault` unless user specifies what to run, `Required` ones are always enabled
+func GetSubtasksFlag(subtaskMetas []core.SubTaskMeta, subtaskNames []string) (map[string]bool, errors.Error) {
+	subtasksFlag := make(map[string]bool)
+	for _, subtaskMeta := range subtaskMetas {
+		subtasksFlag[subtaskMeta.Name] = subtaskMeta.EnabledByDefault
+	}
+	/* subtasksFlag example
+	subtasksFlag := map[string]bool{
+		"collectProject": true,
+		"convertCommits": true,
+		...
+	}
+	*/
+
+	// user specifies what subtasks to run
+	if len(subtaskNames) != 0 {
+		// decode user specified subtasks
+		var specifiedTasks []string
+		err := helper.Decode(subtaskNames, &specifiedTasks, nil)
+		if err != nil {
+			return nil, errors.Default.Wrap(err, "subtasks could not be decoded")
+		}
+		if len(specifiedTasks) > 0 {
+			// first, disable all subtasks
+			for task := range subtasksFlag {
+				subtasksFlag[task] = false
+			}
+			// second, check specified subtasks is valid and enable them if so
+			for _, task := range specifiedTasks {
+				if _, ok := subtasksFlag[task]; ok {
+					subtasksFlag[task] = true
+				} else {
+					return nil, errors.Default.New(fmt.Sprintf("subtask %s does not exist", task))
+				}
+			}
+		}
+	}
+
+	// make sure `Required` subtasks are always enabled
+	for _, subtaskMeta := range subtaskMetas {
+		if subtaskMeta.Required {
+			subtasksFlag[subtaskMeta.Name] = true
+		}
+	}
+	return subtasksFlag, nil
+}
+
 // UpdateProgressDetail FIXME ...
 func UpdateProgressDetail(db *gorm.DB, log core.Logger, taskId uint64, progressDetail *models.TaskProgressDetail, p *core.RunningProgress) {
 	task := &models.Task{}
@@ -324,7 +392,7 @@ func runSubtask(
 	subtaskNumber int,
 	ctx core.SubTaskContext,
 	entryPoint core.SubTaskEntryPoint,
-) errors.Error {
+) (err errors.Error) {
 	beginAt := time.Now()
 	subtask := &models.Subtask{
 		Name:    ctx.GetName(),
@@ -332,13 +400,104 @@ func runSubtask(
 		Number:  subtaskNumber,
 		BeganAt: &beginAt,
 	}
+	returned := false
 	defer func() {
 		finishedAt := time.Now()
 		subtask.FinishedAt = &finishedAt
 		subtask.SpentSeconds = finishedAt.Unix() - beginAt.Unix()
+		subtask.Status = models.TASK_COMPLETED
+		// a panicking entryPoint never returns, it must not be skipped when the task is resumed
+		if err != nil || !returned {
+			subtask.Status = models.TASK_FAILED
+		}
+		if recorder, ok := ctx.(*helper.DefaultSubTaskContext); ok {
+			subtask.ApiCalls = recorder.GetApiCalls()
+		}
 		recordSubtask(log, db, subtask)
 	}()
-	return entryPoint(ctx)
+	err = entryPoint(ctx)
+	returned = true
+	return err
+}
+
+// resumeState holds the progress made by previous attempts of a task
+type resumeState struct {
+	// finished subtasks which could be skipped
+	finished map[string]bool
+	// began time of the first failed attempt of unfinished subtasks
+	since map[string]*time.Time
+	// whether the first unfinished subtask has been reached
+	resumed bool
+}
+
+// next is called for the subtasks to be executed in order, it tells whether the subtask was finished by previous
+// attempts and could be skipped, and when the failed attempts of the first unfinished subtask began. Subtasks are
+// only skipped until the first unfinished one, and `Required` ones are always executed since they might be preparing
+// task data for the following subtasks
+func (s *resumeState) next(subtaskMeta *core.SubTaskMeta) (bool, *time.Time) {
+	if s == nil || s.resumed || subtaskMeta.Required {
+		return false, nil
+	}
+	if s.finished[subtaskMeta.Name] {
+		return true, nil
+	}
+	s.resumed = true
+	return false, s.since[subtaskMeta.Name]
+}
+
+// loadResumeState walks through the chain of failed tasks the specified task was spawned from and gathers
+// their subtasks, nil would be returned if the task is not resuming anything
+func loadResumeState(db *gorm.DB, taskId uint64) (*resumeState, errors.Error) {
+	if taskId == 0 {
+		return nil, nil
+	}
+	task := &models.Task{}
+	if err := db.First(task, taskId).Error; err != nil {
+		return nil, errors.Convert(err)
+	}
+	var attemptIds []uint64
+	// the task itself is being retried
+	if task.Attempts > 1 {
+		attemptIds = append(attemptIds, task.ID)
+	}
+	for id := task.ResumeFrom; id != 0; id = task.ResumeFrom {
+		attemptIds = append(attemptIds, id)
+		task = &models.Task{}
+		if err := db.First(task, id).Error; err != nil {
+			return nil, errors.Convert(err)
+		}
+	}
+	if len(attemptIds) == 0 {
+		return nil, nil
+	}
+	var attempts []models.Subtask
+	if err := db.Where("task_id IN ?", attemptIds).Order("id").Find(&attempts).Error; err != nil {
+		return nil, errors.Convert(err)
+	}
+	return newResumeState(attempts), nil
+}
+
+func newResumeState(attempts []models.Subtask) *resumeState {
+	state := &resumeState{
+		finished: make(map[string]bool),
+		since:    make(map[string]*time.Time),
+	}
+	for _, attempt := range attempts {
+		if attempt.Status == models.TASK_COMPLETED {
+			state.finished[attempt.Name] = true
+			continue
+		}
+		if attempt.BeganAt == nil {
+			continue
+		}
+		if since := state.since[attempt.Name]; since == nil || attempt.BeganAt.Before(*since) {
+			state.since[attempt.Name] = attempt.BeganAt
+		}
+	}
+	for name := range state.finished {
+		delete(state.since, name)
+	}
+	return state
 }
 
 func recordSubtask(log core.Logger, db *gorm.DB, subtask *models.Subtask) {
@@ -0,0 +1,176 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/logger"
+	"github.com/apache/incubator-devlake/models"
+	"github.com/apache/incubator-devlake/plugins/core"
+	githubGraphqlImpl "github.com/apache/incubator-devlake/plugins/github_graphql/impl"
+	"github.com/apache/incubator-devlake/plugins/helper"
+	jenkinsImpl "github.com/apache/incubator-devlake/plugins/jenkins/impl"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func TestNewResumeState(t *testing.T) {
+	t1 := time.Date(2022, 12, 8, 1, 0, 0, 0, time.UTC)
+	t2 := t1.Add(time.Hour)
+	t3 := t2.Add(time.Hour)
+	state := newResumeState([]models.Subtask{
+		// first attempt failed at collectIssues
+		{Name: "collectProject", Status: models.TASK_COMPLETED, BeganAt: &t1},
+		{Name: "collectIssues", Status: models.TASK_FAILED, BeganAt: &t1},
+		// second attempt finished collectIssues and failed at extractIssues
+		{Name: "collectIssues", Status: models.TASK_COMPLETED, BeganAt: &t2},
+		{Name: "extractIssues", Status: models.TASK_FAILED, BeganAt: &t2},
+		// third attempt failed at extractIssues again
+		{Name: "extractIssues", Status: models.TASK_FAILED, BeganAt: &t3},
+		// subtasks recorded before status was introduced are considered unfinished
+		{Name: "convertIssues", BeganAt: nil},
+	})
+	assert.True(t, state.finished["collectProject"])
+	assert.True(t, state.finished["collectIssues"])
+	assert.False(t, state.finished["extractIssues"])
+	assert.False(t, state.finished["convertIssues"])
+	assert.Nil(t, state.since["collectIssues"])
+	assert.Equal(t, &t2, state.since["extractIssues"])
+	assert.Nil(t, state.since["convertIssues"])
+}
+
+func TestGetSubtasksFlag(t *testing.T) {
+	subtaskMetas := []core.SubTaskMeta{
+		{Name: "prepare", Required: true},
+		{Name: "collectIssues", EnabledByDefault: true},
+		{Name: "extractIssues", EnabledByDefault: true},
+		{Name: "collectChangelogs", EnabledByDefault: false},
+	}
+
+	flag, err := GetSubtasksFlag(subtaskMetas, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]bool{
+		"prepare":           true,
+		"collectIssues":     true,
+		"extractIssues":     true,
+		"collectChangelogs": false,
+	}, flag)
+
+	flag, err = GetSubtasksFlag(subtaskMetas, []string{"collectChangelogs"})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]bool{
+		"prepare":           true,
+		"collectIssues":     false,
+		"extractIssues":     false,
+		"collectChangelogs": true,
+	}, flag)
+
+	_, err = GetSubtasksFlag(subtaskMetas, []string{"collectNothing"})
+	assert.NotNil(t, err)
+}
+
+// resumeSubtasks returns the subtasks to be executed when resuming with `state`
+func resumeSubtasks(state *resumeState, subtaskMetas []core.SubTaskMeta) ([]string, *time.Time) {
+	var executed []string
+	var resumeSince *time.Time
+	for i := range subtaskMetas {
+		skip, since := state.next(&subtaskMetas[i])
+		if skip {
+			continue
+		}
+		if since != nil {
+			resumeSince = since
+		}
+		executed = append(executed, subtaskMetas[i].Name)
+	}
+	return executed, resumeSince
+}
+
+func TestResumeAfterSubtasksPreparingTaskData(t *testing.T) {
+	t1 := time.Date(2022, 12, 8, 1, 0, 0, 0, time.UTC)
+
+	// CollectRepo sets the repo into the task data, which is needed by the following collectors
+	var githubGraphql githubGraphqlImpl.GithubGraphql
+	state := newResumeState([]models.Subtask{
+		{Name: "CollectRepo", Status: models.TASK_COMPLETED, BeganAt: &t1},
+		{Name: "CollectMilestone", Status: models.TASK_COMPLETED, BeganAt: &t1},
+		{Name: "CollectIssue", Status: models.TASK_FAILED, BeganAt: &t1},
+	})
+	executed, since := resumeSubtasks(state, githubGraphql.SubTaskMetas())
+	assert.Equal(t, "CollectRepo", executed[0])
+	assert.Equal(t, "CollectIssue", executed[1])
+	assert.NotContains(t, executed, "CollectMilestone")
+	assert.Equal(t, &t1, since)
+
+	// extractApiJobs sets the full name and class of the job into the options, which decide how builds are collected
+	var jenkins jenkinsImpl.Jenkins
+	state = newResumeState([]models.Subtask{
+		{Name: "collectApiJobs", Status: models.TASK_COMPLETED, BeganAt: &t1},
+		{Name: "extractApiJobs", Status: models.TASK_COMPLETED, BeganAt: &t1},
+		{Name: "convertJobs", Status: models.TASK_COMPLETED, BeganAt: &t1},
+		{Name: "collectApiBuilds", Status: models.TASK_FAILED, BeganAt: &t1},
+	})
+	executed, _ = resumeSubtasks(state, jenkins.SubTaskMetas())
+	assert.Equal(t, []string{"collectApiJobs", "extractApiJobs", "collectApiBuilds"}, executed[:3])
+
+	// nothing is skipped if the task is not resuming
+	executed, since = resumeSubtasks(nil, jenkins.SubTaskMetas())
+	assert.Len(t, executed, len(jenkins.SubTaskMetas()))
+	assert.Nil(t, since)
+}
+
+func TestRunSubtaskRecordsPanicAsFailed(t *testing.T) {
+	db, err := gorm.Open(mysql.New(mysql.Config{SkipInitializeWithVersion: true}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+	})
+	assert.Nil(t, err)
+	var recorded []*models.Subtask
+	err = db.Callback().Create().Before("gorm:create").Register("test:record_subtask", func(tx *gorm.DB) {
+		recorded = append(recorded, *tx.Statement.Dest.(**models.Subtask))
+	})
+	assert.Nil(t, err)
+
+	taskCtx := helper.NewDefaultTaskContext(context.Background(), nil, logger.Global, db, "test", map[string]bool{"collectIssues": true}, nil)
+	subtaskCtx, e := taskCtx.SubTaskContext("collectIssues")
+	assert.Nil(t, e)
+
+	assert.Panics(t, func() {
+		_ = runSubtask(logger.Global, db, 1, 1, subtaskCtx, func(core.SubTaskContext) errors.Error {
+			panic("something went wrong")
+		})
+	})
+	e = runSubtask(logger.Global, db, 1, 2, subtaskCtx, func(core.SubTaskContext) errors.Error {
+		return errors.Default.New("something went wrong")
+	})
+	assert.NotNil(t, e)
+	e = runSubtask(logger.Global, db, 1, 3, subtaskCtx, func(core.SubTaskContext) errors.Error {
+		return nil
+	})
+	assert.Nil(t, e)
+
+	assert.Len(t, recorded, 3)
+	assert.Equal(t, models.TASK_FAILED, recorded[0].Status)
+	assert.Equal(t, models.TASK_FAILED, recorded[1].Status)
+	assert.Equal(t, models.TASK_COMPLETED, recorded[2].Status)
+}
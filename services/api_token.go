@@ -0,0 +1,166 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	goerror "errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/config"
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/models"
+	"gorm.io/gorm"
+)
+
+// AuthEnabledEnvStr turns on authentication and authorization of the REST API
+const AuthEnabledEnvStr = "AUTH_ENABLED"
+
+// AdminTokenEnvStr specifies a token granted the admin role, it works even before the database is migrated so the
+// migration could be confirmed and other tokens could be created
+const AdminTokenEnvStr = "API_ADMIN_TOKEN"
+
+// ADMIN_TOKEN_NAME is the name of the token specified by API_ADMIN_TOKEN
+const ADMIN_TOKEN_NAME = "admin"
+
+// NewApiToken is the input for creating an ApiToken
+type NewApiToken struct {
+	Name      string     `json:"name" validate:"required"`
+	Role      string     `json:"role" validate:"required,oneof=viewer operator admin"`
+	ExpiredAt *time.Time `json:"expiredAt"`
+}
+
+// CreatedApiToken carries the plain token which would not be retrievable afterward
+type CreatedApiToken struct {
+	models.ApiToken
+	Token string `json:"token"`
+}
+
+// IsAuthEnabled returns if authentication is required for the REST API
+func IsAuthEnabled() bool {
+	return config.GetConfig().GetBool(AuthEnabledEnvStr)
+}
+
+// IsApiTokenAvailable returns if any api token could be authenticated, which is not the case before api tokens
+// are migrated unless API_ADMIN_TOKEN is set
+func IsApiTokenAvailable() bool {
+	return config.GetConfig().GetString(AdminTokenEnvStr) != "" || db.Migrator().HasTable(&models.ApiToken{})
+}
+
+// CreateApiToken generates a random token with the specified role
+func CreateApiToken(newToken *NewApiToken, createdBy string) (*CreatedApiToken, errors.Error) {
+	err := vld.Struct(newToken)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "invalid api token")
+	}
+	if newToken.Name == ADMIN_TOKEN_NAME {
+		return nil, errors.BadInput.New(fmt.Sprintf("name %s is reserved", ADMIN_TOKEN_NAME))
+	}
+	randomBytes := make([]byte, 32)
+	_, err = rand.Read(randomBytes)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "failed to generate api token")
+	}
+	token := hex.EncodeToString(randomBytes)
+	apiToken := &CreatedApiToken{
+		ApiToken: models.ApiToken{
+			Name:      newToken.Name,
+			Role:      newToken.Role,
+			TokenHash: hashApiToken(token),
+			ExpiredAt: newToken.ExpiredAt,
+			CreatedBy: createdBy,
+		},
+		Token: token,
+	}
+	err = db.Create(&apiToken.ApiToken).Error
+	if err != nil {
+		if strings.Contains(strings.ToLower(err.Error()), "duplicate") {
+			return nil, errors.BadInput.Wrap(err, "duplicated api token name")
+		}
+		return nil, errors.Default.Wrap(err, "error creating api token")
+	}
+	recordAudit(createdBy, AUDIT_ACTION_CREATE, "api_token", apiToken.ID, nil, apiToken.ApiToken)
+	return apiToken, nil
+}
+
+// GetApiTokens returns all api tokens
+func GetApiTokens() ([]models.ApiToken, errors.Error) {
+	tokens := make([]models.ApiToken, 0)
+	err := db.Order("id").Find(&tokens).Error
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error loading api tokens")
+	}
+	return tokens, nil
+}
+
+// DeleteApiToken revokes the api token
+func DeleteApiToken(id uint64, actor string) errors.Error {
+	apiToken := &models.ApiToken{}
+	err := db.First(apiToken, id).Error
+	if goerror.Is(err, gorm.ErrRecordNotFound) {
+		return errors.NotFound.New("api token not found")
+	}
+	if err != nil {
+		return errors.Default.Wrap(err, "error loading api token")
+	}
+	err = db.Delete(apiToken).Error
+	if err != nil {
+		return errors.Default.Wrap(err, "error deleting api token")
+	}
+	recordAudit(actor, AUDIT_ACTION_DELETE, "api_token", id, apiToken, nil)
+	return nil
+}
+
+// AuthenticateApiToken returns the ApiToken matching the plain token, errors.Unauthorized would be returned
+// if the token is unknown or expired
+func AuthenticateApiToken(token string) (*models.ApiToken, errors.Error) {
+	adminToken := config.GetConfig().GetString(AdminTokenEnvStr)
+	if adminToken != "" && subtle.ConstantTimeCompare([]byte(token), []byte(adminToken)) == 1 {
+		return &models.ApiToken{Name: ADMIN_TOKEN_NAME, Role: models.ROLE_ADMIN}, nil
+	}
+	apiToken := &models.ApiToken{}
+	err := db.First(apiToken, "token_hash = ?", hashApiToken(token)).Error
+	if goerror.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.Unauthorized.New("invalid api token")
+	}
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error loading api token")
+	}
+	now := time.Now()
+	if apiToken.ExpiredAt != nil && apiToken.ExpiredAt.Before(now) {
+		return nil, errors.Unauthorized.New("api token expired")
+	}
+	// no need to be precise, avoid writing to db on every request
+	if apiToken.LastUsedAt == nil || now.Sub(*apiToken.LastUsedAt) > time.Minute {
+		err = db.Model(apiToken).UpdateColumn("last_used_at", now).Error
+		if err != nil {
+			log.Warn(err, "failed to update last_used_at of api token %s", apiToken.Name)
+		}
+	}
+	return apiToken, nil
+}
+
+func hashApiToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
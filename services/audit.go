@@ -0,0 +1,282 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/models"
+	"github.com/apache/incubator-devlake/plugins/helper"
+)
+
+const (
+	AUDIT_ACTION_CREATE = "create"
+	AUDIT_ACTION_UPDATE = "update"
+	AUDIT_ACTION_DELETE = "delete"
+	AUDIT_ACTION_ROTATE = "rotate"
+)
+
+// keys containing any of these words are considered credentials, their values are replaced by hashes in audit logs,
+// so changes remain visible without leaking them
+var auditMaskedKeywords = []string{"password", "token", "secret"}
+
+// AuditLogQuery filters audit logs
+type AuditLogQuery struct {
+	Actor      string    `form:"actor"`
+	EntityType string    `form:"entityType"`
+	EntityId   string    `form:"entityId"`
+	Since      time.Time `form:"since" time_format:"2006-01-02T15:04:05Z07:00"`
+	Until      time.Time `form:"until" time_format:"2006-01-02T15:04:05Z07:00"`
+	Page       int       `form:"page"`
+	PageSize   int       `form:"pageSize"`
+}
+
+// AuditChange is the change of a field, nested fields are joined by dots, e.g. `settings.version`
+type AuditChange struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// AuditLogOutput is an AuditLog with its snapshots decrypted and the diff between them
+type AuditLogOutput struct {
+	ID         uint64                  `json:"id"`
+	CreatedAt  time.Time               `json:"createdAt"`
+	Actor      string                  `json:"actor"`
+	Action     string                  `json:"action"`
+	EntityType string                  `json:"entityType"`
+	EntityId   string                  `json:"entityId"`
+	Before     json.RawMessage         `json:"before"`
+	After      json.RawMessage         `json:"after"`
+	Diff       map[string]*AuditChange `json:"diff"`
+}
+
+// RecordAudit records the mutation of the entity made by actor, before/after could be anything json marshallable,
+// use auditSnapshot to capture the state of an entity which is going to be modified in place
+func RecordAudit(actor string, action string, entityType string, entityId string, before interface{}, after interface{}) errors.Error {
+	store, err := helper.GetSecretStore(basicRes)
+	if err != nil {
+		return err
+	}
+	auditLog := &models.AuditLog{
+		Actor:      actor,
+		Action:     action,
+		EntityType: entityType,
+		EntityId:   entityId,
+	}
+	for _, field := range []struct {
+		value  interface{}
+		target *string
+	}{{before, &auditLog.Before}, {after, &auditLog.After}} {
+		snapshot, err := maskAuditSnapshot(field.value)
+		if err != nil {
+			return err
+		}
+		if snapshot == "" {
+			continue
+		}
+		*field.target, err = store.Encrypt(snapshot)
+		if err != nil {
+			return err
+		}
+	}
+	err = errors.Convert(db.Create(auditLog).Error)
+	if err != nil {
+		return errors.Default.Wrap(err, "error saving audit log")
+	}
+	return nil
+}
+
+// recordAudit is RecordAudit for mutations which have been done already, failures are logged instead of returned
+func recordAudit(actor string, action string, entityType string, entityId interface{}, before interface{}, after interface{}) {
+	id := fmt.Sprintf("%v", entityId)
+	err := RecordAudit(actor, action, entityType, id, before, after)
+	if err != nil {
+		log.Error(err, "failed to record audit log for %s %s %s", action, entityType, id)
+	}
+}
+
+// auditSnapshot captures the current state of v
+func auditSnapshot(v interface{}) json.RawMessage {
+	snapshot, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	return snapshot
+}
+
+// GetAuditLogs returns audit logs matching the query, latest first
+func GetAuditLogs(query *AuditLogQuery) ([]*AuditLogOutput, int64, errors.Error) {
+	dbQuery := db.Model(&models.AuditLog{}).Order("id DESC")
+	if query.Actor != "" {
+		dbQuery = dbQuery.Where("actor = ?", query.Actor)
+	}
+	if query.EntityType != "" {
+		dbQuery = dbQuery.Where("entity_type = ?", query.EntityType)
+	}
+	if query.EntityId != "" {
+		dbQuery = dbQuery.Where("entity_id = ?", query.EntityId)
+	}
+	if !query.Since.IsZero() {
+		dbQuery = dbQuery.Where("created_at >= ?", query.Since)
+	}
+	if !query.Until.IsZero() {
+		dbQuery = dbQuery.Where("created_at < ?", query.Until)
+	}
+	var count int64
+	err := dbQuery.Count(&count).Error
+	if err != nil {
+		return nil, 0, errors.Default.Wrap(err, "error counting audit logs")
+	}
+	auditLogs := make([]*models.AuditLog, 0)
+	err = processDbClausesWithPager(dbQuery, query.PageSize, query.Page).Find(&auditLogs).Error
+	if err != nil {
+		return nil, 0, errors.Default.Wrap(err, "error finding audit logs")
+	}
+	store, e := helper.GetSecretStore(basicRes)
+	if e != nil {
+		return nil, 0, e
+	}
+	outputs := make([]*AuditLogOutput, 0, len(auditLogs))
+	for _, auditLog := range auditLogs {
+		output := &AuditLogOutput{
+			ID:         auditLog.ID,
+			CreatedAt:  auditLog.CreatedAt,
+			Actor:      auditLog.Actor,
+			Action:     auditLog.Action,
+			EntityType: auditLog.EntityType,
+			EntityId:   auditLog.EntityId,
+		}
+		for _, field := range []struct {
+			value  string
+			target *json.RawMessage
+		}{{auditLog.Before, &output.Before}, {auditLog.After, &output.After}} {
+			if field.value == "" {
+				continue
+			}
+			snapshot, e := store.Decrypt(field.value)
+			if e != nil {
+				return nil, 0, errors.Default.Wrap(e, fmt.Sprintf("error decrypting audit log %d", auditLog.ID))
+			}
+			*field.target = json.RawMessage(snapshot)
+		}
+		output.Diff, e = diffAuditSnapshots(output.Before, output.After)
+		if e != nil {
+			return nil, 0, errors.Default.Wrap(e, fmt.Sprintf("error comparing snapshots of audit log %d", auditLog.ID))
+		}
+		outputs = append(outputs, output)
+	}
+	return outputs, count, nil
+}
+
+// maskAuditSnapshot converts v to json with credentials masked, empty string would be returned if v is nil
+func maskAuditSnapshot(v interface{}) (string, errors.Error) {
+	if v == nil {
+		return "", nil
+	}
+	var generic interface{}
+	err := errors.Convert(json.Unmarshal(auditSnapshot(v), &generic))
+	if err != nil || generic == nil {
+		return "", err
+	}
+	masked, err := errors.Convert01(json.Marshal(maskAuditValue("", generic)))
+	if err != nil {
+		return "", err
+	}
+	return string(masked), nil
+}
+
+func maskAuditValue(key string, value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for k, child := range v {
+			v[k] = maskAuditValue(k, child)
+		}
+		return v
+	case []interface{}:
+		for i, child := range v {
+			v[i] = maskAuditValue(key, child)
+		}
+		return v
+	case string:
+		lowerKey := strings.ToLower(key)
+		for _, keyword := range auditMaskedKeywords {
+			if v != "" && strings.Contains(lowerKey, keyword) {
+				sum := sha256.Sum256([]byte(v))
+				return "sha256:" + hex.EncodeToString(sum[:])[:8]
+			}
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// diffAuditSnapshots returns changed fields between before and after, nested objects are flattened
+func diffAuditSnapshots(before, after json.RawMessage) (map[string]*AuditChange, errors.Error) {
+	beforeFields := make(map[string]interface{})
+	afterFields := make(map[string]interface{})
+	for _, pair := range []struct {
+		snapshot json.RawMessage
+		fields   map[string]interface{}
+	}{{before, beforeFields}, {after, afterFields}} {
+		if len(pair.snapshot) == 0 {
+			continue
+		}
+		var generic interface{}
+		err := errors.Convert(json.Unmarshal(pair.snapshot, &generic))
+		if err != nil {
+			return nil, err
+		}
+		flattenAuditValue("", generic, pair.fields)
+	}
+	diff := make(map[string]*AuditChange)
+	for key, beforeValue := range beforeFields {
+		afterValue, ok := afterFields[key]
+		if !ok || !reflect.DeepEqual(beforeValue, afterValue) {
+			diff[key] = &AuditChange{Before: beforeValue, After: afterValue}
+		}
+	}
+	for key, afterValue := range afterFields {
+		if _, ok := beforeFields[key]; !ok {
+			diff[key] = &AuditChange{After: afterValue}
+		}
+	}
+	return diff, nil
+}
+
+func flattenAuditValue(prefix string, value interface{}, fields map[string]interface{}) {
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		fields[prefix] = value
+		return
+	}
+	for k, child := range object {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+		flattenAuditValue(key, child, fields)
+	}
+}
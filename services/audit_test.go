@@ -0,0 +1,61 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaskAuditSnapshot(t *testing.T) {
+	snapshot, err := maskAuditSnapshot(map[string]interface{}{
+		"name":  "gitlab",
+		"token": "glpat-xxx",
+		"auth":  map[string]interface{}{"password": "pass", "username": "user"},
+	})
+	assert.Nil(t, err)
+	masked := make(map[string]interface{})
+	assert.Nil(t, json.Unmarshal([]byte(snapshot), &masked))
+	assert.Equal(t, "gitlab", masked["name"])
+	assert.NotEqual(t, "glpat-xxx", masked["token"])
+	assert.Contains(t, masked["token"], "sha256:")
+	assert.NotEqual(t, "pass", masked["auth"].(map[string]interface{})["password"])
+	assert.Equal(t, "user", masked["auth"].(map[string]interface{})["username"])
+
+	snapshot, err = maskAuditSnapshot(nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "", snapshot)
+}
+
+func TestDiffAuditSnapshots(t *testing.T) {
+	diff, err := diffAuditSnapshots(
+		json.RawMessage(`{"name": "bp", "enable": true, "settings": {"version": "1.0.0", "connections": [1]}}`),
+		json.RawMessage(`{"name": "bp", "enable": false, "settings": {"version": "1.0.0", "connections": [1, 2]}, "labels": ["a"]}`),
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(diff))
+	assert.Equal(t, &AuditChange{Before: true, After: false}, diff["enable"])
+	assert.Equal(t, &AuditChange{Before: []interface{}{1.0}, After: []interface{}{1.0, 2.0}}, diff["settings.connections"])
+	assert.Equal(t, &AuditChange{After: []interface{}{"a"}}, diff["labels"])
+
+	diff, err = diffAuditSnapshots(nil, json.RawMessage(`{"name": "bp"}`))
+	assert.Nil(t, err)
+	assert.Equal(t, &AuditChange{After: "bp"}, diff["name"])
+}
@@ -43,13 +43,16 @@ type BlueprintQuery struct {
 	Label    string `form:"label"`
 }
 
+// PIPELINE_TRIGGERED_BY_CRON is recorded as the TriggeredBy of pipelines created by blueprint cron jobs
+const PIPELINE_TRIGGERED_BY_CRON = "cron"
+
 var (
 	blueprintLog = logger.Global.Nested("blueprint")
 	vld          = validator.New()
 )
 
 // CreateBlueprint accepts a Blueprint instance and insert it to database
-func CreateBlueprint(blueprint *models.Blueprint) errors.Error {
+func CreateBlueprint(blueprint *models.Blueprint, actor string) errors.Error {
 	err := validateBlueprintAndMakePlan(blueprint)
 	if err != nil {
 		return err
@@ -64,6 +67,7 @@ func CreateBlueprint(blueprint *models.Blueprint) errors.Error {
 		return err
 	}
 	blueprint.Model = dbBlueprint.Model
+	recordAudit(actor, AUDIT_ACTION_CREATE, "blueprint", blueprint.ID, nil, blueprint)
 	err = ReloadBlueprints(cronManager)
 	if err != nil {
 		return errors.Internal.Wrap(err, "error reloading blueprints")
@@ -125,6 +129,25 @@ func GetBlueprintByProjectName(projectName string) (*models.Blueprint, errors.Er
 }
 
 func validateBlueprintAndMakePlan(blueprint *models.Blueprint) errors.Error {
+	err := validateBlueprint(blueprint)
+	if err != nil {
+		return err
+	}
+	if blueprint.Mode == models.BLUEPRINT_MODE_NORMAL {
+		plan, err := MakePlanForBlueprint(blueprint)
+		if err != nil {
+			return errors.Default.Wrap(err, "invalid plan")
+		}
+		blueprint.Plan, err = errors.Convert01(json.Marshal(plan))
+		if err != nil {
+			return errors.Default.Wrap(err, "failed to markshal plan")
+		}
+	}
+	return nil
+}
+
+// validateBlueprint validates the blueprint without making the plan for NORMAL mode
+func validateBlueprint(blueprint *models.Blueprint) errors.Error {
 	// validation
 	err := vld.Struct(blueprint)
 	if err != nil {
@@ -157,14 +180,12 @@ func validateBlueprintAndMakePlan(blueprint *models.Blueprint) errors.Error {
 		if len(plan) == 0 || len(plan[0]) == 0 {
 			return errors.Default.New("empty plan")
 		}
-	} else if blueprint.Mode == models.BLUEPRINT_MODE_NORMAL {
-		plan, err := MakePlanForBlueprint(blueprint)
-		if err != nil {
-			return errors.Default.Wrap(err, "invalid plan")
-		}
-		blueprint.Plan, err = errors.Convert01(json.Marshal(plan))
-		if err != nil {
-			return errors.Default.Wrap(err, "failed to markshal plan")
+		for _, stage := range plan {
+			for _, task := range stage {
+				if err := task.RetryPolicy.Validate(); err != nil {
+					return err
+				}
+			}
 		}
 	}
 
@@ -199,7 +220,7 @@ func saveBlueprint(blueprint *models.Blueprint) (*models.Blueprint, errors.Error
 }
 
 // PatchBlueprintEnableByProjectName FIXME ...
-func PatchBlueprintEnableByProjectName(projectName string, enable bool) (*models.Blueprint, errors.Error) {
+func PatchBlueprintEnableByProjectName(projectName string, enable bool, actor string) (*models.Blueprint, errors.Error) {
 	blueprint, err := GetBlueprintByProjectName(projectName)
 	if err != nil {
 		return nil, err
@@ -209,23 +230,26 @@ func PatchBlueprintEnableByProjectName(projectName string, enable bool) (*models
 		return nil, errors.Default.New(fmt.Sprintf("do not surpport to set enable for projectName:[%s] ,because it has no blueprint.", projectName))
 	}
 
+	before := auditSnapshot(blueprint)
 	blueprint.Enable = enable
 
 	blueprint, err = saveBlueprint(blueprint)
 	if err != nil {
 		return nil, err
 	}
+	recordAudit(actor, AUDIT_ACTION_UPDATE, "blueprint", blueprint.ID, before, blueprint)
 
 	return blueprint, nil
 }
 
 // PatchBlueprint FIXME ...
-func PatchBlueprint(id uint64, body map[string]interface{}) (*models.Blueprint, errors.Error) {
+func PatchBlueprint(id uint64, body map[string]interface{}, actor string) (*models.Blueprint, errors.Error) {
 	// load record from db
 	blueprint, err := GetBlueprint(id)
 	if err != nil {
 		return nil, err
 	}
+	before := auditSnapshot(blueprint)
 
 	originMode := blueprint.Mode
 	err = helper.DecodeMapStruct(body, blueprint)
@@ -241,16 +265,22 @@ func PatchBlueprint(id uint64, body map[string]interface{}) (*models.Blueprint,
 	if err != nil {
 		return nil, err
 	}
+	recordAudit(actor, AUDIT_ACTION_UPDATE, "blueprint", blueprint.ID, before, blueprint)
 
 	return blueprint, nil
 }
 
 // DeleteBlueprint FIXME ...
-func DeleteBlueprint(id uint64) errors.Error {
-	err := DeleteDbBlueprint(id)
+func DeleteBlueprint(id uint64, actor string) errors.Error {
+	blueprint, err := GetBlueprint(id)
+	if err != nil {
+		return err
+	}
+	err = DeleteDbBlueprint(id)
 	if err != nil {
 		return errors.Internal.Wrap(err, fmt.Sprintf("error deleting blueprint %d", id))
 	}
+	recordAudit(actor, AUDIT_ACTION_DELETE, "blueprint", id, blueprint, nil)
 	err = ReloadBlueprints(cronManager)
 	if err != nil {
 		return errors.Internal.Wrap(err, "error reloading blueprints")
@@ -281,7 +311,7 @@ func ReloadBlueprints(c *cron.Cron) errors.Error {
 			return err
 		}
 		if _, err := c.AddFunc(blueprint.CronConfig, func() {
-			pipeline, err := createPipelineByBlueprint(blueprint)
+			pipeline, err := createPipelineByBlueprint(blueprint, PIPELINE_TRIGGERED_BY_CRON)
 			if err != nil {
 				blueprintLog.Error(err, "run cron job failed")
 			} else {
@@ -299,7 +329,7 @@ func ReloadBlueprints(c *cron.Cron) errors.Error {
 	return nil
 }
 
-func createPipelineByBlueprint(blueprint *models.Blueprint) (*models.Pipeline, errors.Error) {
+func createPipelineByBlueprint(blueprint *models.Blueprint, triggeredBy string) (*models.Pipeline, errors.Error) {
 	var plan core.PipelinePlan
 	var err errors.Error
 	if blueprint.Mode == models.BLUEPRINT_MODE_NORMAL {
@@ -314,7 +344,9 @@ func createPipelineByBlueprint(blueprint *models.Blueprint) (*models.Pipeline, e
 	newPipeline.Plan = plan
 	newPipeline.Name = blueprint.Name
 	newPipeline.BlueprintId = blueprint.ID
+	newPipeline.Priority = blueprint.Priority
 	newPipeline.Labels = blueprint.Labels
+	newPipeline.TriggeredBy = triggeredBy
 	pipeline, err := CreatePipeline(&newPipeline)
 	// Return all created tasks to the User
 	if err != nil {
@@ -326,12 +358,25 @@ func createPipelineByBlueprint(blueprint *models.Blueprint) (*models.Pipeline, e
 
 // MakePlanForBlueprint generates pipeline plan by version
 func MakePlanForBlueprint(blueprint *models.Blueprint) (core.PipelinePlan, errors.Error) {
+	return makePlanForBlueprint(blueprint, GeneratePlanJsonV200)
+}
+
+// makePlanForBlueprint makes the plan with generatePlanJsonV200 for v2.0.0 blueprints, so the caller decides
+// whether the project_mapping should be refreshed
+func makePlanForBlueprint(
+	blueprint *models.Blueprint,
+	generatePlanJsonV200 func(string, *models.BlueprintSettings, map[string]json.RawMessage) (core.PipelinePlan, errors.Error),
+) (core.PipelinePlan, errors.Error) {
 	bpSettings := new(models.BlueprintSettings)
 	err := errors.Convert(json.Unmarshal(blueprint.Settings, bpSettings))
 
 	if err != nil {
 		return nil, errors.Default.Wrap(err, fmt.Sprintf("settings:%s", string(blueprint.Settings)))
 	}
+	err = bpSettings.RetryPolicy.Validate()
+	if err != nil {
+		return nil, err
+	}
 	var plan core.PipelinePlan
 	switch bpSettings.Version {
 	case "1.0.0":
@@ -347,14 +392,33 @@ func MakePlanForBlueprint(blueprint *models.Blueprint) (core.PipelinePlan, error
 		for _, projectMetric := range projectMetrics {
 			metrics[projectMetric.PluginName] = json.RawMessage(projectMetric.PluginOption)
 		}
-		plan, err = GeneratePlanJsonV200(blueprint.ProjectName, bpSettings, metrics)
+		plan, err = generatePlanJsonV200(blueprint.ProjectName, bpSettings, metrics)
 	default:
 		return nil, errors.Default.New(fmt.Sprintf("unknown version of blueprint settings: %s", bpSettings.Version))
 	}
 	if err != nil {
 		return nil, err
 	}
-	return WrapPipelinePlans(bpSettings.BeforePlan, plan, bpSettings.AfterPlan)
+	plan, err = WrapPipelinePlans(bpSettings.BeforePlan, plan, bpSettings.AfterPlan)
+	if err != nil {
+		return nil, err
+	}
+	applyRetryPolicy(plan, bpSettings.RetryPolicy)
+	return plan, nil
+}
+
+// applyRetryPolicy sets the blueprint level retry policy to tasks which don't have their own
+func applyRetryPolicy(plan core.PipelinePlan, retryPolicy *core.RetryPolicy) {
+	if retryPolicy == nil {
+		return
+	}
+	for _, stage := range plan {
+		for _, task := range stage {
+			if task.RetryPolicy == nil {
+				task.RetryPolicy = retryPolicy
+			}
+		}
+	}
 }
 
 // WrapPipelinePlans merges multiple pipelines and append before and after pipeline
@@ -408,13 +472,13 @@ func SequencializePipelinePlans(plans ...core.PipelinePlan) core.PipelinePlan {
 }
 
 // TriggerBlueprint triggers blueprint immediately
-func TriggerBlueprint(id uint64) (*models.Pipeline, errors.Error) {
+func TriggerBlueprint(id uint64, triggeredBy string) (*models.Pipeline, errors.Error) {
 	// load record from db
 	blueprint, err := GetBlueprint(id)
 	if err != nil {
 		return nil, err
 	}
-	pipeline, err := createPipelineByBlueprint(blueprint)
+	pipeline, err := createPipelineByBlueprint(blueprint, triggeredBy)
 	// done
 	return pipeline, err
 }
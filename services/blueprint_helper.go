@@ -21,10 +21,9 @@ import (
 	goerror "errors"
 	"fmt"
 
-	"github.com/apache/incubator-devlake/config"
 	"github.com/apache/incubator-devlake/errors"
 	"github.com/apache/incubator-devlake/models"
-	"github.com/apache/incubator-devlake/plugins/core"
+	"github.com/apache/incubator-devlake/plugins/helper"
 	"gorm.io/gorm"
 )
 
@@ -173,6 +172,7 @@ func parseBlueprint(dbBlueprint *models.DbBlueprint) *models.Blueprint {
 		CronConfig:  dbBlueprint.CronConfig,
 		IsManual:    dbBlueprint.IsManual,
 		SkipOnFail:  dbBlueprint.SkipOnFail,
+		Priority:    dbBlueprint.Priority,
 		Settings:    []byte(dbBlueprint.Settings),
 		Model:       dbBlueprint.Model,
 		Labels:      labelList,
@@ -191,6 +191,7 @@ func parseDbBlueprint(blueprint *models.Blueprint) *models.DbBlueprint {
 		CronConfig:  blueprint.CronConfig,
 		IsManual:    blueprint.IsManual,
 		SkipOnFail:  blueprint.SkipOnFail,
+		Priority:    blueprint.Priority,
 		Settings:    string(blueprint.Settings),
 		Model:       blueprint.Model,
 	}
@@ -207,13 +208,16 @@ func parseDbBlueprint(blueprint *models.Blueprint) *models.DbBlueprint {
 
 // encryptDbBlueprint
 func encryptDbBlueprint(dbBlueprint *models.DbBlueprint) (*models.DbBlueprint, errors.Error) {
-	encKey := config.GetConfig().GetString(core.EncodeKeyEnvStr)
-	planEncrypt, err := core.Encrypt(encKey, dbBlueprint.Plan)
+	store, err := helper.GetSecretStore(basicRes)
+	if err != nil {
+		return nil, err
+	}
+	planEncrypt, err := store.Encrypt(dbBlueprint.Plan)
 	if err != nil {
 		return nil, err
 	}
 	dbBlueprint.Plan = planEncrypt
-	settingsEncrypt, err := core.Encrypt(encKey, dbBlueprint.Settings)
+	settingsEncrypt, err := store.Encrypt(dbBlueprint.Settings)
 	dbBlueprint.Settings = settingsEncrypt
 	if err != nil {
 		return nil, err
@@ -223,13 +227,16 @@ func encryptDbBlueprint(dbBlueprint *models.DbBlueprint) (*models.DbBlueprint, e
 
 // decryptDbBlueprint
 func decryptDbBlueprint(dbBlueprint *models.DbBlueprint) (*models.DbBlueprint, errors.Error) {
-	encKey := config.GetConfig().GetString(core.EncodeKeyEnvStr)
-	plan, err := core.Decrypt(encKey, dbBlueprint.Plan)
+	store, err := helper.GetSecretStore(basicRes)
+	if err != nil {
+		return nil, err
+	}
+	plan, err := store.Decrypt(dbBlueprint.Plan)
 	if err != nil {
 		return nil, err
 	}
 	dbBlueprint.Plan = plan
-	settings, err := core.Decrypt(encKey, dbBlueprint.Settings)
+	settings, err := store.Decrypt(dbBlueprint.Settings)
 	dbBlueprint.Settings = settings
 	if err != nil {
 		return nil, err
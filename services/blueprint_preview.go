@@ -0,0 +1,252 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/models"
+	"github.com/apache/incubator-devlake/plugins/core"
+	"github.com/apache/incubator-devlake/runner"
+)
+
+// number of recent tasks of a plugin to look through for estimating api calls
+const planPreviewHistoryDepth = 100
+
+// PlanPreview describes what would be executed if the blueprint were triggered
+type PlanPreview struct {
+	Stages      [][]*PlanPreviewTask `json:"stages"`
+	DomainTypes []string             `json:"domainTypes"`
+	// EstimatedApiCalls sums up api calls made by the latest runs of the collectors, subtasks never run
+	// before are not counted, they are listed in UnestimatedSubtasks instead
+	EstimatedApiCalls   int      `json:"estimatedApiCalls"`
+	UnestimatedSubtasks []string `json:"unestimatedSubtasks"`
+}
+
+// PlanPreviewTask is the expanded form of a core.PipelineTask
+type PlanPreviewTask struct {
+	Plugin            string                 `json:"plugin"`
+	SkipOnFail        bool                   `json:"skipOnFail"`
+	Options           map[string]interface{} `json:"options"`
+	Subtasks          []*PlanPreviewSubtask  `json:"subtasks"`
+	DomainTypes       []string               `json:"domainTypes"`
+	EstimatedApiCalls int                    `json:"estimatedApiCalls"`
+}
+
+// PlanPreviewSubtask is a subtask that would be executed by the task
+type PlanPreviewSubtask struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Required    bool     `json:"required"`
+	DomainTypes []string `json:"domainTypes"`
+	// EstimatedApiCalls is nil if the subtask has never been completed with the same options
+	EstimatedApiCalls *int `json:"estimatedApiCalls"`
+}
+
+// PreviewBlueprintPlan returns what would happen if the specified blueprint were triggered now
+func PreviewBlueprintPlan(blueprintId uint64) (*PlanPreview, errors.Error) {
+	blueprint, err := GetBlueprint(blueprintId)
+	if err != nil {
+		return nil, err
+	}
+	var plan core.PipelinePlan
+	if blueprint.Mode == models.BLUEPRINT_MODE_NORMAL {
+		plan, err = previewPlanForBlueprint(blueprint)
+	} else {
+		plan, err = blueprint.UnmarshalPlan()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return PreviewPipelinePlan(plan)
+}
+
+// PreviewUnsavedBlueprintPlan validates the blueprint without saving it and returns its plan preview
+func PreviewUnsavedBlueprintPlan(blueprint *models.Blueprint) (*PlanPreview, errors.Error) {
+	err := validateBlueprint(blueprint)
+	if err != nil {
+		return nil, errors.BadInput.WrapRaw(err)
+	}
+	var plan core.PipelinePlan
+	if blueprint.Mode == models.BLUEPRINT_MODE_NORMAL {
+		plan, err = previewPlanForBlueprint(blueprint)
+		if err != nil {
+			return nil, errors.BadInput.Wrap(err, "invalid plan")
+		}
+	} else {
+		plan, err = blueprint.UnmarshalPlan()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return PreviewPipelinePlan(plan)
+}
+
+// previewPlanForBlueprint makes the plan like MakePlanForBlueprint but leaves the project_mapping untouched,
+// a preview must not change anything
+func previewPlanForBlueprint(blueprint *models.Blueprint) (core.PipelinePlan, errors.Error) {
+	return makePlanForBlueprint(blueprint, func(
+		projectName string,
+		sources *models.BlueprintSettings,
+		metrics map[string]json.RawMessage,
+	) (core.PipelinePlan, errors.Error) {
+		plan, _, err := genPlanJsonV200(projectName, sources, metrics)
+		return plan, err
+	})
+}
+
+// PreviewPipelinePlan expands the plan with subtasks to be executed and estimates the api calls based on history
+func PreviewPipelinePlan(plan core.PipelinePlan) (*PlanPreview, errors.Error) {
+	preview := &PlanPreview{
+		Stages:              make([][]*PlanPreviewTask, 0, len(plan)),
+		UnestimatedSubtasks: make([]string, 0),
+	}
+	domainTypes := make(map[string]bool)
+	for _, stage := range plan {
+		previewStage := make([]*PlanPreviewTask, 0, len(stage))
+		for _, task := range stage {
+			previewTask, err := previewPipelineTask(task)
+			if err != nil {
+				return nil, err
+			}
+			for _, subtask := range previewTask.Subtasks {
+				if subtask.EstimatedApiCalls == nil {
+					preview.UnestimatedSubtasks = append(
+						preview.UnestimatedSubtasks,
+						fmt.Sprintf("%s.%s", previewTask.Plugin, subtask.Name),
+					)
+				}
+			}
+			for _, domainType := range previewTask.DomainTypes {
+				domainTypes[domainType] = true
+			}
+			preview.EstimatedApiCalls += previewTask.EstimatedApiCalls
+			previewStage = append(previewStage, previewTask)
+		}
+		preview.Stages = append(preview.Stages, previewStage)
+	}
+	preview.DomainTypes = sortDomainTypes(domainTypes)
+	return preview, nil
+}
+
+func previewPipelineTask(task *core.PipelineTask) (*PlanPreviewTask, errors.Error) {
+	previewTask := &PlanPreviewTask{
+		Plugin:     task.Plugin,
+		SkipOnFail: task.SkipOnFail,
+		Options:    task.Options,
+		Subtasks:   make([]*PlanPreviewSubtask, 0),
+	}
+	pluginMeta, err := core.GetPlugin(task.Plugin)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, fmt.Sprintf("plugin %s is not available", task.Plugin))
+	}
+	pluginTask, ok := pluginMeta.(core.PluginTask)
+	if !ok {
+		return previewTask, nil
+	}
+	subtaskMetas := pluginTask.SubTaskMetas()
+	subtasksFlag, err := runner.GetSubtasksFlag(subtaskMetas, task.Subtasks)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, fmt.Sprintf("invalid subtasks for plugin %s", task.Plugin))
+	}
+	apiCalls, err := getLatestSubtaskApiCalls(task.Plugin, task.Options)
+	if err != nil {
+		return nil, err
+	}
+	domainTypes := make(map[string]bool)
+	for _, subtaskMeta := range subtaskMetas {
+		if !subtasksFlag[subtaskMeta.Name] {
+			continue
+		}
+		subtask := &PlanPreviewSubtask{
+			Name:        subtaskMeta.Name,
+			Description: subtaskMeta.Description,
+			Required:    subtaskMeta.Required,
+			DomainTypes: subtaskMeta.DomainTypes,
+		}
+		if calls, ok := apiCalls[subtaskMeta.Name]; ok {
+			subtask.EstimatedApiCalls = &calls
+			previewTask.EstimatedApiCalls += calls
+		}
+		for _, domainType := range subtaskMeta.DomainTypes {
+			domainTypes[domainType] = true
+		}
+		previewTask.Subtasks = append(previewTask.Subtasks, subtask)
+	}
+	previewTask.DomainTypes = sortDomainTypes(domainTypes)
+	return previewTask, nil
+}
+
+// getLatestSubtaskApiCalls returns api calls made by the latest completed run of each subtask of the plugin
+// with the same options
+func getLatestSubtaskApiCalls(plugin string, options map[string]interface{}) (map[string]int, errors.Error) {
+	// options went through json before being saved, do the same so they could be compared
+	var normalizedOptions map[string]interface{}
+	optionsJson, err := errors.Convert01(json.Marshal(options))
+	if err != nil {
+		return nil, err
+	}
+	err = errors.Convert(json.Unmarshal(optionsJson, &normalizedOptions))
+	if err != nil {
+		return nil, err
+	}
+	tasks := make([]*models.Task, 0)
+	err = errors.Convert(db.Where("plugin = ?", plugin).Order("id DESC").Limit(planPreviewHistoryDepth).Find(&tasks).Error)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, fmt.Sprintf("error loading history tasks of plugin %s", plugin))
+	}
+	taskIds := make([]uint64, 0)
+	for _, task := range tasks {
+		var taskOptions map[string]interface{}
+		if json.Unmarshal(task.Options, &taskOptions) != nil {
+			continue
+		}
+		if reflect.DeepEqual(taskOptions, normalizedOptions) {
+			taskIds = append(taskIds, task.ID)
+		}
+	}
+	apiCalls := make(map[string]int)
+	if len(taskIds) == 0 {
+		return apiCalls, nil
+	}
+	subtasks := make([]*models.Subtask, 0)
+	err = errors.Convert(db.Where("task_id IN ? AND status = ?", taskIds, models.TASK_COMPLETED).Order("id DESC").Find(&subtasks).Error)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, fmt.Sprintf("error loading history subtasks of plugin %s", plugin))
+	}
+	for _, subtask := range subtasks {
+		if _, ok := apiCalls[subtask.Name]; !ok {
+			apiCalls[subtask.Name] = subtask.ApiCalls
+		}
+	}
+	return apiCalls, nil
+}
+
+// sortDomainTypes returns the domain types in the order of core.DOMAIN_TYPES
+func sortDomainTypes(domainTypes map[string]bool) []string {
+	sorted := make([]string, 0, len(domainTypes))
+	for _, domainType := range core.DOMAIN_TYPES {
+		if domainTypes[domainType] {
+			sorted = append(sorted, domainType)
+		}
+	}
+	return sorted
+}
@@ -0,0 +1,96 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/apache/incubator-devlake/impl"
+	"github.com/apache/incubator-devlake/impl/dalgorm"
+	"github.com/apache/incubator-devlake/mocks"
+	"github.com/apache/incubator-devlake/models"
+	"github.com/apache/incubator-devlake/models/domainlayer"
+	"github.com/apache/incubator-devlake/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/plugins/core"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"gorm.io/gorm/utils/tests"
+)
+
+func TestPreviewUnsavedBlueprintPlanKeepsProjectMapping(t *testing.T) {
+	const projectName = "TestPreviewUnsavedBlueprintPlan-project"
+	githubName := "TestPreviewUnsavedBlueprintPlan-github"
+	githubConnId := uint64(1)
+	githubScopes := []*core.BlueprintScopeV200{
+		{Id: "", Name: "apache/incubator-devlake"},
+	}
+	githubOutputPlan := core.PipelinePlan{
+		{
+			{Plugin: githubName, Options: map[string]interface{}{"name": "apache/incubator-devlake"}},
+		},
+	}
+	githubOutputScopes := []core.Scope{
+		&code.Repo{DomainEntity: domainlayer.DomainEntity{Id: "github:GithubRepo:1:123"}, Name: "apache/incubator-devlake"},
+	}
+	github := new(mocks.CompositeDataSourcePluginBlueprintV200)
+	github.On("MakeDataSourcePipelinePlanV200", githubConnId, githubScopes).Return(githubOutputPlan, githubOutputScopes, nil)
+	core.RegisterPlugin(githubName, github)
+
+	// statements are not executed in dry run mode, record the tables being written instead
+	dryRunDb, err := gorm.Open(tests.DummyDialector{}, &gorm.Config{DryRun: true})
+	assert.Nil(t, err)
+	writtenTables := make([]string, 0)
+	recordWrittenTable := func(tx *gorm.DB) {
+		writtenTables = append(writtenTables, tx.Statement.Table)
+	}
+	assert.Nil(t, dryRunDb.Callback().Create().Before("gorm:create").Register("test:create", recordWrittenTable))
+	assert.Nil(t, dryRunDb.Callback().Update().Before("gorm:update").Register("test:update", recordWrittenTable))
+	assert.Nil(t, dryRunDb.Callback().Delete().Before("gorm:delete").Register("test:delete", recordWrittenTable))
+	originalDb, originalBasicRes := db, basicRes
+	defer func() {
+		db, basicRes = originalDb, originalBasicRes
+	}()
+	db = dryRunDb
+	basicRes = impl.NewDefaultBasicRes(viper.New(), nil, dalgorm.NewDalgorm(dryRunDb))
+
+	connections, _ := json.Marshal([]*core.BlueprintConnectionV200{
+		{Plugin: githubName, ConnectionId: githubConnId, Scopes: githubScopes},
+	})
+	settings, _ := json.Marshal(&models.BlueprintSettings{
+		Version:     "2.0.0",
+		Connections: connections,
+	})
+	preview, err := PreviewUnsavedBlueprintPlan(&models.Blueprint{
+		Name:        "TestPreviewUnsavedBlueprintPlan",
+		ProjectName: projectName,
+		Mode:        models.BLUEPRINT_MODE_NORMAL,
+		CronConfig:  "manual",
+		Settings:    settings,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(preview.Stages))
+	assert.Equal(t, githubName, preview.Stages[0][0].Plugin)
+	assert.Empty(t, writtenTables)
+
+	// making the plan for real refreshes the project_mapping
+	_, err = GeneratePlanJsonV200(projectName, &models.BlueprintSettings{Version: "2.0.0", Connections: connections}, nil)
+	assert.Nil(t, err)
+	assert.Contains(t, writtenTables, "project_mapping")
+}
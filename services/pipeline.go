@@ -90,8 +90,12 @@ func pipelineServiceInit() {
 		globalPipelineLog.Warn(nil, `pipelineMaxParallel=0 means pipeline will be run No Limit`)
 		pipelineMaxParallel = 10000
 	}
+	var connectionMaxParallel = cfg.GetInt("PIPELINE_CONNECTION_MAX_PARALLEL")
+	if connectionMaxParallel < 0 {
+		panic(errors.BadInput.New(`PIPELINE_CONNECTION_MAX_PARALLEL should be a positive integer`))
+	}
 	// run pipeline with independent goroutine
-	go RunPipelineInQueue(pipelineMaxParallel)
+	go RunPipelineInQueue(pipelineMaxParallel, connectionMaxParallel)
 }
 
 // CreatePipeline and return the model
@@ -154,11 +158,13 @@ func GetPipelineLogsArchivePath(pipeline *models.Pipeline) (string, errors.Error
 	return archive, err
 }
 
-// RunPipelineInQueue query pipeline from db and run it in a queue
-func RunPipelineInQueue(pipelineMaxParallel int64) {
+// RunPipelineInQueue query pipeline from db and run it in a queue, pipelines with higher priority go first, and
+// pipelines working on connections which are used by `connectionMaxParallel` running pipelines would wait
+func RunPipelineInQueue(pipelineMaxParallel int64, connectionMaxParallel int) {
 	sema := semaphore.NewWeighted(pipelineMaxParallel)
 	runningParallelLabels := []string{}
 	var runningParallelLabelLock sync.Mutex
+	slots := newConnectionSlots(connectionMaxParallel)
 	for {
 		globalPipelineLog.Info("acquire lock")
 		// start goroutine when sema lock ready and pipeline exist.
@@ -169,9 +175,11 @@ func RunPipelineInQueue(pipelineMaxParallel int64) {
 		}
 		globalPipelineLog.Info("get lock and wait next pipeline")
 		dbPipeline := &models.DbPipeline{}
+		var connectionKeys []string
 		for {
 			cronLocker.Lock()
-			// prepare query to find an appropriate pipeline to execute
+			// prepare query to find appropriate pipelines to execute
+			var candidates []models.DbPipeline
 			db.Where("status IN ?", []string{models.TASK_CREATED, models.TASK_RERUN}).
 				Joins(`left join _devlake_pipeline_labels ON
 						_devlake_pipeline_labels.pipeline_id = _devlake_pipelines.id AND
@@ -180,7 +188,20 @@ func RunPipelineInQueue(pipelineMaxParallel int64) {
 				Group(`id`).
 				Having(`count(_devlake_pipeline_labels.name)=0`).
 				Select("id").
-				Order("id ASC").Limit(1).Find(dbPipeline)
+				Order("priority DESC, id ASC").Limit(100).Find(&candidates)
+			// pick the first one whose connections aren't fully occupied
+			for _, candidate := range candidates {
+				keys, err := getPipelineConnectionKeys(candidate.ID)
+				if err != nil {
+					globalPipelineLog.Error(err, "failed to get connections of pipeline #%d", candidate.ID)
+					continue
+				}
+				if slots.tryAcquire(keys) {
+					dbPipeline.ID = candidate.ID
+					connectionKeys = keys
+					break
+				}
+			}
 			cronLocker.Unlock()
 			if dbPipeline.ID != 0 {
 				break
@@ -209,8 +230,9 @@ func RunPipelineInQueue(pipelineMaxParallel int64) {
 		runningParallelLabels = append(runningParallelLabels, pipelineParallelLabels...)
 		runningParallelLabelLock.Unlock()
 
-		go func(pipelineId uint64, parallelLabels []string) {
+		go func(pipelineId uint64, parallelLabels []string, connectionKeys []string) {
 			defer sema.Release(1)
+			defer slots.release(connectionKeys)
 			defer func() {
 				runningParallelLabelLock.Lock()
 				runningParallelLabels = utils.SliceRemove(runningParallelLabels, parallelLabels...)
@@ -222,7 +244,7 @@ func RunPipelineInQueue(pipelineMaxParallel int64) {
 			if err != nil {
 				globalPipelineLog.Error(err, "failed to run pipeline %d", pipelineId)
 			}
-		}(dbPipeline.ID, pipelineParallelLabels)
+		}(dbPipeline.ID, pipelineParallelLabels, connectionKeys)
 	}
 }
 
@@ -347,7 +369,7 @@ func NotifyExternal(pipelineId uint64) errors.Error {
 }
 
 // CancelPipeline FIXME ...
-func CancelPipeline(pipelineId uint64) errors.Error {
+func CancelPipeline(pipelineId uint64, cancelledBy string) errors.Error {
 	// prevent RunPipelineInQueue from consuming pending pipelines
 	cronLocker.Lock()
 	defer cronLocker.Unlock()
@@ -358,6 +380,7 @@ func CancelPipeline(pipelineId uint64) errors.Error {
 	}
 	if pipeline.Status == models.TASK_CREATED || pipeline.Status == models.TASK_RERUN {
 		pipeline.Status = models.TASK_CANCELLED
+		pipeline.CancelledBy = cancelledBy
 		result := db.Save(pipeline)
 		if result.Error != nil {
 			return errors.Default.Wrap(result.Error, "faile to update pipeline")
@@ -373,6 +396,13 @@ func CancelPipeline(pipelineId uint64) errors.Error {
 		// the target pipeline is pending, no running, no need to perform the actual cancel operation
 		return nil
 	}
+	// finished pipelines are left as they are
+	if pipeline.Status == models.TASK_RUNNING {
+		err = db.Model(pipeline).Update("cancelled_by", cancelledBy).Error
+		if err != nil {
+			return errors.Default.Wrap(err, "failed to update pipeline")
+		}
+	}
 	if temporalClient != nil {
 		return errors.Convert(temporalClient.CancelWorkflow(context.Background(), getTemporalWorkflowId(pipelineId), ""))
 	}
@@ -22,10 +22,9 @@ import (
 	goerror "errors"
 	"fmt"
 
-	"github.com/apache/incubator-devlake/config"
 	"github.com/apache/incubator-devlake/errors"
 	"github.com/apache/incubator-devlake/models"
-	"github.com/apache/incubator-devlake/plugins/core"
+	"github.com/apache/incubator-devlake/plugins/helper"
 	"gorm.io/gorm"
 )
 
@@ -58,6 +57,8 @@ func CreateDbPipeline(newPipeline *models.NewPipeline) (*models.DbPipeline, erro
 		Message:       "",
 		SpentSeconds:  0,
 		Plan:          string(planByte),
+		TriggeredBy:   newPipeline.TriggeredBy,
+		Priority:      newPipeline.Priority,
 	}
 	if newPipeline.BlueprintId != 0 {
 		dbPipeline.BlueprintId = newPipeline.BlueprintId
@@ -211,6 +212,9 @@ func parsePipeline(dbPipeline *models.DbPipeline) *models.Pipeline {
 		SpentSeconds:  dbPipeline.SpentSeconds,
 		Stage:         dbPipeline.Stage,
 		Labels:        labelList,
+		TriggeredBy:   dbPipeline.TriggeredBy,
+		CancelledBy:   dbPipeline.CancelledBy,
+		Priority:      dbPipeline.Priority,
 	}
 	return &pipeline
 }
@@ -231,6 +235,9 @@ func parseDbPipeline(pipeline *models.Pipeline) *models.DbPipeline {
 		Message:       pipeline.Message,
 		SpentSeconds:  pipeline.SpentSeconds,
 		Stage:         pipeline.Stage,
+		TriggeredBy:   pipeline.TriggeredBy,
+		CancelledBy:   pipeline.CancelledBy,
+		Priority:      pipeline.Priority,
 	}
 	dbPipeline.Labels = []models.DbPipelineLabel{}
 	for _, label := range pipeline.Labels {
@@ -245,8 +252,11 @@ func parseDbPipeline(pipeline *models.Pipeline) *models.DbPipeline {
 
 // encryptDbPipeline encrypts dbPipeline.Plan
 func encryptDbPipeline(dbPipeline *models.DbPipeline) (*models.DbPipeline, errors.Error) {
-	encKey := config.GetConfig().GetString(core.EncodeKeyEnvStr)
-	planEncrypt, err := core.Encrypt(encKey, dbPipeline.Plan)
+	store, err := helper.GetSecretStore(basicRes)
+	if err != nil {
+		return nil, err
+	}
+	planEncrypt, err := store.Encrypt(dbPipeline.Plan)
 	if err != nil {
 		return nil, err
 	}
@@ -256,8 +266,11 @@ func encryptDbPipeline(dbPipeline *models.DbPipeline) (*models.DbPipeline, error
 
 // encryptDbPipeline decrypts dbPipeline.Plan
 func decryptDbPipeline(dbPipeline *models.DbPipeline) (*models.DbPipeline, errors.Error) {
-	encKey := config.GetConfig().GetString(core.EncodeKeyEnvStr)
-	plan, err := core.Decrypt(encKey, dbPipeline.Plan)
+	store, err := helper.GetSecretStore(basicRes)
+	if err != nil {
+		return nil, err
+	}
+	plan, err := store.Decrypt(dbPipeline.Plan)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,96 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/models"
+	"github.com/apache/incubator-devlake/plugins/helper"
+)
+
+// connectionSlots keeps track of the connections used by running pipelines, so pipelines hitting a busy
+// connection could wait for the others to finish instead of starving each other
+type connectionSlots struct {
+	mu          sync.Mutex
+	maxParallel int
+	running     map[string]int
+}
+
+func newConnectionSlots(maxParallel int) *connectionSlots {
+	return &connectionSlots{
+		maxParallel: maxParallel,
+		running:     make(map[string]int),
+	}
+}
+
+// tryAcquire occupies a slot of all connections if every one of them has a free slot
+func (s *connectionSlots) tryAcquire(connectionKeys []string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.maxParallel > 0 {
+		for _, key := range connectionKeys {
+			if s.running[key] >= s.maxParallel {
+				return false
+			}
+		}
+	}
+	for _, key := range connectionKeys {
+		s.running[key]++
+	}
+	return true
+}
+
+// release gives back the slots occupied by tryAcquire
+func (s *connectionSlots) release(connectionKeys []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, key := range connectionKeys {
+		s.running[key]--
+		if s.running[key] <= 0 {
+			delete(s.running, key)
+		}
+	}
+}
+
+// getPipelineConnectionKeys returns the connections the pending tasks of the pipeline are working on
+func getPipelineConnectionKeys(pipelineId uint64) ([]string, errors.Error) {
+	var tasks []models.Task
+	err := db.Select("plugin, options").
+		Where("pipeline_id = ? AND status = ?", pipelineId, models.TASK_CREATED).
+		Find(&tasks).Error
+	if err != nil {
+		return nil, errors.Convert(err)
+	}
+	keys := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, task := range tasks {
+		var options map[string]interface{}
+		if err := json.Unmarshal(task.Options, &options); err != nil {
+			return nil, errors.Default.Wrap(err, "failed to unmarshal task options")
+		}
+		key := helper.GetConnectionKey(task.Plugin, options)
+		if key != "" && !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
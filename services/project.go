@@ -33,7 +33,7 @@ type ProjectQuery struct {
 }
 
 // CreateProject accepts a project instance and insert it to database
-func CreateProject(project *models.Project) errors.Error {
+func CreateProject(project *models.Project, actor string) errors.Error {
 	if project.Name == "" {
 		return errors.Default.New("can not use empty name for project")
 	}
@@ -46,11 +46,12 @@ func CreateProject(project *models.Project) errors.Error {
 	if err != nil {
 		return err
 	}
+	recordAudit(actor, AUDIT_ACTION_CREATE, "project", project.Name, nil, project)
 	return nil
 }
 
 // CreateProjectMetric accepts a ProjectMetric instance and insert it to database
-func CreateProjectMetric(projectMetric *models.ProjectMetric) errors.Error {
+func CreateProjectMetric(projectMetric *models.ProjectMetric, actor string) errors.Error {
 	/*enProjectMetric, err := encryptProjectMetric(projectMetric)
 	if err != nil {
 		return err
@@ -59,6 +60,7 @@ func CreateProjectMetric(projectMetric *models.ProjectMetric) errors.Error {
 	if err != nil {
 		return err
 	}
+	recordAudit(actor, AUDIT_ACTION_CREATE, "project_metric", projectMetricAuditId(projectMetric), nil, projectMetric)
 	return nil
 }
 
@@ -93,23 +95,31 @@ func GetProjectMetric(projectName string, pluginName string) (*models.ProjectMet
 }
 
 // FlushProjectMetrics remove all Project metrics by project name and create new metrics by baseMetrics
-func FlushProjectMetrics(projectName string, baseMetrics *[]models.BaseMetric) errors.Error {
-	err := removeAllDbProjectMetricsByProjectName(projectName)
+func FlushProjectMetrics(projectName string, baseMetrics *[]models.BaseMetric, actor string) errors.Error {
+	before, _, err := GetProjectMetrics(projectName)
+	if err != nil {
+		return err
+	}
+	err = removeAllDbProjectMetricsByProjectName(projectName)
 	if err != nil {
 		return errors.Default.Wrap(err, fmt.Sprintf("error to removeAllDbProjectMetricsByProjectName for %s", projectName))
 	}
 
+	after := make([]models.ProjectMetric, 0, len(*baseMetrics))
 	for _, baseMetric := range *baseMetrics {
-		err = CreateProjectMetric(&models.ProjectMetric{
+		projectMetric := &models.ProjectMetric{
 			BaseProjectMetric: models.BaseProjectMetric{
 				ProjectName: projectName,
 				BaseMetric:  baseMetric,
 			},
-		})
+		}
+		err = CreateDbProjectMetric(projectMetric)
 		if err != nil {
 			return errors.Default.Wrap(err, fmt.Sprintf("failed to  CreateProjectMetric for [%s][%s]", projectName, baseMetric.PluginName))
 		}
+		after = append(after, *projectMetric)
 	}
+	recordAudit(actor, AUDIT_ACTION_UPDATE, "project_metrics", projectName, before, after)
 
 	return nil
 }
@@ -177,7 +187,7 @@ func GetProjects(query *ProjectQuery) ([]*models.Project, int64, errors.Error) {
 }
 
 // PatchProject FIXME ...
-func PatchProject(name string, body map[string]interface{}) (*models.ApiOutputProject, errors.Error) {
+func PatchProject(name string, body map[string]interface{}, actor string) (*models.ApiOutputProject, errors.Error) {
 	projectInput := &models.ApiInputProject{}
 	projectOutput := &models.ApiOutputProject{}
 
@@ -186,6 +196,11 @@ func PatchProject(name string, body map[string]interface{}) (*models.ApiOutputPr
 	if err != nil {
 		return nil, err
 	}
+	before := &models.ApiOutputProject{BaseProject: project.BaseProject}
+	err = LoadBluePrintAndMetrics(before)
+	if err != nil {
+		return nil, err
+	}
 
 	err = helper.DecodeMapStruct(body, projectInput)
 	if err != nil {
@@ -254,7 +269,7 @@ func PatchProject(name string, body map[string]interface{}) (*models.ApiOutputPr
 
 	// check if need to changed the blueprint setting
 	if projectInput.Enable != nil {
-		_, err = PatchBlueprintEnableByProjectName(projectInput.Name, *projectInput.Enable)
+		_, err = PatchBlueprintEnableByProjectName(projectInput.Name, *projectInput.Enable, actor)
 		if err != nil {
 			return nil, errors.Default.Wrap(err, "Failed to set if project enable")
 		}
@@ -262,7 +277,7 @@ func PatchProject(name string, body map[string]interface{}) (*models.ApiOutputPr
 
 	// check if need flush the Metrics
 	if projectInput.Metrics != nil {
-		err = FlushProjectMetrics(projectInput.Name, projectInput.Metrics)
+		err = FlushProjectMetrics(projectInput.Name, projectInput.Metrics, actor)
 		if err != nil {
 			return nil, errors.Default.Wrap(err, "Failed to flush project metrics")
 		}
@@ -273,18 +288,20 @@ func PatchProject(name string, body map[string]interface{}) (*models.ApiOutputPr
 	if err != nil {
 		return nil, errors.Default.Wrap(err, fmt.Sprintf("Failed to LoadBluePrintAndMetrics on PatchProject for %s", projectOutput.Name))
 	}
+	recordAudit(actor, AUDIT_ACTION_UPDATE, "project", name, before, projectOutput)
 
 	// done
 	return projectOutput, nil
 }
 
 // PatchProjectMetric FIXME ...
-func PatchProjectMetric(projectName string, pluginName string, body map[string]interface{}) (*models.ProjectMetric, errors.Error) {
+func PatchProjectMetric(projectName string, pluginName string, body map[string]interface{}, actor string) (*models.ProjectMetric, errors.Error) {
 	// load record from db
 	projectMetric, err := GetDbProjectMetric(projectName, pluginName)
 	if err != nil {
 		return nil, err
 	}
+	before := auditSnapshot(projectMetric)
 
 	err = helper.DecodeMapStruct(body, projectMetric)
 	if err != nil {
@@ -301,7 +318,12 @@ func PatchProjectMetric(projectName string, pluginName string, body map[string]i
 	if err != nil {
 		return nil, errors.Internal.Wrap(err, "error saving project")
 	}
+	recordAudit(actor, AUDIT_ACTION_UPDATE, "project_metric", projectMetricAuditId(projectMetric), before, projectMetric)
 
 	// done
 	return projectMetric, nil
 }
+
+func projectMetricAuditId(projectMetric *models.ProjectMetric) string {
+	return fmt.Sprintf("%s/%s", projectMetric.ProjectName, projectMetric.PluginName)
+}
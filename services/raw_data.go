@@ -0,0 +1,171 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	goerror "errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/models"
+	"github.com/apache/incubator-devlake/plugins/helper"
+	"gorm.io/gorm"
+)
+
+// RawDataScope summarizes raw data of a scope in a raw table
+type RawDataScope struct {
+	Params         string     `json:"params"`
+	RowCount       int64      `json:"rowCount"`
+	Collections    int64      `json:"collections"`
+	FirstCreatedAt *time.Time `json:"firstCreatedAt"`
+	LastCreatedAt  *time.Time `json:"lastCreatedAt"`
+}
+
+// RawDataPurgeQuery specifies what to be kept when purging raw data of a scope
+type RawDataPurgeQuery struct {
+	Params          string `form:"params"`
+	KeepCollections int    `form:"keepCollections"`
+	KeepDays        int    `form:"keepDays"`
+}
+
+// GetRawDataRetentionPolicies returns all raw data retention policies
+func GetRawDataRetentionPolicies() ([]models.RawDataRetentionPolicy, errors.Error) {
+	policies := make([]models.RawDataRetentionPolicy, 0)
+	err := db.Order("plugin, raw_data_table").Find(&policies).Error
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error loading raw data retention policies")
+	}
+	return policies, nil
+}
+
+// SaveRawDataRetentionPolicy creates the policy, or updates the existing one of the same plugin and raw table
+func SaveRawDataRetentionPolicy(policy *models.RawDataRetentionPolicy, actor string) errors.Error {
+	if policy.Plugin == "" && policy.RawDataTable == "" {
+		return errors.BadInput.New("either plugin or rawDataTable is required")
+	}
+	if policy.RawDataTable != "" && !strings.HasPrefix(policy.RawDataTable, "_raw_") {
+		return errors.BadInput.New("rawDataTable must be a _raw_ table")
+	}
+	if policy.KeepCollections < 0 || policy.KeepDays < 0 {
+		return errors.BadInput.New("keepCollections and keepDays must not be negative")
+	}
+	existing := &models.RawDataRetentionPolicy{}
+	action := AUDIT_ACTION_UPDATE
+	err := db.First(existing, "plugin = ? AND raw_data_table = ?", policy.Plugin, policy.RawDataTable).Error
+	if err == nil {
+		policy.ID = existing.ID
+		policy.CreatedAt = existing.CreatedAt
+	} else if goerror.Is(err, gorm.ErrRecordNotFound) {
+		existing = nil
+		action = AUDIT_ACTION_CREATE
+	} else {
+		return errors.Default.Wrap(err, "error loading raw data retention policy")
+	}
+	err = db.Save(policy).Error
+	if err != nil {
+		return errors.Default.Wrap(err, "error saving raw data retention policy")
+	}
+	recordAudit(actor, action, "raw_data_retention_policy", policy.ID, existing, policy)
+	return nil
+}
+
+// DeleteRawDataRetentionPolicy deletes the policy by id
+func DeleteRawDataRetentionPolicy(policyId uint64, actor string) errors.Error {
+	policy := &models.RawDataRetentionPolicy{}
+	err := db.First(policy, policyId).Error
+	if goerror.Is(err, gorm.ErrRecordNotFound) {
+		return errors.NotFound.New("raw data retention policy not found")
+	}
+	if err != nil {
+		return errors.Default.Wrap(err, "error loading raw data retention policy")
+	}
+	err = db.Delete(policy).Error
+	if err != nil {
+		return errors.Default.Wrap(err, "error deleting raw data retention policy")
+	}
+	recordAudit(actor, AUDIT_ACTION_DELETE, "raw_data_retention_policy", policyId, policy, nil)
+	return nil
+}
+
+// GetRawDataScopes summarizes raw data of the table by scope
+func GetRawDataScopes(rawTable string) ([]*RawDataScope, errors.Error) {
+	err := validateRawTable(rawTable)
+	if err != nil {
+		return nil, err
+	}
+	scopes := make([]*RawDataScope, 0)
+	e := db.Table(rawTable).
+		Select("params, COUNT(*) AS row_count, MIN(created_at) AS first_created_at, MAX(created_at) AS last_created_at").
+		Group("params").
+		Order("params").
+		Scan(&scopes).Error
+	if e != nil {
+		return nil, errors.Default.Wrap(e, fmt.Sprintf("error summarizing %s", rawTable))
+	}
+	var collections []struct {
+		RawDataParams string
+		Collections   int64
+	}
+	e = db.Model(&models.RawDataCollection{}).
+		Select("raw_data_params, COUNT(*) AS collections").
+		Where("raw_data_table = ?", rawTable).
+		Group("raw_data_params").
+		Scan(&collections).Error
+	if e != nil {
+		return nil, errors.Default.Wrap(e, fmt.Sprintf("error counting collections of %s", rawTable))
+	}
+	for _, scope := range scopes {
+		for _, c := range collections {
+			if c.RawDataParams == scope.Params {
+				scope.Collections = c.Collections
+			}
+		}
+	}
+	return scopes, nil
+}
+
+// PurgeRawDataOfScope deletes raw data of the scope that are out of the specified window immediately,
+// the latest collection and raw data still referred by extracted records are kept
+func PurgeRawDataOfScope(rawTable string, query *RawDataPurgeQuery) errors.Error {
+	err := validateRawTable(rawTable)
+	if err != nil {
+		return err
+	}
+	if query.KeepCollections <= 0 && query.KeepDays <= 0 {
+		return errors.BadInput.New("either keepCollections or keepDays must be positive")
+	}
+	return helper.PurgeRawData(basicRes.GetDal(), rawTable, query.Params, query.KeepCollections, query.KeepDays)
+}
+
+func validateRawTable(rawTable string) errors.Error {
+	if !strings.HasPrefix(rawTable, "_raw_") {
+		return errors.BadInput.New(fmt.Sprintf("%s is not a raw table", rawTable))
+	}
+	tables, err := basicRes.GetDal().AllTables()
+	if err != nil {
+		return err
+	}
+	for _, table := range tables {
+		if table == rawTable {
+			return nil
+		}
+	}
+	return errors.NotFound.New(fmt.Sprintf("raw table %s not found", rawTable))
+}
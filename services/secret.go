@@ -0,0 +1,177 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/apache/incubator-devlake/config"
+	"github.com/apache/incubator-devlake/errors"
+	"github.com/apache/incubator-devlake/models"
+	"github.com/apache/incubator-devlake/plugins/core"
+	"github.com/apache/incubator-devlake/plugins/helper"
+	"gorm.io/gorm"
+)
+
+const secretRotationBatchSize = 500
+
+// SecretRotation specifies how secrets should be rotated
+type SecretRotation struct {
+	// EncodeKey is the new key for the `aes` secret store, it is ignored by the `keyring` store which rotates to the
+	// active key of the keyring file
+	EncodeKey string `json:"encodeKey"`
+}
+
+// SecretRotationResult reports number of records re-encrypted for each table
+type SecretRotationResult struct {
+	Tables map[string]int `json:"tables"`
+}
+
+// RotateSecrets re-encrypts all connections and pipeline/blueprint plans with the new key, and switches to it
+// afterward, all or nothing
+func RotateSecrets(rotation *SecretRotation, actor string) (*SecretRotationResult, errors.Error) {
+	storeType := strings.ToLower(cfg.GetString(helper.SecretStoreEnvStr))
+	var newStore core.SecretStore
+	var err errors.Error
+	if storeType == "" || storeType == helper.SECRET_STORE_AES {
+		if rotation.EncodeKey == "" {
+			return nil, errors.BadInput.New("encodeKey is required")
+		}
+		// the environment variable would override the rotated key in the .env file on the next start
+		if _, ok := os.LookupEnv(core.EncodeKeyEnvStr); ok {
+			return nil, errors.BadInput.New(fmt.Sprintf("%s is set by the environment variable, secrets could not be rotated", core.EncodeKeyEnvStr))
+		}
+		if rotation.EncodeKey == cfg.GetString(core.EncodeKeyEnvStr) {
+			return nil, errors.BadInput.New("encodeKey is not changed")
+		}
+		newStore = helper.NewAesSecretStore(rotation.EncodeKey)
+	} else {
+		// reload the keyring file to pick up the new active key
+		newStore, err = helper.NewSecretStore(basicRes)
+		if err != nil {
+			return nil, err
+		}
+	}
+	tables := getSecretTables()
+	result := &SecretRotationResult{Tables: make(map[string]int)}
+	err = helper.ReplaceSecretStore(basicRes, newStore, func(oldStore, newStore core.SecretStore) errors.Error {
+		return errors.Convert(db.Transaction(func(tx *gorm.DB) error {
+			for _, table := range tables {
+				count, err := rotateSecretsOfTable(tx, table, oldStore, newStore)
+				if err != nil {
+					return errors.Default.Wrap(err, fmt.Sprintf("failed to rotate secrets of %s", table.TableName()))
+				}
+				result.Tables[table.TableName()] = count
+			}
+			return nil
+		}))
+	})
+	if err != nil {
+		return nil, err
+	}
+	log.Info("secrets of %d tables were rotated", len(tables))
+	if storeType == "" {
+		storeType = helper.SECRET_STORE_AES
+	}
+	recordAudit(actor, AUDIT_ACTION_ROTATE, "secrets", storeType, nil, result)
+	if rotation.EncodeKey != "" && (storeType == "" || storeType == helper.SECRET_STORE_AES) {
+		// other processes like workers must be updated manually
+		cfg.Set(core.EncodeKeyEnvStr, rotation.EncodeKey)
+		err = config.WriteConfig(cfg)
+		if err != nil {
+			return nil, errors.Default.Wrap(err, "secrets were rotated but failed to save the new ENCODE_KEY")
+		}
+	}
+	return result, nil
+}
+
+// getSecretTables returns tables of framework and all plugins which have encrypted fields
+func getSecretTables() []core.Tabler {
+	candidates := []core.Tabler{
+		&models.DbPipeline{},
+		&models.DbBlueprint{},
+		&models.AuditLog{},
+	}
+	pluginNames := make([]string, 0)
+	for pluginName := range core.AllPlugins() {
+		pluginNames = append(pluginNames, pluginName)
+	}
+	sort.Strings(pluginNames)
+	for _, pluginName := range pluginNames {
+		pluginMeta := core.AllPlugins()[pluginName]
+		if pluginModel, ok := pluginMeta.(core.PluginModel); ok {
+			candidates = append(candidates, pluginModel.GetTablesInfo()...)
+		}
+		if pluginSource, ok := pluginMeta.(core.PluginSource); ok {
+			if connection, ok := pluginSource.Connection().(core.Tabler); ok {
+				candidates = append(candidates, connection)
+			}
+		}
+	}
+	tables := make([]core.Tabler, 0)
+	seen := make(map[string]bool)
+	for _, table := range candidates {
+		if seen[table.TableName()] || !helper.HasEncryptFields(reflect.TypeOf(table)) {
+			continue
+		}
+		seen[table.TableName()] = true
+		tables = append(tables, table)
+	}
+	return tables
+}
+
+func rotateSecretsOfTable(tx *gorm.DB, table core.Tabler, oldStore, newStore core.SecretStore) (int, errors.Error) {
+	if !tx.Migrator().HasTable(table.TableName()) {
+		return 0, nil
+	}
+	rotate := func(cipherText string) (string, errors.Error) {
+		if cipherText == "" {
+			return cipherText, nil
+		}
+		plainText, err := oldStore.Decrypt(cipherText)
+		if err != nil {
+			return "", err
+		}
+		return newStore.Encrypt(plainText)
+	}
+	count := 0
+	rows := reflect.New(reflect.SliceOf(reflect.TypeOf(table)))
+	err := tx.Table(table.TableName()).FindInBatches(rows.Interface(), secretRotationBatchSize, func(batch *gorm.DB, _ int) error {
+		for i := 0; i < rows.Elem().Len(); i++ {
+			row := rows.Elem().Index(i).Interface()
+			err := helper.UpdateEncryptFields(row, rotate)
+			if err != nil {
+				return err
+			}
+			err = errors.Convert(tx.Save(row).Error)
+			if err != nil {
+				return err
+			}
+			count++
+		}
+		return nil
+	}).Error
+	if err != nil {
+		return 0, errors.Convert(err)
+	}
+	return count, nil
+}
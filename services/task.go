@@ -143,6 +143,13 @@ func CreateTask(newTask *models.NewTask) (*models.Task, errors.Error) {
 	if err != nil {
 		return nil, errors.Convert(err)
 	}
+	var retryPolicy []byte
+	if newTask.RetryPolicy != nil {
+		retryPolicy, err = json.Marshal(newTask.RetryPolicy)
+		if err != nil {
+			return nil, errors.Convert(err)
+		}
+	}
 
 	task := models.Task{
 		Plugin:      newTask.Plugin,
@@ -154,6 +161,7 @@ func CreateTask(newTask *models.NewTask) (*models.Task, errors.Error) {
 		PipelineRow: newTask.PipelineRow,
 		PipelineCol: newTask.PipelineCol,
 		SkipOnFail:  newTask.SkipOnFail,
+		RetryPolicy: retryPolicy,
 	}
 	err = db.Save(&task).Error
 	if err != nil {
@@ -229,6 +237,8 @@ func GetTasksWithLastStatus(pipelineId uint64) ([]models.Task, errors.Error) {
 func SpawnTasks(input []models.Task) ([]models.Task, errors.Error) {
 	var result []models.Task
 	for _, task := range input {
+		// subtasks finished by the failed task would be skipped
+		task.ResumeFrom = task.ID
 		task.Model = common.Model{}
 		task.Status = models.TASK_CREATED
 		task.Message = ""
@@ -238,6 +248,7 @@ func SpawnTasks(input []models.Task) ([]models.Task, errors.Error) {
 		task.BeganAt = nil
 		task.FinishedAt = nil
 		task.SpentSeconds = 0
+		task.Attempts = 0
 		task.SkipOnFail = true
 		result = append(result, task)
 	}